@@ -166,6 +166,16 @@ const toContainsHover = (token: MetaPredicate): string => {
             return `**Built-in predicate**. Search only inside repositories that contain **file content** matching the regular expression \`${parameters}\`.`
         case 'contains.commit.after':
             return `**Built-in predicate**. Search only inside repositories that have been committed to since \`${parameters}\`.`
+        case 'contains.symbol':
+            return `**Built-in predicate**. Search only inside repositories or files that define a **symbol** matching \`${parameters}\`.`
+        case 'has.topic':
+            return `**Built-in predicate**. Search only inside repositories tagged with the **topic** \`${parameters}\` on their code host.`
+        case 'has.language':
+            return `**Built-in predicate**. Search only inside GitHub repositories whose **primary language** on GitHub is \`${parameters}\`.`
+        case 'has.file':
+            return `**Built-in predicate**. Search only inside repositories that contain a **file** at the path \`${parameters}\`.`
+        case 'has.owner':
+            return `**Built-in predicate**. Search only inside files owned by \`${parameters}\` according to the repository's CODEOWNERS file.`
     }
     return ''
 }
@@ -47,12 +47,18 @@ describe('scanPredicate', () => {
             '{"path":["contains"],"parameters":"(stuff)"}'
         )
     })
+
+    test('scan recognized file.has.owner syntax', () => {
+        expect(scanPredicate('file', 'has.owner(@alice)')).toMatchInlineSnapshot(
+            '{"path":["has","owner"],"parameters":"(@alice)"}'
+        )
+    })
 })
 
 describe('resolveAccess', () => {
     test('resolves partial access tree', () => {
         expect(resolveAccess(['repo', 'contains'], PREDICATES)).toMatchInlineSnapshot(
-            '[{"name":"file"},{"name":"content"},{"name":"commit","fields":[{"name":"after"}]}]'
+            '[{"name":"file"},{"name":"content"},{"name":"commit","fields":[{"name":"after"}]},{"name":"symbol"}]'
         )
     })
 
@@ -11,7 +11,7 @@ export const SELECTORS: Access[] = [
     },
     {
         name: 'file',
-        fields: [{ name: 'directory' }, { name: 'path' }],
+        fields: [{ name: 'directory' }, { name: 'owners' }, { name: 'path' }],
     },
     {
         name: 'content',
@@ -5,7 +5,9 @@ import BitbucketIcon from 'mdi-react/BitbucketIcon'
 import GithubIcon from 'mdi-react/GithubIcon'
 import GitIcon from 'mdi-react/GitIcon'
 import GitLabIcon from 'mdi-react/GitlabIcon'
+import LanguageGoIcon from 'mdi-react/LanguageGoIcon'
 import LanguageJavaIcon from 'mdi-react/LanguageJavaIcon'
+import LanguagePythonIcon from 'mdi-react/LanguagePythonIcon'
 import NpmIcon from 'mdi-react/NpmIcon'
 import React from 'react'
 
@@ -14,15 +16,18 @@ import { PhabricatorIcon } from '@sourcegraph/shared/src/components/icons'
 import awsCodeCommitSchemaJSON from '../../../../../schema/aws_codecommit.schema.json'
 import bitbucketCloudSchemaJSON from '../../../../../schema/bitbucket_cloud.schema.json'
 import bitbucketServerSchemaJSON from '../../../../../schema/bitbucket_server.schema.json'
+import gerritSchemaJSON from '../../../../../schema/gerrit.schema.json'
 import githubSchemaJSON from '../../../../../schema/github.schema.json'
 import gitlabSchemaJSON from '../../../../../schema/gitlab.schema.json'
 import gitoliteSchemaJSON from '../../../../../schema/gitolite.schema.json'
+import goModulesSchemaJSON from '../../../../../schema/go-modules.schema.json'
 import jvmPackagesSchemaJSON from '../../../../../schema/jvm-packages.schema.json'
 import npmPackagesSchemaJSON from '../../../../../schema/npm-packages.schema.json'
 import otherExternalServiceSchemaJSON from '../../../../../schema/other_external_service.schema.json'
 import pagureSchemaJSON from '../../../../../schema/pagure.schema.json'
 import perforceSchemaJSON from '../../../../../schema/perforce.schema.json'
 import phabricatorSchemaJSON from '../../../../../schema/phabricator.schema.json'
+import pythonPackagesSchemaJSON from '../../../../../schema/python-packages.schema.json'
 import { ExternalServiceKind } from '../../graphql-operations'
 import { EditorAction } from '../../site-admin/configHelpers'
 import { PerforceIcon } from '../PerforceIcon'
@@ -1235,6 +1240,38 @@ const PAGURE: AddExternalServiceOptions = {
     editorActions: [],
 }
 
+const GERRIT: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.GERRIT,
+    title: 'Gerrit',
+    icon: GitIcon,
+    jsonSchema: gerritSchemaJSON,
+    defaultDisplayName: 'Gerrit',
+    defaultConfig: `{
+  "url": "https://gerrit.example.com",
+  "username": "<username>",
+  "password": "<HTTP password>"
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to the URL of the Gerrit instance.
+                </li>
+                <li>
+                    Set <Field>username</Field> and <Field>password</Field> to the username and HTTP password of a
+                    Gerrit account. The HTTP password can be generated on the <i>HTTP Credentials</i> page of the
+                    account settings.
+                </li>
+                <li>
+                    Optionally, set <Field>projects</Field> to the list of projects to mirror. By default, all projects
+                    visible to the account are mirrored.
+                </li>
+            </ol>
+        </div>
+    ),
+    editorActions: [],
+}
+
 const NPM_PACKAGES: AddExternalServiceOptions = {
     kind: ExternalServiceKind.NPMPACKAGES,
     title: 'NPM Dependencies',
@@ -1268,6 +1305,63 @@ const NPM_PACKAGES: AddExternalServiceOptions = {
     editorActions: [],
 }
 
+const PYTHON_PACKAGES: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.PYTHONPACKAGES,
+    title: 'Python Dependencies',
+    icon: LanguagePythonIcon,
+    jsonSchema: pythonPackagesSchemaJSON,
+    defaultDisplayName: 'Python Dependencies',
+    defaultConfig: `{
+  "urls": ["https://pypi.org/simple"],
+  "dependencies": []
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>urls</Field> to the "simple" API URLs of the package indexes
+                    to fetch packages from, such as <code>"https://pypi.org/simple"</code>. Local indexes can be
+                    referenced with <code>file://</code> URLs.
+                </li>
+                <li>
+                    In the configuration below, set <Field>dependencies</Field> to the list of packages that you want to
+                    manually add. For example, <code>"requests==2.27.1"</code>. Version ranges are not supported.
+                </li>
+            </ol>
+        </div>
+    ),
+    editorActions: [],
+}
+
+const GO_MODULES: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.GOMODULES,
+    title: 'Go Dependencies',
+    icon: LanguageGoIcon,
+    jsonSchema: goModulesSchemaJSON,
+    defaultDisplayName: 'Go Dependencies',
+    defaultConfig: `{
+  "urls": ["https://proxy.golang.org"],
+  "dependencies": []
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>urls</Field> to the Go module proxies to fetch modules from,
+                    such as <code>"https://proxy.golang.org"</code>. Local proxies can be referenced with{' '}
+                    <code>file://</code> URLs.
+                </li>
+                <li>
+                    In the configuration below, set <Field>dependencies</Field> to the list of modules that you want to
+                    manually add. For example, <code>"golang.org/x/mod@v0.5.1"</code>. Version queries like{' '}
+                    <code>latest</code> are not supported.
+                </li>
+            </ol>
+        </div>
+    ),
+    editorActions: [],
+}
+
 export const codeHostExternalServices: Record<string, AddExternalServiceOptions> = {
     github: GITHUB_DOTCOM,
     ghe: GITHUB_ENTERPRISE,
@@ -1282,7 +1376,10 @@ export const codeHostExternalServices: Record<string, AddExternalServiceOptions>
     ...(window.context?.experimentalFeatures?.perforce === 'enabled' ? { perforce: PERFORCE } : {}),
     ...(window.context?.experimentalFeatures?.jvmPackages === 'enabled' ? { jvmPackages: JVM_PACKAGES } : {}),
     ...(window.context?.experimentalFeatures?.pagure === 'enabled' ? { pagure: PAGURE } : {}),
+    ...(window.context?.experimentalFeatures?.gerrit === 'enabled' ? { gerrit: GERRIT } : {}),
     ...(window.context?.experimentalFeatures?.npmPackages === 'enabled' ? { npmPackages: NPM_PACKAGES } : {}),
+    ...(window.context?.experimentalFeatures?.pythonPackages === 'enabled' ? { pythonPackages: PYTHON_PACKAGES } : {}),
+    ...(window.context?.experimentalFeatures?.goModules === 'enabled' ? { goModules: GO_MODULES } : {}),
 }
 
 export const nonCodeHostExternalServices: Record<string, AddExternalServiceOptions> = {
@@ -1306,5 +1403,8 @@ export const defaultExternalServices: Record<ExternalServiceKind, AddExternalSer
     [ExternalServiceKind.PERFORCE]: PERFORCE,
     [ExternalServiceKind.JVMPACKAGES]: JVM_PACKAGES,
     [ExternalServiceKind.PAGURE]: PAGURE,
+    [ExternalServiceKind.GERRIT]: GERRIT,
     [ExternalServiceKind.NPMPACKAGES]: NPM_PACKAGES,
+    [ExternalServiceKind.PYTHONPACKAGES]: PYTHON_PACKAGES,
+    [ExternalServiceKind.GOMODULES]: GO_MODULES,
 }
@@ -57,8 +57,26 @@ const helpTexts: Record<ExternalServiceKind, JSX.Element> = {
         </>
     ),
 
+    [ExternalServiceKind.BITBUCKETCLOUD]: (
+        <>
+            <a href={HELP_TEXT_LINK_URL} rel="noreferrer noopener" target="_blank">
+                Create a new app password
+            </a>{' '}
+            with <code>account:read</code>, <code>repository:write</code>, and <code>pullrequest:write</code>{' '}
+            permissions.
+        </>
+    ),
+
+    [ExternalServiceKind.GERRIT]: (
+        <>
+            <a href={HELP_TEXT_LINK_URL} rel="noreferrer noopener" target="_blank">
+                Generate a new HTTP password
+            </a>{' '}
+            on the <i>HTTP Credentials</i> page of your Gerrit account settings.
+        </>
+    ),
+
     // These are just for type completeness and serve as placeholders for a bright future.
-    [ExternalServiceKind.BITBUCKETCLOUD]: <span>Unsupported</span>,
     [ExternalServiceKind.GITOLITE]: <span>Unsupported</span>,
     [ExternalServiceKind.JVMPACKAGES]: <span>Unsupported</span>,
     [ExternalServiceKind.NPMPACKAGES]: <span>Unsupported</span>,
@@ -66,6 +84,8 @@ const helpTexts: Record<ExternalServiceKind, JSX.Element> = {
     [ExternalServiceKind.PHABRICATOR]: <span>Unsupported</span>,
     [ExternalServiceKind.AWSCODECOMMIT]: <span>Unsupported</span>,
     [ExternalServiceKind.PAGURE]: <span>Unsupported</span>,
+    [ExternalServiceKind.PYTHONPACKAGES]: <span>Unsupported</span>,
+    [ExternalServiceKind.GOMODULES]: <span>Unsupported</span>,
     [ExternalServiceKind.OTHER]: <span>Unsupported</span>,
 }
 
@@ -84,6 +104,7 @@ export const AddCredentialModal: React.FunctionComponent<AddCredentialModalProps
     const labelId = 'addCredential'
     const [isLoading, setIsLoading] = useState<boolean | Error>(false)
     const [credential, setCredential] = useState<string>('')
+    const [username, setUsername] = useState<string>('')
     const [sshPublicKey, setSSHPublicKey] = useState<string>()
     const [step, setStep] = useState<Step>(initialStep)
 
@@ -91,6 +112,15 @@ export const AddCredentialModal: React.FunctionComponent<AddCredentialModalProps
         setCredential(event.target.value)
     }, [])
 
+    const onChangeUsername = useCallback<React.ChangeEventHandler<HTMLInputElement>>(event => {
+        setUsername(event.target.value)
+    }, [])
+
+    // Bitbucket Cloud app passwords and Gerrit HTTP passwords can only be used
+    // together with the username they belong to.
+    const requiresUsername =
+        externalServiceKind === ExternalServiceKind.BITBUCKETCLOUD || externalServiceKind === ExternalServiceKind.GERRIT
+
     const onSubmit = useCallback<React.FormEventHandler>(
         async event => {
             event.preventDefault()
@@ -98,6 +128,7 @@ export const AddCredentialModal: React.FunctionComponent<AddCredentialModalProps
             try {
                 const createdCredential = await createBatchChangesCredential({
                     user: userID,
+                    username: requiresUsername ? username : null,
                     credential,
                     externalServiceKind,
                     externalServiceURL,
@@ -115,6 +146,8 @@ export const AddCredentialModal: React.FunctionComponent<AddCredentialModalProps
         [
             afterCreate,
             userID,
+            username,
+            requiresUsername,
             credential,
             externalServiceKind,
             externalServiceURL,
@@ -166,8 +199,31 @@ export const AddCredentialModal: React.FunctionComponent<AddCredentialModalProps
                     <>
                         {isErrorLike(isLoading) && <ErrorAlert error={isLoading} />}
                         <Form onSubmit={onSubmit}>
+                            {requiresUsername && (
+                                <div className="form-group">
+                                    <label htmlFor="username">Username</label>
+                                    <input
+                                        id="username"
+                                        name="username"
+                                        type="text"
+                                        autoComplete="off"
+                                        className="form-control"
+                                        required={true}
+                                        spellCheck="false"
+                                        minLength={1}
+                                        value={username}
+                                        onChange={onChangeUsername}
+                                    />
+                                </div>
+                            )}
                             <div className="form-group">
-                                <label htmlFor="token">Personal access token</label>
+                                <label htmlFor="token">
+                                    {externalServiceKind === ExternalServiceKind.BITBUCKETCLOUD
+                                        ? 'App password'
+                                        : externalServiceKind === ExternalServiceKind.GERRIT
+                                        ? 'HTTP password'
+                                        : 'Personal access token'}
+                                </label>
                                 <input
                                     id="token"
                                     name="token"
@@ -193,7 +249,11 @@ export const AddCredentialModal: React.FunctionComponent<AddCredentialModalProps
                                 </button>
                                 <button
                                     type="submit"
-                                    disabled={isLoading === true || credential.length === 0}
+                                    disabled={
+                                        isLoading === true ||
+                                        credential.length === 0 ||
+                                        (requiresUsername && username.length === 0)
+                                    }
                                     className="btn btn-primary test-add-credential-modal-submit"
                                 >
                                     {isLoading === true && <LoadingSpinner className="icon-inline" />}
@@ -125,6 +125,8 @@ func AllowAnonymousRequest(req *http.Request) bool {
 		"/.api/github-webhooks",
 		"/.api/gitlab-webhooks",
 		"/.api/bitbucket-server-webhooks",
+		"/.api/bitbucket-cloud-webhooks",
+		"/.api/gerrit-webhooks",
 	} {
 		if strings.HasPrefix(req.URL.Path, prefix) {
 			return true
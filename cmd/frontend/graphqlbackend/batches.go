@@ -161,6 +161,7 @@ type CreateBatchChangesCredentialArgs struct {
 	ExternalServiceKind string
 	ExternalServiceURL  string
 	User                *graphql.ID
+	Username            *string
 	Credential          string
 }
 
@@ -608,6 +609,12 @@ type BatchChangeResolver interface {
 	CurrentSpec(ctx context.Context) (BatchSpecResolver, error)
 	BulkOperations(ctx context.Context, args *ListBatchChangeBulkOperationArgs) (BulkOperationConnectionResolver, error)
 	BatchSpecs(ctx context.Context, args *ListBatchSpecArgs) (BatchSpecConnectionResolver, error)
+	ChangesetDependencies(ctx context.Context) ([]ChangesetDependencyResolver, error)
+}
+
+type ChangesetDependencyResolver interface {
+	Dependent() ExternalChangesetResolver
+	DependsOn() ExternalChangesetResolver
 }
 
 type BatchChangesConnectionResolver interface {
@@ -715,6 +722,20 @@ type ExternalChangesetResolver interface {
 	ScheduleEstimateAt(ctx context.Context) (*DateTime, error)
 
 	CurrentSpec(ctx context.Context) (VisibleChangesetSpecResolver, error)
+
+	DependsOn(ctx context.Context) ([]ExternalChangesetResolver, error)
+	BlockedByDependencies(ctx context.Context) (bool, error)
+
+	// Mergeability returns a value of type *btypes.ChangesetMergeability.
+	Mergeability() *string
+	// RebaseState returns a value of type *btypes.ChangesetRebaseState.
+	RebaseState() *string
+	RebaseConflicts() []ChangesetRebaseConflictResolver
+}
+
+type ChangesetRebaseConflictResolver interface {
+	Path() string
+	Hunk() *string
 }
 
 type ChangesetEventsConnectionResolver interface {
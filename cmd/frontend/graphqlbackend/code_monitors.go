@@ -48,6 +48,7 @@ type MonitorTrigger interface {
 type MonitorQueryResolver interface {
 	ID() graphql.ID
 	Query() string
+	Mode() string
 	Events(ctx context.Context, args *ListEventsArgs) (MonitorTriggerEventConnectionResolver, error)
 }
 
@@ -152,6 +153,7 @@ type CreateCodeMonitorArgs struct {
 
 type CreateTriggerArgs struct {
 	Query string
+	Mode  *string
 }
 
 type CreateActionArgs struct {
@@ -0,0 +1,30 @@
+package graphqlbackend
+
+import "github.com/sourcegraph/sourcegraph/internal/search/result"
+
+// CodeOwnerSearchResultResolver is a resolver for the GraphQL type
+// `CodeOwnerSearchResult`.
+type CodeOwnerSearchResultResolver struct {
+	result.OwnerMatch
+}
+
+func (r *CodeOwnerSearchResultResolver) Handle() string {
+	return r.OwnerMatch.Handle
+}
+
+func (r *CodeOwnerSearchResultResolver) FileCount() int32 {
+	return int32(r.OwnerMatch.Count)
+}
+
+func (r *CodeOwnerSearchResultResolver) ToRepository() (*RepositoryResolver, bool) { return nil, false }
+func (r *CodeOwnerSearchResultResolver) ToFileMatch() (*FileMatchResolver, bool)   { return nil, false }
+func (r *CodeOwnerSearchResultResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
+	return nil, false
+}
+func (r *CodeOwnerSearchResultResolver) ToCodeOwnerSearchResult() (*CodeOwnerSearchResultResolver, bool) {
+	return r, true
+}
+
+func (r *CodeOwnerSearchResultResolver) ResultCount() int32 {
+	return int32(r.OwnerMatch.ResultCount())
+}
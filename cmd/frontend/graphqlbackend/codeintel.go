@@ -162,6 +162,9 @@ type GitBlobLSIFDataResolver interface {
 	Definitions(ctx context.Context, args *LSIFQueryPositionArgs) (LocationConnectionResolver, error)
 	References(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
 	Implementations(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
+	TypeDefinitions(ctx context.Context, args *LSIFQueryPositionArgs) (LocationConnectionResolver, error)
+	IncomingCalls(ctx context.Context, args *LSIFQueryPositionArgs) ([]CallHierarchyCallResolver, error)
+	OutgoingCalls(ctx context.Context, args *LSIFQueryPositionArgs) ([]CallHierarchyCallResolver, error)
 	Hover(ctx context.Context, args *LSIFQueryPositionArgs) (HoverResolver, error)
 	Documentation(ctx context.Context, args *LSIFQueryPositionArgs) (DocumentationResolver, error)
 }
@@ -222,6 +225,18 @@ type LocationConnectionResolver interface {
 	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
 }
 
+type CallHierarchyItemResolver interface {
+	Name() string
+	Kind() string /* enum SymbolKind */
+	Location() LocationResolver
+	FullRange() RangeResolver
+}
+
+type CallHierarchyCallResolver interface {
+	Item() CallHierarchyItemResolver
+	Locations(ctx context.Context) ([]LocationResolver, error)
+}
+
 type HoverResolver interface {
 	Markdown() Markdown
 	Range() RangeResolver
@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
@@ -13,18 +14,45 @@ type CommitSearchResultResolver struct {
 
 	db database.DB
 
+	// rangeDiff is set if this result is the aggregated diff of a revision
+	// range rather than a single commit. Commit() then resolves to the head of
+	// the range.
+	rangeDiff *result.RangeDiffMatch
+
 	// gitCommitResolver should not be used directly since it may be uninitialized.
 	// Use Commit() instead.
 	gitCommitResolver *GitCommitResolver
 	gitCommitOnce     sync.Once
 }
 
+// newRangeDiffSearchResultResolver returns a resolver that renders the
+// aggregated diff of a revision range as a commit search result, like the
+// streaming API does.
+func newRangeDiffSearchResultResolver(db database.DB, m *result.RangeDiffMatch) *CommitSearchResultResolver {
+	return &CommitSearchResultResolver{
+		CommitMatch: result.CommitMatch{
+			Repo:        m.Repo,
+			Commit:      gitdomain.Commit{ID: m.Head},
+			DiffPreview: &m.DiffPreview,
+			Body:        m.Body,
+		},
+		db:        db,
+		rangeDiff: m,
+	}
+}
+
 func (r *CommitSearchResultResolver) Commit() *GitCommitResolver {
 	r.gitCommitOnce.Do(func() {
 		if r.gitCommitResolver != nil {
 			return
 		}
 		repoResolver := NewRepositoryResolver(r.db, r.Repo.ToRepo())
+		if r.rangeDiff != nil {
+			// We only know the ID of the head commit, so let the resolver
+			// load the rest.
+			r.gitCommitResolver = NewGitCommitResolver(r.db, repoResolver, r.rangeDiff.Head, nil)
+			return
+		}
 		r.gitCommitResolver = NewGitCommitResolver(r.db, repoResolver, r.CommitMatch.Commit.ID, &r.CommitMatch.Commit)
 	})
 	return r.gitCommitResolver
@@ -67,14 +95,23 @@ func (r *CommitSearchResultResolver) DiffPreview() *highlightedStringResolver {
 }
 
 func (r *CommitSearchResultResolver) Label() Markdown {
+	if r.rangeDiff != nil {
+		return Markdown(r.rangeDiff.Label())
+	}
 	return Markdown(r.CommitMatch.Label())
 }
 
 func (r *CommitSearchResultResolver) URL() string {
+	if r.rangeDiff != nil {
+		return r.rangeDiff.URL().String()
+	}
 	return r.CommitMatch.URL().String()
 }
 
 func (r *CommitSearchResultResolver) Detail() Markdown {
+	if r.rangeDiff != nil {
+		return Markdown(r.rangeDiff.Detail())
+	}
 	return Markdown(r.CommitMatch.Detail())
 }
 
@@ -82,7 +119,7 @@ func (r *CommitSearchResultResolver) Matches() []*searchResultMatchResolver {
 	match := &searchResultMatchResolver{
 		body:       r.CommitMatch.Body.Value,
 		highlights: r.CommitMatch.Body.Highlights,
-		url:        r.Commit().URL(),
+		url:        r.URL(),
 	}
 	matches := []*searchResultMatchResolver{match}
 	return matches
@@ -93,6 +130,9 @@ func (r *CommitSearchResultResolver) ToFileMatch() (*FileMatchResolver, bool)
 func (r *CommitSearchResultResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
 	return r, true
 }
+func (r *CommitSearchResultResolver) ToCodeOwnerSearchResult() (*CodeOwnerSearchResultResolver, bool) {
+	return nil, false
+}
 
 func (r *CommitSearchResultResolver) ResultCount() int32 {
 	return 1
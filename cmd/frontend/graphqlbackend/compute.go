@@ -23,7 +23,7 @@ type ComputeResolver interface {
 // A dummy type to express the union of compute results. This how its done by the GQL library we use.
 // https://github.com/graph-gophers/graphql-go/blob/af5bb93e114f0cd4cc095dd8eae0b67070ae8f20/example/starwars/starwars.go#L485-L487
 //
-// union ComputeResult = ComputeMatchContext | ComputeText
+// union ComputeResult = ComputeMatchContext | ComputeText | ComputeTable
 type computeResultResolver struct {
 	result interface{}
 }
@@ -132,6 +132,29 @@ func (c *computeTextResolver) Kind() *string {
 }
 func (c *computeTextResolver) Value() string { return c.t.Value }
 
+// ComputeTable GQL result resolver definitions.
+
+type computeTableResolver struct {
+	t *compute.Table
+}
+
+func (c *computeTableResolver) Kind() string { return c.t.Kind }
+
+func (c *computeTableResolver) Rows() []*computeTableRowResolver {
+	rows := make([]*computeTableRowResolver, 0, len(c.t.Rows))
+	for _, row := range c.t.Rows {
+		rows = append(rows, &computeTableRowResolver{row: row})
+	}
+	return rows
+}
+
+type computeTableRowResolver struct {
+	row compute.Row
+}
+
+func (r *computeTableRowResolver) Value() string { return r.row.Value }
+func (r *computeTableRowResolver) Count() int32  { return int32(r.row.Count) }
+
 // Definitions required by https://github.com/graph-gophers/graphql-go to resolve
 // a union type in GraphQL.
 
@@ -145,6 +168,11 @@ func (r *computeResultResolver) ToComputeText() (*computeTextResolver, bool) {
 	return res, ok
 }
 
+func (r *computeResultResolver) ToComputeTable() (*computeTableResolver, bool) {
+	res, ok := r.result.(*computeTableResolver)
+	return res, ok
+}
+
 func toComputeMatchContextResolver(mc *compute.MatchContext, repository *RepositoryResolver, path, commit string) *computeMatchContextResolver {
 	var computeMatches []*computeMatchResolver
 	for _, m := range mc.Matches {
@@ -174,6 +202,8 @@ func toComputeResultResolver(result compute.Result, repoResolver *RepositoryReso
 		return &computeResultResolver{result: toComputeMatchContextResolver(r, repoResolver, path, commit)}
 	case *compute.Text:
 		return &computeResultResolver{result: toComputeTextResolver(r, repoResolver, path, commit)}
+	case *compute.Table:
+		return &computeResultResolver{result: &computeTableResolver{t: r}}
 	default:
 		panic(fmt.Sprintf("unsupported compute result %T", r))
 	}
@@ -185,6 +215,8 @@ func pathAndCommitFromResult(m result.Match) (string, string) {
 		return v.Path, string(v.CommitID)
 	case *result.CommitMatch:
 		return "", string(v.Commit.ID)
+	case *result.RangeDiffMatch:
+		return "", string(v.Head)
 	case *result.RepoMatch:
 		return "", v.Rev
 	}
@@ -207,6 +239,10 @@ func toResultResolverList(ctx context.Context, cmd compute.Command, matches []re
 		return resolver
 	}
 
+	if _, ok := cmd.(*compute.Aggregate); ok {
+		return toAggregateResolverList(ctx, cmd, matches)
+	}
+
 	results := make([]*computeResultResolver, 0, len(matches))
 	for _, m := range matches {
 		computeResult, err := cmd.Run(ctx, m)
@@ -221,6 +257,22 @@ func toResultResolverList(ctx context.Context, cmd compute.Command, matches []re
 	return results, nil
 }
 
+// toAggregateResolverList folds the tables computed for each match into a
+// single table.
+func toAggregateResolverList(ctx context.Context, cmd compute.Command, matches []result.Match) ([]*computeResultResolver, error) {
+	aggregator := compute.NewAggregator(0, nil)
+	for _, m := range matches {
+		computeResult, err := cmd.Run(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		if table, ok := computeResult.(*compute.Table); ok {
+			aggregator.Add(table)
+		}
+	}
+	return []*computeResultResolver{{result: &computeTableResolver{t: aggregator.Table()}}}, nil
+}
+
 // NewComputeImplementer is a function that abstracts away the need to have a
 // handle on (*schemaResolver) Compute.
 func NewComputeImplementer(ctx context.Context, db database.DB, args *ComputeArgs) ([]*computeResultResolver, error) {
@@ -126,6 +126,10 @@ func (r *externalServiceResolver) WebhookURL() (*string, error) {
 			if len(c.Webhooks) > 0 {
 				r.webhookURL = u
 			}
+		case *schema.GerritConnection:
+			if c.WebhookSecret != "" {
+				r.webhookURL = u
+			}
 		}
 	})
 	if r.webhookURL == "" {
@@ -10,13 +10,27 @@ import (
 
 func (r *GitTreeEntryResolver) Blame(ctx context.Context,
 	args *struct {
-		StartLine int32
-		EndLine   int32
+		StartLine      int32
+		EndLine        int32
+		IgnoreRevsFile bool
+		IgnoreRevs     *[]string
+		DetectMoves    bool
+		DetectCopies   int32
 	}) ([]*hunkResolver, error) {
+	var ignoreRevs []api.CommitID
+	if args.IgnoreRevs != nil {
+		for _, rev := range *args.IgnoreRevs {
+			ignoreRevs = append(ignoreRevs, api.CommitID(rev))
+		}
+	}
 	hunks, err := git.BlameFile(ctx, r.commit.repoResolver.RepoName(), r.Path(), &git.BlameOptions{
-		NewestCommit: api.CommitID(r.commit.OID()),
-		StartLine:    int(args.StartLine),
-		EndLine:      int(args.EndLine),
+		NewestCommit:   api.CommitID(r.commit.OID()),
+		StartLine:      int(args.StartLine),
+		EndLine:        int(args.EndLine),
+		IgnoreRevs:     ignoreRevs,
+		IgnoreRevsFile: args.IgnoreRevsFile,
+		DetectMoves:    args.DetectMoves,
+		DetectCopies:   int(args.DetectCopies),
 	}, authz.DefaultSubRepoPermsChecker)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,75 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+type searchQueryDiagnosticResolver struct {
+	d query.Diagnostic
+}
+
+func (r *searchQueryDiagnosticResolver) Severity() string {
+	return strings.ToUpper(string(r.d.Severity))
+}
+
+func (r *searchQueryDiagnosticResolver) Message() string { return r.d.Message }
+
+func (r *searchQueryDiagnosticResolver) Range() RangeResolver {
+	return NewRangeResolver(queryRangeToLSP(r.d.Range))
+}
+
+func (r *searchQueryDiagnosticResolver) Suggestions() []*searchQuerySuggestionResolver {
+	suggestions := make([]*searchQuerySuggestionResolver, 0, len(r.d.Suggestions))
+	for _, s := range r.d.Suggestions {
+		suggestions = append(suggestions, &searchQuerySuggestionResolver{s: s})
+	}
+	return suggestions
+}
+
+type searchQuerySuggestionResolver struct {
+	s query.Suggestion
+}
+
+func (r *searchQuerySuggestionResolver) Description() string { return r.s.Description }
+
+func (r *searchQuerySuggestionResolver) Range() RangeResolver {
+	return NewRangeResolver(queryRangeToLSP(r.s.Range))
+}
+
+func (r *searchQuerySuggestionResolver) Replacement() string { return r.s.Replacement }
+func (r *searchQuerySuggestionResolver) Query() string       { return r.s.Query }
+
+func queryRangeToLSP(r query.Range) lsp.Range {
+	return lsp.Range{
+		Start: lsp.Position{Line: r.Start.Line, Character: r.Start.Column},
+		End:   lsp.Position{Line: r.End.Line, Character: r.End.Column},
+	}
+}
+
+func (r *schemaResolver) LintSearchQuery(ctx context.Context, args *struct {
+	Query       string
+	PatternType string
+}) ([]*searchQueryDiagnosticResolver, error) {
+	var searchType query.SearchType
+	switch args.PatternType {
+	case "regexp":
+		searchType = query.SearchTypeRegex
+	case "structural":
+		searchType = query.SearchTypeStructural
+	default:
+		searchType = query.SearchTypeLiteral
+	}
+	searchType = overrideSearchType(args.Query, searchType)
+
+	diagnostics := query.Lint(args.Query, searchType)
+	resolvers := make([]*searchQueryDiagnosticResolver, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		resolvers = append(resolvers, &searchQueryDiagnosticResolver{d: d})
+	}
+	return resolvers, nil
+}
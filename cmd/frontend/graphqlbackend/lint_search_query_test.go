@@ -0,0 +1,54 @@
+package graphqlbackend
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+func TestLintSearchQuery(t *testing.T) {
+	db := database.NewMockDB()
+	RunTests(t, []*Test{
+		{
+			Schema: mustParseGraphQLSchema(t, db),
+			Query: `
+				{
+					lintSearchQuery(query: "foo case:no", patternType: regexp) {
+						severity
+						message
+						range {
+							start { line character }
+							end { line character }
+						}
+						suggestions {
+							description
+							replacement
+							query
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"lintSearchQuery": [
+						{
+							"severity": "INFO",
+							"message": "case:no is the default and can be removed",
+							"range": {
+								"start": { "line": 0, "character": 4 },
+								"end": { "line": 0, "character": 11 }
+							},
+							"suggestions": [
+								{
+									"description": "Remove case:no",
+									"replacement": "",
+									"query": "foo"
+								}
+							]
+						}
+					]
+				}
+			`,
+		},
+	})
+}
@@ -85,13 +85,22 @@ func (r *schemaResolver) ParseSearchQuery(ctx context.Context, args *struct {
 		searchType = query.SearchTypeLiteral
 	}
 
-	plan, err := query.Pipeline(query.Init(args.Query, searchType))
+	settings, err := decodedViewerFinalSettings(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	expansion, err := query.ExpandMacros(args.Query, searchMacros(settings))
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := query.Pipeline(query.Init(expansion.Query, searchType))
 	if err != nil {
 		return nil, err
 	}
 
 	var jsons []interface{}
-	for _, node := range plan.ToParseTree() {
+	for _, node := range expansion.MapRanges(plan.ToParseTree()) {
 		jsons = append(jsons, toJSON(node))
 	}
 	json, err := json.Marshal(jsons)
@@ -6,6 +6,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/google/zoekt"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/endpoint"
@@ -34,6 +35,14 @@ type SearchArgs struct {
 	// to make it visible in the browser.
 	Stream streaming.Sender
 
+	// ExcludeRepos are not searched. Resumed streams use it to skip the
+	// repositories which the interrupted stream searched completely.
+	ExcludeRepos []api.RepoName
+
+	// TrackCompletedRepos, if true, reports the repositories all matches of
+	// which have been sent with streaming.Stats.CompletedRepos.
+	TrackCompletedRepos bool
+
 	// For tests
 	Settings *schema.Settings
 }
@@ -63,23 +72,33 @@ func NewSearchImplementer(ctx context.Context, db database.DB, args *SearchArgs)
 		}
 	}
 
+	expansion, err := query.ExpandMacros(args.Query, searchMacros(settings))
+	if err != nil {
+		return alertForQuery(args.Query, err).wrapSearchImplementer(db), nil
+	}
+	rawQuery := expansion.Query
+
 	searchType, err := detectSearchType(args.Version, args.PatternType)
 	if err != nil {
 		return nil, err
 	}
-	searchType = overrideSearchType(args.Query, searchType)
+	searchType = overrideSearchType(rawQuery, searchType)
 
 	if searchType == query.SearchTypeStructural && !conf.StructuralSearchEnabled() {
 		return nil, errors.New("Structural search is disabled in the site configuration.")
 	}
 
 	var plan query.Plan
-	plan, err = query.Pipeline(query.Init(args.Query, searchType))
+	plan, err = query.Pipeline(query.Init(rawQuery, searchType))
 	if err != nil {
-		return alertForQuery(args.Query, err).wrapSearchImplementer(db), nil
+		return alertForQuery(rawQuery, err).wrapSearchImplementer(db), nil
 	}
 	tr.LazyPrintf("parsing done")
 
+	if len(args.ExcludeRepos) > 0 {
+		plan = query.MapPlan(plan, excludeRepos(args.ExcludeRepos))
+	}
+
 	defaultLimit := defaultMaxSearchResults
 	if args.Stream != nil {
 		defaultLimit = defaultMaxSearchResultsStreaming
@@ -94,14 +113,15 @@ func NewSearchImplementer(ctx context.Context, db database.DB, args *SearchArgs)
 		SearchInputs: &run.SearchInputs{
 			Plan:          plan,
 			Query:         plan.ToParseTree(),
-			OriginalQuery: args.Query,
+			OriginalQuery: rawQuery,
 			UserSettings:  settings,
 			Features:      featureflag.FromContext(ctx),
 			PatternType:   searchType,
 			DefaultLimit:  defaultLimit,
 		},
 
-		stream: args.Stream,
+		stream:              args.Stream,
+		trackCompletedRepos: args.TrackCompletedRepos,
 
 		zoekt:        search.Indexed(),
 		searcherURLs: search.SearcherURLs(),
@@ -179,6 +199,10 @@ type searchResolver struct {
 	// stream if non-nil will send all search events we receive down it.
 	stream streaming.Sender
 
+	// trackCompletedRepos is true if the repositories which are searched
+	// completely are reported on stream.
+	trackCompletedRepos bool
+
 	zoekt        zoekt.Streamer
 	searcherURLs *endpoint.Map
 }
@@ -0,0 +1,187 @@
+package graphqlbackend
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
+	"github.com/sourcegraph/sourcegraph/internal/search/run"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+// excludeRepos returns a pass which adds a -repo: filter for names to a basic
+// query.
+func excludeRepos(names []api.RepoName) query.BasicPass {
+	patterns := make([]string, 0, len(names))
+	for _, name := range names {
+		patterns = append(patterns, regexp.QuoteMeta(string(name)))
+	}
+	exclude := query.Parameter{
+		Field:   query.FieldRepo,
+		Value:   "^(?:" + strings.Join(patterns, "|") + ")$",
+		Negated: true,
+	}
+	return func(b query.Basic) query.Basic {
+		parameters := make([]query.Parameter, 0, len(b.Parameters)+1)
+		parameters = append(parameters, b.Parameters...)
+		return b.MapParameters(append(parameters, exclude))
+	}
+}
+
+// canTrackCompletedRepos returns whether the repositories searched completely
+// by plan can be tracked. Only queries which are evaluated with a single set of
+// jobs are supported: a repository which one operand of an and/or expression
+// completed may still have matches for another.
+func canTrackCompletedRepos(plan query.Plan) bool {
+	if len(plan) != 1 {
+		return false
+	}
+	if op, ok := plan[0].Pattern.(query.Operator); ok && (op.Kind == query.And || op.Kind == query.Or) {
+		return false
+	}
+	return true
+}
+
+// pagedJobs are the jobs which have searched a page of repositories once
+// their searchrepos.Pager callback returns. Other jobs have only searched
+// their repositories once they return.
+var pagedJobs = map[string]bool{
+	"RepoSubsetText":   true,
+	"RepoSubsetSymbol": true,
+	"Structural":       true,
+	"Repo":             true,
+}
+
+// repoCompletionTracker sends the repositories which all jobs of a search
+// have searched on parent, with streaming.Stats.CompletedRepos. It must be
+// sent all events of the search.
+//
+// Repositories which were not searched successfully, for example because they
+// timed out or are still cloning, are not reported. Neither is any repository
+// once a limit was hit, since their matches may have been truncated.
+type repoCompletionTracker struct {
+	parent streaming.Sender
+	jobs   uint64 // bit set of all jobs
+
+	mu       sync.Mutex
+	done     uint64                      // bit set of the jobs which returned without error
+	searched map[api.RepoID]uint64       // bit set of the jobs which searched a repo
+	names    map[api.RepoID]api.RepoName // names of the repos in searched
+	failed   map[api.RepoID]struct{}     // repos with a search status
+	limitHit bool
+}
+
+// newRepoCompletionTracker returns a tracker for a search running numJobs
+// jobs, or nil if it has too many jobs to be tracked.
+func newRepoCompletionTracker(parent streaming.Sender, numJobs int) *repoCompletionTracker {
+	if numJobs > 64 {
+		return nil
+	}
+	return &repoCompletionTracker{
+		parent:   parent,
+		jobs:     1<<uint(numJobs) - 1,
+		searched: map[api.RepoID]uint64{},
+		names:    map[api.RepoID]api.RepoName{},
+		failed:   map[api.RepoID]struct{}{},
+	}
+}
+
+func (t *repoCompletionTracker) Send(event streaming.SearchEvent) {
+	if event.Stats.IsLimitHit || event.Stats.Status.Len() > 0 {
+		t.mu.Lock()
+		t.limitHit = t.limitHit || event.Stats.IsLimitHit
+		event.Stats.Status.Filter(search.RepoStatusCloning|search.RepoStatusMissing|search.RepoStatusLimitHit|search.RepoStatusTimedout, func(id api.RepoID) {
+			t.failed[id] = struct{}{}
+		})
+		t.mu.Unlock()
+	}
+	t.parent.Send(event)
+}
+
+// Pager returns the pager to pass to the i-th job, which reports the pages of
+// repositories the job searched.
+func (t *repoCompletionTracker) Pager(i int, job run.Job, repos searchrepos.Pager) searchrepos.Pager {
+	if !pagedJobs[job.Name()] {
+		return repos
+	}
+	return &completionPager{Pager: repos, tracker: t, job: uint64(1) << uint(i)}
+}
+
+type completionPager struct {
+	searchrepos.Pager
+	tracker *repoCompletionTracker
+	job     uint64
+}
+
+func (p *completionPager) Paginate(ctx context.Context, op *search.RepoOptions, handle func(*searchrepos.Resolved) error) error {
+	return p.Pager.Paginate(ctx, op, func(page *searchrepos.Resolved) error {
+		// Jobs may filter the page, so remember which repos it contained.
+		repoRevs := append([]*search.RepositoryRevisions(nil), page.RepoRevs...)
+		if err := handle(page); err != nil {
+			return err
+		}
+		p.tracker.pageSearched(p.job, repoRevs)
+		return nil
+	})
+}
+
+func (t *repoCompletionTracker) pageSearched(job uint64, repoRevs []*search.RepositoryRevisions) {
+	t.mu.Lock()
+	for _, rr := range repoRevs {
+		t.searched[rr.Repo.ID] |= job
+		t.names[rr.Repo.ID] = rr.Repo.Name
+	}
+	completed := t.completedLocked()
+	t.mu.Unlock()
+
+	t.send(completed)
+}
+
+// JobDone records that the i-th job returned err.
+func (t *repoCompletionTracker) JobDone(i int, err error) {
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	t.done |= uint64(1) << uint(i)
+	completed := t.completedLocked()
+	t.mu.Unlock()
+
+	t.send(completed)
+}
+
+// completedLocked returns the repos which were completed since it was last
+// called. t.mu must be held.
+func (t *repoCompletionTracker) completedLocked() map[api.RepoName]struct{} {
+	if t.limitHit {
+		return nil
+	}
+	var completed map[api.RepoName]struct{}
+	for id, jobs := range t.searched {
+		if jobs|t.done != t.jobs {
+			continue
+		}
+		if _, ok := t.failed[id]; !ok {
+			if completed == nil {
+				completed = map[api.RepoName]struct{}{}
+			}
+			completed[t.names[id]] = struct{}{}
+		}
+		delete(t.searched, id)
+		delete(t.names, id)
+	}
+	return completed
+}
+
+func (t *repoCompletionTracker) send(completed map[api.RepoName]struct{}) {
+	if len(completed) == 0 {
+		return
+	}
+	t.parent.Send(streaming.SearchEvent{Stats: streaming.Stats{CompletedRepos: completed}})
+}
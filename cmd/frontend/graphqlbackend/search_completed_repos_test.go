@@ -0,0 +1,105 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hexops/autogold"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestExcludeRepos(t *testing.T) {
+	plan, err := query.Pipeline(query.Init("repo:foo bar", query.SearchTypeLiteral))
+	require.NoError(t, err)
+
+	plan = query.MapPlan(plan, excludeRepos([]api.RepoName{"github.com/a/b", "github.com/c/d.e"}))
+	autogold.Want(
+		"excluded repos are added as a -repo: filter",
+		`(and "repo:foo" "-repo:^(?:github\\.com/a/b|github\\.com/c/d\\.e)$" "bar")`).
+		Equal(t, plan.ToParseTree().String())
+}
+
+func TestCanTrackCompletedRepos(t *testing.T) {
+	for _, tc := range []struct {
+		query string
+		want  bool
+	}{
+		{"foo bar", true},
+		{"foo and bar", false},
+		{"foo or bar", false},
+		{"(repo:a foo) or (repo:b bar)", false},
+	} {
+		plan, err := query.Pipeline(query.Init(tc.query, query.SearchTypeLiteral))
+		require.NoError(t, err)
+		require.Equal(t, tc.want, canTrackCompletedRepos(plan), tc.query)
+	}
+}
+
+type staticPager []*search.RepositoryRevisions
+
+func (p staticPager) Paginate(_ context.Context, _ *search.RepoOptions, handle func(*searchrepos.Resolved) error) error {
+	for _, rr := range p {
+		if err := handle(&searchrepos.Resolved{RepoRevs: []*search.RepositoryRevisions{rr}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type fakeJob struct{ name string }
+
+func (j fakeJob) Run(context.Context, streaming.Sender, searchrepos.Pager) error { return nil }
+func (j fakeJob) Name() string                                                   { return j.name }
+func (j fakeJob) Required() bool                                                 { return true }
+
+func TestRepoCompletionTracker(t *testing.T) {
+	repo := func(id int, name string) *search.RepositoryRevisions {
+		return &search.RepositoryRevisions{Repo: types.MinimalRepo{ID: api.RepoID(id), Name: api.RepoName(name)}}
+	}
+	repos := staticPager{repo(1, "a"), repo(2, "b"), repo(3, "c")}
+
+	var completed []api.RepoName
+	tracker := newRepoCompletionTracker(streaming.StreamFunc(func(e streaming.SearchEvent) {
+		for name := range e.Stats.CompletedRepos {
+			completed = append(completed, name)
+		}
+	}), 2)
+
+	// b timed out, so it must be searched again.
+	var status search.RepoStatusMap
+	status.Update(2, search.RepoStatusTimedout)
+	tracker.Send(streaming.SearchEvent{Stats: streaming.Stats{Status: status}})
+
+	// Repos are completed once every job searched them.
+	textJob := fakeJob{name: "RepoSubsetText"}
+	err := tracker.Pager(0, textJob, repos).Paginate(context.Background(), nil, func(*searchrepos.Resolved) error { return nil })
+	require.NoError(t, err)
+	tracker.JobDone(0, err)
+	require.Empty(t, completed)
+
+	// Commit search only has searched its repos when it returns.
+	commitJob := fakeJob{name: "Commit"}
+	require.Equal(t, searchrepos.Pager(repos), tracker.Pager(1, commitJob, repos))
+	tracker.JobDone(1, nil)
+	require.ElementsMatch(t, []api.RepoName{"a", "c"}, completed)
+
+	// Nothing is completed once a limit is hit.
+	completed = nil
+	tracker = newRepoCompletionTracker(streaming.StreamFunc(func(e streaming.SearchEvent) {
+		for name := range e.Stats.CompletedRepos {
+			completed = append(completed, name)
+		}
+	}), 1)
+	tracker.Send(streaming.SearchEvent{Stats: streaming.Stats{IsLimitHit: true}})
+	err = tracker.Pager(0, textJob, repos).Paginate(context.Background(), nil, func(*searchrepos.Resolved) error { return nil })
+	require.NoError(t, err)
+	tracker.JobDone(0, err)
+	require.Empty(t, completed)
+}
@@ -0,0 +1,151 @@
+package graphqlbackend
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/errors"
+	"github.com/sourcegraph/jsonx"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// searchMacros returns the search query macros defined in settings.
+func searchMacros(settings *schema.Settings) query.Macros {
+	if settings == nil || len(settings.SearchMacros) == 0 {
+		return nil
+	}
+	macros := make(query.Macros, len(settings.SearchMacros))
+	for name, macro := range settings.SearchMacros {
+		macros[name] = macro.Query
+	}
+	return macros
+}
+
+type searchMacroResolver struct {
+	name  string
+	macro schema.SearchMacro
+}
+
+func (r *searchMacroResolver) Name() string  { return r.name }
+func (r *searchMacroResolver) Query() string { return r.macro.Query }
+
+func (r *searchMacroResolver) Description() *string {
+	if r.macro.Description == "" {
+		return nil
+	}
+	return &r.macro.Description
+}
+
+func (r *searchMacroResolver) Version() int32 { return int32(r.macro.Version) }
+
+// SearchMacros defines the SettingsCascade.searchMacros field.
+func (r *settingsCascade) SearchMacros(ctx context.Context) ([]*searchMacroResolver, error) {
+	settings, err := r.finalTyped(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolvers []*searchMacroResolver
+	for name, macro := range settings.SearchMacros {
+		resolvers = append(resolvers, &searchMacroResolver{name: name, macro: macro})
+	}
+	sort.Slice(resolvers, func(i, j int) bool { return resolvers[i].name < resolvers[j].name })
+	return resolvers, nil
+}
+
+type searchMacroArgs struct {
+	Name        string
+	Query       string
+	Description *string
+	Version     *int32
+}
+
+// CreateSearchMacro defines the SettingsMutation.createSearchMacro field.
+func (r *settingsMutation) CreateSearchMacro(ctx context.Context, args *searchMacroArgs) (*searchMacroResolver, error) {
+	return r.editSearchMacro(ctx, args.Name, func(existing *schema.SearchMacro) (*schema.SearchMacro, error) {
+		if existing != nil {
+			return nil, errors.Errorf("search macro @%s already exists", args.Name)
+		}
+		return newSearchMacro(args, 1), nil
+	})
+}
+
+// UpdateSearchMacro defines the SettingsMutation.updateSearchMacro field.
+func (r *settingsMutation) UpdateSearchMacro(ctx context.Context, args *searchMacroArgs) (*searchMacroResolver, error) {
+	return r.editSearchMacro(ctx, args.Name, func(existing *schema.SearchMacro) (*schema.SearchMacro, error) {
+		if existing == nil {
+			return nil, errors.Errorf("search macro @%s does not exist", args.Name)
+		}
+		if args.Version != nil && int(*args.Version) != existing.Version {
+			return nil, errors.Errorf("search macro @%s version mismatch: version is %d (mutation wanted %d)", args.Name, existing.Version, *args.Version)
+		}
+		return newSearchMacro(args, existing.Version+1), nil
+	})
+}
+
+// DeleteSearchMacro defines the SettingsMutation.deleteSearchMacro field.
+func (r *settingsMutation) DeleteSearchMacro(ctx context.Context, args *struct {
+	Name string
+}) (*EmptyResponse, error) {
+	_, err := r.editSearchMacro(ctx, args.Name, func(existing *schema.SearchMacro) (*schema.SearchMacro, error) {
+		if existing == nil {
+			return nil, errors.Errorf("search macro @%s does not exist", args.Name)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+func newSearchMacro(args *searchMacroArgs, version int) *schema.SearchMacro {
+	macro := &schema.SearchMacro{Query: args.Query, Version: version}
+	if args.Description != nil {
+		macro.Description = *args.Description
+	}
+	return macro
+}
+
+// editSearchMacro replaces the macro name in the settings of the subject with
+// the macro returned by edit, which receives the existing macro, if any. If
+// edit returns a nil macro, the macro is removed.
+func (r *settingsMutation) editSearchMacro(ctx context.Context, name string, edit func(existing *schema.SearchMacro) (*schema.SearchMacro, error)) (*searchMacroResolver, error) {
+	if !query.IsValidMacroName(name) {
+		return nil, errors.Errorf("invalid search macro name %q: names may only contain letters, digits, '_', '-' and '.'", name)
+	}
+
+	var updated *schema.SearchMacro
+	_, err := r.doUpdateSettings(ctx, func(oldSettings string) ([]jsonx.Edit, error) {
+		var settings schema.Settings
+		if err := jsonc.Unmarshal(oldSettings, &settings); err != nil {
+			return nil, err
+		}
+		var existing *schema.SearchMacro
+		if macro, ok := settings.SearchMacros[name]; ok {
+			existing = &macro
+		}
+
+		var err error
+		updated, err = edit(existing)
+		if err != nil {
+			return nil, err
+		}
+
+		keyPath := jsonx.MakePath("search.macros", name)
+		if updated == nil {
+			edits, _, err := jsonx.ComputePropertyRemoval(oldSettings, keyPath, conf.FormatOptions)
+			return edits, err
+		}
+		edits, _, err := jsonx.ComputePropertyEdit(oldSettings, keyPath, updated, nil, conf.FormatOptions)
+		return edits, err
+	})
+	if err != nil || updated == nil {
+		return nil, err
+	}
+	return &searchMacroResolver{name: name, macro: *updated}, nil
+}
@@ -0,0 +1,89 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestSettingsMutation_UpdateSearchMacro(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByIDFunc.SetDefaultReturn(&types.User{ID: 1}, nil)
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: false}, nil)
+
+	settings := database.NewMockSettingsStore()
+	settings.GetLatestFunc.SetDefaultReturn(&api.Settings{ID: 1, Contents: `{"search.macros": {"go": {"query": "lang:go", "version": 2}}}`}, nil)
+	settings.CreateIfUpToDateFunc.SetDefaultHook(func(ctx context.Context, subject api.SettingsSubject, lastID, authorUserID *int32, contents string) (*api.Settings, error) {
+		var got schema.Settings
+		if err := jsonc.Unmarshal(contents, &got); err != nil {
+			t.Fatal(err)
+		}
+		want := schema.SearchMacro{Query: `lang:go -file:_test\.go$`, Description: "Go code", Version: 3}
+		if diff := cmp.Diff(want, got.SearchMacros["go"]); diff != "" {
+			t.Error(diff)
+		}
+		return &api.Settings{ID: 2, Contents: contents}, nil
+	})
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.SettingsFunc.SetDefaultReturn(settings)
+
+	RunTests(t, []*Test{
+		{
+			Context: actor.WithActor(context.Background(), &actor.Actor{UID: 1}),
+			Schema:  mustParseGraphQLSchema(t, db),
+			Query: `
+				mutation {
+					settingsMutation(input: {subject: "VXNlcjox", lastID: 1}) {
+						updateSearchMacro(name: "go", query: "lang:go -file:_test\\.go$", description: "Go code", version: 2) {
+							name
+							query
+							description
+							version
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"settingsMutation": {
+						"updateSearchMacro": {
+							"name": "go",
+							"query": "lang:go -file:_test\\.go$",
+							"description": "Go code",
+							"version": 3
+						}
+					}
+				}
+			`,
+		},
+	})
+}
+
+func TestNewSearchImplementer_Macros(t *testing.T) {
+	settings := &schema.Settings{
+		SearchMacros: map[string]schema.SearchMacro{
+			"go": {Query: "lang:go"},
+		},
+	}
+	sr, err := NewSearchImplementer(context.Background(), database.NewMockDB(), &SearchArgs{
+		Query:    "@go foo",
+		Version:  "V2",
+		Settings: settings,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sr.Inputs().OriginalQuery, "lang:go foo"; got != want {
+		t.Errorf("got query %q, want %q", got, want)
+	}
+}
@@ -33,6 +33,7 @@ import (
 	searchhoney "github.com/sourcegraph/sourcegraph/internal/honey/search"
 	"github.com/sourcegraph/sourcegraph/internal/rcache"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/codeowners"
 	"github.com/sourcegraph/sourcegraph/internal/search/commit"
 	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
@@ -189,6 +190,10 @@ func matchesToResolvers(db database.DB, matches []result.Match) []SearchResultRe
 				db:          db,
 				CommitMatch: *v,
 			})
+		case *result.RangeDiffMatch:
+			resolvers = append(resolvers, newRangeDiffSearchResultResolver(db, v))
+		case *result.OwnerMatch:
+			resolvers = append(resolvers, &CodeOwnerSearchResultResolver{OwnerMatch: *v})
 		}
 	}
 	return resolvers
@@ -330,6 +335,10 @@ loop:
 		case *result.RepoMatch:
 			// We don't care about repo results here.
 			continue
+		case *result.RangeDiffMatch:
+			// The aggregated diff of a range has no single date. The commits
+			// in the range are added as CommitMatch results already.
+			continue
 		case *result.CommitMatch:
 			// Diff searches are cheap, because we implicitly have author date info.
 			addPoint(m.Commit.Author.Date)
@@ -498,6 +507,8 @@ func (r *searchResolver) toRepoOptions(q query.Q) search.RepoOptions {
 	visibility := query.ParseVisibility(visibilityStr)
 
 	commitAfter, _ := q.StringValue(query.FieldRepoHasCommitAfter)
+	hasTopics, _ := q.StringValues(query.FieldRepoHasTopic)
+	hasLanguages, _ := q.StringValues(query.FieldRepoHasLanguage)
 	searchContextSpec, _ := q.StringValue(query.FieldContext)
 
 	return search.RepoOptions{
@@ -511,6 +522,8 @@ func (r *searchResolver) toRepoOptions(q query.Q) search.RepoOptions {
 		NoArchived:        archived == query.No,
 		Visibility:        visibility,
 		CommitAfter:       commitAfter,
+		HasTopics:         hasTopics,
+		HasLanguages:      hasLanguages,
 		Query:             q,
 	}
 }
@@ -1143,7 +1156,15 @@ func (r *searchResolver) resultsStreaming(ctx context.Context) (*SearchResultsRe
 		// Ensure downstream events sent on the stream are processed by `select:`.
 		selectPath, _ := filter.SelectPathFromString(sp) // Invariant: error already checked
 		r.stream = streaming.WithSelect(r.stream, selectPath)
+		if isSelectOwners(selectPath) {
+			// Owners are resolved from CODEOWNERS files before select runs.
+			r.stream = codeowners.WithOwners(ctx, r.stream, codeowners.NewResolver())
+		}
 	}
+	if owner, _ := r.Plan.ToParseTree().StringValue(query.FieldFileHasOwner); owner != "" {
+		r.stream = codeowners.WithOwnerFilter(ctx, r.stream, codeowners.NewResolver(), owner)
+	}
+	r.trackCompletedRepos = r.trackCompletedRepos && canTrackCompletedRepos(r.Plan)
 	sr, err := r.resultsRecursive(ctx, r.Plan)
 	srr := r.resultsToResolver(sr)
 	return srr, err
@@ -1232,6 +1253,10 @@ func (r *searchResolver) resultsRecursive(ctx context.Context, plan query.Plan)
 		}
 
 		if newResult != nil {
+			newResult.Matches, err = resolveOwners(ctx, q, newResult.Matches)
+			if err != nil {
+				return nil, err
+			}
 			newResult.Matches = result.Select(newResult.Matches, q)
 			sr = union(sr, newResult)
 			if len(sr.Matches) > wantCount {
@@ -1248,18 +1273,31 @@ func (r *searchResolver) resultsRecursive(ctx context.Context, plan query.Plan)
 }
 
 // searchResultsToRepoNodes converts a set of search results into repository nodes
-// such that they can be used to replace a repository predicate
+// such that they can be used to replace a repository predicate. File matches
+// (e.g., from symbol predicates) are converted to the repository containing
+// them.
 func searchResultsToRepoNodes(matches []result.Match) ([]query.Node, error) {
 	nodes := make([]query.Node, 0, len(matches))
+	seen := make(map[api.RepoName]struct{}, len(matches))
 	for _, match := range matches {
-		repoMatch, ok := match.(*result.RepoMatch)
-		if !ok {
+		var name api.RepoName
+		switch m := match.(type) {
+		case *result.RepoMatch:
+			name = m.Name
+		case *result.FileMatch:
+			name = m.Repo.Name
+		default:
 			return nil, errors.Errorf("expected type %T, but got %T", &result.RepoMatch{}, match)
 		}
 
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+
 		nodes = append(nodes, query.Parameter{
 			Field: query.FieldRepo,
-			Value: "^" + regexp.QuoteMeta(string(repoMatch.Name)) + "$",
+			Value: "^" + regexp.QuoteMeta(string(name)) + "$",
 		})
 	}
 
@@ -1296,6 +1334,35 @@ func searchResultsToFileNodes(matches []result.Match) ([]query.Node, error) {
 	return nodes, nil
 }
 
+// resolveOwners applies file:has.owner() filters and select:file.owners to
+// matches. Both need to read CODEOWNERS files, so they can't be part of
+// result.Select.
+func resolveOwners(ctx context.Context, q query.Basic, matches []result.Match) ([]result.Match, error) {
+	owner, _ := q.ToParseTree().StringValue(query.FieldFileHasOwner)
+	sp, _ := q.ToParseTree().StringValue(query.FieldSelect)
+	selectPath, _ := filter.SelectPathFromString(sp) // Invariant: select already validated
+	if owner == "" && !isSelectOwners(selectPath) {
+		return matches, nil
+	}
+
+	resolver := codeowners.NewResolver()
+	var err error
+	if owner != "" {
+		matches, err = resolver.FilterByOwner(ctx, matches, owner)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if isSelectOwners(selectPath) {
+		return resolver.OwnerMatches(ctx, matches)
+	}
+	return matches, nil
+}
+
+func isSelectOwners(sp filter.SelectPath) bool {
+	return sp.Root() == filter.File && len(sp) > 1 && sp[1] == "owners"
+}
+
 // resultsWithTimeoutSuggestion calls doResults, and in case of deadline
 // exceeded returns a search alert with a did-you-mean link for the same
 // query with a longer timeout.
@@ -1614,6 +1681,12 @@ func (r *searchResolver) doResults(ctx context.Context, args *search.TextParamet
 	// per backend. This works better than batch based since we have higher
 	// defaults.
 	stream := r.stream
+	var tracker *repoCompletionTracker
+	if stream != nil && r.trackCompletedRepos {
+		if tracker = newRepoCompletionTracker(stream, len(jobs)); tracker != nil {
+			stream = tracker
+		}
+	}
 	if stream != nil {
 		var cancelOnLimit context.CancelFunc
 		ctx, stream, cancelOnLimit = streaming.WithLimit(ctx, stream, limit)
@@ -1687,13 +1760,18 @@ func (r *searchResolver) doResults(ctx context.Context, args *search.TextParamet
 	}
 
 	// Start all specific search jobs, if any.
-	for _, job := range jobs {
-		job := job
+	for i, job := range jobs {
+		i, job := i, job
 		wg := wgForJob(job)
 		wg.Add(1)
 		goroutine.Go(func() {
 			defer wg.Done()
-			_ = agg.DoSearch(ctx, job, repos)
+			if tracker == nil {
+				_ = agg.DoSearch(ctx, job, repos)
+				return
+			}
+			err := agg.DoSearch(ctx, job, tracker.Pager(i, job, repos))
+			tracker.JobDone(i, err)
 		})
 	}
 
@@ -1765,6 +1843,7 @@ type SearchResultResolver interface {
 	ToRepository() (*RepositoryResolver, bool)
 	ToFileMatch() (*FileMatchResolver, bool)
 	ToCommitSearchResult() (*CommitSearchResultResolver, bool)
+	ToCodeOwnerSearchResult() (*CodeOwnerSearchResultResolver, bool)
 
 	ResultCount() int32
 }
@@ -1814,6 +1893,12 @@ func compareSearchResults(left, right result.Match, exactFilePatterns map[string
 			// or path names. We use ~ as the key for repo and
 			// paths,lexicographically last in ASCII.
 			return "~", "~", &r.Commit.Author.Date
+		case *result.RangeDiffMatch:
+			// Range diffs have no date of their own and are listed
+			// after all commits.
+			return "~", "~~", nil
+		case *result.OwnerMatch:
+			return r.Handle, "", nil
 		}
 		// Unreachable.
 		panic("unreachable: compareSearchResults expects RepositoryResolver, FileMatchResolver, or CommitSearchResultResolver")
@@ -964,3 +964,59 @@ func TestIsContextError(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchesToResolvers_RangeDiff(t *testing.T) {
+	rangeDiff := &result.RangeDiffMatch{
+		Repo:        types.MinimalRepo{ID: 1, Name: "github.com/a/b"},
+		Range:       "v1.2..v1.3",
+		Base:        "1111111111111111111111111111111111111111",
+		Head:        "2222222222222222222222222222222222222222",
+		DiffPreview: result.HighlightedString{Value: "diff"},
+		Body:        result.HighlightedString{Value: "```diff\ndiff\n```"},
+	}
+
+	resolvers := matchesToResolvers(database.NewMockDB(), []result.Match{rangeDiff})
+	require.Len(t, resolvers, 1)
+
+	commit, ok := resolvers[0].ToCommitSearchResult()
+	require.True(t, ok)
+	require.Equal(t, rangeDiff.URL().String(), commit.URL())
+	require.Equal(t, Markdown(rangeDiff.Label()), commit.Label())
+	require.Equal(t, "diff", commit.DiffPreview().Value())
+	require.Equal(t, GitObjectID(rangeDiff.Head), commit.Commit().OID())
+}
+
+func TestMatchesToResolvers_Owner(t *testing.T) {
+	owner := &result.OwnerMatch{Handle: "@alice", Repo: types.MinimalRepo{ID: 1, Name: "github.com/a/b"}, Count: 3}
+
+	resolvers := matchesToResolvers(database.NewMockDB(), []result.Match{owner})
+	require.Len(t, resolvers, 1)
+
+	r, ok := resolvers[0].ToCodeOwnerSearchResult()
+	require.True(t, ok)
+	require.Equal(t, "@alice", r.Handle())
+	require.Equal(t, int32(3), r.FileCount())
+}
+
+func TestSearchResultsToRepoNodes(t *testing.T) {
+	matches := []result.Match{
+		&result.RepoMatch{Name: "github.com/a/b"},
+		&result.FileMatch{File: result.File{Repo: types.MinimalRepo{Name: "github.com/c/d"}, Path: "x.go"}},
+		&result.FileMatch{File: result.File{Repo: types.MinimalRepo{Name: "github.com/c/d"}, Path: "y.go"}},
+	}
+
+	nodes, err := searchResultsToRepoNodes(matches)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := query.Q(nodes).String()
+	want := `"repo:^github\\.com/a/b$" "repo:^github\\.com/c/d$"`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if _, err := searchResultsToRepoNodes([]result.Match{&result.CommitMatch{}}); err == nil {
+		t.Fatal("expected error for commit match")
+	}
+}
@@ -296,6 +296,8 @@ func makeExternalAPI(db database.DB, schema *graphql.Schema, enterprise enterpri
 		enterprise.GitHubWebhook,
 		enterprise.GitLabWebhook,
 		enterprise.BitbucketServerWebhook,
+		enterprise.BitbucketCloudWebhook,
+		enterprise.GerritWebhook,
 		enterprise.NewCodeIntelUploadHandler,
 		enterprise.NewExecutorProxyHandler,
 		rateLimiter,
@@ -27,6 +27,8 @@ func newTest() *httptestutil.Client {
 		enterpriseServices.GitHubWebhook,
 		enterpriseServices.GitLabWebhook,
 		enterpriseServices.BitbucketServerWebhook,
+		enterpriseServices.BitbucketCloudWebhook,
+		enterpriseServices.GerritWebhook,
 		enterpriseServices.NewCodeIntelUploadHandler,
 		rateLimiter,
 	))
@@ -2,6 +2,7 @@ package httpapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -344,8 +345,9 @@ func serveGitExec(db database.DB) func(http.ResponseWriter, *http.Request) error
 			return errors.Wrap(err, "Encode")
 		}
 
-		// Find the correct shard to query
-		addr := gitserver.DefaultClient.AddrForRepo(repo.Name)
+		// Find the correct shard to query. The request can't be retried once
+		// it's proxied, so we pick a replica which has the repo cloned.
+		addr := gitserver.DefaultClient.ClonedAddrForRepo(r.Context(), repo.Name)
 
 		director := func(req *http.Request) {
 			req.URL.Scheme = "http"
@@ -364,7 +366,7 @@ func serveGitExec(db database.DB) func(http.ResponseWriter, *http.Request) error
 // gitserver for the repo.
 type gitServiceHandler struct {
 	Gitserver interface {
-		AddrForRepo(api.RepoName) string
+		ClonedAddrForRepo(context.Context, api.RepoName) string
 	}
 }
 
@@ -381,7 +383,7 @@ func (s *gitServiceHandler) redirectToGitServer(w http.ResponseWriter, r *http.R
 
 	u := &url.URL{
 		Scheme:   "http",
-		Host:     s.Gitserver.AddrForRepo(api.RepoName(repo)),
+		Host:     s.Gitserver.ClonedAddrForRepo(r.Context(), api.RepoName(repo)),
 		Path:     path.Join("/git", repo, gitPath),
 		RawQuery: r.URL.RawQuery,
 	}
@@ -0,0 +1,148 @@
+package search
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/compute"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// ComputeStreamHandler is an http handler which streams back the results of
+// a compute query. The results of an aggregate command are folded as they are
+// found, and the partial table is streamed periodically, followed by the final
+// table.
+func ComputeStreamHandler(db database.DB) http.Handler {
+	return &computeStreamHandler{
+		db:                db,
+		newSearchResolver: defaultNewSearchResolver,
+		aggregateInterval: time.Second,
+	}
+}
+
+type computeStreamHandler struct {
+	db                database.DB
+	newSearchResolver func(context.Context, database.DB, *graphqlbackend.SearchArgs) (searchResolver, error)
+
+	// aggregateInterval is the minimum time between two partial tables of
+	// an aggregate command.
+	aggregateInterval time.Duration
+}
+
+func (h *computeStreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	q := r.URL.Query().Get("q")
+	if q == "" {
+		http.Error(w, "no query found", http.StatusBadRequest)
+		return
+	}
+	computeQuery, err := compute.Parse(q)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	searchQuery, err := computeQuery.ToSearchQuery()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tr, ctx := trace.New(ctx, "compute.ServeStream", q)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	eventWriter, err := streamhttp.NewWriter(w)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Results are computed and sent as the search streams matches, which
+	// may happen concurrently.
+	var mu sync.Mutex
+	send := func(event string, data interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		_ = eventWriter.Event(event, data)
+	}
+
+	// Always send a final done event so clients know the stream is shutting
+	// down.
+	defer send("done", map[string]interface{}{})
+
+	var aggregator *compute.Aggregator
+	if _, ok := computeQuery.Command.(*compute.Aggregate); ok {
+		aggregator = compute.NewAggregator(h.aggregateInterval, func(t *compute.Table) {
+			send("table", t)
+		})
+	}
+
+	var (
+		runErrMu sync.Mutex
+		runErr   error
+	)
+	stream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		var results []compute.Result
+		for _, m := range event.Results {
+			result, err := computeQuery.Command.Run(ctx, m)
+			if err != nil {
+				runErrMu.Lock()
+				if runErr == nil {
+					runErr = err
+				}
+				runErrMu.Unlock()
+				cancel()
+				return
+			}
+			if result == nil {
+				continue
+			}
+			if aggregator != nil {
+				if table, ok := result.(*compute.Table); ok {
+					aggregator.Add(table)
+				}
+				continue
+			}
+			results = append(results, result)
+		}
+		if len(results) > 0 {
+			send("results", results)
+		}
+	})
+
+	patternType := "regexp"
+	sr, err := h.newSearchResolver(ctx, h.db, &graphqlbackend.SearchArgs{
+		Query:       searchQuery,
+		PatternType: &patternType,
+		Stream:      stream,
+	})
+	if err == nil {
+		_, err = sr.Results(ctx)
+	}
+	runErrMu.Lock()
+	if runErr != nil {
+		err = runErr
+	}
+	runErrMu.Unlock()
+	if err != nil {
+		log15.Warn("compute stream failed", "query", q, "error", err)
+		send("error", streamhttp.EventError{Message: err.Error()})
+		return
+	}
+
+	if aggregator != nil {
+		send("table", aggregator.Table())
+	}
+}
@@ -0,0 +1,93 @@
+package search
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/compute"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+func TestServeComputeStream_aggregate(t *testing.T) {
+	mocks := make(chan *mockSearchResolver, 1)
+	ts := httptest.NewServer(&computeStreamHandler{
+		// Send a partial table for every folded result.
+		aggregateInterval: 0,
+		newSearchResolver: func(_ context.Context, _ database.DB, args *graphqlbackend.SearchArgs) (searchResolver, error) {
+			mock := &mockSearchResolver{
+				done: make(chan struct{}),
+				c:    args.Stream,
+			}
+			mocks <- mock
+			return mock, nil
+		}})
+	defer ts.Close()
+
+	go func() {
+		mock := <-mocks
+		commit := func(message string) result.Match {
+			return &result.CommitMatch{Commit: gitdomain.Commit{
+				Committer: &gitdomain.Signature{},
+				Message:   gitdomain.Message(message),
+			}}
+		}
+		mock.c.Send(streaming.SearchEvent{Results: []result.Match{commit("1 2"), commit("1")}})
+		mock.Close()
+	}()
+
+	resp, err := http.Get(ts.URL + "?q=" + url.QueryEscape(`content:aggregate(\d, by:$0) type:commit`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var events []string
+	scanner := bufio.NewScanner(resp.Body)
+	var event string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: ") && event == "table":
+			var table compute.Table
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &table); err != nil {
+				t.Fatal(err)
+			}
+			var rows []string
+			for _, row := range table.Rows {
+				rows = append(rows, fmt.Sprintf("%s:%d", row.Value, row.Count))
+			}
+			events = append(events, "table "+strings.Join(rows, " "))
+		case strings.HasPrefix(line, "data: "):
+			events = append(events, event)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Two partial tables, one per folded result, followed by the final table.
+	want := []string{
+		"table 1:1 2:1",
+		"table 1:2 2:1",
+		"table 1:2 2:1",
+		"done",
+	}
+	if diff := cmp.Diff(want, events); diff != "" {
+		t.Fatalf("unexpected events (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,441 @@
+package search
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	searchshared "github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// ExportHandler is an http handler which runs a search exhaustively and
+// writes the results as CSV, JSON Lines or Parquet.
+func ExportHandler(db database.DB) http.Handler {
+	return &exportHandler{
+		db:                db,
+		newSearchResolver: defaultNewSearchResolver,
+	}
+}
+
+type exportHandler struct {
+	db                database.DB
+	newSearchResolver func(context.Context, database.DB, *graphqlbackend.SearchArgs) (searchResolver, error)
+}
+
+// The trailers of an export response. Since results are written as they are
+// found, errors and truncation are reported after the body.
+const (
+	exportTrailerTruncated = "X-Sourcegraph-Export-Truncated"
+	exportTrailerError     = "X-Sourcegraph-Export-Error"
+)
+
+func (h *exportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	args, err := parseExportURLQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	limits := searchshared.SearchLimits(conf.Get())
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(limits.MaxTimeoutSeconds)*time.Second)
+	defer cancel()
+
+	tr, ctx := trace.New(ctx, "search.ServeExport", args.Query,
+		trace.Tag{Key: "format", Value: args.Format},
+		trace.Tag{Key: "pattern_type", Value: args.PatternType},
+	)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	export := &exportStream{maxRows: limits.MaxExportResults, cancel: cancel}
+	sr, err := h.newSearchResolver(ctx, h.db, &graphqlbackend.SearchArgs{
+		Query:       exhaustiveQuery(args.Query, limits.MaxExportResults),
+		Version:     args.Version,
+		PatternType: strPtr(args.PatternType),
+		Stream:      export,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", exportContentTypes[args.Format])
+	w.Header().Set("Content-Disposition", `attachment; filename="search-results.`+args.Format+`"`)
+	w.Header().Set("Trailer", exportTrailerTruncated+", "+exportTrailerError)
+	export.w = newExportWriter(w, args.Format)
+
+	results, err := sr.Results(ctx)
+	if err == nil && results != nil && results.Alert() != nil && export.rows == 0 {
+		err = errors.New(results.Alert().Title())
+	}
+	if export.truncated {
+		// Hitting the limit cancels the search, which is not an error.
+		err = nil
+	}
+	if closeErr := export.Close(); err == nil {
+		err = closeErr
+	}
+
+	w.Header().Set(exportTrailerTruncated, strconv.FormatBool(export.truncated))
+	if err != nil {
+		log15.Warn("search export failed", "query", args.Query, "error", err)
+		w.Header().Set(exportTrailerError, err.Error())
+	}
+}
+
+// exhaustiveQuery returns q with a count of maxResults, unless q already
+// specifies a count. A count also makes the search use the maximum timeout.
+func exhaustiveQuery(q string, maxResults int) string {
+	nodes, err := query.Parse(q, query.SearchTypeLiteral)
+	if err != nil {
+		// Let the search report the error.
+		return q
+	}
+	if count := query.Q(nodes).Count(); count != nil {
+		return q
+	}
+	return q + " count:" + strconv.Itoa(maxResults)
+}
+
+// exportStream writes the matches sent to it as rows, up to maxRows rows.
+type exportStream struct {
+	mu        sync.Mutex
+	w         exportWriter
+	err       error
+	rows      int
+	maxRows   int
+	truncated bool
+	cancel    context.CancelFunc
+
+	// owners are the counts of owners, which are written when the stream is
+	// closed, since the count of an owner grows as more files are found.
+	owners map[string]*result.OwnerMatch
+}
+
+func (s *exportStream) Send(event streaming.SearchEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, match := range event.Results {
+		if owner, ok := match.(*result.OwnerMatch); ok {
+			if s.owners == nil {
+				s.owners = map[string]*result.OwnerMatch{}
+			}
+			if prev, ok := s.owners[owner.Handle]; ok {
+				prev.AppendMatches(owner)
+			} else {
+				s.owners[owner.Handle] = owner
+			}
+			continue
+		}
+		for _, row := range exportRows(match) {
+			s.write(row)
+		}
+	}
+}
+
+func (s *exportStream) write(row exportRow) {
+	if s.err != nil || s.truncated || s.w == nil {
+		return
+	}
+	if s.rows >= s.maxRows {
+		s.truncated = true
+		s.cancel()
+		return
+	}
+	s.err = s.w.Write(row)
+	s.rows++
+}
+
+// Close writes the owners and flushes the writer.
+func (s *exportStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	handles := make([]string, 0, len(s.owners))
+	for handle := range s.owners {
+		handles = append(handles, handle)
+	}
+	sort.Strings(handles)
+	for _, handle := range handles {
+		for _, row := range exportRows(s.owners[handle]) {
+			s.write(row)
+		}
+	}
+
+	if s.w == nil {
+		return s.err
+	}
+	if err := s.w.Close(); s.err == nil {
+		s.err = err
+	}
+	return s.err
+}
+
+// exportColumns are the columns of exported rows, in order. The schema is the
+// same for all match types: columns that do not apply to a match are empty.
+var exportColumns = []string{
+	"type",
+	"repository",
+	"revision",
+	"commit",
+	"path",
+	"line",
+	"content",
+	"symbol_name",
+	"symbol_kind",
+	"author",
+	"date",
+	"count",
+}
+
+// exportRow is a row of exported results.
+type exportRow struct {
+	Type       string `json:"type"`
+	Repository string `json:"repository"`
+	Revision   string `json:"revision"`
+	Commit     string `json:"commit"`
+	Path       string `json:"path"`
+	Line       int64  `json:"line"` // 1-based, 0 if the row is not for a line.
+	Content    string `json:"content"`
+	SymbolName string `json:"symbol_name"`
+	SymbolKind string `json:"symbol_kind"`
+	Author     string `json:"author"`
+	Date       string `json:"date"` // RFC 3339
+	Count      int64  `json:"count"`
+}
+
+// values returns the values of the row in the order of exportColumns.
+func (r exportRow) values() []interface{} {
+	return []interface{}{
+		r.Type,
+		r.Repository,
+		r.Revision,
+		r.Commit,
+		r.Path,
+		r.Line,
+		r.Content,
+		r.SymbolName,
+		r.SymbolKind,
+		r.Author,
+		r.Date,
+		r.Count,
+	}
+}
+
+// exportRows returns the rows for a match. File matches have a row for each
+// line or symbol they match.
+func exportRows(match result.Match) []exportRow {
+	switch m := match.(type) {
+	case *result.FileMatch:
+		base := exportRow{
+			Repository: string(m.Repo.Name),
+			Revision:   fromStrPtr(m.InputRev),
+			Commit:     string(m.CommitID),
+			Path:       m.Path,
+		}
+		if len(m.Symbols) > 0 {
+			rows := make([]exportRow, 0, len(m.Symbols))
+			for _, sym := range m.Symbols {
+				row := base
+				row.Type = "symbol"
+				row.Line = int64(sym.Symbol.Line)
+				row.SymbolName = sym.Symbol.Name
+				row.SymbolKind = sym.Symbol.Kind
+				row.Count = 1
+				rows = append(rows, row)
+			}
+			return rows
+		}
+		if len(m.LineMatches) > 0 {
+			rows := make([]exportRow, 0, len(m.LineMatches))
+			for _, lm := range m.LineMatches {
+				row := base
+				row.Type = "content"
+				row.Line = int64(lm.LineNumber) + 1
+				row.Content = lm.Preview
+				row.Count = int64(len(lm.OffsetAndLengths))
+				rows = append(rows, row)
+			}
+			return rows
+		}
+		base.Type = "path"
+		base.Count = 1
+		return []exportRow{base}
+
+	case *result.RepoMatch:
+		return []exportRow{{
+			Type:       "repo",
+			Repository: string(m.Name),
+			Revision:   m.Rev,
+			Count:      1,
+		}}
+
+	case *result.CommitMatch:
+		row := exportRow{
+			Type:       "commit",
+			Repository: string(m.Repo.Name),
+			Commit:     string(m.Commit.ID),
+			Content:    string(m.Commit.Message),
+			Author:     m.Commit.Author.Name,
+			Date:       m.Commit.Author.Date.UTC().Format(time.RFC3339),
+			Count:      int64(m.ResultCount()),
+		}
+		if m.DiffPreview != nil {
+			row.Type = "diff"
+			row.Content = m.DiffPreview.Value
+		}
+		return []exportRow{row}
+
+	case *result.RangeDiffMatch:
+		return []exportRow{{
+			Type:       "diff",
+			Repository: string(m.Repo.Name),
+			Revision:   m.Range,
+			Commit:     string(m.Head),
+			Content:    m.Body.Value,
+			Count:      int64(m.ResultCount()),
+		}}
+
+	case *result.OwnerMatch:
+		return []exportRow{{
+			Type:       "owner",
+			Repository: string(m.Repo.Name),
+			Content:    m.Handle,
+			Count:      int64(m.Count),
+		}}
+	}
+	return nil
+}
+
+type exportWriter interface {
+	Write(exportRow) error
+	Close() error
+}
+
+var exportContentTypes = map[string]string{
+	"csv":     "text/csv; charset=utf-8",
+	"jsonl":   "application/x-ndjson",
+	"parquet": "application/vnd.apache.parquet",
+}
+
+func newExportWriter(w io.Writer, format string) exportWriter {
+	switch format {
+	case "jsonl":
+		return &jsonlExportWriter{enc: json.NewEncoder(w)}
+	case "parquet":
+		return &parquetExportWriter{w: newParquetWriter(w, exportColumns, map[string]bool{"line": true, "count": true})}
+	default:
+		return &csvExportWriter{w: csv.NewWriter(w)}
+	}
+}
+
+type csvExportWriter struct {
+	w             *csv.Writer
+	wroteHeader   bool
+	recordScratch []string
+}
+
+func (c *csvExportWriter) Write(row exportRow) error {
+	if !c.wroteHeader {
+		c.wroteHeader = true
+		if err := c.w.Write(exportColumns); err != nil {
+			return err
+		}
+	}
+
+	c.recordScratch = c.recordScratch[:0]
+	for _, v := range row.values() {
+		switch v := v.(type) {
+		case string:
+			c.recordScratch = append(c.recordScratch, v)
+		case int64:
+			c.recordScratch = append(c.recordScratch, strconv.FormatInt(v, 10))
+		}
+	}
+	return c.w.Write(c.recordScratch)
+}
+
+func (c *csvExportWriter) Close() error {
+	if !c.wroteHeader {
+		// Always write the header, so that empty exports have the schema.
+		c.wroteHeader = true
+		if err := c.w.Write(exportColumns); err != nil {
+			return err
+		}
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type jsonlExportWriter struct {
+	enc *json.Encoder
+}
+
+func (j *jsonlExportWriter) Write(row exportRow) error {
+	return j.enc.Encode(row)
+}
+
+func (j *jsonlExportWriter) Close() error {
+	return nil
+}
+
+type parquetExportWriter struct {
+	w *parquetWriter
+}
+
+func (p *parquetExportWriter) Write(row exportRow) error {
+	return p.w.Write(row.values())
+}
+
+func (p *parquetExportWriter) Close() error {
+	return p.w.Close()
+}
+
+type exportArgs struct {
+	Query       string
+	Version     string
+	PatternType string
+	Format      string
+}
+
+func parseExportURLQuery(q url.Values) (*exportArgs, error) {
+	a := exportArgs{
+		Query:       q.Get("q"),
+		Version:     q.Get("v"),
+		PatternType: q.Get("t"),
+		Format:      q.Get("format"),
+	}
+	if a.Query == "" {
+		return nil, errors.New("no query found")
+	}
+	if a.Version == "" {
+		a.Version = "V2"
+	}
+	if a.Format == "" {
+		a.Format = "csv"
+	}
+	if _, ok := exportContentTypes[a.Format]; !ok {
+		return nil, errors.Errorf("format must be one of csv, jsonl or parquet, got %q", a.Format)
+	}
+	return &a, nil
+}
@@ -0,0 +1,308 @@
+package search
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// parquetWriter writes rows of string and int64 columns as an uncompressed
+// Parquet file. All columns are required and PLAIN encoded, which is the
+// subset of the format needed to export search results. Rows are buffered
+// and written as a row group every parquetRowGroupSize rows.
+//
+// See https://github.com/apache/parquet-format for the file format.
+type parquetWriter struct {
+	w       io.Writer
+	offset  int64
+	columns []parquetColumn
+
+	numRows   int64
+	groupRows int64
+	rowGroups []parquetRowGroup
+}
+
+const parquetRowGroupSize = 10000
+
+const parquetMagic = "PAR1"
+
+// Parquet physical types.
+const (
+	parquetInt64     = 2
+	parquetByteArray = 6
+)
+
+type parquetColumn struct {
+	name     string
+	typ      int32
+	values   bytes.Buffer
+	numValue int64
+}
+
+type parquetRowGroup struct {
+	numRows   int64
+	totalSize int64
+	chunks    []parquetColumnChunk
+}
+
+type parquetColumnChunk struct {
+	offset    int64
+	size      int64
+	numValues int64
+}
+
+// newParquetWriter returns a writer of rows with the given columns. Columns
+// in int64Columns have type int64, all others are strings.
+func newParquetWriter(w io.Writer, columns []string, int64Columns map[string]bool) *parquetWriter {
+	pw := &parquetWriter{w: w}
+	for _, name := range columns {
+		typ := int32(parquetByteArray)
+		if int64Columns[name] {
+			typ = parquetInt64
+		}
+		pw.columns = append(pw.columns, parquetColumn{name: name, typ: typ})
+	}
+	return pw
+}
+
+// Write writes a row. values must have a string or int64 for each column.
+func (pw *parquetWriter) Write(values []interface{}) error {
+	for i := range pw.columns {
+		c := &pw.columns[i]
+		switch v := values[i].(type) {
+		case int64:
+			var b [8]byte
+			binary.LittleEndian.PutUint64(b[:], uint64(v))
+			c.values.Write(b[:])
+		case string:
+			var b [4]byte
+			binary.LittleEndian.PutUint32(b[:], uint32(len(v)))
+			c.values.Write(b[:])
+			c.values.WriteString(v)
+		}
+		c.numValue++
+	}
+	pw.numRows++
+	pw.groupRows++
+
+	if pw.groupRows >= parquetRowGroupSize {
+		return pw.flushRowGroup()
+	}
+	return nil
+}
+
+func (pw *parquetWriter) write(b []byte) error {
+	n, err := pw.w.Write(b)
+	pw.offset += int64(n)
+	return err
+}
+
+func (pw *parquetWriter) flushRowGroup() error {
+	if pw.offset == 0 {
+		if err := pw.write([]byte(parquetMagic)); err != nil {
+			return err
+		}
+	}
+	if pw.groupRows == 0 {
+		return nil
+	}
+
+	group := parquetRowGroup{numRows: pw.groupRows}
+	for i := range pw.columns {
+		c := &pw.columns[i]
+
+		var header thriftWriter
+		header.beginStruct()
+		header.i32(1, 0) // type: DATA_PAGE
+		header.i32(2, int32(c.values.Len()))
+		header.i32(3, int32(c.values.Len()))
+		header.structField(5) // data_page_header
+		header.i32(1, int32(c.numValue))
+		header.i32(2, 0) // encoding: PLAIN
+		header.i32(3, 3) // definition_level_encoding: RLE
+		header.i32(4, 3) // repetition_level_encoding: RLE
+		header.endStruct()
+		header.endStruct()
+
+		chunk := parquetColumnChunk{
+			offset:    pw.offset,
+			size:      int64(header.buf.Len() + c.values.Len()),
+			numValues: c.numValue,
+		}
+		if err := pw.write(header.buf.Bytes()); err != nil {
+			return err
+		}
+		if err := pw.write(c.values.Bytes()); err != nil {
+			return err
+		}
+		group.chunks = append(group.chunks, chunk)
+		group.totalSize += chunk.size
+
+		c.values.Reset()
+		c.numValue = 0
+	}
+
+	pw.rowGroups = append(pw.rowGroups, group)
+	pw.groupRows = 0
+	return nil
+}
+
+// Close writes the remaining rows and the file footer.
+func (pw *parquetWriter) Close() error {
+	if err := pw.flushRowGroup(); err != nil {
+		return err
+	}
+
+	var meta thriftWriter
+	meta.beginStruct()
+	meta.i32(1, 1) // version
+
+	meta.list(2, thriftStruct, len(pw.columns)+1) // schema
+	meta.beginStruct()
+	meta.binary(4, "schema")
+	meta.i32(5, int32(len(pw.columns)))
+	meta.endStruct()
+	for _, c := range pw.columns {
+		meta.beginStruct()
+		meta.i32(1, c.typ)
+		meta.i32(3, 0) // repetition_type: REQUIRED
+		meta.binary(4, c.name)
+		if c.typ == parquetByteArray {
+			meta.i32(6, 0) // converted_type: UTF8
+		}
+		meta.endStruct()
+	}
+
+	meta.i64(3, pw.numRows)
+
+	meta.list(4, thriftStruct, len(pw.rowGroups)) // row_groups
+	for _, group := range pw.rowGroups {
+		meta.beginStruct()
+		meta.list(1, thriftStruct, len(group.chunks))
+		for i, chunk := range group.chunks {
+			c := pw.columns[i]
+			meta.beginStruct()
+			meta.i64(2, chunk.offset)
+			meta.structField(3) // meta_data
+			meta.i32(1, c.typ)
+			meta.list(2, thriftI32, 1)
+			meta.listI32(0) // PLAIN
+			meta.list(3, thriftBinary, 1)
+			meta.listBinary(c.name)
+			meta.i32(4, 0) // codec: UNCOMPRESSED
+			meta.i64(5, chunk.numValues)
+			meta.i64(6, chunk.size)
+			meta.i64(7, chunk.size)
+			meta.i64(9, chunk.offset)
+			meta.endStruct()
+			meta.endStruct()
+		}
+		meta.i64(2, group.totalSize)
+		meta.i64(3, group.numRows)
+		meta.endStruct()
+	}
+
+	meta.binary(6, "Sourcegraph") // created_by
+	meta.endStruct()
+
+	var footerLen [4]byte
+	binary.LittleEndian.PutUint32(footerLen[:], uint32(meta.buf.Len()))
+	if err := pw.write(meta.buf.Bytes()); err != nil {
+		return err
+	}
+	if err := pw.write(footerLen[:]); err != nil {
+		return err
+	}
+	return pw.write([]byte(parquetMagic))
+}
+
+// Thrift compact protocol types.
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes structs in the Thrift compact protocol, which Parquet
+// uses for its metadata. Fields must be written in increasing order of ID.
+type thriftWriter struct {
+	buf bytes.Buffer
+
+	// lastField is a stack of the last field ID written in each struct.
+	lastField []int16
+}
+
+func (w *thriftWriter) beginStruct() {
+	w.lastField = append(w.lastField, 0)
+}
+
+func (w *thriftWriter) endStruct() {
+	w.buf.WriteByte(0) // stop
+	w.lastField = w.lastField[:len(w.lastField)-1]
+}
+
+func (w *thriftWriter) field(id int16, typ byte) {
+	last := &w.lastField[len(w.lastField)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.varint(int64(id))
+	}
+	*last = id
+}
+
+// structField begins a struct-valued field. It is ended with endStruct.
+func (w *thriftWriter) structField(id int16) {
+	w.field(id, thriftStruct)
+	w.beginStruct()
+}
+
+func (w *thriftWriter) i32(id int16, v int32) {
+	w.field(id, thriftI32)
+	w.varint(int64(v))
+}
+
+func (w *thriftWriter) i64(id int16, v int64) {
+	w.field(id, thriftI64)
+	w.varint(v)
+}
+
+func (w *thriftWriter) binary(id int16, v string) {
+	w.field(id, thriftBinary)
+	w.listBinary(v)
+}
+
+// list begins a list-valued field of size elements. Struct elements are
+// written with beginStruct and endStruct.
+func (w *thriftWriter) list(id int16, elemType byte, size int) {
+	w.field(id, thriftList)
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elemType)
+	} else {
+		w.buf.WriteByte(0xf0 | elemType)
+		w.uvarint(uint64(size))
+	}
+}
+
+func (w *thriftWriter) listI32(v int32) {
+	w.varint(int64(v))
+}
+
+func (w *thriftWriter) listBinary(v string) {
+	w.uvarint(uint64(len(v)))
+	w.buf.WriteString(v)
+}
+
+// varint writes v zigzag encoded.
+func (w *thriftWriter) varint(v int64) {
+	w.uvarint(uint64(v<<1) ^ uint64(v>>63))
+}
+
+func (w *thriftWriter) uvarint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	w.buf.Write(b[:n])
+}
@@ -0,0 +1,311 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestServeExport(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		SearchLimits: &schema.SearchLimits{MaxExportResults: 3},
+	}})
+	defer conf.Mock(nil)
+
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/foo/bar"}
+	matches := []result.Match{
+		&result.FileMatch{
+			File: result.File{Repo: repo, CommitID: "abc", Path: "a.go"},
+			LineMatches: []*result.LineMatch{
+				{Preview: "foo, foo", LineNumber: 9, OffsetAndLengths: [][2]int32{{0, 3}, {5, 3}}},
+			},
+		},
+		&result.CommitMatch{
+			Repo: repo,
+			Commit: gitdomain.Commit{
+				ID:      "def",
+				Author:  gitdomain.Signature{Name: "alice", Date: time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)},
+				Message: "fix foo",
+			},
+		},
+		&result.RepoMatch{ID: 1, Name: repo.Name},
+		&result.RepoMatch{ID: 2, Name: "github.com/foo/baz"},
+	}
+
+	var gotQuery string
+	ts := httptest.NewServer(&exportHandler{
+		newSearchResolver: func(_ context.Context, _ database.DB, args *graphqlbackend.SearchArgs) (searchResolver, error) {
+			gotQuery = args.Query
+			mock := &exportSearchResolver{mockSearchResolver{done: make(chan struct{}), c: args.Stream}, matches}
+			mock.Close()
+			return mock, nil
+		},
+	})
+	defer ts.Close()
+
+	export := func(format string) (string, http.Header) {
+		resp, err := http.Get(ts.URL + "?q=foo&format=" + format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("got status %d: %s", resp.StatusCode, b)
+		}
+		return string(b), resp.Trailer
+	}
+
+	csv, trailer := export("csv")
+	autogold.Want("csv", `type,repository,revision,commit,path,line,content,symbol_name,symbol_kind,author,date,count
+content,github.com/foo/bar,,abc,a.go,10,"foo, foo",,,,,2
+commit,github.com/foo/bar,,def,,0,fix foo,,,alice,2021-01-02T03:04:05Z,1
+repo,github.com/foo/bar,,,,0,,,,,,1
+`).Equal(t, csv)
+	autogold.Want("query", "foo count:3").Equal(t, gotQuery)
+	if got := trailer.Get(exportTrailerTruncated); got != "true" {
+		t.Errorf("got truncated trailer %q, want true", got)
+	}
+
+	jsonl, _ := export("jsonl")
+	autogold.Want("jsonl", `{"type":"content","repository":"github.com/foo/bar","revision":"","commit":"abc","path":"a.go","line":10,"content":"foo, foo","symbol_name":"","symbol_kind":"","author":"","date":"","count":2}
+{"type":"commit","repository":"github.com/foo/bar","revision":"","commit":"def","path":"","line":0,"content":"fix foo","symbol_name":"","symbol_kind":"","author":"alice","date":"2021-01-02T03:04:05Z","count":1}
+{"type":"repo","repository":"github.com/foo/bar","revision":"","commit":"","path":"","line":0,"content":"","symbol_name":"","symbol_kind":"","author":"","date":"","count":1}
+`).Equal(t, jsonl)
+
+	// The Parquet export has the same rows as the JSON lines export.
+	var want []exportRow
+	for _, line := range strings.Split(strings.TrimSpace(jsonl), "\n") {
+		var row exportRow
+		if err := json.Unmarshal([]byte(line), &row); err != nil {
+			t.Fatal(err)
+		}
+		want = append(want, row)
+	}
+	parquet, _ := export("parquet")
+	if diff := cmp.Diff(want, readParquet(t, []byte(parquet))); diff != "" {
+		t.Errorf("unexpected parquet rows (-want +got):\n%s", diff)
+	}
+}
+
+func TestParquetWriter_rowGroups(t *testing.T) {
+	var buf bytes.Buffer
+	w := newParquetWriter(&buf, exportColumns, map[string]bool{"line": true, "count": true})
+	var want []exportRow
+	for i := 0; i < parquetRowGroupSize*2+1; i++ {
+		row := exportRow{Type: "content", Path: fmt.Sprintf("%d.go", i), Line: int64(i), Count: 1}
+		if err := w.Write(row.values()); err != nil {
+			t.Fatal(err)
+		}
+		want = append(want, row)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(want, readParquet(t, buf.Bytes())); diff != "" {
+		t.Errorf("unexpected parquet rows (-want +got):\n%s", diff)
+	}
+}
+
+// readParquet reads the export rows in data. It decodes the file from its
+// footer, independently of the state of parquetWriter, but only supports the
+// subset of the format that parquetWriter writes.
+func readParquet(t *testing.T, data []byte) []exportRow {
+	t.Helper()
+
+	if !bytes.HasPrefix(data, []byte(parquetMagic)) || !bytes.HasSuffix(data, []byte(parquetMagic)) {
+		t.Fatal("missing Parquet magic number")
+	}
+	footerEnd := len(data) - len(parquetMagic) - 4
+	footerLen := int(binary.LittleEndian.Uint32(data[footerEnd:]))
+	meta := readThriftStruct(t, bytes.NewReader(data[footerEnd-footerLen:footerEnd]))
+
+	var columns []string
+	for _, elem := range meta[2].([]interface{})[1:] {
+		columns = append(columns, elem.(map[int16]interface{})[4].(string))
+	}
+
+	var rows []exportRow
+	for _, group := range meta[4].([]interface{}) {
+		groupRows := make([]map[string]interface{}, group.(map[int16]interface{})[3].(int64))
+		for i := range groupRows {
+			groupRows[i] = map[string]interface{}{}
+		}
+
+		for i, chunk := range group.(map[int16]interface{})[1].([]interface{}) {
+			chunkMeta := chunk.(map[int16]interface{})[3].(map[int16]interface{})
+			r := bytes.NewReader(data[chunkMeta[9].(int64):])
+			readThriftStruct(t, r) // page header
+
+			for _, row := range groupRows {
+				var v interface{}
+				switch chunkMeta[1].(int64) {
+				case parquetInt64:
+					var n int64
+					if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+						t.Fatal(err)
+					}
+					v = n
+				case parquetByteArray:
+					var n uint32
+					if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+						t.Fatal(err)
+					}
+					b := make([]byte, n)
+					if _, err := io.ReadFull(r, b); err != nil {
+						t.Fatal(err)
+					}
+					v = string(b)
+				}
+				row[columns[i]] = v
+			}
+		}
+
+		for _, row := range groupRows {
+			b, err := json.Marshal(row)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var decoded exportRow
+			if err := json.Unmarshal(b, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			rows = append(rows, decoded)
+		}
+	}
+	return rows
+}
+
+// readThriftStruct decodes a struct in the Thrift compact protocol into a map
+// of field IDs to values. It supports the types written by thriftWriter.
+func readThriftStruct(t *testing.T, r *bytes.Reader) map[int16]interface{} {
+	t.Helper()
+
+	fields := map[int16]interface{}{}
+	var id int16
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b == 0 {
+			return fields
+		}
+
+		if delta := int16(b >> 4); delta != 0 {
+			id += delta
+		} else {
+			id = int16(readThriftVarint(t, r))
+		}
+		fields[id] = readThriftValue(t, r, b&0x0f)
+	}
+}
+
+func readThriftValue(t *testing.T, r *bytes.Reader, typ byte) interface{} {
+	t.Helper()
+
+	switch typ {
+	case thriftI32, thriftI64:
+		return readThriftVarint(t, r)
+	case thriftBinary:
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := make([]byte, n)
+		if _, err := io.ReadFull(r, b); err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	case thriftList:
+		header, err := r.ReadByte()
+		if err != nil {
+			t.Fatal(err)
+		}
+		size := uint64(header >> 4)
+		if size == 15 {
+			if size, err = binary.ReadUvarint(r); err != nil {
+				t.Fatal(err)
+			}
+		}
+		list := make([]interface{}, 0, size)
+		for i := uint64(0); i < size; i++ {
+			list = append(list, readThriftValue(t, r, header&0x0f))
+		}
+		return list
+	case thriftStruct:
+		return readThriftStruct(t, r)
+	}
+
+	t.Fatalf("unsupported Thrift type %d", typ)
+	return nil
+}
+
+// readThriftVarint reads a zigzag encoded varint.
+func readThriftVarint(t *testing.T, r *bytes.Reader) int64 {
+	t.Helper()
+
+	v, err := binary.ReadUvarint(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func TestThriftWriter(t *testing.T) {
+	var w thriftWriter
+	w.beginStruct()
+	w.i32(1, 1)
+	w.list(2, thriftBinary, 1)
+	w.listBinary("a")
+	w.i64(20, -1)
+	w.structField(21)
+	w.binary(1, "b")
+	w.endStruct()
+	w.endStruct()
+
+	autogold.Want("compact protocol", []byte{
+		0x15, 0x02, // field 1 i32: 1
+		0x19, 0x18, 0x01, 'a', // field 2 list<binary>: ["a"]
+		0x06, 0x28, 0x01, // field 20 i64: -1, long form
+		0x1c,            // field 21 struct
+		0x18, 0x01, 'b', // field 1 binary: "b"
+		0x00, // stop
+		0x00, // stop
+	}).Equal(t, w.buf.Bytes())
+}
+
+// exportSearchResolver sends matches when its results are requested.
+type exportSearchResolver struct {
+	mockSearchResolver
+	matches []result.Match
+}
+
+func (r *exportSearchResolver) Results(ctx context.Context) (*graphqlbackend.SearchResultsResolver, error) {
+	r.c.Send(streaming.SearchEvent{Results: r.matches})
+	return r.mockSearchResolver.Results(ctx)
+}
@@ -42,6 +42,7 @@ func StreamHandler(db database.DB) http.Handler {
 		newSearchResolver:   defaultNewSearchResolver,
 		flushTickerInternal: 100 * time.Millisecond,
 		pingTickerInterval:  5 * time.Second,
+		checkpoints:         streaming.NewCheckpointStore(600), // 10m
 	}
 }
 
@@ -50,6 +51,9 @@ type streamHandler struct {
 	newSearchResolver   func(context.Context, database.DB, *graphqlbackend.SearchArgs) (searchResolver, error)
 	flushTickerInternal time.Duration
 	pingTickerInterval  time.Duration
+
+	// checkpoints stores the progress of resumable streams.
+	checkpoints streaming.CheckpointStore
 }
 
 func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -84,7 +88,29 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Log events to trace
 	eventWriter.StatHook = eventStreamOTHook(tr.LogFields)
 
-	events, inputs, results := h.startSearch(ctx, args)
+	// For resumable streams, checkpoint records the matches sent in each
+	// event and the repositories which were searched completely. If the
+	// client is reconnecting, the repositories completed before the event it
+	// acknowledged with Last-Event-ID are not searched again, and the matches
+	// it acknowledged are not sent again.
+	var (
+		checkpoint       *streaming.Checkpoint
+		acknowledged     int64
+		pendingKeys      []result.Key
+		pendingCompleted []api.RepoName
+		completedRepos   []api.RepoName
+	)
+	if args.Resumable {
+		checkpoint, acknowledged = h.resumeCheckpoint(args, r.Header.Get("Last-Event-ID"))
+		completedRepos = checkpoint.CompletedRepos(acknowledged)
+		tr.LogFields(
+			otlog.String("stream_id", checkpoint.StreamID),
+			otlog.Int64("acknowledged", acknowledged),
+			otlog.Int("completed_repos", len(completedRepos)),
+		)
+	}
+
+	events, inputs, results := h.startSearch(ctx, args, completedRepos)
 	events = batchEvents(events, 50*time.Millisecond)
 
 	// Display is the number of results we send down. If display is < 0 we
@@ -118,7 +144,18 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// 32kb. 32kb chosen to be smaller than bufio.MaxTokenSize. Note: we can
 	// still write more than that.
 	matchesBuf := streamhttp.NewJSONArrayBuf(32*1024, func(data []byte) error {
-		return eventWriter.EventBytes("matches", data)
+		if checkpoint == nil {
+			return eventWriter.EventBytes("matches", data)
+		}
+
+		// Save the checkpoint before sending the event, so that its ID can
+		// always be resumed from.
+		update := checkpoint.Add(pendingKeys, pendingCompleted)
+		pendingKeys, pendingCompleted = pendingKeys[:0], pendingCompleted[:0]
+		if err := h.checkpoints.Append(checkpoint, update); err != nil {
+			log15.Warn("streaming: failed to save checkpoint", "stream", checkpoint.StreamID, "error", err)
+		}
+		return eventWriter.EventBytesWithID("matches", checkpoint.EventID(update.Seq), data)
 	})
 	matchesFlush := func() {
 		if err := matchesBuf.Flush(); err != nil {
@@ -140,6 +177,10 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	first := true
 	handleEvent := func(event streaming.SearchEvent) {
+		// Completed repositories are only recorded by the checkpoint.
+		completed := event.Stats.CompletedRepos
+		event.Stats.CompletedRepos = nil
+
 		progress.Update(event)
 		filters.Update(event)
 
@@ -169,11 +210,36 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				continue
 			}
 
+			if checkpoint != nil {
+				key := match.Key()
+				if checkpoint.Acknowledged(key, acknowledged) {
+					continue
+				}
+				pendingKeys = append(pendingKeys, key)
+			}
+
 			eventMatch := fromMatch(match, repoMetadata)
 			if args.DecorationLimit == -1 || args.DecorationLimit > i {
 				eventMatch = withDecoration(ctx, eventMatch, match, args.DecorationKind, args.DecorationContextLines)
 			}
-			_ = matchesBuf.Append(eventMatch)
+			// Append flushes the buffer once it's full, which records the
+			// pending keys. If it fails without flushing, the match couldn't
+			// be marshalled and isn't sent, so neither its key nor its
+			// repository must be recorded. Other errors are from writing to
+			// a client which went away.
+			pending := len(pendingKeys)
+			if err := matchesBuf.Append(eventMatch); err != nil && checkpoint != nil && len(pendingKeys) == pending {
+				pendingKeys = pendingKeys[:pending-1]
+				delete(completed, repo.Name)
+			}
+		}
+
+		// A repository is completed once its last match is in the buffer,
+		// unless the display limit dropped some of its matches.
+		if checkpoint != nil && display > 0 {
+			for repo := range completed {
+				pendingCompleted = append(pendingCompleted, repo)
+			}
 		}
 
 		// Instantly send results if we have not sent any yet.
@@ -279,10 +345,36 @@ LOOP:
 	}
 }
 
+// resumeCheckpoint returns the checkpoint of the stream identified by
+// lastEventID and the sequence number of the last event the client received.
+// If the stream cannot be resumed, the checkpoint of a new stream is returned.
+func (h *streamHandler) resumeCheckpoint(a *args, lastEventID string) (*streaming.Checkpoint, int64) {
+	query := a.Version + ":" + a.PatternType + ":" + a.Query
+	if lastEventID == "" {
+		return streaming.NewCheckpoint(query), 0
+	}
+
+	streamID, seq, err := streaming.ParseEventID(lastEventID)
+	if err != nil {
+		return streaming.NewCheckpoint(query), 0
+	}
+	checkpoint, ok := h.checkpoints.Get(streamID)
+	if !ok || checkpoint.Query != query || seq > checkpoint.Seq {
+		return streaming.NewCheckpoint(query), 0
+	}
+	if err := h.checkpoints.Append(checkpoint, checkpoint.Resume(seq)); err != nil {
+		// Starting from scratch sends matches again, but resuming with a
+		// stale checkpoint could skip matches the client never received.
+		log15.Warn("streaming: failed to resume checkpoint", "stream", streamID, "error", err)
+		return streaming.NewCheckpoint(query), 0
+	}
+	return checkpoint, seq
+}
+
 // startSearch will start a search. It returns the events channel which
 // streams out search events. Once events is closed you can call results which
 // will return the results resolver and error.
-func (h *streamHandler) startSearch(ctx context.Context, a *args) (events <-chan streaming.SearchEvent, inputs run.SearchInputs, results func() (*graphqlbackend.SearchResultsResolver, error)) {
+func (h *streamHandler) startSearch(ctx context.Context, a *args, excludeRepos []api.RepoName) (events <-chan streaming.SearchEvent, inputs run.SearchInputs, results func() (*graphqlbackend.SearchResultsResolver, error)) {
 	eventsC := make(chan streaming.SearchEvent)
 
 	search, err := h.newSearchResolver(ctx, h.db, &graphqlbackend.SearchArgs{
@@ -293,6 +385,9 @@ func (h *streamHandler) startSearch(ctx context.Context, a *args) (events <-chan
 		Stream: streaming.StreamFunc(func(event streaming.SearchEvent) {
 			eventsC <- event
 		}),
+
+		ExcludeRepos:        excludeRepos,
+		TrackCompletedRepos: a.Resumable,
 	})
 	if err != nil {
 		close(eventsC)
@@ -335,6 +430,11 @@ type args struct {
 	PatternType string
 	Display     int
 
+	// Resumable streams assign IDs to match events. A client which
+	// reconnects with the Last-Event-ID header continues the stream without
+	// receiving the matches it already has.
+	Resumable bool
+
 	// Optional decoration parameters for server-side rendering a result set
 	// or subset. Decorations may specify, e.g., highlighting results with
 	// HTML markup up-front, and/or including context lines around file results.
@@ -369,6 +469,11 @@ func parseURLQuery(q url.Values) (*args, error) {
 		return nil, errors.Errorf("display must be an integer, got %q: %w", display, err)
 	}
 
+	resumable := get("resumable", "false")
+	if a.Resumable, err = strconv.ParseBool(resumable); err != nil {
+		return nil, errors.Errorf("resumable must be a boolean, got %q: %w", resumable, err)
+	}
+
 	decorationLimit := get("dl", "0")
 	if a.DecorationLimit, err = strconv.Atoi(decorationLimit); err != nil {
 		return nil, errors.Errorf("decorationLimit must be an integer, got %q: %w", decorationLimit, err)
@@ -423,6 +528,10 @@ func fromMatch(match result.Match, repoCache map[api.RepoID]*types.SearchedRepo)
 		return fromRepository(v, repoCache)
 	case *result.CommitMatch:
 		return fromCommit(v, repoCache)
+	case *result.RangeDiffMatch:
+		return fromRangeDiff(v, repoCache)
+	case *result.OwnerMatch:
+		return fromOwner(v)
 	default:
 		panic(fmt.Sprintf("unknown match type %T", v))
 	}
@@ -579,6 +688,41 @@ func fromCommit(commit *result.CommitMatch, repoCache map[api.RepoID]*types.Sear
 	return commitEvent
 }
 
+// fromRangeDiff sends aggregated range diffs as commit events, which clients
+// already know how to render.
+func fromRangeDiff(rangeDiff *result.RangeDiffMatch, repoCache map[api.RepoID]*types.SearchedRepo) *streamhttp.EventCommitMatch {
+	highlights := rangeDiff.Body.Highlights
+	ranges := make([][3]int32, len(highlights))
+	for i, h := range highlights {
+		ranges[i] = [3]int32{h.Line, h.Character, h.Length}
+	}
+
+	commitEvent := &streamhttp.EventCommitMatch{
+		Type:       streamhttp.CommitMatchType,
+		Label:      rangeDiff.Label(),
+		URL:        rangeDiff.URL().String(),
+		Detail:     rangeDiff.Detail(),
+		Repository: string(rangeDiff.Repo.Name),
+		Content:    rangeDiff.Body.Value,
+		Ranges:     ranges,
+	}
+
+	if r, ok := repoCache[rangeDiff.Repo.ID]; ok {
+		commitEvent.RepoStars = r.Stars
+		commitEvent.RepoLastFetched = r.LastFetched
+	}
+
+	return commitEvent
+}
+
+func fromOwner(owner *result.OwnerMatch) *streamhttp.EventOwnerMatch {
+	return &streamhttp.EventOwnerMatch{
+		Type:   streamhttp.OwnerMatchType,
+		Handle: owner.Handle,
+		Count:  owner.Count,
+	}
+}
+
 // eventStreamOTHook returns a StatHook which logs to log.
 func eventStreamOTHook(log func(...otlog.Field)) func(streamhttp.WriterStat) {
 	return func(stat streamhttp.WriterStat) {
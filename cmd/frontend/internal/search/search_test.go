@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
@@ -243,3 +244,145 @@ func (h *mockSearchResolver) Inputs() run.SearchInputs {
 	}
 	return *h.inputs
 }
+
+func TestServeStream_resume(t *testing.T) {
+	database.Mocks.Repos.Metadata = func(ctx context.Context, ids ...api2.RepoID) (_ []*types.SearchedRepo, err error) {
+		res := make([]*types.SearchedRepo, 0, len(ids))
+		for _, id := range ids {
+			res = append(res, &types.SearchedRepo{
+				ID:   id,
+				Name: api2.RepoName(fmt.Sprintf("repo%d", id)),
+			})
+		}
+		return res, nil
+	}
+	defer func() { database.Mocks.Repos.Metadata = nil }()
+
+	mocks := make(chan *mockSearchResolver, 1)
+	excludedRepos := make(chan []api2.RepoName, 1)
+	ts := httptest.NewServer(&streamHandler{
+		flushTickerInternal: 1 * time.Millisecond,
+		pingTickerInterval:  1 * time.Millisecond,
+		checkpoints:         streaming.NewMemoryCheckpointStore(),
+		newSearchResolver: func(_ context.Context, _ database.DB, args *graphqlbackend.SearchArgs) (searchResolver, error) {
+			if !args.TrackCompletedRepos {
+				t.Error("expected resumable stream to track completed repos")
+			}
+			excludedRepos <- args.ExcludeRepos
+			q, err := query.Parse("foo", query.Literal)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mock := &mockSearchResolver{
+				done:   make(chan struct{}),
+				c:      args.Stream,
+				inputs: &run.SearchInputs{Query: q},
+			}
+			mocks <- mock
+			return mock, nil
+		}})
+	defer ts.Close()
+
+	// search streams the matches for repos, reporting the repos in completed
+	// as completed. It returns the names of the repos received, the last
+	// event ID and the repos the search excluded.
+	search := func(lastEventID string, completed []int, repos ...int) (names []string, eventID string, excluded []api2.RepoName) {
+		req, _ := streamhttp.NewRequest(ts.URL, "foo")
+		q := req.URL.Query()
+		q.Add("resumable", "true")
+		req.URL.RawQuery = q.Encode()
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+
+		decoder := streamhttp.FrontendStreamDecoder{
+			OnMatches: func(matches []streamhttp.EventMatch) {
+				for _, m := range matches {
+					names = append(names, m.(*streamhttp.EventRepoMatch).Repository)
+				}
+			},
+			OnEventID: func(id string) {
+				eventID = id
+			},
+		}
+		g := errgroup.Group{}
+		g.Go(func() error {
+			return decoder.ReadAll(resp.Body)
+		})
+
+		mock := <-mocks
+		excluded = <-excludedRepos
+		var matches []result.Match
+		for _, id := range repos {
+			matches = append(matches, mkRepoMatch(id))
+		}
+		var stats streaming.Stats
+		for _, id := range completed {
+			if stats.CompletedRepos == nil {
+				stats.CompletedRepos = map[api2.RepoName]struct{}{}
+			}
+			stats.CompletedRepos[mkRepoMatch(id).Name] = struct{}{}
+		}
+		mock.c.Send(streaming.SearchEvent{Results: matches, Stats: stats})
+		mock.Close()
+		if err := g.Wait(); err != nil {
+			t.Fatal(err)
+		}
+		return names, eventID, excluded
+	}
+
+	names, eventID, excluded := search("", []int{1}, 1, 2)
+	if eventID == "" {
+		t.Fatal("expected matches event to have an ID")
+	}
+	if diff := cmp.Diff([]string{"repo1", "repo2"}, names); diff != "" {
+		t.Fatal(diff)
+	}
+	if len(excluded) != 0 {
+		t.Fatalf("expected no excluded repos, got %v", excluded)
+	}
+
+	// Reconnecting doesn't search the completed repos again, and skips the
+	// matches that were acknowledged.
+	names, _, excluded = search(eventID, nil, 2, 3)
+	if diff := cmp.Diff([]string{"repo3"}, names); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff([]api2.RepoName{"repo1"}, excluded); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// Reconnecting again with the same event ID, as if the events of the
+	// previous reconnect were lost, sends their matches again.
+	names, eventID, excluded = search(eventID, []int{3}, 3, 4)
+	if diff := cmp.Diff([]string{"repo3", "repo4"}, names); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff([]api2.RepoName{"repo1"}, excluded); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// Reconnecting a third time skips everything received so far.
+	names, _, excluded = search(eventID, nil, 3, 4, 5)
+	if diff := cmp.Diff([]string{"repo5"}, names); diff != "" {
+		t.Fatal(diff)
+	}
+	if diff := cmp.Diff([]api2.RepoName{"repo1", "repo3"}, excluded); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// An unknown stream starts from scratch.
+	names, _, excluded = search("unknown:1", nil, 1, 2)
+	if diff := cmp.Diff([]string{"repo1", "repo2"}, names); diff != "" {
+		t.Fatal(diff)
+	}
+	if len(excluded) != 0 {
+		t.Fatalf("expected no excluded repos, got %v", excluded)
+	}
+}
@@ -6,10 +6,14 @@ import (
 	"context"
 	"database/sql"
 	"log"
+	"math"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"regexp"
+	"sort"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -97,6 +101,11 @@ func main() {
 	}, nil)
 	externalServiceStore := database.ExternalServices(db)
 
+	if err := server.CheckPartialCloneGitVersion(ctx); err != nil {
+		log15.Warn("partial clones are disabled", "error", err)
+		errPartialClones = err
+	}
+
 	err = keyring.Init(ctx)
 	if err != nil {
 		log.Fatalf("failed to initialise keyring: %s", err)
@@ -126,6 +135,9 @@ func main() {
 		GetVCSSyncer: func(ctx context.Context, repo api.RepoName) (server.VCSSyncer, error) {
 			return getVCSSyncer(ctx, externalServiceStore, repoStore, codeintelDB, repo)
 		},
+		GetDiskQuotasFunc: func(ctx context.Context, repos []api.RepoName) (map[api.RepoName]*server.DiskQuota, error) {
+			return getDiskQuotas(ctx, externalServiceStore, repoStore, repos)
+		},
 		Hostname:   hostname.Get(),
 		DB:         db,
 		CloneQueue: server.NewCloneQueue(list.New()),
@@ -335,6 +347,170 @@ func getVCSSyncer(ctx context.Context, externalServiceStore database.ExternalSer
 			return nil, err
 		}
 		return &server.NPMPackagesSyncer{Config: &c}, nil
+	case extsvc.TypePythonPackages:
+		var c schema.PythonPackagesConnection
+		if err := extractOptions(&c); err != nil {
+			return nil, err
+		}
+		return &server.PythonPackagesSyncer{Config: &c}, nil
+	case extsvc.TypeGoModules:
+		var c schema.GoModulesConnection
+		if err := extractOptions(&c); err != nil {
+			return nil, err
+		}
+		return &server.GoModulesSyncer{Config: &c}, nil
+	case extsvc.TypeGitHub, extsvc.TypeGitLab, extsvc.TypeBitbucketServer, extsvc.TypeBitbucketCloud:
+		var c struct {
+			PartialClones []*partialClone `json:"partialClones"`
+		}
+		if err := extractOptions(&c); err != nil {
+			return nil, err
+		}
+		return gitRepoSyncer(r.Name, c.PartialClones)
+	case extsvc.TypeOther:
+		var c schema.OtherExternalServiceConnection
+		if err := extractOptions(&c); err != nil {
+			return nil, err
+		}
+		if c.Vcs == "hg" {
+			return &server.HgRepoSyncer{}, nil
+		}
+		partialClones := make([]*partialClone, 0, len(c.PartialClones))
+		for _, pc := range c.PartialClones {
+			partialClones = append(partialClones, (*partialClone)(pc))
+		}
+		return gitRepoSyncer(r.Name, partialClones)
 	}
 	return &server.GitRepoSyncer{}, nil
 }
+
+// errPartialClones is set at startup if the installed git doesn't support
+// partial clones. The partialClones setting is ignored then.
+var errPartialClones error
+
+// partialClone is an entry of the partialClones setting, which is the same
+// for all code hosts supporting it.
+type partialClone schema.OtherPartialClone
+
+// gitRepoSyncer returns the syncer for the Git repository repo, which is
+// partially cloned if it matches one of partialClones.
+func gitRepoSyncer(repo api.RepoName, partialClones []*partialClone) (*server.GitRepoSyncer, error) {
+	for _, pc := range partialClones {
+		re, err := regexp.Compile(pc.Pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid partialClones pattern %q", pc.Pattern)
+		}
+		if !re.MatchString(string(repo)) {
+			continue
+		}
+		if errPartialClones != nil {
+			break
+		}
+		blobLimit := pc.BlobLimit
+		if blobLimit == "" {
+			blobLimit = "1m"
+		}
+		return &server.GitRepoSyncer{PartialCloneFilter: "blob:limit=" + blobLimit}, nil
+	}
+	return &server.GitRepoSyncer{}, nil
+}
+
+// getDiskQuotas returns the gitserverDiskQuota of the first external service
+// of each of repos which configures one. Repositories without a quota are left
+// out. The configuration of each external service is only read once.
+func getDiskQuotas(ctx context.Context, externalServiceStore database.ExternalServiceStore, repoStore database.RepoStore, repos []api.RepoName) (map[api.RepoName]*server.DiskQuota, error) {
+	if len(repos) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		names = append(names, string(repo))
+	}
+	rs, err := repoStore.List(actor.WithInternalActor(ctx), database.ReposListOptions{Names: names})
+	if err != nil {
+		return nil, errors.Wrap(err, "list repositories")
+	}
+
+	// The quota of each external service, nil if it has none.
+	byExternalService := make(map[int64]*server.DiskQuota)
+	quotas := make(map[api.RepoName]*server.DiskQuota)
+	for _, r := range rs {
+		ids := make([]int64, 0, len(r.Sources))
+		for _, info := range r.Sources {
+			ids = append(ids, info.ExternalServiceID())
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+		for _, id := range ids {
+			quota, ok := byExternalService[id]
+			if !ok {
+				if quota, err = externalServiceDiskQuota(ctx, externalServiceStore, id); err != nil {
+					return nil, err
+				}
+				byExternalService[id] = quota
+			}
+			if quota != nil {
+				quotas[r.Name] = quota
+				break
+			}
+		}
+	}
+	return quotas, nil
+}
+
+// externalServiceDiskQuota returns the gitserverDiskQuota of the external
+// service with the given ID, or nil if it doesn't configure one.
+func externalServiceDiskQuota(ctx context.Context, externalServiceStore database.ExternalServiceStore, id int64) (*server.DiskQuota, error) {
+	extSvc, err := externalServiceStore.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "get external service")
+	}
+	normalized, err := jsonc.Parse(extSvc.Config)
+	if err != nil {
+		return nil, errors.Wrap(err, "normalize JSON")
+	}
+	var c struct {
+		GitserverDiskQuota string `json:"gitserverDiskQuota"`
+	}
+	if err := jsoniter.Unmarshal(normalized, &c); err != nil {
+		return nil, errors.Wrap(err, "unmarshal JSON")
+	}
+	if c.GitserverDiskQuota == "" {
+		return nil, nil
+	}
+	maxBytes, err := parseByteSize(c.GitserverDiskQuota)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid gitserverDiskQuota of external service %d", id)
+	}
+	return &server.DiskQuota{ExternalServiceID: id, MaxBytes: maxBytes}, nil
+}
+
+// parseByteSize parses a size in bytes with an optional k, m, g or t suffix,
+// which are powers of 1024 like in git.
+func parseByteSize(s string) (int64, error) {
+	var shift uint
+	if n := len(s); n > 0 {
+		switch s[n-1] {
+		case 'k':
+			shift = 10
+		case 'm':
+			shift = 20
+		case 'g':
+			shift = 30
+		case 't':
+			shift = 40
+		}
+		if shift > 0 {
+			s = s[:n-1]
+		}
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if v < 0 || v > math.MaxInt64>>shift {
+		return 0, errors.Errorf("size %s out of range", s)
+	}
+	return v << shift, nil
+}
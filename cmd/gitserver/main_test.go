@@ -5,6 +5,8 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	codeinteldbstore "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
@@ -79,3 +81,183 @@ func TestGetVCSSyncer(t *testing.T) {
 		t.Fatalf("Want *server.PerforceDepotSyncer, got %T", s)
 	}
 }
+
+func TestGetVCSSyncer_Hg(t *testing.T) {
+	extsvcStore := database.NewMockExternalServiceStore()
+	repoStore := database.NewMockRepoStore()
+
+	repoStore.GetByNameFunc.SetDefaultHook(func(ctx context.Context, name api.RepoName) (*types.Repo, error) {
+		return &types.Repo{
+			ExternalRepo: api.ExternalRepoSpec{
+				ServiceType: extsvc.TypeOther,
+			},
+			Sources: map[string]*types.SourceInfo{
+				"a": {
+					ID:       "abc",
+					CloneURL: "https://hg.example.org/repo",
+				},
+			},
+		}, nil
+	})
+
+	for config, want := range map[string]server.VCSSyncer{
+		`{"repos": ["repo"], "vcs": "hg"}`: &server.HgRepoSyncer{},
+		`{"repos": ["repo"]}`:              &server.GitRepoSyncer{},
+	} {
+		config := config
+		extsvcStore.GetByIDFunc.SetDefaultHook(func(ctx context.Context, i int64) (*types.ExternalService, error) {
+			return &types.ExternalService{
+				ID:          1,
+				Kind:        extsvc.KindOther,
+				DisplayName: "test",
+				Config:      config,
+			}, nil
+		})
+
+		s, err := getVCSSyncer(context.Background(), extsvcStore, repoStore, nil, "hg.example.org/repo")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Type() != want.Type() {
+			t.Errorf("config %s: got syncer %T, want %T", config, s, want)
+		}
+	}
+}
+
+func TestGetVCSSyncer_PartialClone(t *testing.T) {
+	extsvcStore := database.NewMockExternalServiceStore()
+	repoStore := database.NewMockRepoStore()
+
+	repoStore.GetByNameFunc.SetDefaultHook(func(ctx context.Context, name api.RepoName) (*types.Repo, error) {
+		return &types.Repo{
+			Name: name,
+			ExternalRepo: api.ExternalRepoSpec{
+				ServiceType: extsvc.TypeGitHub,
+			},
+			Sources: map[string]*types.SourceInfo{
+				"a": {
+					ID:       "abc",
+					CloneURL: "https://github.com/" + string(name),
+				},
+			},
+		}, nil
+	})
+	extsvcStore.GetByIDFunc.SetDefaultHook(func(ctx context.Context, i int64) (*types.ExternalService, error) {
+		return &types.ExternalService{
+			ID:          1,
+			Kind:        extsvc.KindGitHub,
+			DisplayName: "test",
+			Config: `{
+				"url": "https://github.com",
+				"partialClones": [
+					{"pattern": "^github\\.com/sourcegraph/monorepo$"},
+					{"pattern": "^github\\.com/sourcegraph/assets$", "blobLimit": "0"}
+				]
+			}`,
+		}, nil
+	})
+
+	for repo, want := range map[api.RepoName]string{
+		"github.com/sourcegraph/monorepo":    "blob:limit=1m",
+		"github.com/sourcegraph/assets":      "blob:limit=0",
+		"github.com/sourcegraph/sourcegraph": "",
+	} {
+		s, err := getVCSSyncer(context.Background(), extsvcStore, repoStore, nil, repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gitSyncer, ok := s.(*server.GitRepoSyncer)
+		if !ok {
+			t.Fatalf("Want *server.GitRepoSyncer, got %T", s)
+		}
+		if gitSyncer.PartialCloneFilter != want {
+			t.Errorf("repo %s: got partial clone filter %q, want %q", repo, gitSyncer.PartialCloneFilter, want)
+		}
+	}
+}
+
+func TestGetDiskQuotas(t *testing.T) {
+	extsvcStore := database.NewMockExternalServiceStore()
+	repoStore := database.NewMockRepoStore()
+
+	sources := map[api.RepoName][]int64{
+		"github.com/foo/bar": {2, 1},
+		"github.com/foo/baz": {2},
+		"github.com/foo/qux": {1},
+	}
+	repoStore.ListFunc.SetDefaultHook(func(ctx context.Context, opts database.ReposListOptions) ([]*types.Repo, error) {
+		var rs []*types.Repo
+		for _, name := range opts.Names {
+			r := &types.Repo{Name: api.RepoName(name), Sources: map[string]*types.SourceInfo{}}
+			for _, id := range sources[r.Name] {
+				urn := extsvc.URN(extsvc.KindGitHub, id)
+				r.Sources[urn] = &types.SourceInfo{ID: urn}
+			}
+			rs = append(rs, r)
+		}
+		return rs, nil
+	})
+	configs := map[int64]string{
+		1: `{"url": "https://github.com"}`,
+		2: `{"url": "https://github.com", "gitserverDiskQuota": "10g"}`,
+	}
+	extsvcStore.GetByIDFunc.SetDefaultHook(func(ctx context.Context, id int64) (*types.ExternalService, error) {
+		return &types.ExternalService{ID: id, Kind: extsvc.KindGitHub, Config: configs[id]}, nil
+	})
+
+	repos := []api.RepoName{"github.com/foo/bar", "github.com/foo/baz", "github.com/foo/qux"}
+	quotas, err := getDiskQuotas(context.Background(), extsvcStore, repoStore, repos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	quota := &server.DiskQuota{ExternalServiceID: 2, MaxBytes: 10 << 30}
+	want := map[api.RepoName]*server.DiskQuota{
+		"github.com/foo/bar": quota,
+		"github.com/foo/baz": quota,
+	}
+	if diff := cmp.Diff(want, quotas); diff != "" {
+		t.Fatalf("unexpected quotas (-want +got):\n%s", diff)
+	}
+	// Repositories are listed at once, and each external service is only
+	// read once.
+	if n := len(repoStore.ListFunc.History()); n != 1 {
+		t.Errorf("got %d calls to list repositories, want 1", n)
+	}
+	if n := len(extsvcStore.GetByIDFunc.History()); n != 2 {
+		t.Errorf("got %d calls to get external services, want 2", n)
+	}
+
+	configs[2] = `{"url": "https://github.com"}`
+	quotas, err = getDiskQuotas(context.Background(), extsvcStore, repoStore, repos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quotas) != 0 {
+		t.Fatalf("got quotas %+v, want none", quotas)
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	for s, want := range map[string]int64{
+		"0":    0,
+		"1234": 1234,
+		"2k":   2 << 10,
+		"3m":   3 << 20,
+		"4g":   4 << 30,
+		"5t":   5 << 40,
+	} {
+		got, err := parseByteSize(s)
+		if err != nil {
+			t.Fatalf("%s: %v", s, err)
+		}
+		if got != want {
+			t.Errorf("%s: got %d, want %d", s, got, want)
+		}
+	}
+
+	for _, s := range []string{"", "k", "1x", "-1", "99999999999t"} {
+		if _, err := parseByteSize(s); err == nil {
+			t.Errorf("%s: want error", s)
+		}
+	}
+}
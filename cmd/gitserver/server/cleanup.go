@@ -12,7 +12,6 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"sort"
 	"strconv"
 	"strings"
 	"syscall"
@@ -27,6 +26,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -57,6 +57,12 @@ var enableGCAuto, _ = strconv.ParseBool(env.Get("SRC_ENABLE_GC_AUTO", "true", "U
 // SRC_ENABLE_SG_MAINTENANCE.
 var enableSGMaintenance, _ = strconv.ParseBool(env.Get("SRC_ENABLE_SG_MAINTENANCE", "false", "Use sg maintenance during janitorial cleanup phases"))
 
+// wrongShardReposDeleteLimit is the maximum number of repos which do not belong
+// on this gitserver, not even as a replica, to remove per janitor run. Such
+// repos are left behind when gitservers are added or the replication factor is
+// lowered.
+var wrongShardReposDeleteLimit, _ = strconv.Atoi(env.Get("SRC_WRONG_SHARD_DELETE_LIMIT", "0", "Maximum number of repos on the wrong gitserver shard to remove per janitor run. 0 disables removal."))
+
 var (
 	reposRemoved = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "src_gitserver_repos_removed",
@@ -70,6 +76,10 @@ var (
 		Name: "src_gitserver_repos_removed_disk_pressure",
 		Help: "number of repos removed due to not enough disk space",
 	})
+	wrongShardReposTotal = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "src_gitserver_repo_wrong_shard",
+		Help: "number of repos on this gitserver which do not belong on it, neither as primary nor as replica",
+	})
 	janitorRunning = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "src_gitserver_janitor_running",
 		Help: "set to 1 when the gitserver janitor background job is running",
@@ -84,15 +94,17 @@ const reposStatsName = "repos-stats.json"
 
 // cleanupRepos walks the repos directory and performs maintenance tasks:
 //
-// 1. Compute the amount of space used by the repo
-// 2. Remove corrupt repos.
-// 3. Remove stale lock files.
-// 4. Ensure correct git attributes
-// 5. Scrub remote URLs
-// 6. Perform garbage collection
-// 7. Re-clone repos after a while. (simulate git gc)
-// 8. Remove repos based on disk pressure.
-// 9. Perform sg-maintenance
+// 1. Compute and record the amount of space used by the repo
+// 2. Remove repos which belong on other gitservers.
+// 3. Remove corrupt repos.
+// 4. Remove stale lock files.
+// 5. Ensure correct git attributes
+// 6. Scrub remote URLs
+// 7. Perform garbage collection
+// 8. Re-clone repos after a while. (simulate git gc)
+// 9. Remove repos exceeding the disk quota of their code host.
+// 10. Remove repos based on disk pressure.
+// 11. Perform sg-maintenance
 func (s *Server) cleanupRepos() {
 	janitorRunning.Set(1)
 	defer janitorRunning.Set(0)
@@ -102,13 +114,38 @@ func (s *Server) cleanupRepos() {
 
 	stats := protocol.ReposStats{
 		UpdatedAt: time.Now(),
+		RepoBytes: map[api.RepoName]int64{},
 	}
 
 	computeStats := func(dir GitDir) (done bool, err error) {
-		stats.GitDirBytes += dirSize(dir.Path("."))
+		size := dirSize(dir.Path("."))
+		stats.GitDirBytes += size
+		stats.RepoBytes[s.name(dir)] = size
 		return false, nil
 	}
 
+	addrs := conf.Get().ServiceConnections().GitServers
+	replicationFactor := conf.GitServerReplicationFactor()
+	var wrongShardRepos, wrongShardReposRemoved int
+	maybeRemoveWrongShard := func(dir GitDir) (done bool, err error) {
+		if !s.isWrongShard(s.name(dir), addrs, replicationFactor) {
+			return false, nil
+		}
+		wrongShardRepos++
+
+		if wrongShardReposRemoved >= wrongShardReposDeleteLimit {
+			return false, nil
+		}
+
+		log15.Info("removing repo cloned on the wrong shard", "repo", dir)
+		if err := s.removeRepoDirectory(dir); err != nil {
+			return true, err
+		}
+		wrongShardReposRemoved++
+		reposRemoved.WithLabelValues("wrong-shard").Inc()
+		return true, nil
+	}
+
 	maybeRemoveCorrupt := func(dir GitDir) (done bool, _ error) {
 		var reason string
 
@@ -148,6 +185,12 @@ func (s *Server) cleanupRepos() {
 
 	scrubRemoteURL := func(dir GitDir) (done bool, err error) {
 		cmd := exec.Command("git", "remote", "remove", "origin")
+		if isPartialClone(dir) {
+			// Removing origin would remove its promisor and filter
+			// configuration too, which partial clones need to fetch
+			// with the filter and to fetch missing objects.
+			cmd = exec.Command("git", "config", "--unset-all", "remote.origin.url")
+		}
 		dir.Set(cmd)
 		// ignore error since we fail if the remote has already been scrubbed.
 		_ = cmd.Run()
@@ -262,6 +305,9 @@ func (s *Server) cleanupRepos() {
 	cleanups := []cleanupFn{
 		// Compute the amount of space used by the repo
 		{"compute statistics", computeStats},
+		// Repos which belong on other gitservers are counted, and removed up
+		// to a limit. Replicas of a repo belong on this gitserver.
+		{"maybe remove wrong shard", maybeRemoveWrongShard},
 		// Do some sanity checks on the repository.
 		{"maybe remove corrupt", maybeRemoveCorrupt},
 		// If git is interrupted it can leave lock files lying around. It does not clean
@@ -334,12 +380,20 @@ func (s *Server) cleanupRepos() {
 	if err != nil {
 		log15.Error("cleanup: error iterating over repositories", "error", err)
 	}
+	wrongShardReposTotal.Set(float64(wrongShardRepos))
 
 	if b, err := json.Marshal(stats); err != nil {
 		log15.Error("cleanup: failed to marshal periodic stats", "error", err)
 	} else if err = os.WriteFile(filepath.Join(s.ReposDir, reposStatsName), b, 0666); err != nil {
 		log15.Error("cleanup: failed to write periodic stats", "error", err)
 	}
+	// Keep the sizes, so they are available to RepoInfo and eviction without
+	// walking the repos again.
+	s.setRepoSizes(stats.RepoBytes)
+
+	if err := s.enforceDiskQuotas(bCtx); err != nil {
+		log15.Error("cleanup: error enforcing disk quotas", "error", err)
+	}
 
 	if s.DiskSizer == nil {
 		s.DiskSizer = &StatDiskSizer{}
@@ -353,6 +407,29 @@ func (s *Server) cleanupRepos() {
 	}
 }
 
+// isWrongShard returns true if repo belongs on other gitservers than this one,
+// taking replicas into account. It returns false if this gitserver is not in
+// addrs, since we can't tell where repos belong then.
+func (s *Server) isWrongShard(repo api.RepoName, addrs []string, replicationFactor int) bool {
+	var found bool
+	for _, addr := range addrs {
+		if s.hostnameMatch(addr) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+
+	for _, addr := range gitserver.AddrsForRepo(repo, addrs, replicationFactor) {
+		if s.hostnameMatch(addr) {
+			return false
+		}
+	}
+	return true
+}
+
 // DiskSizer gets information about disk size and free space.
 type DiskSizer interface {
 	BytesFreeOnDisk(mountPoint string) (uint64, error)
@@ -405,47 +482,38 @@ func (s *StatDiskSizer) DiskSizeBytes(mountPoint string) (uint64, error) {
 	return free, nil
 }
 
-// freeUpSpace removes git directories under ReposDir, in order from least
-// recently to most recently used, until it has freed howManyBytesToFree.
+// freeUpSpace removes git directories under ReposDir, in the order given by
+// the eviction policy, until it has freed howManyBytesToFree. Pinned
+// repositories are never removed.
 func (s *Server) freeUpSpace(howManyBytesToFree int64) error {
 	if howManyBytesToFree <= 0 {
 		return nil
 	}
 
-	// Get the git directories and their mod times.
-	gitDirs, err := s.findGitDirs()
+	// Get the git directories sorted in the order they should be removed.
+	policy := conf.GitServerEvictionPolicy()
+	candidates, err := s.evictionCandidates(policy, pinnedRepoPatterns())
 	if err != nil {
-		return errors.Wrap(err, "finding git dirs")
-	}
-	dirModTimes := make(map[GitDir]time.Time, len(gitDirs))
-	for _, d := range gitDirs {
-		mt, err := gitDirModTime(d)
-		if err != nil {
-			return errors.Wrap(err, "computing mod time of git dir")
-		}
-		dirModTimes[d] = mt
+		return err
 	}
 
-	// Sort the repos from least to most recently used.
-	sort.Slice(gitDirs, func(i, j int) bool {
-		return dirModTimes[gitDirs[i]].Before(dirModTimes[gitDirs[j]])
-	})
-
 	// Remove repos until howManyBytesToFree is met or exceeded.
 	var spaceFreed int64
 	diskSizeBytes, err := s.DiskSizer.DiskSizeBytes(s.ReposDir)
 	if err != nil {
 		return errors.Wrap(err, "getting disk size")
 	}
-	for _, d := range gitDirs {
+	for _, c := range candidates {
 		if spaceFreed >= howManyBytesToFree {
 			return nil
 		}
-		delta := dirSize(d.Path("."))
-		if err := s.removeRepoDirectory(d); err != nil {
+		if c.pinned {
+			continue
+		}
+		if err := s.removeRepoDirectory(c.dir); err != nil {
 			return errors.Wrap(err, "removing repo directory")
 		}
-		spaceFreed += delta
+		spaceFreed += c.size
 		reposRemovedDiskPressure.Inc()
 
 		// Report the new disk usage situation after removing this repo.
@@ -454,9 +522,11 @@ func (s *Server) freeUpSpace(howManyBytesToFree int64) error {
 			return errors.Wrap(err, "finding the amount of space free on disk")
 		}
 		G := float64(1024 * 1024 * 1024)
-		log15.Warn("cleanup: removed least recently used repo",
-			"repo", d,
-			"how old", time.Since(dirModTimes[d]),
+		log15.Warn("cleanup: removed repo to free up space",
+			"repo", c.dir,
+			"policy", policy,
+			"how old", time.Since(c.modTime),
+			"size in GiB", float64(c.size)/G,
 			"free space in GiB", float64(actualFreeBytes)/G,
 			"actual percent of disk space free", float64(actualFreeBytes)/float64(diskSizeBytes)*100.0,
 			"desired percent of disk space free", float64(s.DesiredPercentFree),
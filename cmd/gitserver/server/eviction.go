@@ -0,0 +1,226 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+// DiskQuota limits the disk space used on a gitserver by the repositories of
+// an external service.
+type DiskQuota struct {
+	// ExternalServiceID is the ID of the external service the quota applies
+	// to.
+	ExternalServiceID int64
+
+	// MaxBytes is the maximum amount of bytes the repositories of the
+	// external service may use.
+	MaxBytes int64
+}
+
+// evictionPolicyLargestFirst removes the largest repositories first. The
+// default policy "lru" removes the least recently used repositories first.
+const evictionPolicyLargestFirst = "largest-first"
+
+// evictionCandidate is a repository which may be removed to free up space.
+type evictionCandidate struct {
+	dir     GitDir
+	repo    api.RepoName
+	modTime time.Time
+	size    int64
+
+	// pinned repositories are never removed.
+	pinned bool
+}
+
+// evictionCandidates returns all repositories under ReposDir, sorted in the
+// order they should be removed according to policy.
+func (s *Server) evictionCandidates(policy string, pinned []*regexp.Regexp) ([]*evictionCandidate, error) {
+	gitDirs, err := s.findGitDirs()
+	if err != nil {
+		return nil, errors.Wrap(err, "finding git dirs")
+	}
+
+	candidates := make([]*evictionCandidate, 0, len(gitDirs))
+	for _, d := range gitDirs {
+		mt, err := gitDirModTime(d)
+		if err != nil {
+			return nil, errors.Wrap(err, "computing mod time of git dir")
+		}
+		repo := s.name(d)
+		candidates = append(candidates, &evictionCandidate{
+			dir:     d,
+			repo:    repo,
+			modTime: mt,
+			size:    s.repoSizeBestEffort(d),
+			pinned:  matchesAny(pinned, string(repo)),
+		})
+	}
+
+	sortEvictionCandidates(candidates, policy)
+	return candidates, nil
+}
+
+// sortEvictionCandidates sorts candidates in the order they should be removed
+// according to policy. Ties, and all candidates under the default policy, are
+// sorted from least to most recently used.
+func sortEvictionCandidates(candidates []*evictionCandidate, policy string) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if policy == evictionPolicyLargestFirst && candidates[i].size != candidates[j].size {
+			return candidates[i].size > candidates[j].size
+		}
+		return candidates[i].modTime.Before(candidates[j].modTime)
+	})
+}
+
+// pinnedRepoPatterns returns the patterns of the repositories which must
+// never be removed to free up space. Invalid patterns are logged and ignored.
+func pinnedRepoPatterns() []*regexp.Regexp {
+	eviction := conf.Get().GitServerEviction
+	if eviction == nil {
+		return nil
+	}
+	patterns := make([]*regexp.Regexp, 0, len(eviction.PinnedRepos))
+	for _, p := range eviction.PinnedRepos {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			log15.Warn("ignoring invalid pinned repo pattern", "pattern", p, "error", err)
+			continue
+		}
+		patterns = append(patterns, re)
+	}
+	return patterns
+}
+
+func matchesAny(patterns []*regexp.Regexp, s string) bool {
+	for _, re := range patterns {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// enforceDiskQuotas removes repositories of external services which use more
+// disk space than their quota allows, in the order given by the eviction
+// policy, until the repositories fit. Pinned repositories count towards the
+// quota but are never removed.
+func (s *Server) enforceDiskQuotas(ctx context.Context) error {
+	if s.GetDiskQuotasFunc == nil {
+		return nil
+	}
+
+	candidates, err := s.evictionCandidates(conf.GitServerEvictionPolicy(), pinnedRepoPatterns())
+	if err != nil {
+		return err
+	}
+
+	repos := make([]api.RepoName, 0, len(candidates))
+	for _, c := range candidates {
+		repos = append(repos, c.repo)
+	}
+	quotas, err := s.GetDiskQuotasFunc(ctx, repos)
+	if err != nil {
+		return errors.Wrap(err, "getting disk quotas")
+	}
+
+	// The quota and usage of each external service.
+	maxBytes := make(map[int64]int64)
+	usage := make(map[int64]int64)
+	for _, c := range candidates {
+		if quota, ok := quotas[c.repo]; ok {
+			maxBytes[quota.ExternalServiceID] = quota.MaxBytes
+			usage[quota.ExternalServiceID] += c.size
+		}
+	}
+
+	for _, c := range candidates {
+		quota, ok := quotas[c.repo]
+		if !ok || c.pinned {
+			continue
+		}
+		id := quota.ExternalServiceID
+		if usage[id] <= maxBytes[id] {
+			continue
+		}
+		if err := s.removeRepoDirectory(c.dir); err != nil {
+			return errors.Wrap(err, "removing repo directory")
+		}
+		usage[id] -= c.size
+		reposRemoved.WithLabelValues("disk-quota").Inc()
+
+		log15.Warn("cleanup: removed repo exceeding disk quota",
+			"repo", c.repo,
+			"size", c.size,
+			"externalServiceID", id,
+			"usage", usage[id],
+			"quota", maxBytes[id])
+	}
+
+	for id, bytes := range usage {
+		if bytes > maxBytes[id] {
+			log15.Warn("cleanup: pinned repos exceed disk quota", "externalServiceID", id, "usage", bytes, "quota", maxBytes[id])
+		}
+	}
+
+	return nil
+}
+
+// setRepoSizes records the sizes in bytes of all repositories, so that they
+// are available without walking the repositories.
+func (s *Server) setRepoSizes(sizes map[api.RepoName]int64) {
+	s.repoSizesMu.Lock()
+	s.repoSizes = sizes
+	s.repoSizesMu.Unlock()
+}
+
+// repoSize returns the size in bytes of repo as last recorded by
+// setRepoSizes, and whether it has been recorded. Until the first cleanup run
+// completes, the sizes computed by the cleanup run of the previous process are
+// used.
+func (s *Server) repoSize(repo api.RepoName) (int64, bool) {
+	s.repoSizesMu.Lock()
+	defer s.repoSizesMu.Unlock()
+	if s.repoSizes == nil {
+		s.repoSizes = s.readRepoSizes()
+	}
+	size, ok := s.repoSizes[repo]
+	return size, ok
+}
+
+// readRepoSizes returns the repository sizes of the repos stats written by
+// the last cleanup run, or an empty map if there are none.
+func (s *Server) readRepoSizes() map[api.RepoName]int64 {
+	var stats protocol.ReposStats
+	b, err := os.ReadFile(filepath.Join(s.ReposDir, reposStatsName))
+	if err == nil {
+		err = json.Unmarshal(b, &stats)
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		log15.Warn("failed to read repo sizes", "error", err)
+	}
+	if stats.RepoBytes == nil {
+		return map[api.RepoName]int64{}
+	}
+	return stats.RepoBytes
+}
+
+// repoSizeBestEffort returns the recorded size of the repository, and falls
+// back to computing it if it has not been recorded.
+func (s *Server) repoSizeBestEffort(dir GitDir) int64 {
+	if size, ok := s.repoSize(s.name(dir)); ok {
+		return size
+	}
+	return dirSize(dir.Path("."))
+}
@@ -0,0 +1,175 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// makeFakeRepos creates fake repos under a new temporary directory with the
+// given sizes. The repos are used in the order given, so the first repo is the
+// least recently used.
+func makeFakeRepos(t *testing.T, sizes map[string]int, order ...string) string {
+	t.Helper()
+	rd := t.TempDir()
+	mtime := time.Now().Add(-time.Hour)
+	for _, name := range order {
+		if err := makeFakeRepo(filepath.Join(rd, name), sizes[name]); err != nil {
+			t.Fatal(err)
+		}
+		mtime = mtime.Add(time.Second)
+		if err := os.Chtimes(filepath.Join(rd, name, ".git", "HEAD"), mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return rd
+}
+
+func TestFreeUpSpace_EvictionPolicy(t *testing.T) {
+	sizes := map[string]int{"small": 100, "large": 3000, "medium": 1000}
+	order := []string{"small", "large", "medium"}
+
+	for _, tc := range []struct {
+		name     string
+		eviction *schema.GitServerEviction
+		want     []string
+	}{
+		{
+			name: "lru",
+			want: []string{"medium/.git/HEAD", "medium/.git/space_eater"},
+		},
+		{
+			name:     "largest-first",
+			eviction: &schema.GitServerEviction{Policy: "largest-first"},
+			want: []string{
+				"medium/.git/HEAD", "medium/.git/space_eater",
+				"small/.git/HEAD", "small/.git/space_eater",
+			},
+		},
+		{
+			name:     "pinned",
+			eviction: &schema.GitServerEviction{PinnedRepos: []string{"^small$"}},
+			want: []string{
+				"medium/.git/HEAD", "medium/.git/space_eater",
+				"small/.git/HEAD", "small/.git/space_eater",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{GitServerEviction: tc.eviction}})
+			defer conf.Mock(nil)
+
+			rd := makeFakeRepos(t, sizes, order...)
+			s := &Server{ReposDir: rd, DiskSizer: &fakeDiskSizer{}}
+			if err := s.freeUpSpace(2000); err != nil {
+				t.Fatal(err)
+			}
+			assertPaths(t, rd, append([]string{".tmp"}, tc.want...)...)
+		})
+	}
+
+	t.Run("pinned repos are never removed", func(t *testing.T) {
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+			GitServerEviction: &schema.GitServerEviction{PinnedRepos: []string{"."}},
+		}})
+		defer conf.Mock(nil)
+
+		rd := makeFakeRepos(t, sizes, order...)
+		s := &Server{ReposDir: rd, DiskSizer: &fakeDiskSizer{}}
+		if err := s.freeUpSpace(1); err == nil {
+			t.Fatal("want error")
+		}
+	})
+}
+
+func TestEnforceDiskQuotas(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		GitServerEviction: &schema.GitServerEviction{PinnedRepos: []string{"^a/pinned$"}},
+	}})
+	defer conf.Mock(nil)
+
+	sizes := map[string]int{
+		"a/pinned": 1000,
+		"a/old":    1000,
+		"a/new":    1000,
+		"b/old":    1000,
+		"c/old":    1000,
+	}
+	rd := makeFakeRepos(t, sizes, "a/pinned", "a/old", "b/old", "c/old", "a/new")
+
+	quotas := map[string]*DiskQuota{
+		"a": {ExternalServiceID: 1, MaxBytes: 2000},
+		"b": {ExternalServiceID: 2, MaxBytes: 2000},
+	}
+	s := &Server{
+		ReposDir: rd,
+		GetDiskQuotasFunc: func(_ context.Context, repos []api.RepoName) (map[api.RepoName]*DiskQuota, error) {
+			m := make(map[api.RepoName]*DiskQuota)
+			for _, repo := range repos {
+				if quota, ok := quotas[strings.Split(string(repo), "/")[0]]; ok {
+					m[repo] = quota
+				}
+			}
+			return m, nil
+		},
+	}
+	if err := s.enforceDiskQuotas(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// a/old is the least recently used repo of a, which is not pinned.
+	assertPaths(t, rd,
+		".tmp",
+		"a/new/.git/HEAD", "a/new/.git/space_eater",
+		"a/pinned/.git/HEAD", "a/pinned/.git/space_eater",
+		"b/old/.git/HEAD", "b/old/.git/space_eater",
+		"c/old/.git/HEAD", "c/old/.git/space_eater")
+}
+
+func TestRepoSize(t *testing.T) {
+	rd := t.TempDir()
+	remote := filepath.Join(rd, "repo")
+	if err := os.MkdirAll(remote, 0755); err != nil {
+		t.Fatal(err)
+	}
+	makeSingleCommitRepo(func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, remote, name, arg...)
+	})
+
+	s := &Server{ReposDir: rd}
+	dir := s.dir("repo")
+	if size, ok := s.repoSize("repo"); ok {
+		t.Fatalf("got size %d, want no size before the first cleanup run", size)
+	}
+	if size := s.repoSizeBestEffort(dir); size != dirSize(dir.Path(".")) {
+		t.Fatalf("got best effort size %d, want computed size %d", size, dirSize(dir.Path(".")))
+	}
+
+	// The sizes of the last cleanup run are used after a restart.
+	b, err := json.Marshal(protocol.ReposStats{RepoBytes: map[api.RepoName]int64{"repo": 1234}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(rd, reposStatsName), b, 0666); err != nil {
+		t.Fatal(err)
+	}
+	s = &Server{ReposDir: rd}
+	if size, ok := s.repoSize("repo"); !ok || size != 1234 {
+		t.Fatalf("got size %d (recorded: %t), want 1234", size, ok)
+	}
+
+	s.setRepoSizes(map[api.RepoName]int64{"repo": 42})
+	if size := s.repoSizeBestEffort(dir); size != 42 {
+		t.Fatalf("got best effort size %d, want recorded size 42", size)
+	}
+}
@@ -0,0 +1,266 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+)
+
+// Partial clones omit objects, usually large blobs, when cloning and fetching.
+// Git fetches omitted objects on demand from the "promisor" remote when a
+// command needs them.
+//
+// We never store the URL of a remote on disk since it may contain
+// credentials. Instead the remote "origin" is configured as the promisor
+// remote without a URL, and the URL is passed through the environment to the
+// commands which may need to fetch missing objects.
+
+var (
+	lazyFetchCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_gitserver_lazy_fetch_total",
+		Help: "Number of objects fetched on demand for partial clones, by status.",
+	}, []string{"status"})
+	partialCloneFallbackCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_partial_clone_fallback_total",
+		Help: "Number of partial clones which failed and were retried as full clones.",
+	})
+)
+
+// partialCloneConfigRe matches the extensions.partialClone key, which git only
+// reads for partial clones.
+var partialCloneConfigRe = lazyregexp.New(`(?mi)^\s*partialclone\s*=`)
+
+// isPartialClone returns true if the repository at dir is a partial clone.
+func isPartialClone(dir GitDir) bool {
+	b, err := os.ReadFile(dir.Path("config"))
+	if err != nil {
+		return false
+	}
+	return partialCloneConfigRe.Match(b)
+}
+
+// configurePartialClone configures the repository at dir to be a partial
+// clone of origin which omits objects according to filter, such as
+// "blob:limit=1m".
+func configurePartialClone(dir GitDir, filter string) error {
+	for _, kv := range [][2]string{
+		{"remote.origin.promisor", "true"},
+		{"remote.origin.partialclonefilter", filter},
+		{"extensions.partialClone", "origin"},
+	} {
+		if err := gitConfigSet(dir, kv[0], kv[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// minPartialCloneGitVersion is the oldest git version which supports partial
+// clones as we configure them: promisorRemoteEnv relies on GIT_CONFIG_COUNT,
+// which was added in git 2.31.
+var minPartialCloneGitVersion = [2]int{2, 31}
+
+// CheckPartialCloneGitVersion returns an error if the installed git is older
+// than minPartialCloneGitVersion.
+func CheckPartialCloneGitVersion(ctx context.Context) error {
+	out, err := exec.CommandContext(ctx, "git", "version").Output()
+	if err != nil {
+		return errors.Wrap(err, "git version")
+	}
+	major, minor, err := parseGitVersion(string(out))
+	if err != nil {
+		return err
+	}
+	if major < minPartialCloneGitVersion[0] || (major == minPartialCloneGitVersion[0] && minor < minPartialCloneGitVersion[1]) {
+		return errors.Errorf("partial clones require git %d.%d or later, found %d.%d",
+			minPartialCloneGitVersion[0], minPartialCloneGitVersion[1], major, minor)
+	}
+	return nil
+}
+
+// parseGitVersion returns the major and minor version of the output of git
+// version, such as "git version 2.34.1" or "git version 2.30.1 (Apple
+// Git-130)".
+func parseGitVersion(out string) (major, minor int, err error) {
+	fields := strings.Fields(out)
+	if len(fields) < 3 || fields[0] != "git" || fields[1] != "version" {
+		return 0, 0, errors.Errorf("unexpected git version output %q", out)
+	}
+	parts := strings.SplitN(fields[2], ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, errors.Errorf("unexpected git version %q", fields[2])
+	}
+	if major, err = strconv.Atoi(parts[0]); err != nil {
+		return 0, 0, errors.Wrapf(err, "unexpected git version %q", fields[2])
+	}
+	if minor, err = strconv.Atoi(parts[1]); err != nil {
+		return 0, 0, errors.Wrapf(err, "unexpected git version %q", fields[2])
+	}
+	return major, minor, nil
+}
+
+// promisorRemoteEnv returns the environment variables which configure
+// remoteURL as the URL of the promisor remote.
+func promisorRemoteEnv(remoteURL *vcs.URL) []string {
+	// Lazy fetches are run by git itself, so the options which
+	// configureRemoteGitCommand passes as arguments are passed through the
+	// environment as well.
+	config := [][2]string{
+		{"remote.origin.url", remoteURL.String()},
+		{"credential.helper", ""},
+		{"protocol.version", "2"},
+	}
+	env := append(remoteGitCommandEnv(tlsExternal().(*tlsConfig)), "GIT_CONFIG_COUNT="+strconv.Itoa(len(config)))
+	for i, kv := range config {
+		env = append(env,
+			"GIT_CONFIG_KEY_"+strconv.Itoa(i)+"="+kv[0],
+			"GIT_CONFIG_VALUE_"+strconv.Itoa(i)+"="+kv[1])
+	}
+	return env
+}
+
+// partialCloneFetchCmd returns the command to fetch refspecs into a partial
+// clone. The filter configured for origin is applied to the fetch.
+func partialCloneFetchCmd(ctx context.Context, remoteURL *vcs.URL, refspecs []string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"fetch", "--progress", "--prune", "origin"}, refspecs...)...)
+	cmd.Env = append(os.Environ(), promisorRemoteEnv(remoteURL)...)
+	return cmd
+}
+
+// prepareLazyFetch allows cmd, which runs in the repository at dir, to fetch
+// objects missing from a partial clone of repo. The returned function must be
+// called with the stderr of cmd after it ran. It records the lazy fetches and
+// returns stderr with credentials of the remote URL redacted.
+//
+// For full clones cmd is left as is.
+func (s *Server) prepareLazyFetch(ctx context.Context, repo api.RepoName, dir GitDir, cmd *exec.Cmd) (done func(stderr string) string) {
+	noop := func(stderr string) string { return stderr }
+	if !isPartialClone(dir) {
+		return noop
+	}
+
+	remoteURL, err := s.getRemoteURL(ctx, repo)
+	if err != nil {
+		log15.Warn("failed to get remote URL for lazy fetches", "repo", repo, "error", err)
+		return noop
+	}
+
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, promisorRemoteEnv(remoteURL)...)
+
+	// Git reports the processes it runs, including lazy fetches and their
+	// exit codes, as trace2 events.
+	trace, err := os.CreateTemp("", "lazy-fetch-trace2-*.json")
+	if err != nil {
+		log15.Warn("failed to create trace2 file for lazy fetches", "repo", repo, "error", err)
+		return func(stderr string) string { return newURLRedactor(remoteURL).redact(stderr) }
+	}
+	trace.Close()
+	cmd.Env = append(cmd.Env, "GIT_TRACE2_EVENT="+trace.Name())
+
+	return func(stderr string) string {
+		defer os.Remove(trace.Name())
+		stderr = newURLRedactor(remoteURL).redact(stderr)
+		events, err := os.ReadFile(trace.Name())
+		if err != nil {
+			log15.Warn("failed to read trace2 events for lazy fetches", "repo", repo, "error", err)
+			return stderr
+		}
+		observeLazyFetches(repo, events, stderr)
+		return stderr
+	}
+}
+
+// trace2Event is the subset of a git trace2 event we use to observe lazy
+// fetches. See
+// https://git-scm.com/docs/api-trace2#_event_format.
+type trace2Event struct {
+	Event   string   `json:"event"`
+	SID     string   `json:"sid"`
+	ChildID int      `json:"child_id"`
+	Argv    []string `json:"argv"`
+	Code    int      `json:"code"`
+}
+
+// lazyFetchResults returns the number of lazy fetches which succeeded and
+// failed, given the trace2 events of a command. Lazy fetches are the git
+// fetch child processes which read the missing objects from stdin. A lazy
+// fetch which didn't exit, for example because the command was canceled,
+// failed.
+func lazyFetchResults(events []byte) (succeeded, failed int) {
+	type childKey struct {
+		sid string
+		id  int
+	}
+	pending := map[childKey]struct{}{}
+	for _, line := range bytes.Split(events, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		var e trace2Event
+		if err := json.Unmarshal(line, &e); err != nil {
+			continue
+		}
+		k := childKey{sid: e.SID, id: e.ChildID}
+		switch e.Event {
+		case "child_start":
+			if isLazyFetchArgv(e.Argv) {
+				pending[k] = struct{}{}
+			}
+		case "child_exit":
+			if _, ok := pending[k]; !ok {
+				continue
+			}
+			delete(pending, k)
+			if e.Code == 0 {
+				succeeded++
+			} else {
+				failed++
+			}
+		}
+	}
+	return succeeded, failed + len(pending)
+}
+
+// isLazyFetchArgv returns true if argv is the command line git runs to fetch
+// objects missing from a partial clone.
+func isLazyFetchArgv(argv []string) bool {
+	fetch, stdin := false, false
+	for _, arg := range argv {
+		switch arg {
+		case "fetch":
+			fetch = true
+		case "--stdin":
+			stdin = true
+		}
+	}
+	return fetch && stdin
+}
+
+// observeLazyFetches records the lazy fetches of a command, given its trace2
+// events and its output.
+func observeLazyFetches(repo api.RepoName, events []byte, output string) {
+	succeeded, failed := lazyFetchResults(events)
+	if failed > 0 {
+		log15.Warn("lazy fetch for partial clone failed", "repo", repo, "output", output)
+		lazyFetchCounter.WithLabelValues("error").Add(float64(failed))
+	}
+	if succeeded > 0 {
+		lazyFetchCounter.WithLabelValues("success").Add(float64(succeeded))
+	}
+}
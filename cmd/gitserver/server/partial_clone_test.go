@@ -0,0 +1,198 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+)
+
+func TestGitRepoSyncer_PartialClone(t *testing.T) {
+	ctx := context.Background()
+
+	remote := t.TempDir()
+	cmd := func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, remote, name, arg...)
+	}
+	cmd("git", "init", ".")
+	cmd("git", "config", "uploadpack.allowFilter", "true")
+	cmd("git", "config", "uploadpack.allowAnySHA1InWant", "true")
+	bigContent := strings.Repeat("big file\n", 1000)
+	if err := os.WriteFile(filepath.Join(remote, "big.txt"), []byte(bigContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd("sh", "-c", "echo small > small.txt")
+	cmd("git", "add", ".")
+	cmd("git", "commit", "-m", "init")
+
+	remoteURL, err := vcs.ParseURL("file://" + remote)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := GitDir(filepath.Join(t.TempDir(), ".git"))
+	syncer := &GitRepoSyncer{PartialCloneFilter: "blob:limit=1k"}
+	clone, err := syncer.CloneCommand(ctx, remoteURL, string(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output, err := runWithRemoteOpts(ctx, clone, nil); err != nil {
+		t.Fatalf("clone failed: %s\nOutput: %s", err, output)
+	}
+
+	if !isPartialClone(dir) {
+		t.Fatal("expected a partial clone")
+	}
+	config, err := os.ReadFile(dir.Path("config"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(config), remote) {
+		t.Fatalf("expected remote URL not to be stored in git config:\n%s", config)
+	}
+
+	missingObjects := func() int {
+		t.Helper()
+		c := exec.Command("git", "rev-list", "--objects", "--all", "--missing=print")
+		dir.Set(c)
+		out, err := c.Output()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.Count(string(out), "\n?")
+	}
+	if got := missingObjects(); got != 1 {
+		t.Fatalf("got %d missing objects, want only big.txt to be missing", got)
+	}
+
+	// Commands fetch the missing blob on demand.
+	s := &Server{GetRemoteURLFunc: staticGetRemoteURL(remoteURL.String())}
+	packs := countPromisorPacks(dir)
+	succeeded, failed := lazyFetchCount("success"), lazyFetchCount("error")
+	show := exec.Command("git", "show", "HEAD:big.txt")
+	dir.Set(show)
+	done := s.prepareLazyFetch(ctx, "repo", dir, show)
+	out, err := show.Output()
+	done("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != bigContent {
+		t.Fatalf("unexpected content of big.txt: %q", out)
+	}
+	if got := countPromisorPacks(dir); got != packs+1 {
+		t.Errorf("got %d promisor packs, want %d", got, packs+1)
+	}
+	if got := missingObjects(); got != 0 {
+		t.Errorf("got %d missing objects after lazy fetch, want 0", got)
+	}
+	if got := lazyFetchCount("success"); got != succeeded+1 {
+		t.Errorf("got %v successful lazy fetches, want %v", got, succeeded+1)
+	}
+	if got := lazyFetchCount("error"); got != failed {
+		t.Errorf("got %v failed lazy fetches, want %v", got, failed)
+	}
+
+	// Fetches keep the repository partial, even if it is no longer configured
+	// to be cloned partially.
+	if err := os.WriteFile(filepath.Join(remote, "big.txt"), []byte(bigContent+bigContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd("git", "commit", "-am", "bigger")
+	if err := (&GitRepoSyncer{}).Fetch(ctx, remoteURL, dir); err != nil {
+		t.Fatal(err)
+	}
+	if got := missingObjects(); got != 1 {
+		t.Errorf("got %d missing objects after fetch, want 1", got)
+	}
+
+	// Failed lazy fetches are recorded as well.
+	s = &Server{GetRemoteURLFunc: staticGetRemoteURL("file://" + t.TempDir())}
+	show = exec.Command("git", "show", "HEAD:big.txt")
+	dir.Set(show)
+	done = s.prepareLazyFetch(ctx, "repo", dir, show)
+	out, err = show.CombinedOutput()
+	done(string(out))
+	if err == nil {
+		t.Fatal("expected lazy fetch from missing remote to fail")
+	}
+	if got := lazyFetchCount("error"); got != failed+1 {
+		t.Errorf("got %v failed lazy fetches, want %v", got, failed+1)
+	}
+}
+
+func lazyFetchCount(status string) float64 {
+	return testutil.ToFloat64(lazyFetchCounter.WithLabelValues(status))
+}
+
+// countPromisorPacks returns the number of packs in the repository at dir
+// which were fetched from the promisor remote. Every lazy fetch adds one.
+func countPromisorPacks(dir GitDir) int {
+	matches, _ := filepath.Glob(dir.Path("objects", "pack", "*.promisor"))
+	return len(matches)
+}
+
+func TestGitRepoSyncer_FullClone(t *testing.T) {
+	ctx := context.Background()
+
+	remote := t.TempDir()
+	makeSingleCommitRepo(func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, remote, name, arg...)
+	})
+	remoteURL, err := vcs.ParseURL("file://" + remote)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := GitDir(filepath.Join(t.TempDir(), ".git"))
+	clone, err := (&GitRepoSyncer{}).CloneCommand(ctx, remoteURL, string(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output, err := runWithRemoteOpts(ctx, clone, nil); err != nil {
+		t.Fatalf("clone failed: %s\nOutput: %s", err, output)
+	}
+
+	if isPartialClone(dir) {
+		t.Fatal("expected a full clone")
+	}
+
+	// Commands against full clones are left as is.
+	s := &Server{}
+	c := exec.Command("git", "log")
+	s.prepareLazyFetch(ctx, "repo", dir, c)
+	if c.Env != nil {
+		t.Errorf("expected no environment to be set, got %v", c.Env)
+	}
+}
+
+func TestParseGitVersion(t *testing.T) {
+	for out, want := range map[string][2]int{
+		"git version 2.34.1\n":                 {2, 34},
+		"git version 2.30.1 (Apple Git-130)\n": {2, 30},
+		"git version 2.35.1.windows.2\n":       {2, 35},
+	} {
+		major, minor, err := parseGitVersion(out)
+		if err != nil {
+			t.Errorf("parseGitVersion(%q): %s", out, err)
+			continue
+		}
+		if got := [2]int{major, minor}; got != want {
+			t.Errorf("parseGitVersion(%q) = %v, want %v", out, got, want)
+		}
+	}
+
+	for _, out := range []string{"", "git version", "git version two.thirty", "hg version 2.34.1"} {
+		if _, _, err := parseGitVersion(out); err == nil {
+			t.Errorf("parseGitVersion(%q): expected error", out)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -251,7 +252,11 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 	}
 
 	if req.Push != nil {
-		cmd = exec.CommandContext(ctx, "git", "push", "--force", remoteURL.String(), fmt.Sprintf("%s:%s", cmtHash, ref))
+		pushRef := ref
+		if req.PushRef != nil {
+			pushRef = *req.PushRef
+		}
+		cmd = exec.CommandContext(ctx, "git", "push", "--force", remoteURL.String(), fmt.Sprintf("%s:%s", cmtHash, pushRef))
 		cmd.Dir = repoGitDir
 
 		// If the protocol is SSH and a private key was given, we want to
@@ -280,8 +285,14 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 		}
 
 		if out, err = run(cmd, "pushing ref"); err != nil {
-			log15.Error("Failed to push", "ref", ref, "commit", cmtHash, "output", string(out))
-			return http.StatusInternalServerError, resp
+			// Gerrit rejects pushes of commits that are already a patch set
+			// of a change, which happens when a push is retried.
+			if req.PushRef != nil && bytes.Contains(out, []byte("no new changes")) {
+				resp.Error = nil
+			} else {
+				log15.Error("Failed to push", "ref", ref, "commit", cmtHash, "output", string(out))
+				return http.StatusInternalServerError, resp
+			}
 		}
 	}
 
@@ -59,6 +59,10 @@ func (s *Server) repoInfo(ctx context.Context, repo api.RepoName) (*protocol.Rep
 		} else {
 			resp.LastChanged = &lastChanged
 		}
+
+		if size, ok := s.repoSize(repo); ok {
+			resp.SizeBytes = size
+		}
 	}
 	return &resp, nil
 }
@@ -206,6 +206,13 @@ type Server struct {
 	// usually set to return a GitRepoSyncer.
 	GetVCSSyncer func(context.Context, api.RepoName) (VCSSyncer, error)
 
+	// GetDiskQuotasFunc is a function which returns the disk quotas which apply
+	// to the given repositories. Repositories without a quota are left out. In
+	// production this will speak to the database to look up the configuration
+	// of the code hosts of the repositories. Disk quotas are not enforced if
+	// GetDiskQuotasFunc is nil.
+	GetDiskQuotasFunc func(context.Context, []api.RepoName) (map[api.RepoName]*DiskQuota, error)
+
 	// Hostname is how we identify this instance of gitserver. Generally it is the
 	// actual hostname but can also be overridden by the HOSTNAME environment variable.
 	Hostname string
@@ -243,6 +250,11 @@ type Server struct {
 
 	repoUpdateLocksMu sync.Mutex // protects the map below and also updates to locks.once
 	repoUpdateLocks   map[api.RepoName]*locks
+
+	repoSizesMu sync.Mutex // protects the map below
+	// repoSizes are the sizes in bytes of the repositories as computed by the
+	// last cleanup run. Use s.repoSize() instead of using this directly.
+	repoSizes map[api.RepoName]int64
 }
 
 type locks struct {
@@ -364,6 +376,10 @@ func (s *Server) Handler() http.Handler {
 
 	gitAdapter := &adapters.Git{
 		ReposDir: s.ReposDir,
+		// Objects missing from partial clones are fetched on demand.
+		PrepareCommand: func(ctx context.Context, repo api.RepoName, cmd *exec.Cmd) func(output string) string {
+			return s.prepareLazyFetch(ctx, repo, s.dir(repo), cmd)
+		},
 	}
 	getObjectService := gitdomain.GetObjectService{
 		RevParse:      gitAdapter.RevParse,
@@ -1058,6 +1074,11 @@ func (s *Server) search(ctx context.Context, args *protocol.SearchRequest, match
 			Revisions:   args.Revisions,
 			Query:       mt,
 			IncludeDiff: args.IncludeDiff,
+			// Diffs of partial clones may need blobs which are fetched on
+			// demand.
+			PrepareCommand: func(cmd *exec.Cmd) func(string) string {
+				return s.prepareLazyFetch(ctx, args.Repo, dir, cmd)
+			},
 		}
 
 		return searcher.Search(ctx, func(match *protocol.CommitMatch) {
@@ -1324,6 +1345,7 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 	dir.Set(cmd)
 	cmd.Stdout = stdoutW
 	cmd.Stderr = stderrW
+	lazyFetchDone := s.prepareLazyFetch(ctx, req.Repo, dir, cmd)
 
 	exitStatus, execErr = runCommand(ctx, cmd)
 
@@ -1331,7 +1353,7 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 	stdoutN = stdoutW.n
 	stderrN = stderrW.n
 
-	stderr := stderrBuf.String()
+	stderr := lazyFetchDone(stderrBuf.String())
 	checkMaybeCorruptRepo(req.Repo, dir, stderr)
 
 	// write trailer
@@ -1735,25 +1757,47 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syn
 		s.setCloneStatusNonFatal(context.Background(), repo, cloneStatus(repoCloned(dir), false))
 	}()
 
-	cmd, err := syncer.CloneCommand(ctx, remoteURL, tmpPath)
-	if err != nil {
-		return errors.Wrap(err, "get clone command")
-	}
-	if cmd.Env == nil {
-		cmd.Env = os.Environ()
-	}
-
-	// see issue #7322: skip LFS content in repositories with Git LFS configured
-	cmd.Env = append(cmd.Env, "GIT_LFS_SKIP_SMUDGE=1")
-	log15.Info("cloning repo", "repo", repo, "tmp", tmpPath, "dst", dstPath)
-
 	pr, pw := io.Pipe()
 	defer pw.Close()
 
 	go readCloneProgress(newURLRedactor(remoteURL), lock, pr, repo)
 
-	if output, err := runWithRemoteOpts(ctx, cmd, pw); err != nil {
-		return errors.Wrapf(err, "clone failed. Output: %s", string(output))
+	clone := func(syncer VCSSyncer) error {
+		cmd, err := syncer.CloneCommand(ctx, remoteURL, tmpPath)
+		if err != nil {
+			return errors.Wrap(err, "get clone command")
+		}
+		if cmd.Env == nil {
+			cmd.Env = os.Environ()
+		}
+
+		// see issue #7322: skip LFS content in repositories with Git LFS configured
+		cmd.Env = append(cmd.Env, "GIT_LFS_SKIP_SMUDGE=1")
+		log15.Info("cloning repo", "repo", repo, "tmp", tmpPath, "dst", dstPath)
+
+		if output, err := runWithRemoteOpts(ctx, cmd, pw); err != nil {
+			return errors.Wrapf(err, "clone failed. Output: %s", string(output))
+		}
+		return nil
+	}
+
+	if err := clone(syncer); err != nil {
+		// Not every code host supports partial clones, so we retry with a full
+		// clone.
+		gitSyncer, ok := syncer.(*GitRepoSyncer)
+		if !ok || gitSyncer.PartialCloneFilter == "" || ctx.Err() != nil {
+			return err
+		}
+		log15.Warn("partial clone failed, falling back to full clone", "repo", repo, "error", err)
+		partialCloneFallbackCounter.Inc()
+
+		if err := os.RemoveAll(tmpPath); err != nil {
+			return err
+		}
+		syncer = &GitRepoSyncer{}
+		if err := clone(syncer); err != nil {
+			return err
+		}
 	}
 
 	if testRepoCorrupter != nil {
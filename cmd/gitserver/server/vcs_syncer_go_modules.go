@@ -0,0 +1,156 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gomodules/gomodproxy"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+var placeholderGoDependency = func() reposource.GoDependency {
+	dep, err := reposource.ParseGoDependency("sourcegraph.com/placeholder@v1.0.0")
+	if err != nil {
+		panic(fmt.Sprintf("expected placeholder dependency to parse but got %v", err))
+	}
+	return *dep
+}()
+
+// GoModulesSyncer creates git repositories from the zip archives of Go modules
+// served by Go module proxies. Every version of a module is committed to a
+// tag.
+type GoModulesSyncer struct {
+	Config *schema.GoModulesConnection
+}
+
+var _ VCSSyncer = &GoModulesSyncer{}
+
+func (s *GoModulesSyncer) Type() string {
+	return "go_modules"
+}
+
+// IsCloneable checks to see if the VCS remote URL is cloneable. Any non-nil
+// error indicates there is a problem.
+func (s *GoModulesSyncer) IsCloneable(ctx context.Context, remoteURL *vcs.URL) error {
+	_, err := s.packageDependencies(ctx, remoteURL.Path)
+	return err
+}
+
+// Similar to CloneCommand for NPMPackagesSyncer; it handles cloning itself
+// instead of returning a command that does the cloning.
+func (s *GoModulesSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, bareGitDirectory string) (*exec.Cmd, error) {
+	err := os.MkdirAll(bareGitDirectory, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "--bare", "init")
+	if _, err := runCommandInDirectory(ctx, cmd, bareGitDirectory, placeholderGoDependency); err != nil {
+		return nil, err
+	}
+
+	// The Fetch method is responsible for cleaning up temporary directories.
+	if err := s.Fetch(ctx, remoteURL, GitDir(bareGitDirectory)); err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch repo for %s", remoteURL)
+	}
+
+	// no-op command to satisfy VCSSyncer interface, see docstring for more details.
+	return exec.CommandContext(ctx, "git", "--version"), nil
+}
+
+// Fetch adds git tags for newly added dependency versions and removes git tags
+// for deleted versions.
+func (s *GoModulesSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir) error {
+	dependencies, err := s.packageDependencies(ctx, remoteURL.Path)
+	if err != nil {
+		return err
+	}
+
+	versioned := make([]versionedPackageDependency, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		versioned = append(versioned, dependency)
+	}
+	return syncPackageTags(ctx, dir, placeholderGoDependency, versioned, func(dependency versionedPackageDependency, isLatestVersion bool) error {
+		return s.gitPushDependencyTag(ctx, string(dir), dependency.(reposource.GoDependency), isLatestVersion)
+	})
+}
+
+// RemoteShowCommand returns the command to be executed for showing remote.
+func (s *GoModulesSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, err error) {
+	return exec.CommandContext(ctx, "git", "remote", "show", "./"), nil
+}
+
+// packageDependencies returns the list of Go dependencies that belong to the
+// given URL path and exist in the configured proxies. The returned
+// dependencies are sorted in descending semver order (newest first).
+func (s *GoModulesSyncer) packageDependencies(ctx context.Context, repoUrlPath string) (matchingDependencies []reposource.GoDependency, err error) {
+	repoModule, err := reposource.ParseGoModule(repoUrlPath)
+	if err != nil {
+		return nil, err
+	}
+
+	client := s.client()
+	for _, configDependencyString := range s.Config.Dependencies {
+		if !repoModule.MatchesDependencyString(configDependencyString) {
+			continue
+		}
+		dependency, err := reposource.ParseGoDependency(configDependencyString)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := client.Info(ctx, *dependency); err != nil {
+			if errcode.IsNotFound(err) {
+				log15.Warn("skipping missing go dependency", "dependency", dependency.PackageManagerSyntax(), "error", err)
+				continue
+			}
+			return nil, err
+		}
+		matchingDependencies = append(matchingDependencies, *dependency)
+	}
+
+	if len(matchingDependencies) == 0 {
+		return nil, errors.Errorf("no Go dependencies for URL path %s", repoUrlPath)
+	}
+
+	reposource.SortGoDependencies(matchingDependencies)
+	return matchingDependencies, nil
+}
+
+func (s *GoModulesSyncer) client() *gomodproxy.Client {
+	return gomodproxy.NewClient(s.Config.Urls, httpcli.ExternalDoer)
+}
+
+// gitPushDependencyTag pushes a git tag to the given bareGitDirectory path. The
+// tag points to a commit that adds all sources of given dependency. When
+// isLatestVersion is true, the latest branch of the bare git directory will
+// also be updated to point to the same commit as the git tag.
+func (s *GoModulesSyncer) gitPushDependencyTag(ctx context.Context, bareGitDirectory string, dependency reposource.GoDependency, isLatestVersion bool) error {
+	body, err := s.client().Zip(ctx, dependency)
+	if err != nil {
+		return err
+	}
+	zipPath, err := downloadToTempFile(body, "gomod-*.zip")
+	body.Close()
+	if err != nil {
+		return err
+	}
+	defer os.Remove(zipPath)
+
+	return pushPackageTag(ctx, bareGitDirectory, dependency, isLatestVersion, func(workingDirectory string) error {
+		// All files of a module zip are in a "path@version/" directory,
+		// see https://go.dev/ref/mod#zip-files
+		if err := decompressZip(zipPath, workingDirectory, dependency.PackageManagerSyntax()+"/"); err != nil {
+			return errors.Wrapf(err, "failed to decompress zip for %s", dependency.PackageManagerSyntax())
+		}
+		return commitPackageSources(ctx, workingDirectory, dependency)
+	})
+}
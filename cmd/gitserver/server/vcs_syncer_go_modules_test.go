@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGoModulesCloneCommand(t *testing.T) {
+	dir := t.TempDir()
+
+	// A file-backed proxy, laid out like $GOPATH/pkg/mod/cache/download.
+	proxy := path.Join(dir, "proxy")
+	versions := path.Join(proxy, "example.com", "!example", "@v")
+	assert.Nil(t, os.MkdirAll(versions, 0755))
+	for _, version := range []string{"v1.0.0", "v1.1.0"} {
+		assert.Nil(t, os.WriteFile(path.Join(versions, version+".info"), []byte(`{"Version":"`+version+`"}`), 0644))
+		createZip(t, path.Join(versions, version+".zip"), []fileInfo{
+			{"example.com/Example@" + version + "/go.mod", []byte("module example.com/Example")},
+			{"example.com/Example@" + version + "/example.go", []byte("package example // " + version)},
+		})
+	}
+
+	s := GoModulesSyncer{
+		Config: &schema.GoModulesConnection{Urls: []string{"file://" + proxy}},
+	}
+	bareGitDirectory := path.Join(dir, "git")
+	moduleURL := vcs.URL{URL: url.URL{Path: "go/example.com/Example"}}
+	clone := func(dependencies ...string) {
+		t.Helper()
+		s.Config.Dependencies = dependencies
+		cmd, err := s.CloneCommand(context.Background(), &moduleURL, bareGitDirectory)
+		assert.Nil(t, err)
+		assert.Nil(t, cmd.Run())
+	}
+
+	clone("example.com/Example@v1.0.0")
+	assertCommandOutput(t, exec.Command("git", "tag", "--list"), bareGitDirectory, "v1.0.0\n")
+	assertCommandOutput(t, exec.Command("git", "show", "v1.0.0:example.go"), bareGitDirectory, "package example // v1.0.0")
+
+	clone("example.com/Example@v1.0.0", "example.com/Example@v1.1.0")
+	assertCommandOutput(t, exec.Command("git", "tag", "--list"), bareGitDirectory, "v1.0.0\nv1.1.0\n")
+	assertCommandOutput(t, exec.Command("git", "show", "v1.1.0:example.go"), bareGitDirectory, "package example // v1.1.0")
+	assertCommandOutput(t, exec.Command("git", "show", "latest:go.mod"), bareGitDirectory, "module example.com/Example")
+
+	clone("example.com/Example@v1.1.0")
+	assertCommandOutput(t, exec.Command("git", "tag", "--list"), bareGitDirectory, "v1.1.0\n")
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/exec"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+)
+
+// HgRepoSyncer is a syncer for Mercurial repositories. The history of the
+// Mercurial repository is converted to a bare Git repository with the
+// git-remote-hg remote helper.
+//
+// git-remote-hg stores the mapping between Mercurial changesets and Git
+// commits (its marks) in the hg directory of the Git repository. The mapping
+// is kept between fetches, so only new changesets are converted and the
+// resulting commit IDs are stable.
+type HgRepoSyncer struct{}
+
+func (s *HgRepoSyncer) Type() string {
+	return "hg"
+}
+
+// IsCloneable checks to see if the Mercurial remote URL is cloneable.
+func (s *HgRepoSyncer) IsCloneable(ctx context.Context, remoteURL *vcs.URL) error {
+	args := []string{"identify", "--noninteractive", remoteURL.String()}
+	ctx, cancel := context.WithTimeout(ctx, shortGitCommandTimeout(args))
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "hg", args...)
+	out, err := runWith(ctx, cmd, false, nil)
+	if err != nil {
+		if ctxerr := ctx.Err(); ctxerr != nil {
+			err = ctxerr
+		}
+		if len(out) > 0 {
+			err = errors.Errorf("%s (output follows)\n\n%s", err, newURLRedactor(remoteURL).redact(string(out)))
+		}
+		return err
+	}
+	return nil
+}
+
+// CloneCommand returns the command to be executed for cloning a Mercurial
+// repository as a Git repository.
+func (s *HgRepoSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tmpPath string) (cmd *exec.Cmd, err error) {
+	if err := os.MkdirAll(tmpPath, os.ModePerm); err != nil {
+		return nil, errors.Wrapf(err, "clone failed to create tmp dir")
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "init", "--bare", ".")
+	cmd.Dir = tmpPath
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "clone setup failed")
+	}
+
+	cmd = s.fetchCommand(ctx, remoteURL)
+	cmd.Dir = tmpPath
+	return cmd, nil
+}
+
+// fetchCommand returns the command which converts the changesets of the
+// Mercurial repository that are not yet in the Git repository. Mercurial
+// bookmarks and the default branch become branches, named branches become
+// branches/<name>, and tags become tags.
+func (s *HgRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) *exec.Cmd {
+	return exec.CommandContext(ctx, "git", "fetch",
+		"--progress", "--prune", hgRemote(remoteURL),
+		"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*")
+}
+
+// Fetch converts new changesets of a Mercurial repository to the Git
+// repository in dir.
+func (s *HgRepoSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir) error {
+	cmd := s.fetchCommand(ctx, remoteURL)
+	dir.Set(cmd)
+	if output, err := runWith(ctx, cmd, false, nil); err != nil {
+		return errors.Wrapf(err, "failed to update with output %q", newURLRedactor(remoteURL).redact(string(output)))
+	}
+	return nil
+}
+
+// RemoteShowCommand returns the command to be executed for showing the Git
+// remote of a Mercurial repository.
+func (s *HgRepoSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, err error) {
+	// The converted repository is the remote, so HEAD is determined from
+	// its branches.
+	return exec.CommandContext(ctx, "git", "remote", "show", "./"), nil
+}
+
+// hgRemote returns the Git remote for remoteURL which is handled by the
+// git-remote-hg remote helper.
+func hgRemote(remoteURL *vcs.URL) string {
+	return "hg::" + remoteURL.String()
+}
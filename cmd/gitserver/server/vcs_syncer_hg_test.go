@@ -0,0 +1,39 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+)
+
+func TestHgRepoSyncer_CloneCommand(t *testing.T) {
+	remoteURL, err := vcs.ParseURL("https://hg.example.org/repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmp := filepath.Join(t.TempDir(), "repo")
+	cmd, err := (&HgRepoSyncer{}).CloneCommand(context.Background(), remoteURL, tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmp, "HEAD")); err != nil {
+		t.Fatalf("expected a bare repository to be initialized: %s", err)
+	}
+	if cmd.Dir != tmp {
+		t.Errorf("got dir %q, want %q", cmd.Dir, tmp)
+	}
+	want := []string{
+		"git", "fetch", "--progress", "--prune", "hg::https://hg.example.org/repo",
+		"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*",
+	}
+	if diff := cmp.Diff(want, cmd.Args); diff != "" {
+		t.Errorf("unexpected args (-want +got):\n%s", diff)
+	}
+}
@@ -115,46 +115,13 @@ func (s *JVMPackagesSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir G
 		return err
 	}
 
-	tags := map[string]bool{}
-
-	out, err := runCommandInDirectory(ctx, exec.CommandContext(ctx, "git", "tag"), string(dir), placeholderMavenDependency)
-	if err != nil {
-		return err
-	}
-
-	for _, line := range strings.Split(out, "\n") {
-		if len(line) == 0 {
-			continue
-		}
-		tags[line] = true
-	}
-
-	for i, dependency := range dependencies {
-		if tags[dependency.GitTagFromVersion()] {
-			continue
-		}
-		// the gitPushDependencyTag method is reponsible for cleaning up temporary directories.
-		if err := s.gitPushDependencyTag(ctx, string(dir), dependency, i == 0); err != nil {
-			return errors.Wrapf(err, "error pushing dependency %q", dependency.PackageManagerSyntax())
-		}
-	}
-
-	dependencyTags := make(map[string]struct{}, len(dependencies))
+	versioned := make([]versionedPackageDependency, 0, len(dependencies))
 	for _, dependency := range dependencies {
-		dependencyTags[dependency.GitTagFromVersion()] = struct{}{}
+		versioned = append(versioned, dependency)
 	}
-
-	for tag := range tags {
-		if _, isDependencyTag := dependencyTags[tag]; !isDependencyTag {
-			cmd := exec.CommandContext(ctx, "git", "tag", "-d", tag)
-			if _, err := runCommandInDirectory(ctx, cmd, string(dir), placeholderMavenDependency); err != nil {
-				log15.Error("Failed to delete git tag", "error", err, "tag", tag)
-				continue
-			}
-		}
-	}
-
-	return nil
+	return syncPackageTags(ctx, dir, placeholderMavenDependency, versioned, func(dependency versionedPackageDependency, isLatestVersion bool) error {
+		return s.gitPushDependencyTag(ctx, string(dir), dependency.(reposource.MavenDependency), isLatestVersion)
+	})
 }
 
 // RemoteShowCommand returns the command to be executed for showing remote.
@@ -235,58 +202,22 @@ func (s *JVMPackagesSyncer) packageDependencies(ctx context.Context, repoUrlPath
 
 // gitPushDependencyTag pushes a git tag to the given bareGitDirectory path. The
 // tag points to a commit that adds all sources of given dependency. When
-// isMainBranch is true, the main branch of the bare git directory will also be
-// updated to point to the same commit as the git tag.
+// isLatestVersion is true, the latest branch of the bare git directory will
+// also be updated to point to the same commit as the git tag.
 func (s *JVMPackagesSyncer) gitPushDependencyTag(ctx context.Context, bareGitDirectory string, dependency reposource.MavenDependency, isLatestVersion bool) error {
-	tmpDirectory, err := os.MkdirTemp("", "maven")
-	if err != nil {
-		return err
-	}
-	// Always clean up created temporary directories.
-	defer os.RemoveAll(tmpDirectory)
-
 	sourceCodeJarPath, err := coursier.FetchSources(ctx, s.Config, dependency)
 	if err != nil {
 		return err
 	}
 
-	cmd := exec.CommandContext(ctx, "git", "init")
-	if _, err := runCommandInDirectory(ctx, cmd, tmpDirectory, dependency); err != nil {
-		return err
-	}
-
-	err = s.commitJar(ctx, dependency, tmpDirectory, sourceCodeJarPath, s.Config)
-	if err != nil {
-		return err
-	}
-
-	cmd = exec.CommandContext(ctx, "git", "remote", "add", "origin", bareGitDirectory)
-	if _, err := runCommandInDirectory(ctx, cmd, tmpDirectory, dependency); err != nil {
-		return err
-	}
-
-	// Use --no-verify for security reasons. See https://github.com/sourcegraph/sourcegraph/pull/23399
-	cmd = exec.CommandContext(ctx, "git", "push", "--no-verify", "--force", "origin", "--tags")
-	if _, err := runCommandInDirectory(ctx, cmd, tmpDirectory, dependency); err != nil {
-		return err
-	}
-
-	if isLatestVersion {
-		defaultBranch, err := runCommandInDirectory(ctx, exec.CommandContext(ctx, "git", "rev-parse", "--abbrev-ref", "HEAD"), tmpDirectory, dependency)
-		if err != nil {
-			return err
-		}
-		// Use --no-verify for security reasons. See https://github.com/sourcegraph/sourcegraph/pull/23399
-		cmd = exec.CommandContext(ctx, "git", "push", "--no-verify", "--force", "origin", strings.TrimSpace(defaultBranch)+":latest", dependency.GitTagFromVersion())
-		if _, err := runCommandInDirectory(ctx, cmd, tmpDirectory, dependency); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return pushPackageTag(ctx, bareGitDirectory, dependency, isLatestVersion, func(workingDirectory string) error {
+		return s.commitJar(ctx, dependency, workingDirectory, sourceCodeJarPath, s.Config)
+	})
 }
 
-// commitJar creates a git commit in the given working directory that adds all the file contents of the given jar file.
+// commitJar commits all the file contents of the given jar file to the git
+// repository of the given working directory, along with the lsif-java.json
+// file.
 // A `*.jar` file works the same way as a `*.zip` file, it can even be uncompressed with the `unzip` command-line tool.
 func (s *JVMPackagesSyncer) commitJar(ctx context.Context, dependency reposource.MavenDependency,
 	workingDirectory, sourceCodeJarPath string, connection *schema.JVMPackagesConnection) error {
@@ -320,25 +251,12 @@ func (s *JVMPackagesSyncer) commitJar(ctx context.Context, dependency reposource
 		return err
 	}
 
-	cmd := exec.CommandContext(ctx, "git", "add", ".")
-	if _, err := runCommandInDirectory(ctx, cmd, workingDirectory, dependency); err != nil {
-		return err
-	}
-
-	// Use --no-verify for security reasons. See https://github.com/sourcegraph/sourcegraph/pull/23399
-	cmd = exec.CommandContext(ctx, "git", "commit", "--no-verify", "-m", dependency.PackageManagerSyntax(), "--date", stableGitCommitDate)
-	if _, err := runCommandInDirectory(ctx, cmd, workingDirectory, dependency); err != nil {
-		return err
-	}
-
-	cmd = exec.CommandContext(ctx, "git", "tag", "-m", dependency.PackageManagerSyntax(), dependency.GitTagFromVersion())
-	if _, err := runCommandInDirectory(ctx, cmd, workingDirectory, dependency); err != nil {
-		return err
-	}
-
-	return nil
+	return commitPackageSources(ctx, workingDirectory, dependency)
 }
 
+// unzipJarFile extracts the jar file at jarPath to destination. Unlike
+// decompressZip, it doesn't limit the number of files: the sources of the JDK
+// alone exceed that limit.
 func unzipJarFile(jarPath, destination string) (err error) {
 	reader, err := zip.OpenReader(jarPath)
 	if err != nil {
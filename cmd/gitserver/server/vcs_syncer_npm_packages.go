@@ -90,45 +90,13 @@ func (s *NPMPackagesSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir G
 		return err
 	}
 
-	out, err := runCommandInDirectory(ctx, exec.CommandContext(ctx, "git", "tag"), string(dir), placeholderNPMDependency)
-	if err != nil {
-		return err
-	}
-
-	tags := map[string]bool{}
-	for _, line := range strings.Split(out, "\n") {
-		if len(line) == 0 {
-			continue
-		}
-		tags[line] = true
-	}
-
-	for i, dependency := range dependencies {
-		if tags[dependency.GitTagFromVersion()] {
-			continue
-		}
-		// the gitPushDependencyTag method is responsible for cleaning up temporary directories.
-		if err := s.gitPushDependencyTag(ctx, string(dir), dependency, i == 0); err != nil {
-			return errors.Wrapf(err, "error pushing dependency %q", dependency.PackageManagerSyntax())
-		}
-	}
-
-	dependencyTags := make(map[string]struct{}, len(dependencies))
+	versioned := make([]versionedPackageDependency, 0, len(dependencies))
 	for _, dependency := range dependencies {
-		dependencyTags[dependency.GitTagFromVersion()] = struct{}{}
-	}
-
-	for tag := range tags {
-		if _, isDependencyTag := dependencyTags[tag]; !isDependencyTag {
-			cmd := exec.CommandContext(ctx, "git", "tag", "-d", tag)
-			if _, err := runCommandInDirectory(ctx, cmd, string(dir), placeholderNPMDependency); err != nil {
-				log15.Error("Failed to delete git tag", "error", err, "tag", tag)
-				continue
-			}
-		}
+		versioned = append(versioned, dependency)
 	}
-
-	return nil
+	return syncPackageTags(ctx, dir, placeholderNPMDependency, versioned, func(dependency versionedPackageDependency, isLatestVersion bool) error {
+		return s.gitPushDependencyTag(ctx, string(dir), dependency.(reposource.NPMDependency), isLatestVersion)
+	})
 }
 
 // RemoteShowCommand returns the command to be executed for showing remote.
@@ -197,60 +165,22 @@ func (s *NPMPackagesSyncer) npmDependencies() []string {
 
 // gitPushDependencyTag pushes a git tag to the given bareGitDirectory path. The
 // tag points to a commit that adds all sources of given dependency. When
-// isMainBranch is true, the main branch of the bare git directory will also be
-// updated to point to the same commit as the git tag.
+// isLatestVersion is true, the latest branch of the bare git directory will
+// also be updated to point to the same commit as the git tag.
 func (s *NPMPackagesSyncer) gitPushDependencyTag(ctx context.Context, bareGitDirectory string, dependency reposource.NPMDependency, isLatestVersion bool) error {
-	tmpDirectory, err := os.MkdirTemp("", "npm")
-	if err != nil {
-		return err
-	}
-	// Always clean up created temporary directories.
-	defer os.RemoveAll(tmpDirectory)
-
 	sourceCodePath, err := npm.FetchSources(ctx, s.Config, dependency)
 	if err != nil {
 		return err
 	}
 	defer os.Remove(sourceCodePath)
 
-	cmd := exec.CommandContext(ctx, "git", "init")
-	if _, err := runCommandInDirectory(ctx, cmd, tmpDirectory, dependency); err != nil {
-		return err
-	}
-
-	err = s.commitTgz(ctx, dependency, tmpDirectory, sourceCodePath, s.Config)
-	if err != nil {
-		return err
-	}
-
-	cmd = exec.CommandContext(ctx, "git", "remote", "add", "origin", bareGitDirectory)
-	if _, err := runCommandInDirectory(ctx, cmd, tmpDirectory, dependency); err != nil {
-		return err
-	}
-
-	// Use --no-verify for security reasons. See https://github.com/sourcegraph/sourcegraph/pull/23399
-	cmd = exec.CommandContext(ctx, "git", "push", "--no-verify", "--force", "origin", "--tags")
-	if _, err := runCommandInDirectory(ctx, cmd, tmpDirectory, dependency); err != nil {
-		return err
-	}
-
-	if isLatestVersion {
-		defaultBranch, err := runCommandInDirectory(ctx, exec.CommandContext(ctx, "git", "rev-parse", "--abbrev-ref", "HEAD"), tmpDirectory, dependency)
-		if err != nil {
-			return err
-		}
-		// Use --no-verify for security reasons. See https://github.com/sourcegraph/sourcegraph/pull/23399
-		cmd = exec.CommandContext(ctx, "git", "push", "--no-verify", "--force", "origin", strings.TrimSpace(defaultBranch)+":latest", dependency.GitTagFromVersion())
-		if _, err := runCommandInDirectory(ctx, cmd, tmpDirectory, dependency); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return pushPackageTag(ctx, bareGitDirectory, dependency, isLatestVersion, func(workingDirectory string) error {
+		return s.commitTgz(ctx, dependency, workingDirectory, sourceCodePath, s.Config)
+	})
 }
 
-// commitTgz creates a git commit in the given working directory that adds all
-// the file contents of the given tgz file.
+// commitTgz commits all the file contents of the given tarball to the git
+// repository of the given working directory.
 func (s *NPMPackagesSyncer) commitTgz(ctx context.Context, dependency reposource.NPMDependency,
 	workingDirectory, sourceCodeTgzPath string, connection *schema.NPMPackagesConnection) error {
 	if err := decompressTgz(sourceCodeTgzPath, workingDirectory); err != nil {
@@ -260,25 +190,7 @@ func (s *NPMPackagesSyncer) commitTgz(ctx context.Context, dependency reposource
 	// See [NOTE: LSIF-config-json] for why we don't create a JSON file here
 	// like we do for Java.
 
-	cmd := exec.CommandContext(ctx, "git", "add", ".")
-	if _, err := runCommandInDirectory(ctx, cmd, workingDirectory, dependency); err != nil {
-		return err
-	}
-
-	// Use --no-verify for security reasons. See https://github.com/sourcegraph/sourcegraph/pull/23399
-	cmd = exec.CommandContext(ctx, "git", "commit", "--no-verify",
-		"-m", dependency.PackageManagerSyntax(), "--date", stableGitCommitDate)
-	if _, err := runCommandInDirectory(ctx, cmd, workingDirectory, dependency); err != nil {
-		return err
-	}
-
-	cmd = exec.CommandContext(ctx, "git", "tag",
-		"-m", dependency.PackageManagerSyntax(), dependency.GitTagFromVersion())
-	if _, err := runCommandInDirectory(ctx, cmd, workingDirectory, dependency); err != nil {
-		return err
-	}
-
-	return nil
+	return commitPackageSources(ctx, workingDirectory, dependency)
 }
 
 func withTgz(tgzPath string, action func(*tar.Reader) error) (err error) {
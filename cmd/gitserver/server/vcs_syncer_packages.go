@@ -1,12 +1,16 @@
 package server
 
 import (
+	"archive/zip"
 	"context"
+	"io"
+	"os"
 	"os/exec"
 	"path"
 	"strings"
 
 	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 )
@@ -29,6 +33,220 @@ func runCommandInDirectory(ctx context.Context, cmd *exec.Cmd, workingDirectory
 	return string(output), nil
 }
 
+// versionedPackageDependency is a dependency whose sources are committed to a
+// git tag named after its version.
+type versionedPackageDependency interface {
+	reposource.PackageDependency
+	GitTagFromVersion() string
+}
+
+// syncPackageTags adds git tags for the given dependencies which don't have a
+// tag yet in the bare git directory dir, and removes the tags of versions that
+// are not in dependencies anymore. pushTag is called for each dependency to
+// add. The first dependency is the latest version.
+func syncPackageTags(ctx context.Context, dir GitDir, placeholder reposource.PackageDependency, dependencies []versionedPackageDependency,
+	pushTag func(dependency versionedPackageDependency, isLatestVersion bool) error) error {
+	out, err := runCommandInDirectory(ctx, exec.CommandContext(ctx, "git", "tag"), string(dir), placeholder)
+	if err != nil {
+		return err
+	}
+
+	tags := map[string]bool{}
+	for _, line := range strings.Split(out, "\n") {
+		if len(line) == 0 {
+			continue
+		}
+		tags[line] = true
+	}
+
+	for i, dependency := range dependencies {
+		if tags[dependency.GitTagFromVersion()] {
+			continue
+		}
+		if err := pushTag(dependency, i == 0); err != nil {
+			return errors.Wrapf(err, "error pushing dependency %q", dependency.PackageManagerSyntax())
+		}
+	}
+
+	dependencyTags := make(map[string]struct{}, len(dependencies))
+	for _, dependency := range dependencies {
+		dependencyTags[dependency.GitTagFromVersion()] = struct{}{}
+	}
+
+	for tag := range tags {
+		if _, isDependencyTag := dependencyTags[tag]; !isDependencyTag {
+			cmd := exec.CommandContext(ctx, "git", "tag", "-d", tag)
+			if _, err := runCommandInDirectory(ctx, cmd, string(dir), placeholder); err != nil {
+				log15.Error("Failed to delete git tag", "error", err, "tag", tag)
+				continue
+			}
+		}
+	}
+
+	return nil
+}
+
+// pushPackageTag pushes a git tag to the given bareGitDirectory path. The tag
+// is created by commit in the git repository of the working directory it is
+// given, usually with commitPackageSources. When isLatestVersion is true, the
+// latest branch of the bare git directory is also updated to point to the same
+// commit as the git tag.
+func pushPackageTag(ctx context.Context, bareGitDirectory string, dependency versionedPackageDependency, isLatestVersion bool,
+	commit func(workingDirectory string) error) error {
+	tmpDirectory, err := os.MkdirTemp("", "package")
+	if err != nil {
+		return err
+	}
+	// Always clean up created temporary directories.
+	defer os.RemoveAll(tmpDirectory)
+
+	cmd := exec.CommandContext(ctx, "git", "init")
+	if _, err := runCommandInDirectory(ctx, cmd, tmpDirectory, dependency); err != nil {
+		return err
+	}
+
+	if err := commit(tmpDirectory); err != nil {
+		return err
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "remote", "add", "origin", bareGitDirectory)
+	if _, err := runCommandInDirectory(ctx, cmd, tmpDirectory, dependency); err != nil {
+		return err
+	}
+
+	// Use --no-verify for security reasons. See https://github.com/sourcegraph/sourcegraph/pull/23399
+	cmd = exec.CommandContext(ctx, "git", "push", "--no-verify", "--force", "origin", "--tags")
+	if _, err := runCommandInDirectory(ctx, cmd, tmpDirectory, dependency); err != nil {
+		return err
+	}
+
+	if isLatestVersion {
+		defaultBranch, err := runCommandInDirectory(ctx, exec.CommandContext(ctx, "git", "rev-parse", "--abbrev-ref", "HEAD"), tmpDirectory, dependency)
+		if err != nil {
+			return err
+		}
+		// Use --no-verify for security reasons. See https://github.com/sourcegraph/sourcegraph/pull/23399
+		cmd = exec.CommandContext(ctx, "git", "push", "--no-verify", "--force", "origin", strings.TrimSpace(defaultBranch)+":latest", dependency.GitTagFromVersion())
+		if _, err := runCommandInDirectory(ctx, cmd, tmpDirectory, dependency); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// commitPackageSources commits all files in the git repository of the given
+// working directory and tags the commit with the version of dependency.
+func commitPackageSources(ctx context.Context, workingDirectory string, dependency versionedPackageDependency) error {
+	cmd := exec.CommandContext(ctx, "git", "add", ".")
+	if _, err := runCommandInDirectory(ctx, cmd, workingDirectory, dependency); err != nil {
+		return err
+	}
+
+	// Use --no-verify for security reasons. See https://github.com/sourcegraph/sourcegraph/pull/23399
+	cmd = exec.CommandContext(ctx, "git", "commit", "--no-verify",
+		"-m", dependency.PackageManagerSyntax(), "--date", stableGitCommitDate)
+	if _, err := runCommandInDirectory(ctx, cmd, workingDirectory, dependency); err != nil {
+		return err
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "tag",
+		"-m", dependency.PackageManagerSyntax(), dependency.GitTagFromVersion())
+	if _, err := runCommandInDirectory(ctx, cmd, workingDirectory, dependency); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// downloadToTempFile copies body to a new temporary file and returns its path.
+// The caller is responsible for removing the file.
+func downloadToTempFile(body io.Reader, pattern string) (_ string, err error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		errClose := f.Close()
+		if err == nil {
+			err = errClose
+		}
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+	_, err = io.Copy(f, body)
+	return f.Name(), err
+}
+
+// decompressZip extracts the zip archive at zipPath to destination. Only files
+// under prefix are extracted, with prefix stripped from their path. Like for
+// tarballs, the number and size of files is limited.
+func decompressZip(zipPath, destination, prefix string) error {
+	reader, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	destinationDir := strings.TrimSuffix(destination, string(os.PathSeparator)) + string(os.PathSeparator)
+	const zipFileLimit = 10000
+	count := 0
+	for _, file := range reader.File {
+		name := strings.TrimPrefix(file.Name, prefix)
+		if name == file.Name && prefix != "" {
+			continue
+		}
+		cleanedOutputPath, isPotentiallyMalicious := isPotentiallyMaliciousFilepathInArchive(name, destinationDir)
+		if isPotentiallyMalicious || !file.Mode().IsRegular() {
+			continue
+		}
+		// See copyTarFileEntry for the rationale of the limit.
+		const sizeLimitMiB = 15
+		if file.UncompressedSize64 >= sizeLimitMiB*1024*1024 {
+			return errors.Errorf("file size for %s (%d bytes) exceeded limit (%d MiB)",
+				path.Base(name), file.UncompressedSize64, sizeLimitMiB)
+		}
+		if count >= zipFileLimit {
+			return errors.Errorf("number of files in zip archive %s exceeded limit (%d)", path.Base(zipPath), zipFileLimit)
+		}
+		if err := copyZipFileEntry(file, cleanedOutputPath); err != nil {
+			return err
+		}
+		count++
+	}
+	return nil
+}
+
+// zipCommonDirectory returns "dir/" if all files in the zip archive at zipPath
+// are in the same top-level directory dir, and "" otherwise. See
+// [NOTE: npm-strip-outermost-directory] for why it is stripped.
+func zipCommonDirectory(zipPath string) (string, error) {
+	reader, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	common := ""
+	for _, file := range reader.File {
+		if strings.HasSuffix(file.Name, "/") {
+			continue
+		}
+		i := strings.IndexByte(file.Name, '/')
+		if i <= 0 {
+			return "", nil
+		}
+		dir := file.Name[:i+1]
+		if common == "" {
+			common = dir
+		} else if dir != common {
+			return "", nil
+		}
+	}
+	return common, nil
+}
+
 func isPotentiallyMaliciousFilepathInArchive(filepath, destinationDir string) (outputPath string, _ bool) {
 	if strings.HasPrefix(filepath, ".git/") {
 		// For security reasons, don't unzip files under the `.git/`
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+)
+
+func TestDecompressTgz_maliciousFiles(t *testing.T) {
+	dir := t.TempDir()
+	tgzPath := path.Join(dir, "malicious.tgz")
+	extractPath := path.Join(dir, "extracted")
+	assert.Nil(t, os.Mkdir(extractPath, os.ModePerm))
+
+	createMaliciousTgz(t, tgzPath)
+
+	assert.Nil(t, decompressTgz(tgzPath, extractPath))
+
+	dirEntries, err := os.ReadDir(extractPath)
+	assert.Nil(t, err)
+	paths := []string{}
+	for _, dirEntry := range dirEntries {
+		paths = append(paths, dirEntry.Name())
+	}
+	if baseline := []string{"src"}; !reflect.DeepEqual(baseline, paths) {
+		t.Errorf("expected paths: %v\n   found paths:%v", baseline, paths)
+	}
+}
+
+func TestPushPackageTag(t *testing.T) {
+	ctx := context.Background()
+	bareGitDirectory := path.Join(t.TempDir(), "git")
+	assert.Nil(t, exec.Command("git", "init", "--bare", bareGitDirectory).Run())
+
+	v1 := &reposource.NPMDependency{NPMPackage: placeholderNPMDependency.NPMPackage, Version: "1.0.0"}
+	v2 := &reposource.NPMDependency{NPMPackage: placeholderNPMDependency.NPMPackage, Version: "2.0.0"}
+
+	// pushTag writes a file with the name of the dependency and commits it.
+	var pushed []string
+	pushTag := func(dependency versionedPackageDependency, isLatestVersion bool) error {
+		pushed = append(pushed, dependency.GitTagFromVersion())
+		return pushPackageTag(ctx, bareGitDirectory, dependency, isLatestVersion, func(workingDirectory string) error {
+			if err := os.WriteFile(path.Join(workingDirectory, "version"), []byte(dependency.PackageManagerSyntax()), 0o644); err != nil {
+				return err
+			}
+			return commitPackageSources(ctx, workingDirectory, dependency)
+		})
+	}
+
+	err := syncPackageTags(ctx, GitDir(bareGitDirectory), placeholderNPMDependency, []versionedPackageDependency{v2, v1}, pushTag)
+	assert.Nil(t, err)
+	assertCommandOutput(t, exec.Command("git", "tag", "--list"), bareGitDirectory, "v1.0.0\nv2.0.0\n")
+	assertCommandOutput(t, exec.Command("git", "show", "latest:version"), bareGitDirectory, "@sourcegraph/placeholder@2.0.0")
+	assertCommandOutput(t, exec.Command("git", "show", "v1.0.0:version"), bareGitDirectory, "@sourcegraph/placeholder@1.0.0")
+
+	// Only missing tags are pushed, and tags of removed versions are deleted.
+	pushed = nil
+	err = syncPackageTags(ctx, GitDir(bareGitDirectory), placeholderNPMDependency, []versionedPackageDependency{v1}, pushTag)
+	assert.Nil(t, err)
+	assert.Empty(t, pushed)
+	assertCommandOutput(t, exec.Command("git", "tag", "--list"), bareGitDirectory, "v1.0.0\n")
+}
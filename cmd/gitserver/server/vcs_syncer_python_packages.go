@@ -0,0 +1,183 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/pythonpackages/pypi"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+var placeholderPythonDependency = func() reposource.PythonDependency {
+	dep, err := reposource.ParsePythonDependency("sourcegraph.placeholder==1.0.0")
+	if err != nil {
+		panic(fmt.Sprintf("expected placeholder dependency to parse but got %v", err))
+	}
+	return *dep
+}()
+
+// PythonPackagesSyncer creates git repositories from the source distributions
+// or wheels of Python packages on PyPI-compatible indexes. Every version of a
+// package is committed to a tag.
+type PythonPackagesSyncer struct {
+	Config *schema.PythonPackagesConnection
+}
+
+var _ VCSSyncer = &PythonPackagesSyncer{}
+
+func (s *PythonPackagesSyncer) Type() string {
+	return "python_packages"
+}
+
+// IsCloneable checks to see if the VCS remote URL is cloneable. Any non-nil
+// error indicates there is a problem.
+func (s *PythonPackagesSyncer) IsCloneable(ctx context.Context, remoteURL *vcs.URL) error {
+	_, err := s.packageDependencies(ctx, remoteURL.Path)
+	return err
+}
+
+// Similar to CloneCommand for NPMPackagesSyncer; it handles cloning itself
+// instead of returning a command that does the cloning.
+func (s *PythonPackagesSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, bareGitDirectory string) (*exec.Cmd, error) {
+	err := os.MkdirAll(bareGitDirectory, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "--bare", "init")
+	if _, err := runCommandInDirectory(ctx, cmd, bareGitDirectory, placeholderPythonDependency); err != nil {
+		return nil, err
+	}
+
+	// The Fetch method is responsible for cleaning up temporary directories.
+	if err := s.Fetch(ctx, remoteURL, GitDir(bareGitDirectory)); err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch repo for %s", remoteURL)
+	}
+
+	// no-op command to satisfy VCSSyncer interface, see docstring for more details.
+	return exec.CommandContext(ctx, "git", "--version"), nil
+}
+
+// Fetch adds git tags for newly added dependency versions and removes git tags
+// for deleted versions.
+func (s *PythonPackagesSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir) error {
+	dependencies, err := s.packageDependencies(ctx, remoteURL.Path)
+	if err != nil {
+		return err
+	}
+
+	versioned := make([]versionedPackageDependency, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		versioned = append(versioned, dependency)
+	}
+	return syncPackageTags(ctx, dir, placeholderPythonDependency, versioned, func(dependency versionedPackageDependency, isLatestVersion bool) error {
+		return s.gitPushDependencyTag(ctx, string(dir), dependency.(reposource.PythonDependency), isLatestVersion)
+	})
+}
+
+// RemoteShowCommand returns the command to be executed for showing remote.
+func (s *PythonPackagesSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, err error) {
+	return exec.CommandContext(ctx, "git", "remote", "show", "./"), nil
+}
+
+// packageDependencies returns the list of Python dependencies that belong to
+// the given URL path and exist in the configured indexes. The returned
+// dependencies are sorted in descending version order (newest first).
+func (s *PythonPackagesSyncer) packageDependencies(ctx context.Context, repoUrlPath string) (matchingDependencies []reposource.PythonDependency, err error) {
+	repoPackage, err := reposource.ParsePythonPackage(repoUrlPath)
+	if err != nil {
+		return nil, err
+	}
+
+	client := s.client()
+	for _, configDependencyString := range s.Config.Dependencies {
+		if !repoPackage.MatchesDependencyString(configDependencyString) {
+			continue
+		}
+		dependency, err := reposource.ParsePythonDependency(configDependencyString)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := client.Version(ctx, *dependency); err != nil {
+			if errcode.IsNotFound(err) {
+				log15.Warn("skipping missing python dependency", "dependency", dependency.PackageManagerSyntax(), "error", err)
+				continue
+			}
+			return nil, err
+		}
+		matchingDependencies = append(matchingDependencies, *dependency)
+	}
+
+	if len(matchingDependencies) == 0 {
+		return nil, errors.Errorf("no Python dependencies for URL path %s", repoUrlPath)
+	}
+
+	reposource.SortPythonDependencies(matchingDependencies)
+	return matchingDependencies, nil
+}
+
+func (s *PythonPackagesSyncer) client() *pypi.Client {
+	return pypi.NewClient(s.Config.Urls, httpcli.ExternalDoer)
+}
+
+// gitPushDependencyTag pushes a git tag to the given bareGitDirectory path. The
+// tag points to a commit that adds all sources of given dependency. When
+// isLatestVersion is true, the latest branch of the bare git directory will
+// also be updated to point to the same commit as the git tag.
+func (s *PythonPackagesSyncer) gitPushDependencyTag(ctx context.Context, bareGitDirectory string, dependency reposource.PythonDependency, isLatestVersion bool) error {
+	client := s.client()
+	file, err := client.Version(ctx, dependency)
+	if err != nil {
+		return err
+	}
+	body, err := client.Download(ctx, file)
+	if err != nil {
+		return err
+	}
+	archivePath, err := downloadToTempFile(body, "python-*-"+file.Name)
+	body.Close()
+	if err != nil {
+		return err
+	}
+	defer os.Remove(archivePath)
+
+	return pushPackageTag(ctx, bareGitDirectory, dependency, isLatestVersion, func(workingDirectory string) error {
+		if err := decompressPythonPackage(file.Name, archivePath, workingDirectory); err != nil {
+			return errors.Wrapf(err, "failed to decompress %s for %s", file.Name, dependency.PackageManagerSyntax())
+		}
+		return commitPackageSources(ctx, workingDirectory, dependency)
+	})
+}
+
+// decompressPythonPackage extracts a source distribution or wheel with the
+// given filename to destination.
+func decompressPythonPackage(filename, archivePath, destination string) error {
+	switch {
+	case strings.HasSuffix(filename, ".tar.gz"):
+		return decompressTgz(archivePath, destination)
+	case strings.HasSuffix(filename, ".zip"):
+		// Like tarballs, zipped source distributions contain a
+		// name-version/ directory.
+		prefix, err := zipCommonDirectory(archivePath)
+		if err != nil {
+			return err
+		}
+		return decompressZip(archivePath, destination, prefix)
+	case strings.HasSuffix(filename, ".whl"):
+		// Wheels contain the packages at the top-level, along with a
+		// name-version.dist-info/ directory.
+		return decompressZip(archivePath, destination, "")
+	default:
+		return errors.Errorf("unsupported package file %s", filename)
+	}
+}
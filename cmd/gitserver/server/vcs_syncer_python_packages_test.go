@@ -0,0 +1,102 @@
+package server
+
+import (
+	"archive/zip"
+	"context"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestPythonPackagesCloneCommand(t *testing.T) {
+	dir := t.TempDir()
+
+	// A file-backed index with a source distribution of 1.0.0 and a wheel of
+	// 2.0.0.
+	index := path.Join(dir, "index")
+	assert.Nil(t, os.MkdirAll(path.Join(index, "example-pkg"), 0755))
+	createTgz(t, path.Join(index, "example-pkg", "example_pkg-1.0.0.tar.gz"), []fileInfo{
+		{"example_pkg-1.0.0/setup.py", []byte("setup()")},
+		{"example_pkg-1.0.0/example_pkg/__init__.py", []byte("x = 1")},
+	})
+	createZip(t, path.Join(index, "example-pkg", "example_pkg-2.0.0-py3-none-any.whl"), []fileInfo{
+		{"example_pkg/__init__.py", []byte("x = 2")},
+		{"example_pkg-2.0.0.dist-info/METADATA", []byte("Name: example-pkg")},
+	})
+
+	s := PythonPackagesSyncer{
+		Config: &schema.PythonPackagesConnection{Urls: []string{"file://" + index}},
+	}
+	bareGitDirectory := path.Join(dir, "git")
+	packageURL := vcs.URL{URL: url.URL{Path: "python/example-pkg"}}
+	clone := func(dependencies ...string) {
+		t.Helper()
+		s.Config.Dependencies = dependencies
+		cmd, err := s.CloneCommand(context.Background(), &packageURL, bareGitDirectory)
+		assert.Nil(t, err)
+		assert.Nil(t, cmd.Run())
+	}
+
+	clone("example_pkg==1.0.0")
+	assertCommandOutput(t, exec.Command("git", "tag", "--list"), bareGitDirectory, "v1.0.0\n")
+	assertCommandOutput(t, exec.Command("git", "show", "v1.0.0:example_pkg/__init__.py"), bareGitDirectory, "x = 1")
+	assertCommandOutput(t, exec.Command("git", "show", "latest:setup.py"), bareGitDirectory, "setup()")
+
+	clone("example_pkg==1.0.0", "Example.Pkg==2.0.0", "example-pkg==3.0.0")
+	// 3.0.0 doesn't exist in the index and is skipped.
+	assertCommandOutput(t, exec.Command("git", "tag", "--list"), bareGitDirectory, "v1.0.0\nv2.0.0\n")
+	assertCommandOutput(t, exec.Command("git", "show", "v2.0.0:example_pkg/__init__.py"), bareGitDirectory, "x = 2")
+	assertCommandOutput(t, exec.Command("git", "show", "latest:example_pkg-2.0.0.dist-info/METADATA"), bareGitDirectory, "Name: example-pkg")
+
+	clone("example_pkg==1.0.0")
+	assertCommandOutput(t, exec.Command("git", "tag", "--list"), bareGitDirectory, "v1.0.0\n")
+
+	s.Config.Dependencies = []string{"example_pkg==3.0.0"}
+	assert.NotNil(t, s.IsCloneable(context.Background(), &packageURL))
+}
+
+func TestDecompressZip(t *testing.T) {
+	dir := t.TempDir()
+	zipPath := path.Join(dir, "test.zip")
+	fileInfos := []fileInfo{{"prefix/" + harmlessPath, []byte("x")}, {"outside.txt", []byte("x")}}
+	for _, p := range maliciousPaths {
+		fileInfos = append(fileInfos, fileInfo{"prefix/" + p, []byte("x")})
+	}
+	createZip(t, zipPath, fileInfos)
+
+	extractPath := path.Join(dir, "extracted")
+	assert.Nil(t, decompressZip(zipPath, extractPath, "prefix/"))
+	dirEntries, err := os.ReadDir(extractPath)
+	assert.Nil(t, err)
+	var names []string
+	for _, dirEntry := range dirEntries {
+		names = append(names, dirEntry.Name())
+	}
+	assert.Equal(t, []string{"src"}, names)
+
+	common, err := zipCommonDirectory(zipPath)
+	assert.Nil(t, err)
+	assert.Equal(t, "", common)
+}
+
+func createZip(t *testing.T, zipPath string, fileInfos []fileInfo) {
+	t.Helper()
+	f, err := os.Create(zipPath)
+	assert.Nil(t, err)
+	w := zip.NewWriter(f)
+	for _, info := range fileInfos {
+		fw, err := w.Create(info.path)
+		assert.Nil(t, err)
+		_, err = fw.Write(info.contents)
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, w.Close())
+	assert.Nil(t, f.Close())
+}
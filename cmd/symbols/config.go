@@ -4,6 +4,7 @@ import (
 	"os"
 	"runtime"
 	"strconv"
+	"strings"
 
 	"github.com/sourcegraph/sourcegraph/internal/env"
 )
@@ -16,6 +17,10 @@ type Config struct {
 	ctagsLogErrors          bool
 	ctagsDebugLogs          bool
 
+	// treeSitterLanguages are the languages parsed with tree-sitter instead of
+	// universal-ctags.
+	treeSitterLanguages []string
+
 	sanityCheck       bool
 	cacheDir          string
 	cacheSizeMB       int
@@ -31,6 +36,7 @@ func (c *Config) Load() {
 	c.ctagsPatternLengthLimit = c.GetInt("CTAGS_PATTERN_LENGTH_LIMIT", "250", "the maximum length of the patterns output by ctags")
 	c.ctagsLogErrors = os.Getenv("DEPLOY_TYPE") == "dev"
 	c.ctagsDebugLogs = false
+	c.treeSitterLanguages = splitLanguages(c.GetOptional("SYMBOLS_TREE_SITTER_LANGUAGES", "comma-separated list of languages to parse with tree-sitter instead of universal-ctags (go, typescript, kotlin)"))
 
 	c.sanityCheck = c.GetBool("SANITY_CHECK", "false", "check that go-sqlite3 works then exit 0 if it's ok or 1 if not")
 	c.cacheDir = c.Get("CACHE_DIR", "/tmp/symbols-cache", "directory in which to store cached symbols")
@@ -38,3 +44,13 @@ func (c *Config) Load() {
 	c.numCtagsProcesses = c.GetInt("CTAGS_PROCESSES", strconv.Itoa(runtime.GOMAXPROCS(0)), "number of concurrent parser processes to run")
 	c.requestBufferSize = c.GetInt("REQUEST_BUFFER_SIZE", "8192", "maximum size of buffered parser request channel")
 }
+
+func splitLanguages(value string) []string {
+	var languages []string
+	for _, language := range strings.Split(value, ",") {
+		if language = strings.TrimSpace(language); language != "" {
+			languages = append(languages, language)
+		}
+	}
+	return languages
+}
@@ -8,8 +8,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/sourcegraph/go-ctags"
-
 	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/database"
 	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/database/writer"
 	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/fetcher"
@@ -36,7 +34,7 @@ func TestHandler(t *testing.T) {
 
 	cache := diskcache.NewStore(tmpDir, "symbols", diskcache.WithBackgroundTimeout(20*time.Minute))
 
-	parserFactory := func() (ctags.Parser, error) {
+	parserFactory := func() (parser.SymbolParser, error) {
 		return newMockParser("x", "y"), nil
 	}
 	parserPool, err := parser.NewParserPool(parserFactory, 15)
@@ -134,17 +132,17 @@ type mockParser struct {
 	names []string
 }
 
-func newMockParser(names ...string) ctags.Parser {
+func newMockParser(names ...string) parser.SymbolParser {
 	return &mockParser{names: names}
 }
 
-func (m *mockParser) Parse(name string, content []byte) ([]*ctags.Entry, error) {
-	entries := make([]*ctags.Entry, 0, len(m.names))
+func (m *mockParser) Parse(name string, content []byte) ([]result.Symbol, error) {
+	symbols := make([]result.Symbol, 0, len(m.names))
 	for _, name := range m.names {
-		entries = append(entries, &ctags.Entry{Name: name, Path: "a.js"})
+		symbols = append(symbols, result.Symbol{Name: name, Path: "a.js"})
 	}
 
-	return entries, nil
+	return symbols, nil
 }
 
 func (m *mockParser) Close() {}
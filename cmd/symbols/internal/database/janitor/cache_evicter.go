@@ -15,6 +15,10 @@ type cacheEvicter struct {
 	// cache is the disk backed cache.
 	cache diskcache.Store
 
+	// cacheDir is the directory of the cache, which contains a directory of
+	// databases per repository.
+	cacheDir string
+
 	// maxCacheSizeBytes is the maximum size of the cache in bytes. Note that we can
 	// be larger than maxCacheSizeBytes temporarily between runs of this handler.
 	// When we go over maxCacheSizeBytes we trigger delete files until we get below
@@ -29,26 +33,36 @@ var (
 	_ goroutine.ErrorHandler = &cacheEvicter{}
 )
 
-func NewCacheEvicter(interval time.Duration, cache diskcache.Store, maxCacheSizeBytes int64, metrics *Metrics) goroutine.BackgroundRoutine {
+func NewCacheEvicter(interval time.Duration, cache diskcache.Store, cacheDir string, maxCacheSizeBytes int64, metrics *Metrics) goroutine.BackgroundRoutine {
 	return goroutine.NewPeriodicGoroutine(context.Background(), interval, &cacheEvicter{
 		cache:             cache,
+		cacheDir:          cacheDir,
 		maxCacheSizeBytes: maxCacheSizeBytes,
 		metrics:           metrics,
 	})
 }
 
 // Handle periodically checks the size of the cache and evicts/deletes items.
+// Databases which were superseded by a database derived from them are evicted
+// first, and then the least recently used databases.
 func (e *cacheEvicter) Handle(ctx context.Context) error {
 	if e.maxCacheSizeBytes == 0 {
 		return nil
 	}
 
+	cacheSize, evicted, err := e.evictSupersededDatabases(ctx)
+	if err != nil {
+		return errors.Wrap(err, "evictSupersededDatabases")
+	}
+	e.metrics.evictions.Add(float64(evicted))
+	e.metrics.supersededEvictions.Add(float64(evicted))
+
 	stats, err := e.cache.Evict(e.maxCacheSizeBytes)
 	if err != nil {
 		return errors.Wrap(err, "cache.Evict")
 	}
 
-	e.metrics.cacheSizeBytes.Set(float64(stats.CacheSize))
+	e.metrics.cacheSizeBytes.Set(float64(cacheSize))
 	e.metrics.evictions.Add(float64(stats.Evicted))
 	return nil
 }
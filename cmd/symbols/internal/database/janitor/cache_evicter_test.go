@@ -0,0 +1,108 @@
+package janitor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/database"
+	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/database/store"
+)
+
+func init() {
+	database.Init()
+}
+
+func TestEvictSupersededDatabases(t *testing.T) {
+	ctx := context.Background()
+	cacheDir := t.TempDir()
+
+	// writeDatabase creates the database of commit in the directory of repo,
+	// derived from the database of baseCommit if it is not empty.
+	writeDatabase := func(repo, commit, baseCommit string, modTime time.Time) string {
+		t.Helper()
+		dir := filepath.Join(cacheDir, repo)
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, commit+".zip")
+
+		err := store.WithSQLiteStore(path, func(db store.Store) error {
+			if err := db.CreateMetaTable(ctx); err != nil {
+				return err
+			}
+			if baseCommit == "" {
+				return db.InsertMeta(ctx, commit)
+			}
+			if err := db.InsertMeta(ctx, baseCommit); err != nil {
+				return err
+			}
+			return db.UpdateMeta(ctx, commit)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	now := time.Now()
+	paths := []string{
+		// c was derived from b, which was derived from a. a and b were not
+		// used since.
+		writeDatabase("r1", "a", "", now.Add(-3*time.Hour)),
+		writeDatabase("r1", "b", "a", now.Add(-2*time.Hour)),
+		writeDatabase("r1", "c", "b", now.Add(-time.Hour)),
+		// e was derived from d, but d was used since.
+		writeDatabase("r2", "d", "", now),
+		writeDatabase("r2", "e", "d", now.Add(-4*time.Hour)),
+	}
+
+	var cacheSize int64
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cacheSize += info.Size()
+	}
+
+	// Leave room for all but one database, so that only the oldest superseded
+	// database is evicted.
+	e := &cacheEvicter{cacheDir: cacheDir, maxCacheSizeBytes: cacheSize - 1}
+	size, evicted, err := e.evictSupersededDatabases(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != cacheSize || evicted != 1 {
+		t.Errorf("got size %d and %d evicted, want size %d and 1 evicted", size, evicted, cacheSize)
+	}
+
+	// Without room for any database, all superseded databases are evicted.
+	e.maxCacheSizeBytes = 1
+	if _, evicted, err = e.evictSupersededDatabases(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if evicted != 1 {
+		t.Errorf("got %d evicted, want 1", evicted)
+	}
+
+	var remaining []string
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			rel, _ := filepath.Rel(cacheDir, path)
+			remaining = append(remaining, rel)
+		}
+	}
+	sort.Strings(remaining)
+	if diff := cmp.Diff([]string{"r1/c.zip", "r2/d.zip", "r2/e.zip"}, remaining); diff != "" {
+		t.Errorf("unexpected remaining databases (-want +got):\n%s", diff)
+	}
+}
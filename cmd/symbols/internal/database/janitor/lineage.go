@@ -0,0 +1,163 @@
+package janitor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/database/store"
+)
+
+// cachedDatabase is a symbols database in the cache.
+type cachedDatabase struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// evictSupersededDatabases removes databases which were superseded by a
+// database derived from them until the cache is smaller than maxCacheSizeBytes.
+//
+// The symbols of a commit are derived from the database of an ancestor commit
+// by re-parsing only the paths which changed. Afterwards, the database of the
+// descendant is the better base for the following commits, so the database of
+// the ancestor is evicted first unless it was used since the descendant was
+// last used.
+//
+// It returns the size of the cache before evicting, and the number of evicted
+// databases.
+func (e *cacheEvicter) evictSupersededDatabases(ctx context.Context) (cacheSize int64, evicted int, err error) {
+	repoDirs, err := os.ReadDir(e.cacheDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, 0, nil
+		}
+		return 0, 0, err
+	}
+
+	databasesByRepo := make([][]cachedDatabase, 0, len(repoDirs))
+	for _, repoDir := range repoDirs {
+		if !repoDir.IsDir() {
+			continue
+		}
+
+		databases, err := listDatabases(filepath.Join(e.cacheDir, repoDir.Name()))
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, database := range databases {
+			cacheSize += database.size
+		}
+		databasesByRepo = append(databasesByRepo, databases)
+	}
+
+	if cacheSize <= e.maxCacheSizeBytes {
+		return cacheSize, 0, nil
+	}
+
+	var superseded []cachedDatabase
+	for _, databases := range databasesByRepo {
+		superseded = append(superseded, supersededDatabases(ctx, databases)...)
+	}
+	sort.Slice(superseded, func(i, j int) bool { return superseded[i].modTime.Before(superseded[j].modTime) })
+
+	size := cacheSize
+	for _, database := range superseded {
+		if size <= e.maxCacheSizeBytes {
+			break
+		}
+
+		if err := os.Remove(database.path); err != nil {
+			log15.Warn("Failed to remove superseded symbols database", "path", database.path, "error", err)
+			continue
+		}
+		size -= database.size
+		evicted++
+	}
+
+	return cacheSize, evicted, nil
+}
+
+// listDatabases returns the databases in the directory of a repository.
+func listDatabases(dir string) ([]cachedDatabase, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	databases := make([]cachedDatabase, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !strings.HasSuffix(entry.Name(), ".zip") {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		databases = append(databases, cachedDatabase{
+			path:    filepath.Join(dir, entry.Name()),
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+	}
+
+	return databases, nil
+}
+
+// supersededDatabases returns the databases of a repository from which another
+// database was derived, and which were not used since that database was last
+// used.
+func supersededDatabases(ctx context.Context, databases []cachedDatabase) []cachedDatabase {
+	byCommit := make(map[string]cachedDatabase, len(databases))
+	baseCommits := make(map[string]cachedDatabase, len(databases))
+	for _, database := range databases {
+		commit, baseCommit, err := getLineage(ctx, database.path)
+		if err != nil {
+			// Databases with an older schema have no lineage.
+			log15.Debug("Failed to read lineage of symbols database", "path", database.path, "error", err)
+			continue
+		}
+
+		byCommit[commit] = database
+		// Several databases may be derived from the same base, of which the
+		// most recently used one counts.
+		if descendant, ok := baseCommits[baseCommit]; baseCommit != "" && (!ok || database.modTime.After(descendant.modTime)) {
+			baseCommits[baseCommit] = database
+		}
+	}
+
+	var superseded []cachedDatabase
+	for baseCommit, descendant := range baseCommits {
+		if base, ok := byCommit[baseCommit]; ok && !base.modTime.After(descendant.modTime) {
+			superseded = append(superseded, base)
+		}
+	}
+
+	return superseded
+}
+
+// getLineage returns the commit of the database and the commit of the database
+// it was derived from, if any.
+func getLineage(ctx context.Context, dbFile string) (commit, baseCommit string, err error) {
+	// Open the database read-only, so that a database which is evicted
+	// concurrently is not recreated.
+	err = store.WithSQLiteStore("file:"+dbFile+"?mode=ro", func(db store.Store) (err error) {
+		if commit, _, err = db.GetCommit(ctx); err != nil {
+			return err
+		}
+		baseCommit, _, err = db.GetBaseCommit(ctx)
+		return err
+	})
+
+	return commit, baseCommit, err
+}
@@ -7,9 +7,10 @@ import (
 )
 
 type Metrics struct {
-	cacheSizeBytes prometheus.Gauge
-	evictions      prometheus.Counter
-	errors         prometheus.Counter
+	cacheSizeBytes      prometheus.Gauge
+	evictions           prometheus.Counter
+	supersededEvictions prometheus.Counter
+	errors              prometheus.Counter
 }
 
 func NewMetrics(observationContext *observation.Context) *Metrics {
@@ -27,6 +28,13 @@ func NewMetrics(observationContext *observation.Context) *Metrics {
 	})
 	observationContext.Registerer.MustRegister(evictions)
 
+	supersededEvictions := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "src",
+		Name:      "codeintel_symbols_store_superseded_evictions_total",
+		Help:      "The total number of items evicted from the cache because a newer item was derived from them.",
+	})
+	observationContext.Registerer.MustRegister(supersededEvictions)
+
 	errors := prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "src",
 		Name:      "codeintel_symbols_store_errors_total",
@@ -35,8 +43,9 @@ func NewMetrics(observationContext *observation.Context) *Metrics {
 	observationContext.Registerer.MustRegister(errors)
 
 	return &Metrics{
-		cacheSizeBytes: cacheSizeBytes,
-		evictions:      evictions,
-		errors:         errors,
+		cacheSizeBytes:      cacheSizeBytes,
+		evictions:           evictions,
+		supersededEvictions: supersededEvictions,
+		errors:              errors,
 	}
 }
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/keegancsmith/sqlf"
 
@@ -12,7 +13,8 @@ func (w *store) CreateMetaTable(ctx context.Context) error {
 	return w.Exec(ctx, sqlf.Sprintf(`
 		CREATE TABLE IF NOT EXISTS meta (
 			id INTEGER PRIMARY KEY CHECK (id = 0),
-			revision TEXT NOT NULL
+			revision TEXT NOT NULL,
+			base_revision TEXT
 		)
 	`))
 }
@@ -25,6 +27,28 @@ func (s *store) InsertMeta(ctx context.Context, commitID string) error {
 	return s.Exec(ctx, sqlf.Sprintf(`INSERT INTO meta (id, revision) VALUES (0, %s)`, commitID))
 }
 
+// UpdateMeta sets the commit of a database which is derived from the database
+// of another commit, and records that commit as the base commit.
 func (s *store) UpdateMeta(ctx context.Context, commitID string) error {
-	return s.Exec(ctx, sqlf.Sprintf(`UPDATE meta SET revision = %s`, commitID))
+	return s.Exec(ctx, sqlf.Sprintf(`UPDATE meta SET base_revision = revision, revision = %s`, commitID))
+}
+
+// GetBaseCommit returns the commit of the database this database was derived
+// from by UpdateMeta, if any.
+func (s *store) GetBaseCommit(ctx context.Context) (string, bool, error) {
+	commitID, _, err := basestore.ScanFirstString(s.Query(ctx, sqlf.Sprintf(`SELECT COALESCE(base_revision, '') FROM meta`)))
+	return commitID, commitID != "", err
+}
+
+// GetSchemaVersion returns the version of the database schema recorded by
+// SetSchemaVersion, or 0 if none was recorded.
+func (s *store) GetSchemaVersion(ctx context.Context) (int, error) {
+	version, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(`PRAGMA user_version`)))
+	return version, err
+}
+
+// SetSchemaVersion records the version of the database schema in the database.
+func (s *store) SetSchemaVersion(ctx context.Context, version int) error {
+	// PRAGMA statements do not support bind parameters.
+	return s.Exec(ctx, sqlf.Sprintf(`PRAGMA user_version = `+strconv.Itoa(version)))
 }
@@ -26,6 +26,9 @@ func scanSymbols(rows *sql.Rows, queryErr error) (symbols []result.Symbol, err e
 			&symbol.Name,
 			&symbol.Path,
 			&symbol.Line,
+			&symbol.Character,
+			&symbol.EndLine,
+			&symbol.EndCharacter,
 			&symbol.Kind,
 			&symbol.Language,
 			&symbol.Parent,
@@ -50,6 +53,9 @@ func (s *store) Search(ctx context.Context, args types.SearchArgs) ([]result.Sym
 				name,
 				path,
 				line,
+				character,
+				endline,
+				endcharacter,
 				kind,
 				language,
 				parent,
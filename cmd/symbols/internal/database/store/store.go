@@ -24,6 +24,9 @@ type Store interface {
 	GetCommit(ctx context.Context) (string, bool, error)
 	InsertMeta(ctx context.Context, commitID string) error
 	UpdateMeta(ctx context.Context, commitID string) error
+	GetBaseCommit(ctx context.Context) (string, bool, error)
+	GetSchemaVersion(ctx context.Context) (int, error)
+	SetSchemaVersion(ctx context.Context, version int) error
 
 	CreateSymbolsTable(ctx context.Context) error
 	CreateSymbolIndexes(ctx context.Context) error
@@ -20,6 +20,9 @@ func (s *store) CreateSymbolsTable(ctx context.Context) error {
 			path VARCHAR(4096) NOT NULL,
 			pathlowercase VARCHAR(4096) NOT NULL,
 			line INT NOT NULL,
+			character INT NOT NULL,
+			endline INT NOT NULL,
+			endcharacter INT NOT NULL,
 			kind VARCHAR(255) NOT NULL,
 			language VARCHAR(255) NOT NULL,
 			parent VARCHAR(255) NOT NULL,
@@ -95,6 +98,9 @@ func (s *store) WriteSymbols(ctx context.Context, symbolOrErrors <-chan parser.S
 				"path",
 				"pathlowercase",
 				"line",
+				"character",
+				"endline",
+				"endcharacter",
 				"kind",
 				"language",
 				"parent",
@@ -117,6 +123,9 @@ func symbolToRow(symbol result.Symbol) []interface{} {
 		symbol.Path,
 		strings.ToLower(symbol.Path),
 		symbol.Line,
+		symbol.Character,
+		symbol.EndLine,
+		symbol.EndCharacter,
 		symbol.Kind,
 		symbol.Language,
 		symbol.Parent,
@@ -4,19 +4,24 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 )
 
-// findNewestFile lists the directory and returns the newest file's path, prepended with dir.
-func findNewestFile(dir string) (string, error) {
+// findFilesNewestFirst lists the cached databases in the directory and returns their paths, prepended
+// with dir, ordered from the most to the least recently modified.
+func findFilesNewestFirst(dir string) ([]string, error) {
 	files, err := os.ReadDir(dir)
 	if err != nil {
-		return "", nil
+		return nil, nil
 	}
 
-	var mostRecentTime time.Time
-	newest := ""
+	type file struct {
+		path    string
+		modTime time.Time
+	}
+	var found []file
 	for _, fi := range files {
 		if fi.Type().IsRegular() {
 			if !strings.HasSuffix(fi.Name(), ".zip") {
@@ -25,17 +30,20 @@ func findNewestFile(dir string) (string, error) {
 
 			info, err := fi.Info()
 			if err != nil {
-				return "", err
+				return nil, err
 			}
 
-			if newest == "" || info.ModTime().After(mostRecentTime) {
-				mostRecentTime = info.ModTime()
-				newest = filepath.Join(dir, fi.Name())
-			}
+			found = append(found, file{path: filepath.Join(dir, fi.Name()), modTime: info.ModTime()})
 		}
 	}
 
-	return newest, nil
+	sort.Slice(found, func(i, j int) bool { return found[i].modTime.After(found[j].modTime) })
+
+	paths := make([]string, 0, len(found))
+	for _, f := range found {
+		paths = append(paths, f.path)
+	}
+	return paths, nil
 }
 
 func copyFile(from string, to string) error {
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 
 	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/api/observability"
 	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/database/store"
@@ -38,10 +39,10 @@ func NewDatabaseWriter(
 }
 
 func (w *databaseWriter) WriteDBFile(ctx context.Context, args types.SearchArgs, dbFile string) error {
-	if newestDBFile, oldCommit, ok, err := w.getNewestCommit(ctx, args); err != nil {
+	if ancestorDBFile, ancestorCommit, ok, err := w.findAncestorDB(ctx, args); err != nil {
 		return err
 	} else if ok {
-		if ok, err := w.writeFileIncrementally(ctx, args, dbFile, newestDBFile, oldCommit); err != nil || ok {
+		if ok, err := w.writeFileIncrementally(ctx, args, dbFile, ancestorDBFile, ancestorCommit); err != nil || ok {
 			return err
 		}
 	}
@@ -49,13 +50,55 @@ func (w *databaseWriter) WriteDBFile(ctx context.Context, args types.SearchArgs,
 	return w.writeDBFile(ctx, args, dbFile)
 }
 
-func (w *databaseWriter) getNewestCommit(ctx context.Context, args types.SearchArgs) (dbFile string, commit string, ok bool, err error) {
-	newest, err := findNewestFile(filepath.Join(w.path, diskcache.EncodeKeyComponent(string(args.Repo))))
-	if err != nil || newest == "" {
+// The maximum number of cached databases of a repository to check for an ancestor of the requested
+// commit. Each check is a request to gitserver, so we only check the most recently used databases.
+const maxAncestorCandidates = 10
+
+// findAncestorDB returns the most recently used cached database of the repository for a commit which
+// is an ancestor of the requested commit, from which the database of the requested commit can be
+// derived by only re-parsing the paths which changed between the two commits.
+func (w *databaseWriter) findAncestorDB(ctx context.Context, args types.SearchArgs) (dbFile string, commit string, ok bool, err error) {
+	candidates, err := findFilesNewestFirst(filepath.Join(w.path, diskcache.EncodeKeyComponent(string(args.Repo))))
+	if err != nil {
 		return "", "", false, err
 	}
+	if len(candidates) > maxAncestorCandidates {
+		candidates = candidates[:maxAncestorCandidates]
+	}
+
+	for _, candidate := range candidates {
+		commit, ok, err := getCommit(ctx, candidate)
+		if err != nil {
+			return "", "", false, err
+		}
+		if !ok {
+			continue
+		}
+
+		isAncestor, err := w.gitserverClient.IsAncestor(ctx, args.Repo, api.CommitID(commit), args.CommitID)
+		if err != nil {
+			// The commit may no longer exist, e.g. after a force push.
+			log15.Warn("Failed to check ancestry of cached symbols database", "repo", args.Repo, "commit", commit, "error", err)
+			continue
+		}
+		if isAncestor {
+			return candidate, commit, true, nil
+		}
+	}
+
+	return "", "", false, nil
+}
+
+// getCommit returns the commit of the given database, or false if the database has an older schema.
+func getCommit(ctx context.Context, dbFile string) (commit string, ok bool, err error) {
+	err = store.WithSQLiteStore(dbFile, func(db store.Store) (err error) {
+		// Databases with an older schema can't be updated incrementally.
+		if version, err := db.GetSchemaVersion(ctx); err != nil {
+			return errors.Wrap(err, "store.GetSchemaVersion")
+		} else if version != symbolsDBVersion {
+			return nil
+		}
 
-	err = store.WithSQLiteStore(newest, func(db store.Store) (err error) {
 		if commit, ok, err = db.GetCommit(ctx); err != nil {
 			return errors.Wrap(err, "store.GetCommit")
 		}
@@ -63,7 +106,7 @@ func (w *databaseWriter) getNewestCommit(ctx context.Context, args types.SearchA
 		return nil
 	})
 
-	return newest, commit, ok, err
+	return commit, ok, err
 }
 
 func (w *databaseWriter) writeDBFile(ctx context.Context, args types.SearchArgs, dbFile string) error {
@@ -79,6 +122,9 @@ func (w *databaseWriter) writeDBFile(ctx context.Context, args types.SearchArgs,
 		if err := tx.InsertMeta(ctx, string(args.CommitID)); err != nil {
 			return errors.Wrap(err, "store.InsertMeta")
 		}
+		if err := tx.SetSchemaVersion(ctx, symbolsDBVersion); err != nil {
+			return errors.Wrap(err, "store.SetSchemaVersion")
+		}
 		if err := tx.WriteSymbols(ctx, symbolOrErrors); err != nil {
 			return errors.Wrap(err, "store.WriteSymbols")
 		}
@@ -101,10 +147,13 @@ const maxTotalPaths = 999
 // 100KB seems safe.
 const maxTotalPathsLength = 100000
 
-func (w *databaseWriter) writeFileIncrementally(ctx context.Context, args types.SearchArgs, dbFile, newestDBFile, oldCommit string) (bool, error) {
+// writeFileIncrementally derives the database of the requested commit from the database of an
+// ancestor commit. Symbols of paths which did not change between the commits are kept as is, and
+// only the paths which changed are re-parsed.
+func (w *databaseWriter) writeFileIncrementally(ctx context.Context, args types.SearchArgs, dbFile, ancestorDBFile, ancestorCommit string) (bool, error) {
 	observability.SetParseAmount(ctx, observability.PartialParse)
 
-	changes, err := w.gitserverClient.GitDiff(ctx, args.Repo, api.CommitID(oldCommit), args.CommitID)
+	changes, err := w.gitserverClient.GitDiff(ctx, args.Repo, api.CommitID(ancestorCommit), args.CommitID)
 	if err != nil {
 		return false, errors.Wrap(err, "gitserverClient.GitDiff")
 	}
@@ -129,7 +178,7 @@ func (w *databaseWriter) writeFileIncrementally(ctx context.Context, args types.
 		return false, nil
 	}
 
-	if err := copyFile(newestDBFile, dbFile); err != nil {
+	if err := copyFile(ancestorDBFile, dbFile); err != nil {
 		return false, err
 	}
 
@@ -22,6 +22,9 @@ type MockGitserverClient struct {
 	// GitDiffFunc is an instance of a mock function object controlling the
 	// behavior of the method GitDiff.
 	GitDiffFunc *GitserverClientGitDiffFunc
+	// IsAncestorFunc is an instance of a mock function object controlling
+	// the behavior of the method IsAncestor.
+	IsAncestorFunc *GitserverClientIsAncestorFunc
 }
 
 // NewMockGitserverClient creates a new mock of the GitserverClient
@@ -39,6 +42,11 @@ func NewMockGitserverClient() *MockGitserverClient {
 				return gitserver.Changes{}, nil
 			},
 		},
+		IsAncestorFunc: &GitserverClientIsAncestorFunc{
+			defaultHook: func(context.Context, api.RepoName, api.CommitID, api.CommitID) (bool, error) {
+				return false, nil
+			},
+		},
 	}
 }
 
@@ -56,6 +64,11 @@ func NewStrictMockGitserverClient() *MockGitserverClient {
 				panic("unexpected invocation of MockGitserverClient.GitDiff")
 			},
 		},
+		IsAncestorFunc: &GitserverClientIsAncestorFunc{
+			defaultHook: func(context.Context, api.RepoName, api.CommitID, api.CommitID) (bool, error) {
+				panic("unexpected invocation of MockGitserverClient.IsAncestor")
+			},
+		},
 	}
 }
 
@@ -70,6 +83,9 @@ func NewMockGitserverClientFrom(i gitserver.GitserverClient) *MockGitserverClien
 		GitDiffFunc: &GitserverClientGitDiffFunc{
 			defaultHook: i.GitDiff,
 		},
+		IsAncestorFunc: &GitserverClientIsAncestorFunc{
+			defaultHook: i.IsAncestor,
+		},
 	}
 }
 
@@ -302,3 +318,118 @@ func (c GitserverClientGitDiffFuncCall) Args() []interface{} {
 func (c GitserverClientGitDiffFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
+
+// GitserverClientIsAncestorFunc describes the behavior when the IsAncestor method
+// of the parent MockGitserverClient instance is invoked.
+type GitserverClientIsAncestorFunc struct {
+	defaultHook func(context.Context, api.RepoName, api.CommitID, api.CommitID) (bool, error)
+	hooks       []func(context.Context, api.RepoName, api.CommitID, api.CommitID) (bool, error)
+	history     []GitserverClientIsAncestorFuncCall
+	mutex       sync.Mutex
+}
+
+// IsAncestor delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockGitserverClient) IsAncestor(v0 context.Context, v1 api.RepoName, v2 api.CommitID, v3 api.CommitID) (bool, error) {
+	r0, r1 := m.IsAncestorFunc.nextHook()(v0, v1, v2, v3)
+	m.IsAncestorFunc.appendCall(GitserverClientIsAncestorFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the IsAncestor method of
+// the parent MockGitserverClient instance is invoked and the hook queue is
+// empty.
+func (f *GitserverClientIsAncestorFunc) SetDefaultHook(hook func(context.Context, api.RepoName, api.CommitID, api.CommitID) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// IsAncestor method of the parent MockGitserverClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GitserverClientIsAncestorFunc) PushHook(hook func(context.Context, api.RepoName, api.CommitID, api.CommitID) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *GitserverClientIsAncestorFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, api.CommitID, api.CommitID) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *GitserverClientIsAncestorFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName, api.CommitID, api.CommitID) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverClientIsAncestorFunc) nextHook() func(context.Context, api.RepoName, api.CommitID, api.CommitID) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientIsAncestorFunc) appendCall(r0 GitserverClientIsAncestorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientIsAncestorFuncCall objects
+// describing the invocations of this function.
+func (f *GitserverClientIsAncestorFunc) History() []GitserverClientIsAncestorFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientIsAncestorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientIsAncestorFuncCall is an object that describes an invocation
+// of method IsAncestor on an instance of MockGitserverClient.
+type GitserverClientIsAncestorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.CommitID
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 api.CommitID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientIsAncestorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientIsAncestorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
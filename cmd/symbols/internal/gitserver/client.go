@@ -24,6 +24,9 @@ type GitserverClient interface {
 
 	// GitDiff returns the paths that have changed between two commits.
 	GitDiff(context.Context, api.RepoName, api.CommitID, api.CommitID) (Changes, error)
+
+	// IsAncestor returns whether the first commit is an ancestor of the second commit.
+	IsAncestor(context.Context, api.RepoName, api.CommitID, api.CommitID) (bool, error)
 }
 
 // Changes are added, deleted, and modified paths.
@@ -70,6 +73,9 @@ func (c *gitserverClient) GitDiff(ctx context.Context, repo api.RepoName, commit
 	defer endObservation(1, observation.Args{})
 
 	output, err := git.DiffSymbols(ctx, repo, commitA, commitB)
+	if err != nil {
+		return Changes{}, errors.Wrap(err, "failed to run git diff")
+	}
 
 	changes, err := parseGitDiffOutput(output)
 	if err != nil {
@@ -79,6 +85,22 @@ func (c *gitserverClient) GitDiff(ctx context.Context, repo api.RepoName, commit
 	return changes, nil
 }
 
+func (c *gitserverClient) IsAncestor(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID) (_ bool, err error) {
+	ctx, endObservation := c.operations.isAncestor.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("repo", string(repo)),
+		log.String("commitA", string(commitA)),
+		log.String("commitB", string(commitB)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	mergeBase, err := git.MergeBase(ctx, repo, commitA, commitB)
+	if err != nil {
+		return false, err
+	}
+
+	return mergeBase == commitA, nil
+}
+
 var NUL = []byte{0}
 
 // parseGitDiffOutput parses the output of a git diff command, which consists
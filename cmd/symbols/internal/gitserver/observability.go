@@ -8,8 +8,9 @@ import (
 )
 
 type operations struct {
-	fetchTar *observation.Operation
-	gitDiff  *observation.Operation
+	fetchTar   *observation.Operation
+	gitDiff    *observation.Operation
+	isAncestor *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -29,7 +30,8 @@ func newOperations(observationContext *observation.Context) *operations {
 	}
 
 	return &operations{
-		fetchTar: op("FetchTar"),
-		gitDiff:  op("GitDiff"),
+		fetchTar:   op("FetchTar"),
+		gitDiff:    op("GitDiff"),
+		isAncestor: op("IsAncestor"),
 	}
 }
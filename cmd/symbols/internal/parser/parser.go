@@ -10,8 +10,6 @@ import (
 	"github.com/inconshreveable/log15"
 	"github.com/opentracing/opentracing-go/log"
 
-	"github.com/sourcegraph/go-ctags"
-
 	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/fetcher"
 	"github.com/sourcegraph/sourcegraph/cmd/symbols/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -158,30 +156,13 @@ func (p *parser) handleParseRequest(ctx context.Context, symbolOrErrors chan<- S
 	p.operations.parsing.Inc()
 	defer p.operations.parsing.Dec()
 
-	entries, err := parser.Parse(parseRequest.Path, parseRequest.Data)
+	symbols, err := parser.Parse(parseRequest.Path, parseRequest.Data)
 	if err != nil {
 		return errors.Wrap(err, "parser.Parse")
 	}
-	trace.Log(log.Int("numEntries", len(entries)))
-
-	for _, e := range entries {
-		if !shouldPersistEntry(e) {
-			continue
-		}
-
-		symbol := result.Symbol{
-			Name:        e.Name,
-			Path:        e.Path,
-			Line:        e.Line,
-			Kind:        e.Kind,
-			Language:    e.Language,
-			Parent:      e.Parent,
-			ParentKind:  e.ParentKind,
-			Signature:   e.Signature,
-			Pattern:     e.Pattern,
-			FileLimited: e.FileLimited,
-		}
+	trace.Log(log.Int("numSymbols", len(symbols)))
 
+	for _, symbol := range symbols {
 		select {
 		case symbolOrErrors <- SymbolOrError{Symbol: symbol}:
 			atomic.AddUint32(totalSymbols, 1)
@@ -194,7 +175,7 @@ func (p *parser) handleParseRequest(ctx context.Context, symbolOrErrors chan<- S
 	return nil
 }
 
-func (p *parser) parserFromPool(ctx context.Context) (ctags.Parser, error) {
+func (p *parser) parserFromPool(ctx context.Context) (SymbolParser, error) {
 	p.operations.parseQueueSize.Inc()
 	defer p.operations.parseQueueSize.Dec()
 
@@ -210,17 +191,3 @@ func (p *parser) parserFromPool(ctx context.Context) (ctags.Parser, error) {
 
 	return parser, err
 }
-
-func shouldPersistEntry(e *ctags.Entry) bool {
-	if e.Name == "" {
-		return false
-	}
-
-	for _, value := range []string{"__anon", "AnonymousFunction"} {
-		if strings.HasPrefix(e.Name, value) || strings.HasPrefix(e.Parent, value) {
-			return false
-		}
-	}
-
-	return true
-}
@@ -1,5 +1,13 @@
 package parser
 
-import "github.com/sourcegraph/go-ctags"
+import "github.com/sourcegraph/sourcegraph/internal/search/result"
 
-type ParserFactory func() (ctags.Parser, error)
+type ParserFactory func() (SymbolParser, error)
+
+// SymbolParser extracts the symbols of a single file. A SymbolParser is not safe
+// for concurrent use. The ParserPool hands out every parser to one parse request
+// at a time.
+type SymbolParser interface {
+	Parse(path string, content []byte) ([]result.Symbol, error)
+	Close()
+}
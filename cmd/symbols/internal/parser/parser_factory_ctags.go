@@ -3,8 +3,11 @@ package parser
 import (
 	"log"
 	"os"
+	"strings"
 
 	"github.com/sourcegraph/go-ctags"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
 func NewCtagsParserFactory(ctagsCommand string, patternLengthLimit int, logErrors, debugLogs bool) ParserFactory {
@@ -19,5 +22,63 @@ func NewCtagsParserFactory(ctagsCommand string, patternLengthLimit int, logError
 		options.Debug = log.New(os.Stderr, "DBUG ctags: ", log.LstdFlags)
 	}
 
-	return func() (ctags.Parser, error) { return ctags.New(options) }
+	return func() (SymbolParser, error) {
+		parser, err := ctags.New(options)
+		if err != nil {
+			return nil, err
+		}
+		return &ctagsParser{parser: parser}, nil
+	}
+}
+
+// ctagsParser parses symbols with a universal-ctags process.
+type ctagsParser struct {
+	parser ctags.Parser
+}
+
+func (p *ctagsParser) Parse(path string, content []byte) ([]result.Symbol, error) {
+	entries, err := p.parser.Parse(path, content)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := make([]result.Symbol, 0, len(entries))
+	for _, e := range entries {
+		if !shouldPersistEntry(e) {
+			continue
+		}
+
+		symbols = append(symbols, result.Symbol{
+			Name:        e.Name,
+			Path:        e.Path,
+			Line:        e.Line,
+			Kind:        e.Kind,
+			Language:    e.Language,
+			Parent:      e.Parent,
+			ParentKind:  e.ParentKind,
+			Signature:   e.Signature,
+			Pattern:     e.Pattern,
+			FileLimited: e.FileLimited,
+		})
+	}
+
+	return symbols, nil
+}
+
+func (p *ctagsParser) Close() {
+	p.parser.Close()
+}
+
+func shouldPersistEntry(e *ctags.Entry) bool {
+	if e.Name == "" {
+		return false
+	}
+
+	for _, value := range []string{"__anon", "AnonymousFunction"} {
+		if strings.HasPrefix(e.Name, value) || strings.HasPrefix(e.Parent, value) {
+			return false
+		}
+	}
+
+	return true
 }
@@ -7,7 +7,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
-	"github.com/sourcegraph/go-ctags"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
 func TestCtagsParser(t *testing.T) {
@@ -25,7 +25,7 @@ func TestCtagsParser(t *testing.T) {
 	cases := []struct {
 		path string
 		data string
-		want []*ctags.Entry
+		want []result.Symbol
 	}{{
 		path: "com/sourcegraph/A.java",
 		data: `
@@ -42,7 +42,7 @@ class A implements B extends C {
   }
 }
 `,
-		want: []*ctags.Entry{
+		want: []result.Symbol{
 			{
 				Kind:     "package",
 				Language: "Java",
@@ -113,7 +113,7 @@ interface Node {
     id: ID!
 }
 `,
-		want: []*ctags.Entry{
+		want: []result.Symbol{
 			{
 				Name:     "query",
 				Path:     "schema.graphql",
@@ -151,7 +151,7 @@ interface Node {
 			t.Error(err)
 		}
 
-		if d := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(ctags.Entry{}, "Pattern")); d != "" {
+		if d := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(result.Symbol{}, "Pattern")); d != "" {
 			t.Errorf("%s mismatch (-want +got):\n%s", tc.path, d)
 		}
 	}
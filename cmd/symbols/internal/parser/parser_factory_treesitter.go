@@ -0,0 +1,358 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/golang"
+	"github.com/smacker/go-tree-sitter/kotlin"
+	"github.com/smacker/go-tree-sitter/typescript/tsx"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// treeSitterLanguage describes how to find the symbols of a language with
+// tree-sitter.
+//
+// Every pattern of query captures a definition as @definition.<kind> and its
+// name as @name. Patterns may capture the name of the parent of the definition
+// as @parent, which otherwise is the innermost enclosing definition, and the
+// parameters of the definition as @signature. If several patterns match the
+// same definition, the first pattern wins.
+type treeSitterLanguage struct {
+	// name is the name of the language, as reported by universal-ctags.
+	name string
+	// grammars maps file extensions to the grammar which parses them.
+	grammars map[string]*sitter.Language
+	query    string
+}
+
+// treeSitterLanguages are the languages which can be parsed with tree-sitter,
+// keyed by the names accepted by NewTreeSitterParserFactory.
+var treeSitterLanguages = map[string]treeSitterLanguage{
+	"go": {
+		name:     "Go",
+		grammars: map[string]*sitter.Language{".go": golang.GetLanguage()},
+		query: `
+(package_clause (package_identifier) @name) @definition.package
+(function_declaration name: (identifier) @name parameters: (parameter_list) @signature) @definition.func
+(method_declaration
+  receiver: (parameter_list (parameter_declaration type: [
+    (type_identifier) @parent
+    (pointer_type (type_identifier) @parent)
+    (generic_type type: (type_identifier) @parent)
+    (pointer_type (generic_type type: (type_identifier) @parent))
+  ]))
+  name: (field_identifier) @name
+  parameters: (parameter_list) @signature) @definition.method
+(type_spec name: (type_identifier) @name type: (struct_type)) @definition.struct
+(type_spec name: (type_identifier) @name type: (interface_type)) @definition.interface
+(type_spec name: (type_identifier) @name) @definition.type
+(type_alias name: (type_identifier) @name) @definition.type
+(field_declaration (field_identifier) @name) @definition.field
+(method_spec name: (field_identifier) @name parameters: (parameter_list) @signature) @definition.method
+(source_file (const_declaration (const_spec (identifier) @name) @definition.const))
+(source_file (var_declaration (var_spec (identifier) @name) @definition.var))
+`,
+	},
+	"typescript": {
+		name: "TypeScript",
+		grammars: map[string]*sitter.Language{
+			".ts":  typescript.GetLanguage(),
+			".tsx": tsx.GetLanguage(),
+		},
+		query: `
+(function_declaration name: (identifier) @name parameters: (formal_parameters) @signature) @definition.function
+(generator_function_declaration name: (identifier) @name parameters: (formal_parameters) @signature) @definition.function
+(function_signature name: (identifier) @name parameters: (formal_parameters) @signature) @definition.function
+(class_declaration name: (type_identifier) @name) @definition.class
+(abstract_class_declaration name: (type_identifier) @name) @definition.class
+(interface_declaration name: (type_identifier) @name) @definition.interface
+(type_alias_declaration name: (type_identifier) @name) @definition.alias
+(enum_declaration name: (identifier) @name) @definition.enum
+(enum_body (property_identifier) @name @definition.enumerator)
+(enum_body (enum_assignment name: (property_identifier) @name) @definition.enumerator)
+(internal_module name: (identifier) @name) @definition.namespace
+(method_definition name: [(property_identifier) (private_property_identifier)] @name parameters: (formal_parameters) @signature) @definition.method
+(method_signature name: (property_identifier) @name parameters: (formal_parameters) @signature) @definition.method
+(abstract_method_signature name: (property_identifier) @name parameters: (formal_parameters) @signature) @definition.method
+(public_field_definition name: [(property_identifier) (private_property_identifier)] @name) @definition.property
+(property_signature name: (property_identifier) @name) @definition.property
+(program (lexical_declaration (variable_declarator name: (identifier) @name value: [(arrow_function) (function)]) @definition.function))
+(program (export_statement (lexical_declaration (variable_declarator name: (identifier) @name value: [(arrow_function) (function)]) @definition.function)))
+(program (lexical_declaration "const" (variable_declarator name: (identifier) @name) @definition.constant))
+(program (export_statement (lexical_declaration "const" (variable_declarator name: (identifier) @name) @definition.constant)))
+(program (lexical_declaration (variable_declarator name: (identifier) @name) @definition.variable))
+(program (export_statement (lexical_declaration (variable_declarator name: (identifier) @name) @definition.variable)))
+(program (variable_declaration (variable_declarator name: (identifier) @name) @definition.variable))
+(program (export_statement (variable_declaration (variable_declarator name: (identifier) @name) @definition.variable)))
+`,
+	},
+	"kotlin": {
+		name: "Kotlin",
+		grammars: map[string]*sitter.Language{
+			".kt":  kotlin.GetLanguage(),
+			".kts": kotlin.GetLanguage(),
+		},
+		query: `
+(package_header (identifier) @name) @definition.package
+(class_declaration "interface" (type_identifier) @name) @definition.interface
+(class_declaration (type_identifier) @name) @definition.class
+(object_declaration (type_identifier) @name) @definition.object
+(type_alias (type_identifier) @name) @definition.typealias
+(enum_entry (simple_identifier) @name) @definition.enumConstant
+(class_parameter ["val" "var"] (simple_identifier) @name) @definition.property
+(class_body (function_declaration (simple_identifier) @name) @definition.method)
+(function_declaration (simple_identifier) @name) @definition.function
+(class_body (property_declaration (variable_declaration (simple_identifier) @name)) @definition.property)
+(source_file (property_declaration "val" (variable_declaration (simple_identifier) @name)) @definition.constant)
+(source_file (property_declaration (variable_declaration (simple_identifier) @name)) @definition.variable)
+`,
+	},
+}
+
+// NewTreeSitterParserFactory returns a factory of parsers which parse files of
+// the given languages with tree-sitter, and all other files with parsers
+// created by fallback.
+func NewTreeSitterParserFactory(languages []string, patternLengthLimit int, fallback ParserFactory) (ParserFactory, error) {
+	queries := map[string]*treeSitterQuery{}
+	for _, name := range languages {
+		language, ok := treeSitterLanguages[strings.ToLower(name)]
+		if !ok {
+			return nil, errors.Errorf("tree-sitter does not support language %q", name)
+		}
+
+		for extension, grammar := range language.grammars {
+			query, err := sitter.NewQuery([]byte(language.query), grammar)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid tree-sitter query for %s", language.name)
+			}
+
+			queries[extension] = &treeSitterQuery{
+				language:           language.name,
+				grammar:            grammar,
+				query:              query,
+				patternLengthLimit: patternLengthLimit,
+			}
+		}
+	}
+
+	if len(queries) == 0 {
+		return fallback, nil
+	}
+
+	return func() (SymbolParser, error) {
+		fallbackParser, err := fallback()
+		if err != nil {
+			return nil, err
+		}
+
+		return &treeSitterParser{
+			parser:   sitter.NewParser(),
+			queries:  queries,
+			fallback: fallbackParser,
+		}, nil
+	}, nil
+}
+
+// treeSitterParser parses symbols with tree-sitter, and delegates files of
+// other languages to a fallback parser.
+type treeSitterParser struct {
+	parser   *sitter.Parser
+	queries  map[string]*treeSitterQuery
+	fallback SymbolParser
+}
+
+func (p *treeSitterParser) Parse(path string, content []byte) ([]result.Symbol, error) {
+	query, ok := p.queries[filepath.Ext(path)]
+	if !ok {
+		return p.fallback.Parse(path, content)
+	}
+
+	p.parser.SetLanguage(query.grammar)
+	tree, err := p.parser.ParseCtx(context.Background(), nil, content)
+	if err != nil {
+		return nil, errors.Wrap(err, "tree-sitter")
+	}
+	defer tree.Close()
+
+	return query.symbols(path, content, tree.RootNode()), nil
+}
+
+func (p *treeSitterParser) Close() {
+	p.parser.Close()
+	p.fallback.Close()
+}
+
+// treeSitterQuery finds the symbols in the syntax trees of one grammar. It is
+// safe for concurrent use.
+type treeSitterQuery struct {
+	language           string
+	grammar            *sitter.Language
+	query              *sitter.Query
+	patternLengthLimit int
+}
+
+// definition is a match of a treeSitterQuery.
+type definition struct {
+	node      *sitter.Node
+	name      *sitter.Node
+	kind      string
+	parent    *sitter.Node
+	signature *sitter.Node
+	pattern   uint16
+
+	// start, end and nameStart are the byte offsets of node and name, which
+	// are cached to avoid calls into tree-sitter while sorting.
+	start, end, nameStart uint32
+
+	// enclosing is the innermost definition which contains this one.
+	enclosing *definition
+}
+
+func (d *definition) contains(other *definition) bool {
+	return d.start <= other.start && other.end <= d.end
+}
+
+func (q *treeSitterQuery) symbols(path string, content []byte, root *sitter.Node) []result.Symbol {
+	cursor := sitter.NewQueryCursor()
+	defer cursor.Close()
+	cursor.Exec(q.query, root)
+
+	// Definitions keyed by their range and the start of their name, since
+	// several names may be defined at once, such as in "var a, b int".
+	type key struct{ start, end, name uint32 }
+	byKey := map[key]*definition{}
+	for {
+		match, ok := cursor.NextMatch()
+		if !ok {
+			break
+		}
+
+		d := &definition{pattern: match.PatternIndex}
+		for _, capture := range match.Captures {
+			switch name := q.query.CaptureNameForId(capture.Index); {
+			case name == "name":
+				d.name = capture.Node
+			case name == "parent":
+				d.parent = capture.Node
+			case name == "signature":
+				d.signature = capture.Node
+			case strings.HasPrefix(name, "definition."):
+				d.node = capture.Node
+				d.kind = strings.TrimPrefix(name, "definition.")
+			}
+		}
+		if d.node == nil || d.name == nil {
+			continue
+		}
+
+		d.start, d.end, d.nameStart = d.node.StartByte(), d.node.EndByte(), d.name.StartByte()
+		k := key{d.start, d.end, d.nameStart}
+		if existing, ok := byKey[k]; ok && existing.pattern <= d.pattern {
+			continue
+		}
+		byKey[k] = d
+	}
+
+	definitions := make([]*definition, 0, len(byKey))
+	for _, d := range byKey {
+		definitions = append(definitions, d)
+	}
+	sort.Slice(definitions, func(i, j int) bool {
+		a, b := definitions[i], definitions[j]
+		if a.start != b.start {
+			return a.start < b.start
+		}
+		if a.end != b.end {
+			return a.end > b.end
+		}
+		return a.nameStart < b.nameStart
+	})
+
+	// Find the innermost enclosing definition of every definition with a
+	// stack of the definitions which contain the current one.
+	var stack []*definition
+	for _, d := range definitions {
+		for len(stack) > 0 && !stack[len(stack)-1].contains(d) {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) > 0 {
+			if top := stack[len(stack)-1]; top.start == d.start && top.end == d.end {
+				d.enclosing = top.enclosing
+			} else {
+				d.enclosing = top
+			}
+		}
+		stack = append(stack, d)
+	}
+
+	// Explicit parents, such as the receivers of Go methods, refer to types
+	// which are usually defined in the same file.
+	typeKinds := map[string]string{}
+	for _, d := range definitions {
+		if d.enclosing == nil {
+			typeKinds[d.name.Content(content)] = d.kind
+		}
+	}
+
+	symbols := make([]result.Symbol, 0, len(definitions))
+	for _, d := range definitions {
+		start, end := d.name.StartPoint(), d.name.EndPoint()
+		symbol := result.Symbol{
+			Name:         d.name.Content(content),
+			Path:         path,
+			Line:         int(start.Row) + 1,
+			Character:    int(start.Column) + 1,
+			EndLine:      int(end.Row) + 1,
+			EndCharacter: int(end.Column) + 1,
+			Kind:         d.kind,
+			Language:     q.language,
+			Pattern:      q.pattern(content, d.nameStart),
+		}
+		if d.parent != nil {
+			symbol.Parent = d.parent.Content(content)
+			symbol.ParentKind = typeKinds[symbol.Parent]
+			if symbol.ParentKind == "" {
+				symbol.ParentKind = "type"
+			}
+		} else if d.enclosing != nil {
+			symbol.Parent = d.enclosing.name.Content(content)
+			symbol.ParentKind = d.enclosing.kind
+		}
+		if d.signature != nil {
+			symbol.Signature = strings.Join(strings.Fields(d.signature.Content(content)), " ")
+		}
+		symbols = append(symbols, symbol)
+	}
+
+	return symbols
+}
+
+// pattern returns a pattern for the line containing offset, in the format
+// universal-ctags uses.
+func (q *treeSitterQuery) pattern(content []byte, offset uint32) string {
+	start := bytes.LastIndexByte(content[:offset], '\n') + 1
+	end := bytes.IndexByte(content[start:], '\n')
+	if end < 0 {
+		end = len(content) - start
+	}
+	line := strings.TrimSuffix(string(content[start:start+end]), "\r")
+
+	// Like universal-ctags, omit the end of the line from truncated patterns.
+	anchor := "$"
+	if q.patternLengthLimit > 0 && len(line) > q.patternLengthLimit {
+		line = line[:q.patternLengthLimit]
+		anchor = ""
+	}
+
+	return "/^" + patternEscaper.Replace(line) + anchor + "/"
+}
+
+var patternEscaper = strings.NewReplacer(`\`, `\\`, `/`, `\/`)
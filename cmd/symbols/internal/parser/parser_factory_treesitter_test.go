@@ -0,0 +1,204 @@
+package parser
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+type fallbackParser struct {
+	paths []string
+}
+
+func (p *fallbackParser) Parse(path string, content []byte) ([]result.Symbol, error) {
+	p.paths = append(p.paths, path)
+	return nil, nil
+}
+
+func (p *fallbackParser) Close() {}
+
+func newTestTreeSitterParser(t testing.TB, languages ...string) (SymbolParser, *fallbackParser) {
+	fallback := &fallbackParser{}
+	factory, err := NewTreeSitterParserFactory(languages, 250, func() (SymbolParser, error) { return fallback, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := factory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p, fallback
+}
+
+func TestTreeSitterParser(t *testing.T) {
+	p, fallback := newTestTreeSitterParser(t, "go", "TypeScript", "kotlin")
+	defer p.Close()
+
+	cases := []struct {
+		path string
+		data string
+		want []result.Symbol
+	}{{
+		path: "a.go",
+		data: `package a
+
+var x, y int
+
+type S[T any] struct {
+	A, B T
+}
+
+type I interface {
+	M(x int) error
+}
+
+func (s *S[T]) Get() T { return s.A }
+
+func F(a int,
+	b string) {}
+`,
+		want: []result.Symbol{
+			{Name: "a", Path: "a.go", Line: 1, Character: 9, EndLine: 1, EndCharacter: 10, Kind: "package", Language: "Go", Pattern: `/^package a$/`},
+			{Name: "x", Path: "a.go", Line: 3, Character: 5, EndLine: 3, EndCharacter: 6, Kind: "var", Language: "Go", Pattern: `/^var x, y int$/`},
+			{Name: "y", Path: "a.go", Line: 3, Character: 8, EndLine: 3, EndCharacter: 9, Kind: "var", Language: "Go", Pattern: `/^var x, y int$/`},
+			{Name: "S", Path: "a.go", Line: 5, Character: 6, EndLine: 5, EndCharacter: 7, Kind: "struct", Language: "Go", Pattern: `/^type S[T any] struct {$/`},
+			{Name: "A", Path: "a.go", Line: 6, Character: 2, EndLine: 6, EndCharacter: 3, Kind: "field", Language: "Go", Parent: "S", ParentKind: "struct", Pattern: "/^\tA, B T$/"},
+			{Name: "B", Path: "a.go", Line: 6, Character: 5, EndLine: 6, EndCharacter: 6, Kind: "field", Language: "Go", Parent: "S", ParentKind: "struct", Pattern: "/^\tA, B T$/"},
+			{Name: "I", Path: "a.go", Line: 9, Character: 6, EndLine: 9, EndCharacter: 7, Kind: "interface", Language: "Go", Pattern: `/^type I interface {$/`},
+			{Name: "M", Path: "a.go", Line: 10, Character: 2, EndLine: 10, EndCharacter: 3, Kind: "method", Language: "Go", Parent: "I", ParentKind: "interface", Signature: "(x int)", Pattern: "/^\tM(x int) error$/"},
+			{Name: "Get", Path: "a.go", Line: 13, Character: 16, EndLine: 13, EndCharacter: 19, Kind: "method", Language: "Go", Parent: "S", ParentKind: "struct", Signature: "()", Pattern: `/^func (s *S[T]) Get() T { return s.A }$/`},
+			{Name: "F", Path: "a.go", Line: 15, Character: 6, EndLine: 15, EndCharacter: 7, Kind: "func", Language: "Go", Signature: "(a int, b string)", Pattern: `/^func F(a int,$/`},
+		},
+	}, {
+		path: "a.ts",
+		data: `namespace N {
+  export class C {
+    x = 1
+    m(a: number) {}
+  }
+}
+export const f = (a: number) => a
+const k = 1
+let v = 2
+enum E { A, B = 2 }
+`,
+		want: []result.Symbol{
+			{Name: "N", Path: "a.ts", Line: 1, Character: 11, EndLine: 1, EndCharacter: 12, Kind: "namespace", Language: "TypeScript", Pattern: `/^namespace N {$/`},
+			{Name: "C", Path: "a.ts", Line: 2, Character: 16, EndLine: 2, EndCharacter: 17, Kind: "class", Language: "TypeScript", Parent: "N", ParentKind: "namespace", Pattern: `/^  export class C {$/`},
+			{Name: "x", Path: "a.ts", Line: 3, Character: 5, EndLine: 3, EndCharacter: 6, Kind: "property", Language: "TypeScript", Parent: "C", ParentKind: "class", Pattern: `/^    x = 1$/`},
+			{Name: "m", Path: "a.ts", Line: 4, Character: 5, EndLine: 4, EndCharacter: 6, Kind: "method", Language: "TypeScript", Parent: "C", ParentKind: "class", Signature: "(a: number)", Pattern: `/^    m(a: number) {}$/`},
+			{Name: "f", Path: "a.ts", Line: 7, Character: 14, EndLine: 7, EndCharacter: 15, Kind: "function", Language: "TypeScript", Pattern: `/^export const f = (a: number) => a$/`},
+			{Name: "k", Path: "a.ts", Line: 8, Character: 7, EndLine: 8, EndCharacter: 8, Kind: "constant", Language: "TypeScript", Pattern: `/^const k = 1$/`},
+			{Name: "v", Path: "a.ts", Line: 9, Character: 5, EndLine: 9, EndCharacter: 6, Kind: "variable", Language: "TypeScript", Pattern: `/^let v = 2$/`},
+			{Name: "E", Path: "a.ts", Line: 10, Character: 6, EndLine: 10, EndCharacter: 7, Kind: "enum", Language: "TypeScript", Pattern: `/^enum E { A, B = 2 }$/`},
+			{Name: "A", Path: "a.ts", Line: 10, Character: 10, EndLine: 10, EndCharacter: 11, Kind: "enumerator", Language: "TypeScript", Parent: "E", ParentKind: "enum", Pattern: `/^enum E { A, B = 2 }$/`},
+			{Name: "B", Path: "a.ts", Line: 10, Character: 13, EndLine: 10, EndCharacter: 14, Kind: "enumerator", Language: "TypeScript", Parent: "E", ParentKind: "enum", Pattern: `/^enum E { A, B = 2 }$/`},
+		},
+	}, {
+		path: "a.kt",
+		data: `package a.b
+
+val top = 1
+
+class C(val p: Int, q: Int) {
+  fun m() {}
+}
+
+interface I
+fun f() {}
+`,
+		want: []result.Symbol{
+			{Name: "a.b", Path: "a.kt", Line: 1, Character: 9, EndLine: 1, EndCharacter: 12, Kind: "package", Language: "Kotlin", Pattern: `/^package a.b$/`},
+			{Name: "top", Path: "a.kt", Line: 3, Character: 5, EndLine: 3, EndCharacter: 8, Kind: "constant", Language: "Kotlin", Pattern: `/^val top = 1$/`},
+			{Name: "C", Path: "a.kt", Line: 5, Character: 7, EndLine: 5, EndCharacter: 8, Kind: "class", Language: "Kotlin", Pattern: `/^class C(val p: Int, q: Int) {$/`},
+			{Name: "p", Path: "a.kt", Line: 5, Character: 13, EndLine: 5, EndCharacter: 14, Kind: "property", Language: "Kotlin", Parent: "C", ParentKind: "class", Pattern: `/^class C(val p: Int, q: Int) {$/`},
+			{Name: "m", Path: "a.kt", Line: 6, Character: 7, EndLine: 6, EndCharacter: 8, Kind: "method", Language: "Kotlin", Parent: "C", ParentKind: "class", Pattern: `/^  fun m() {}$/`},
+			{Name: "I", Path: "a.kt", Line: 9, Character: 11, EndLine: 9, EndCharacter: 12, Kind: "interface", Language: "Kotlin", Pattern: `/^interface I$/`},
+			{Name: "f", Path: "a.kt", Line: 10, Character: 5, EndLine: 10, EndCharacter: 6, Kind: "function", Language: "Kotlin", Pattern: `/^fun f() {}$/`},
+		},
+	}, {
+		path: "a.py",
+		data: "def f(): pass\n",
+	}}
+
+	for _, tc := range cases {
+		got, err := p.Parse(tc.path, []byte(tc.data))
+		if err != nil {
+			t.Error(err)
+		}
+
+		if d := cmp.Diff(tc.want, got); d != "" {
+			t.Errorf("%s mismatch (-want +got):\n%s", tc.path, d)
+		}
+	}
+
+	if d := cmp.Diff([]string{"a.py"}, fallback.paths); d != "" {
+		t.Errorf("unexpected files parsed by fallback (-want +got):\n%s", d)
+	}
+}
+
+func TestNewTreeSitterParserFactory_UnknownLanguage(t *testing.T) {
+	if _, err := NewTreeSitterParserFactory([]string{"cobol"}, 250, nil); err == nil {
+		t.Fatal("expected error for unsupported language")
+	}
+}
+
+func TestTreeSitterQuery_Pattern(t *testing.T) {
+	q := &treeSitterQuery{patternLengthLimit: 10}
+	content := []byte("first\r\nfunc a/b\\c() {}\n")
+
+	if got, want := q.pattern(content, 0), `/^first$/`; got != want {
+		t.Errorf("got pattern %q, want %q", got, want)
+	}
+	if got, want := q.pattern(content, 12), `/^func a\/b\\c/`; got != want {
+		t.Errorf("got pattern %q, want truncated pattern %q", got, want)
+	}
+}
+
+// benchmarkSource is a Go file with many symbols, used to compare the
+// performance of tree-sitter and universal-ctags.
+func benchmarkSource() []byte {
+	var b strings.Builder
+	b.WriteString("package bench\n\n")
+	for i := 0; i < 500; i++ {
+		fmt.Fprintf(&b, "type T%d struct {\n\tA, B int\n}\n\n", i)
+		fmt.Fprintf(&b, "func (t *T%d) Sum(c int) int {\n\treturn t.A + t.B + c\n}\n\n", i)
+	}
+	return []byte(b.String())
+}
+
+func BenchmarkTreeSitterParser(b *testing.B) {
+	p, _ := newTestTreeSitterParser(b, "go")
+	defer p.Close()
+	benchmarkParser(b, p)
+}
+
+func BenchmarkCtagsParser(b *testing.B) {
+	if _, err := exec.LookPath("universal-ctags"); err != nil {
+		b.Skip("command not in PATH: universal-ctags")
+	}
+
+	p, err := NewCtagsParserFactory("universal-ctags", 250, false, false)()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer p.Close()
+	benchmarkParser(b, p)
+}
+
+func benchmarkParser(b *testing.B, p SymbolParser) {
+	content := benchmarkSource()
+	b.SetBytes(int64(len(content)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := p.Parse("bench.go", content); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -2,22 +2,20 @@ package parser
 
 import (
 	"context"
-
-	"github.com/sourcegraph/go-ctags"
 )
 
 type ParserPool interface {
-	Get(ctx context.Context) (ctags.Parser, error)
-	Done(parser ctags.Parser)
+	Get(ctx context.Context) (SymbolParser, error)
+	Done(parser SymbolParser)
 }
 
 type parserPool struct {
 	newParser ParserFactory
-	pool      chan ctags.Parser
+	pool      chan SymbolParser
 }
 
 func NewParserPool(newParser ParserFactory, numParserProcesses int) (ParserPool, error) {
-	pool := make(chan ctags.Parser, numParserProcesses)
+	pool := make(chan SymbolParser, numParserProcesses)
 	for i := 0; i < numParserProcesses; i++ {
 		parser, err := newParser()
 		if err != nil {
@@ -38,7 +36,7 @@ func NewParserPool(newParser ParserFactory, numParserProcesses int) (ParserPool,
 // the pool. This method always returns a non-nil parser with a nil error value.
 //
 // This method blocks until a parser is available or the given context is canceled.
-func (p *parserPool) Get(ctx context.Context) (ctags.Parser, error) {
+func (p *parserPool) Get(ctx context.Context) (SymbolParser, error) {
 	select {
 	case parser := <-p.pool:
 		if parser != nil {
@@ -52,6 +50,6 @@ func (p *parserPool) Get(ctx context.Context) (ctags.Parser, error) {
 	}
 }
 
-func (p *parserPool) Done(parser ctags.Parser) {
+func (p *parserPool) Done(parser SymbolParser) {
 	p.pool <- parser
 }
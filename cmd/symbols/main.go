@@ -95,13 +95,21 @@ func main() {
 		config.ctagsLogErrors,
 		config.ctagsDebugLogs,
 	)
+	parserFactory, err := parser.NewTreeSitterParserFactory(
+		config.treeSitterLanguages,
+		config.ctagsPatternLengthLimit,
+		ctagsParserFactory,
+	)
+	if err != nil {
+		log.Fatalf("Failed to create tree-sitter parser: %s", err)
+	}
 
 	cache := diskcache.NewStore(config.cacheDir, "symbols",
 		diskcache.WithBackgroundTimeout(20*time.Minute),
 		diskcache.WithObservationContext(observationContext),
 	)
 
-	parserPool, err := parser.NewParserPool(ctagsParserFactory, config.numCtagsProcesses)
+	parserPool, err := parser.NewParserPool(parserFactory, config.numCtagsProcesses)
 	if err != nil {
 		log.Fatalf("Failed to parser pool: %s", err)
 	}
@@ -121,7 +129,7 @@ func main() {
 
 	evictionInterval := time.Second * 10
 	cacheSizeBytes := int64(config.cacheSizeMB) * 1000 * 1000
-	cacheEvicter := janitor.NewCacheEvicter(evictionInterval, cache, cacheSizeBytes, janitor.NewMetrics(observationContext))
+	cacheEvicter := janitor.NewCacheEvicter(evictionInterval, cache, config.cacheDir, cacheSizeBytes, janitor.NewMetrics(observationContext))
 
 	// Mark health server as ready and go!
 	close(ready)
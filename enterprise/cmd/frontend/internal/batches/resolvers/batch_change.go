@@ -352,3 +352,41 @@ func (r *batchChangeResolver) BatchSpecs(
 
 	return &batchSpecConnectionResolver{store: r.store, opts: opts}, nil
 }
+
+func (r *batchChangeResolver) ChangesetDependencies(ctx context.Context) ([]graphqlbackend.ChangesetDependencyResolver, error) {
+	deps, err := r.store.GetChangesetDependencies(ctx, r.batchChange.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := deps.Edges()
+	seen := make(map[int64]struct{})
+	var cs btypes.Changesets
+	for _, edge := range edges {
+		for _, c := range []*btypes.Changeset{edge.Dependent, edge.DependsOn} {
+			if _, ok := seen[c.ID]; !ok {
+				seen[c.ID] = struct{}{}
+				cs = append(cs, c)
+			}
+		}
+	}
+
+	resolversByID, err := newChangesetResolversByID(ctx, r.store, cs)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetDependencyResolver, 0, len(edges))
+	for _, edge := range edges {
+		dependent, ok := resolversByID[edge.Dependent.ID]
+		if !ok {
+			continue
+		}
+		dependsOn, ok := resolversByID[edge.DependsOn.ID]
+		if !ok {
+			continue
+		}
+		resolvers = append(resolvers, &changesetDependencyResolver{dependent: dependent, dependsOn: dependsOn})
+	}
+	return resolvers, nil
+}
@@ -46,6 +46,12 @@ type changesetResolver struct {
 	specOnce sync.Once
 	spec     *btypes.ChangesetSpec
 	specErr  error
+
+	// cache the dependencies of the owner batch change, since they're needed
+	// by multiple methods
+	dependenciesOnce sync.Once
+	dependencies     *btypes.ChangesetDependencies
+	dependenciesErr  error
 }
 
 func NewChangesetResolverWithNextSync(store *store.Store, changeset *btypes.Changeset, repo *types.Repo, nextSyncAt time.Time) *changesetResolver {
@@ -108,6 +114,18 @@ func (r *changesetResolver) computeSpec(ctx context.Context) (*btypes.ChangesetS
 	return r.spec, r.specErr
 }
 
+func (r *changesetResolver) computeDependencies(ctx context.Context) (*btypes.ChangesetDependencies, error) {
+	r.dependenciesOnce.Do(func() {
+		// Imported changesets can't depend on other changesets.
+		if r.changeset.OwnedByBatchChangeID == 0 {
+			return
+		}
+
+		r.dependencies, r.dependenciesErr = r.store.GetChangesetDependencies(ctx, r.changeset.OwnedByBatchChangeID)
+	})
+	return r.dependencies, r.dependenciesErr
+}
+
 func (r *changesetResolver) computeNextSyncAt(ctx context.Context) (time.Time, error) {
 	r.nextSyncAtOnce.Do(func() {
 		if r.attemptedPreloadNextSyncAt {
@@ -379,6 +397,32 @@ func (r *changesetResolver) CheckState() *string {
 	return &state
 }
 
+func (r *changesetResolver) Mergeability() *string {
+	if !r.changeset.Published() {
+		return nil
+	}
+
+	mergeability := string(r.changeset.Mergeability())
+	return &mergeability
+}
+
+func (r *changesetResolver) RebaseState() *string {
+	if r.changeset.RebaseState == "" {
+		return nil
+	}
+
+	state := string(r.changeset.RebaseState)
+	return &state
+}
+
+func (r *changesetResolver) RebaseConflicts() []graphqlbackend.ChangesetRebaseConflictResolver {
+	resolvers := make([]graphqlbackend.ChangesetRebaseConflictResolver, 0, len(r.changeset.RebaseConflicts))
+	for _, c := range r.changeset.RebaseConflicts {
+		resolvers = append(resolvers, &changesetRebaseConflictResolver{conflict: c})
+	}
+	return resolvers
+}
+
 func (r *changesetResolver) Error() *string { return r.changeset.FailureMessage }
 
 func (r *changesetResolver) SyncerError() *string { return r.changeset.SyncErrorMessage }
@@ -410,6 +454,35 @@ func (r *changesetResolver) CurrentSpec(ctx context.Context) (graphqlbackend.Vis
 	return NewChangesetSpecResolverWithRepo(r.store, r.repo, spec), nil
 }
 
+func (r *changesetResolver) DependsOn(ctx context.Context) ([]graphqlbackend.ExternalChangesetResolver, error) {
+	deps, err := r.computeDependencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prereqs := deps.Prerequisites(r.changeset.ID)
+	resolversByID, err := newChangesetResolversByID(ctx, r.store, prereqs)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ExternalChangesetResolver, 0, len(prereqs))
+	for _, c := range prereqs {
+		if resolver, ok := resolversByID[c.ID]; ok {
+			resolvers = append(resolvers, resolver)
+		}
+	}
+	return resolvers, nil
+}
+
+func (r *changesetResolver) BlockedByDependencies(ctx context.Context) (bool, error) {
+	deps, err := r.computeDependencies(ctx)
+	if err != nil {
+		return false, err
+	}
+	return deps.Blocked(r.changeset.ID), nil
+}
+
 func (r *changesetResolver) Labels(ctx context.Context) ([]graphqlbackend.ChangesetLabelResolver, error) {
 	if !r.changeset.Published() {
 		return []graphqlbackend.ChangesetLabelResolver{}, nil
@@ -550,3 +623,18 @@ func (r *changesetLabelResolver) Description() *string {
 	}
 	return &r.label.Description
 }
+
+type changesetRebaseConflictResolver struct {
+	conflict btypes.ChangesetRebaseConflict
+}
+
+func (r *changesetRebaseConflictResolver) Path() string {
+	return r.conflict.Path
+}
+
+func (r *changesetRebaseConflictResolver) Hunk() *string {
+	if r.conflict.Hunk == "" {
+		return nil
+	}
+	return &r.conflict.Hunk
+}
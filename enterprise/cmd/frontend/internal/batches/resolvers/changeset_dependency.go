@@ -0,0 +1,47 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+type changesetDependencyResolver struct {
+	dependent *changesetResolver
+	dependsOn *changesetResolver
+}
+
+var _ graphqlbackend.ChangesetDependencyResolver = &changesetDependencyResolver{}
+
+func (r *changesetDependencyResolver) Dependent() graphqlbackend.ExternalChangesetResolver {
+	return r.dependent
+}
+
+func (r *changesetDependencyResolver) DependsOn() graphqlbackend.ExternalChangesetResolver {
+	return r.dependsOn
+}
+
+// newChangesetResolversByID returns resolvers for the given changesets,
+// indexed by their IDs.
+//
+// 🚨 SECURITY: database.Repos.GetReposSetByIDs uses the authzFilter under the
+// hood and filters out repositories that the user doesn't have access to.
+// Changesets in those repositories are omitted.
+func newChangesetResolversByID(ctx context.Context, s *store.Store, cs btypes.Changesets) (map[int64]*changesetResolver, error) {
+	reposByID, err := s.Repos().GetReposSetByIDs(ctx, cs.RepoIDs()...)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make(map[int64]*changesetResolver, len(cs))
+	for _, c := range cs {
+		repo, ok := reposByID[c.RepoID]
+		if !ok {
+			continue
+		}
+		resolvers[c.ID] = NewChangesetResolver(s, c, repo)
+	}
+	return resolvers, nil
+}
@@ -0,0 +1,147 @@
+package webhooks
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	fewebhooks "github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type BitbucketCloudWebhook struct {
+	*Webhook
+}
+
+func NewBitbucketCloudWebhook(store *store.Store) *BitbucketCloudWebhook {
+	return &BitbucketCloudWebhook{
+		Webhook: &Webhook{store, extsvc.TypeBitbucketCloud},
+	}
+}
+
+func (h *BitbucketCloudWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e, extSvc, hErr := h.parseEvent(r)
+	if hErr != nil {
+		respond(w, hErr.code, hErr)
+		return
+	} else if e == nil {
+		// Events that don't affect changesets are acknowledged and dropped.
+		respond(w, http.StatusOK, nil)
+		return
+	}
+
+	fewebhooks.SetExternalServiceID(r.Context(), extSvc.ID)
+
+	// 🚨 SECURITY: now that the shared secret has been validated, we can use an
+	// internal actor on the context.
+	ctx := actor.WithInternalActor(r.Context())
+
+	externalServiceID, err := extractExternalServiceID(extSvc)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	pr, ev := h.convertEvent(e)
+	if pr == (PR{}) {
+		log15.Warn("Dropping Bitbucket Cloud webhook event", "type", fmt.Sprintf("%T", e))
+		return
+	}
+
+	if err := h.upsertChangesetEvent(ctx, externalServiceID, pr, ev); err != nil {
+		respond(w, http.StatusInternalServerError, err)
+	}
+}
+
+// parseEvent validates the secret of the request and parses its payload.
+// Bitbucket Cloud doesn't sign webhook payloads, so the secret is expected as
+// the "secret" query parameter of the webhook URL.
+func (h *BitbucketCloudWebhook) parseEvent(r *http.Request) (interface{}, *types.ExternalService, *httpError) {
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusInternalServerError, err}
+	}
+
+	secret := r.FormValue("secret")
+	if secret == "" {
+		return nil, nil, &httpError{http.StatusUnauthorized, errors.New("missing secret")}
+	}
+
+	rawID := r.FormValue(extsvc.IDParam)
+	var externalServiceID int64
+	if rawID != "" {
+		externalServiceID, err = strconv.ParseInt(rawID, 10, 64)
+		if err != nil {
+			return nil, nil, &httpError{http.StatusBadRequest, errors.Wrap(err, "invalid external service id")}
+		}
+	}
+
+	args := database.ExternalServicesListOptions{Kinds: []string{extsvc.KindBitbucketCloud}}
+	if externalServiceID != 0 {
+		args.IDs = append(args.IDs, externalServiceID)
+	}
+	es, err := h.Store.ExternalServices().List(r.Context(), args)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusInternalServerError, err}
+	}
+
+	var extSvc *types.ExternalService
+	for _, e := range es {
+		c, _ := e.Configuration()
+		con, ok := c.(*schema.BitbucketCloudConnection)
+		if !ok || con.WebhookSecret == "" {
+			continue
+		}
+
+		if subtle.ConstantTimeCompare([]byte(secret), []byte(con.WebhookSecret)) == 1 {
+			extSvc = e
+			break
+		}
+	}
+
+	if extSvc == nil {
+		return nil, nil, &httpError{http.StatusUnauthorized, errors.New("invalid secret")}
+	}
+
+	e, err := bitbucketcloud.ParseWebhookEvent(bitbucketcloud.WebhookEventType(r), payload)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusBadRequest, errors.Wrap(err, "parsing webhook")}
+	}
+	return e, extSvc, nil
+}
+
+func (h *BitbucketCloudWebhook) convertEvent(theirs interface{}) (pr PR, ours keyer) {
+	log15.Debug("Bitbucket Cloud webhook received", "type", fmt.Sprintf("%T", theirs))
+
+	switch e := theirs.(type) {
+	case *bitbucketcloud.PullRequestApprovedEvent:
+		return bitbucketCloudPR(&e.PullRequestEvent), e.ToEvent()
+	case *bitbucketcloud.PullRequestUnapprovedEvent:
+		return bitbucketCloudPR(&e.PullRequestEvent), e.ToEvent()
+	case *bitbucketcloud.PullRequestChangesRequestCreatedEvent:
+		return bitbucketCloudPR(&e.PullRequestEvent), e.ToEvent()
+	case *bitbucketcloud.PullRequestChangesRequestRemovedEvent:
+		return bitbucketCloudPR(&e.PullRequestEvent), e.ToEvent()
+	case *bitbucketcloud.PullRequestFulfilledEvent:
+		return bitbucketCloudPR(&e.PullRequestEvent), e.ToEvent()
+	case *bitbucketcloud.PullRequestRejectedEvent:
+		return bitbucketCloudPR(&e.PullRequestEvent), e.ToEvent()
+	}
+
+	return
+}
+
+func bitbucketCloudPR(e *bitbucketcloud.PullRequestEvent) PR {
+	return PR{ID: int64(e.PullRequest.ID), RepoExternalID: e.Repository.UUID}
+}
@@ -0,0 +1,40 @@
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+)
+
+func TestBitbucketCloudWebhook_convertEvent(t *testing.T) {
+	h := NewBitbucketCloudWebhook(nil)
+
+	payload := `{
+		"actor": {"uuid": "{alice}"},
+		"pullrequest": {"id": 42, "state": "MERGED", "updated_on": "2021-10-01T12:00:00Z"},
+		"repository": {"uuid": "{repo}", "full_name": "sglocal/mux"},
+		"approval": {"date": "2021-10-01T12:00:00Z", "user": {"uuid": "{alice}"}}
+	}`
+
+	for eventType, wantKey := range map[string]string{
+		"pullrequest:approved":  "{alice}:approved:1633089600",
+		"pullrequest:fulfilled": "merged",
+	} {
+		t.Run(eventType, func(t *testing.T) {
+			e, err := bitbucketcloud.ParseWebhookEvent(eventType, []byte(payload))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pr, ev := h.convertEvent(e)
+			if diff := cmp.Diff(PR{ID: 42, RepoExternalID: "{repo}"}, pr); diff != "" {
+				t.Errorf("unexpected PR (-want +got):\n%s", diff)
+			}
+			if have := ev.Key(); have != wantKey {
+				t.Errorf("unexpected key: have %q, want %q", have, wantKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package webhooks
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	fewebhooks "github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type GerritWebhook struct {
+	*Webhook
+}
+
+func NewGerritWebhook(store *store.Store) *GerritWebhook {
+	return &GerritWebhook{
+		Webhook: &Webhook{store, extsvc.TypeGerrit},
+	}
+}
+
+func (h *GerritWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e, extSvc, hErr := h.parseEvent(r)
+	if hErr != nil {
+		respond(w, hErr.code, hErr)
+		return
+	} else if e == nil {
+		// Events that don't affect changesets are acknowledged and dropped.
+		respond(w, http.StatusOK, nil)
+		return
+	}
+
+	fewebhooks.SetExternalServiceID(r.Context(), extSvc.ID)
+
+	// 🚨 SECURITY: now that the shared secret has been validated, we can use an
+	// internal actor on the context.
+	ctx := actor.WithInternalActor(r.Context())
+
+	externalServiceID, err := extractExternalServiceID(extSvc)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	pr, ev, err := h.convertEvent(e)
+	if err != nil {
+		respond(w, http.StatusBadRequest, err)
+		return
+	}
+	if pr == (PR{}) {
+		// Comments that don't change a Code-Review vote don't affect the
+		// changeset.
+		log15.Debug("Dropping Gerrit webhook event", "type", fmt.Sprintf("%T", e))
+		return
+	}
+
+	if err := h.upsertChangesetEvent(ctx, externalServiceID, pr, ev); err != nil {
+		respond(w, http.StatusInternalServerError, err)
+	}
+}
+
+// parseEvent validates the secret of the request and parses its payload. The
+// Gerrit webhooks plugin doesn't sign payloads, so the secret is expected as
+// the "secret" query parameter of the webhook URL.
+func (h *GerritWebhook) parseEvent(r *http.Request) (interface{}, *types.ExternalService, *httpError) {
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusInternalServerError, err}
+	}
+
+	secret := r.FormValue("secret")
+	if secret == "" {
+		return nil, nil, &httpError{http.StatusUnauthorized, errors.New("missing secret")}
+	}
+
+	rawID := r.FormValue(extsvc.IDParam)
+	var externalServiceID int64
+	if rawID != "" {
+		externalServiceID, err = strconv.ParseInt(rawID, 10, 64)
+		if err != nil {
+			return nil, nil, &httpError{http.StatusBadRequest, errors.Wrap(err, "invalid external service id")}
+		}
+	}
+
+	args := database.ExternalServicesListOptions{Kinds: []string{extsvc.KindGerrit}}
+	if externalServiceID != 0 {
+		args.IDs = append(args.IDs, externalServiceID)
+	}
+	es, err := h.Store.ExternalServices().List(r.Context(), args)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusInternalServerError, err}
+	}
+
+	var extSvc *types.ExternalService
+	for _, e := range es {
+		c, _ := e.Configuration()
+		con, ok := c.(*schema.GerritConnection)
+		if !ok || con.WebhookSecret == "" {
+			continue
+		}
+
+		if subtle.ConstantTimeCompare([]byte(secret), []byte(con.WebhookSecret)) == 1 {
+			extSvc = e
+			break
+		}
+	}
+
+	if extSvc == nil {
+		return nil, nil, &httpError{http.StatusUnauthorized, errors.New("invalid secret")}
+	}
+
+	e, err := gerrit.ParseWebhookEvent(payload)
+	if err != nil {
+		return nil, nil, &httpError{http.StatusBadRequest, errors.Wrap(err, "parsing webhook")}
+	}
+	return e, extSvc, nil
+}
+
+func (h *GerritWebhook) convertEvent(theirs interface{}) (pr PR, ours keyer, err error) {
+	log15.Debug("Gerrit webhook received", "type", fmt.Sprintf("%T", theirs))
+
+	switch e := theirs.(type) {
+	case *gerrit.ChangeMergedEvent:
+		return gerritPR(&e.ChangeEvent), e.ToEvent(), nil
+	case *gerrit.ChangeAbandonedEvent:
+		return gerritPR(&e.ChangeEvent), e.ToEvent(), nil
+	case *gerrit.ChangeRestoredEvent:
+		return gerritPR(&e.ChangeEvent), e.ToEvent(), nil
+	case *gerrit.CommentAddedEvent:
+		review, err := e.ToEvent()
+		if err != nil || review == nil {
+			return PR{}, nil, err
+		}
+		return gerritPR(&e.ChangeEvent), review, nil
+	}
+
+	return
+}
+
+// gerritPR returns the PR of the change of the given event. Changesets on
+// Gerrit are identified by the Change-Id of their change, and repos by the
+// project ID, which is the URL encoded project name.
+func gerritPR(e *gerrit.ChangeEvent) PR {
+	return PR{RepoExternalID: url.QueryEscape(e.Change.Project), ExternalID: e.Change.ID}
+}
@@ -0,0 +1,60 @@
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+)
+
+func TestGerritWebhook_convertEvent(t *testing.T) {
+	h := NewGerritWebhook(nil)
+
+	change := `"change": {"project": "platform/build", "branch": "main", "id": "I0123", "number": 1}, "eventCreatedOn": 1633089600`
+
+	for eventType, wantKey := range map[string]string{
+		"change-merged":    "MERGED:1633089600",
+		"change-abandoned": "ABANDONED:1633089600",
+		"comment-added":    "alice:2:1633089600",
+	} {
+		t.Run(eventType, func(t *testing.T) {
+			payload := `{
+				"type": "` + eventType + `",
+				"author": {"username": "alice"},
+				"approvals": [{"type": "Code-Review", "value": "2", "oldValue": "1"}],
+				` + change + `
+			}`
+			e, err := gerrit.ParseWebhookEvent([]byte(payload))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pr, ev, err := h.convertEvent(e)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(PR{RepoExternalID: "platform%2Fbuild", ExternalID: "I0123"}, pr); diff != "" {
+				t.Errorf("unexpected PR (-want +got):\n%s", diff)
+			}
+			if have := ev.Key(); have != wantKey {
+				t.Errorf("unexpected key: have %q, want %q", have, wantKey)
+			}
+		})
+	}
+
+	t.Run("comment without vote", func(t *testing.T) {
+		e, err := gerrit.ParseWebhookEvent([]byte(`{"type": "comment-added", "author": {"username": "alice"}, ` + change + `}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pr, _, err := h.convertEvent(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pr != (PR{}) {
+			t.Errorf("expected event to be dropped, got %+v", pr)
+		}
+	})
+}
@@ -32,6 +32,17 @@ type Webhook struct {
 type PR struct {
 	ID             int64
 	RepoExternalID string
+
+	// ExternalID is the external ID of the changeset, for code hosts that
+	// don't identify changesets by number. If it's empty, ID is used.
+	ExternalID string
+}
+
+func (pr PR) externalID() string {
+	if pr.ExternalID != "" {
+		return pr.ExternalID
+	}
+	return strconv.FormatInt(pr.ID, 10)
 }
 
 func (h Webhook) getRepoForPR(
@@ -74,6 +85,10 @@ func extractExternalServiceID(extSvc *types.ExternalService) (string, error) {
 		serviceID = c.Url
 	case *schema.GitLabConnection:
 		serviceID = c.Url
+	case *schema.BitbucketCloudConnection:
+		serviceID = c.Url
+	case *schema.GerritConnection:
+		serviceID = c.Url
 	}
 	if serviceID == "" {
 		return "", errors.New("could not determine service id")
@@ -116,7 +131,7 @@ func (h Webhook) upsertChangesetEvent(
 
 	cs, err := tx.GetChangeset(ctx, store.GetChangesetOpts{
 		RepoID:              r.ID,
-		ExternalID:          strconv.FormatInt(pr.ID, 10),
+		ExternalID:          pr.externalID(),
 		ExternalServiceType: h.ServiceType,
 	})
 	if err != nil {
@@ -169,11 +184,20 @@ func (h Webhook) upsertChangesetEvent(
 	events, _, err := tx.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{
 		ChangesetIDs: []int64{cs.ID},
 	})
+	previousState := cs.ExternalState
 	state.SetDerivedState(ctx, tx.Repos(), cs, events)
 	if err := tx.UpdateChangesetCodeHostState(ctx, cs); err != nil {
 		return err
 	}
 
+	// Changesets depending on a newly merged changeset may be ready for
+	// review now.
+	if previousState != btypes.ChangesetExternalStateMerged && cs.ExternalState == btypes.ChangesetExternalStateMerged {
+		if err := tx.EnqueueChangesetsDependingOn(ctx, cs); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
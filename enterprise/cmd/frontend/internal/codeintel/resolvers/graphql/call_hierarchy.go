@@ -0,0 +1,76 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
+)
+
+type CallHierarchyItemResolver struct {
+	item     resolvers.AdjustedCallHierarchyItem
+	location gql.LocationResolver
+}
+
+func NewCallHierarchyItemResolver(item resolvers.AdjustedCallHierarchyItem, location gql.LocationResolver) gql.CallHierarchyItemResolver {
+	return &CallHierarchyItemResolver{
+		item:     item,
+		location: location,
+	}
+}
+
+func (r *CallHierarchyItemResolver) Name() string                   { return r.item.Name }
+func (r *CallHierarchyItemResolver) Location() gql.LocationResolver { return r.location }
+
+func (r *CallHierarchyItemResolver) Kind() string {
+	if r.item.Kind == 0 {
+		return "UNKNOWN"
+	}
+	return strings.ToUpper(r.item.Kind.String())
+}
+
+func (r *CallHierarchyItemResolver) FullRange() gql.RangeResolver {
+	return gql.NewRangeResolver(convertRange(r.item.FullRange))
+}
+
+type CallHierarchyCallResolver struct {
+	item             gql.CallHierarchyItemResolver
+	locations        []resolvers.AdjustedLocation
+	locationResolver *CachedLocationResolver
+}
+
+func NewCallHierarchyCallResolver(item gql.CallHierarchyItemResolver, locations []resolvers.AdjustedLocation, locationResolver *CachedLocationResolver) gql.CallHierarchyCallResolver {
+	return &CallHierarchyCallResolver{
+		item:             item,
+		locations:        locations,
+		locationResolver: locationResolver,
+	}
+}
+
+func (r *CallHierarchyCallResolver) Item() gql.CallHierarchyItemResolver { return r.item }
+
+func (r *CallHierarchyCallResolver) Locations(ctx context.Context) ([]gql.LocationResolver, error) {
+	return resolveLocations(ctx, r.locationResolver, r.locations)
+}
+
+// resolveCalls creates a slice of CallHierarchyCallResolvers for the given list of adjusted calls. The
+// resulting list may be smaller than the input list as any calls whose item has a commit not known by
+// gitserver will be skipped.
+func resolveCalls(ctx context.Context, locationResolver *CachedLocationResolver, calls []resolvers.AdjustedCallHierarchyCall) ([]gql.CallHierarchyCallResolver, error) {
+	resolvedCalls := make([]gql.CallHierarchyCallResolver, 0, len(calls))
+	for _, call := range calls {
+		location, err := resolveLocation(ctx, locationResolver, call.Item.Location)
+		if err != nil {
+			return nil, err
+		}
+		if location == nil {
+			continue
+		}
+
+		item := NewCallHierarchyItemResolver(call.Item, location)
+		resolvedCalls = append(resolvedCalls, NewCallHierarchyCallResolver(item, call.Locations, locationResolver))
+	}
+
+	return resolvedCalls, nil
+}
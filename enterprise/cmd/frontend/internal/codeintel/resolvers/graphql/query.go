@@ -138,6 +138,39 @@ func (r *QueryResolver) Implementations(ctx context.Context, args *gql.LSIFPaged
 	return NewLocationConnectionResolver(locations, strPtr(cursor), r.locationResolver), nil
 }
 
+func (r *QueryResolver) TypeDefinitions(ctx context.Context, args *gql.LSIFQueryPositionArgs) (_ gql.LocationConnectionResolver, err error) {
+	defer r.errTracer.Collect(&err, log.String("queryResolver.field", "typeDefinitions"))
+
+	locations, err := r.resolver.TypeDefinitions(ctx, int(args.Line), int(args.Character))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLocationConnectionResolver(locations, nil, r.locationResolver), nil
+}
+
+func (r *QueryResolver) IncomingCalls(ctx context.Context, args *gql.LSIFQueryPositionArgs) (_ []gql.CallHierarchyCallResolver, err error) {
+	defer r.errTracer.Collect(&err, log.String("queryResolver.field", "incomingCalls"))
+
+	calls, err := r.resolver.IncomingCalls(ctx, int(args.Line), int(args.Character))
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveCalls(ctx, r.locationResolver, calls)
+}
+
+func (r *QueryResolver) OutgoingCalls(ctx context.Context, args *gql.LSIFQueryPositionArgs) (_ []gql.CallHierarchyCallResolver, err error) {
+	defer r.errTracer.Collect(&err, log.String("queryResolver.field", "outgoingCalls"))
+
+	calls, err := r.resolver.OutgoingCalls(ctx, int(args.Line), int(args.Character))
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveCalls(ctx, r.locationResolver, calls)
+}
+
 func (r *QueryResolver) Hover(ctx context.Context, args *gql.LSIFQueryPositionArgs) (_ gql.HoverResolver, err error) {
 	defer r.errTracer.Collect(&err, log.String("queryResolver.field", "hover"))
 
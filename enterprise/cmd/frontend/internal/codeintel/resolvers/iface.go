@@ -56,6 +56,10 @@ type LSIFStore interface {
 	Definitions(ctx context.Context, bundleID int, path string, line, character, limit, offset int) ([]lsifstore.Location, int, error)
 	References(ctx context.Context, bundleID int, path string, line, character, limit, offset int) ([]lsifstore.Location, int, error)
 	Implementations(ctx context.Context, bundleID int, path string, line, character, limit, offset int) ([]lsifstore.Location, int, error)
+	TypeDefinitions(ctx context.Context, bundleID int, path string, line, character, limit, offset int) ([]lsifstore.Location, int, error)
+	CallHierarchyItems(ctx context.Context, bundleID int, path string, line, character int) ([]lsifstore.CallHierarchyItem, error)
+	IncomingCalls(ctx context.Context, bundleID int, locations []lsifstore.Location) ([]lsifstore.CallHierarchyCall, error)
+	OutgoingCalls(ctx context.Context, bundleID int, path string, line, character int) ([]lsifstore.CallHierarchyCall, []lsifstore.Location, error)
 	Hover(ctx context.Context, bundleID int, path string, line, character int) (string, lsifstore.Range, bool, error)
 	Diagnostics(ctx context.Context, bundleID int, prefix string, limit, offset int) ([]lsifstore.Diagnostic, int, error)
 	MonikersByPosition(ctx context.Context, bundleID int, path string, line, character int) ([][]precise.MonikerData, error)
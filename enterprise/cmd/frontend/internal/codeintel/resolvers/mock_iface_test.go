@@ -5733,6 +5733,9 @@ type MockLSIFStore struct {
 	// BulkMonikerResultsFunc is an instance of a mock function object
 	// controlling the behavior of the method BulkMonikerResults.
 	BulkMonikerResultsFunc *LSIFStoreBulkMonikerResultsFunc
+	// CallHierarchyItemsFunc is an instance of a mock function object
+	// controlling the behavior of the method CallHierarchyItems.
+	CallHierarchyItemsFunc *LSIFStoreCallHierarchyItemsFunc
 	// DefinitionsFunc is an instance of a mock function object controlling
 	// the behavior of the method Definitions.
 	DefinitionsFunc *LSIFStoreDefinitionsFunc
@@ -5763,9 +5766,15 @@ type MockLSIFStore struct {
 	// ImplementationsFunc is an instance of a mock function object
 	// controlling the behavior of the method Implementations.
 	ImplementationsFunc *LSIFStoreImplementationsFunc
+	// IncomingCallsFunc is an instance of a mock function object
+	// controlling the behavior of the method IncomingCalls.
+	IncomingCallsFunc *LSIFStoreIncomingCallsFunc
 	// MonikersByPositionFunc is an instance of a mock function object
 	// controlling the behavior of the method MonikersByPosition.
 	MonikersByPositionFunc *LSIFStoreMonikersByPositionFunc
+	// OutgoingCallsFunc is an instance of a mock function object
+	// controlling the behavior of the method OutgoingCalls.
+	OutgoingCallsFunc *LSIFStoreOutgoingCallsFunc
 	// PackageInformationFunc is an instance of a mock function object
 	// controlling the behavior of the method PackageInformation.
 	PackageInformationFunc *LSIFStorePackageInformationFunc
@@ -5778,6 +5787,9 @@ type MockLSIFStore struct {
 	// StencilFunc is an instance of a mock function object controlling the
 	// behavior of the method Stencil.
 	StencilFunc *LSIFStoreStencilFunc
+	// TypeDefinitionsFunc is an instance of a mock function object
+	// controlling the behavior of the method TypeDefinitions.
+	TypeDefinitionsFunc *LSIFStoreTypeDefinitionsFunc
 }
 
 // NewMockLSIFStore creates a new mock of the LSIFStore interface. All
@@ -5789,6 +5801,11 @@ func NewMockLSIFStore() *MockLSIFStore {
 				return nil, 0, nil
 			},
 		},
+		CallHierarchyItemsFunc: &LSIFStoreCallHierarchyItemsFunc{
+			defaultHook: func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyItem, error) {
+				return nil, nil
+			},
+		},
 		DefinitionsFunc: &LSIFStoreDefinitionsFunc{
 			defaultHook: func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
 				return nil, 0, nil
@@ -5839,11 +5856,21 @@ func NewMockLSIFStore() *MockLSIFStore {
 				return nil, 0, nil
 			},
 		},
+		IncomingCallsFunc: &LSIFStoreIncomingCallsFunc{
+			defaultHook: func(context.Context, int, []lsifstore.Location) ([]lsifstore.CallHierarchyCall, error) {
+				return nil, nil
+			},
+		},
 		MonikersByPositionFunc: &LSIFStoreMonikersByPositionFunc{
 			defaultHook: func(context.Context, int, string, int, int) ([][]precise.MonikerData, error) {
 				return nil, nil
 			},
 		},
+		OutgoingCallsFunc: &LSIFStoreOutgoingCallsFunc{
+			defaultHook: func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, []lsifstore.Location, error) {
+				return nil, nil, nil
+			},
+		},
 		PackageInformationFunc: &LSIFStorePackageInformationFunc{
 			defaultHook: func(context.Context, int, string, string) (precise.PackageInformationData, bool, error) {
 				return precise.PackageInformationData{}, false, nil
@@ -5864,6 +5891,11 @@ func NewMockLSIFStore() *MockLSIFStore {
 				return nil, nil
 			},
 		},
+		TypeDefinitionsFunc: &LSIFStoreTypeDefinitionsFunc{
+			defaultHook: func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+				return nil, 0, nil
+			},
+		},
 	}
 }
 
@@ -5876,6 +5908,11 @@ func NewStrictMockLSIFStore() *MockLSIFStore {
 				panic("unexpected invocation of MockLSIFStore.BulkMonikerResults")
 			},
 		},
+		CallHierarchyItemsFunc: &LSIFStoreCallHierarchyItemsFunc{
+			defaultHook: func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyItem, error) {
+				panic("unexpected invocation of MockLSIFStore.CallHierarchyItems")
+			},
+		},
 		DefinitionsFunc: &LSIFStoreDefinitionsFunc{
 			defaultHook: func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
 				panic("unexpected invocation of MockLSIFStore.Definitions")
@@ -5926,11 +5963,21 @@ func NewStrictMockLSIFStore() *MockLSIFStore {
 				panic("unexpected invocation of MockLSIFStore.Implementations")
 			},
 		},
+		IncomingCallsFunc: &LSIFStoreIncomingCallsFunc{
+			defaultHook: func(context.Context, int, []lsifstore.Location) ([]lsifstore.CallHierarchyCall, error) {
+				panic("unexpected invocation of MockLSIFStore.IncomingCalls")
+			},
+		},
 		MonikersByPositionFunc: &LSIFStoreMonikersByPositionFunc{
 			defaultHook: func(context.Context, int, string, int, int) ([][]precise.MonikerData, error) {
 				panic("unexpected invocation of MockLSIFStore.MonikersByPosition")
 			},
 		},
+		OutgoingCallsFunc: &LSIFStoreOutgoingCallsFunc{
+			defaultHook: func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, []lsifstore.Location, error) {
+				panic("unexpected invocation of MockLSIFStore.OutgoingCalls")
+			},
+		},
 		PackageInformationFunc: &LSIFStorePackageInformationFunc{
 			defaultHook: func(context.Context, int, string, string) (precise.PackageInformationData, bool, error) {
 				panic("unexpected invocation of MockLSIFStore.PackageInformation")
@@ -5951,6 +5998,11 @@ func NewStrictMockLSIFStore() *MockLSIFStore {
 				panic("unexpected invocation of MockLSIFStore.Stencil")
 			},
 		},
+		TypeDefinitionsFunc: &LSIFStoreTypeDefinitionsFunc{
+			defaultHook: func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+				panic("unexpected invocation of MockLSIFStore.TypeDefinitions")
+			},
+		},
 	}
 }
 
@@ -5961,6 +6013,9 @@ func NewMockLSIFStoreFrom(i LSIFStore) *MockLSIFStore {
 		BulkMonikerResultsFunc: &LSIFStoreBulkMonikerResultsFunc{
 			defaultHook: i.BulkMonikerResults,
 		},
+		CallHierarchyItemsFunc: &LSIFStoreCallHierarchyItemsFunc{
+			defaultHook: i.CallHierarchyItems,
+		},
 		DefinitionsFunc: &LSIFStoreDefinitionsFunc{
 			defaultHook: i.Definitions,
 		},
@@ -5991,9 +6046,15 @@ func NewMockLSIFStoreFrom(i LSIFStore) *MockLSIFStore {
 		ImplementationsFunc: &LSIFStoreImplementationsFunc{
 			defaultHook: i.Implementations,
 		},
+		IncomingCallsFunc: &LSIFStoreIncomingCallsFunc{
+			defaultHook: i.IncomingCalls,
+		},
 		MonikersByPositionFunc: &LSIFStoreMonikersByPositionFunc{
 			defaultHook: i.MonikersByPosition,
 		},
+		OutgoingCallsFunc: &LSIFStoreOutgoingCallsFunc{
+			defaultHook: i.OutgoingCalls,
+		},
 		PackageInformationFunc: &LSIFStorePackageInformationFunc{
 			defaultHook: i.PackageInformation,
 		},
@@ -6006,6 +6067,9 @@ func NewMockLSIFStoreFrom(i LSIFStore) *MockLSIFStore {
 		StencilFunc: &LSIFStoreStencilFunc{
 			defaultHook: i.Stencil,
 		},
+		TypeDefinitionsFunc: &LSIFStoreTypeDefinitionsFunc{
+			defaultHook: i.TypeDefinitions,
+		},
 	}
 }
 
@@ -6134,6 +6198,125 @@ func (c LSIFStoreBulkMonikerResultsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// LSIFStoreCallHierarchyItemsFunc describes the behavior when the
+// CallHierarchyItems method of the parent MockLSIFStore instance is
+// invoked.
+type LSIFStoreCallHierarchyItemsFunc struct {
+	defaultHook func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyItem, error)
+	hooks       []func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyItem, error)
+	history     []LSIFStoreCallHierarchyItemsFuncCall
+	mutex       sync.Mutex
+}
+
+// CallHierarchyItems delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) CallHierarchyItems(v0 context.Context, v1 int, v2 string, v3 int, v4 int) ([]lsifstore.CallHierarchyItem, error) {
+	r0, r1 := m.CallHierarchyItemsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.CallHierarchyItemsFunc.appendCall(LSIFStoreCallHierarchyItemsFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CallHierarchyItems
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreCallHierarchyItemsFunc) SetDefaultHook(hook func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyItem, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CallHierarchyItems method of the parent MockLSIFStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *LSIFStoreCallHierarchyItemsFunc) PushHook(hook func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyItem, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *LSIFStoreCallHierarchyItemsFunc) SetDefaultReturn(r0 []lsifstore.CallHierarchyItem, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyItem, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *LSIFStoreCallHierarchyItemsFunc) PushReturn(r0 []lsifstore.CallHierarchyItem, r1 error) {
+	f.PushHook(func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyItem, error) {
+		return r0, r1
+	})
+}
+
+func (f *LSIFStoreCallHierarchyItemsFunc) nextHook() func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyItem, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreCallHierarchyItemsFunc) appendCall(r0 LSIFStoreCallHierarchyItemsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreCallHierarchyItemsFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreCallHierarchyItemsFunc) History() []LSIFStoreCallHierarchyItemsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreCallHierarchyItemsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreCallHierarchyItemsFuncCall is an object that describes an
+// invocation of method CallHierarchyItems on an instance of MockLSIFStore.
+type LSIFStoreCallHierarchyItemsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []lsifstore.CallHierarchyItem
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreCallHierarchyItemsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreCallHierarchyItemsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // LSIFStoreDefinitionsFunc describes the behavior when the Definitions
 // method of the parent MockLSIFStore instance is invoked.
 type LSIFStoreDefinitionsFunc struct {
@@ -7330,6 +7513,118 @@ func (c LSIFStoreImplementationsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// LSIFStoreIncomingCallsFunc describes the behavior when the IncomingCalls
+// method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreIncomingCallsFunc struct {
+	defaultHook func(context.Context, int, []lsifstore.Location) ([]lsifstore.CallHierarchyCall, error)
+	hooks       []func(context.Context, int, []lsifstore.Location) ([]lsifstore.CallHierarchyCall, error)
+	history     []LSIFStoreIncomingCallsFuncCall
+	mutex       sync.Mutex
+}
+
+// IncomingCalls delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockLSIFStore) IncomingCalls(v0 context.Context, v1 int, v2 []lsifstore.Location) ([]lsifstore.CallHierarchyCall, error) {
+	r0, r1 := m.IncomingCallsFunc.nextHook()(v0, v1, v2)
+	m.IncomingCallsFunc.appendCall(LSIFStoreIncomingCallsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the IncomingCalls method
+// of the parent MockLSIFStore instance is invoked and the hook queue is
+// empty.
+func (f *LSIFStoreIncomingCallsFunc) SetDefaultHook(hook func(context.Context, int, []lsifstore.Location) ([]lsifstore.CallHierarchyCall, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// IncomingCalls method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreIncomingCallsFunc) PushHook(hook func(context.Context, int, []lsifstore.Location) ([]lsifstore.CallHierarchyCall, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *LSIFStoreIncomingCallsFunc) SetDefaultReturn(r0 []lsifstore.CallHierarchyCall, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, []lsifstore.Location) ([]lsifstore.CallHierarchyCall, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *LSIFStoreIncomingCallsFunc) PushReturn(r0 []lsifstore.CallHierarchyCall, r1 error) {
+	f.PushHook(func(context.Context, int, []lsifstore.Location) ([]lsifstore.CallHierarchyCall, error) {
+		return r0, r1
+	})
+}
+
+func (f *LSIFStoreIncomingCallsFunc) nextHook() func(context.Context, int, []lsifstore.Location) ([]lsifstore.CallHierarchyCall, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreIncomingCallsFunc) appendCall(r0 LSIFStoreIncomingCallsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreIncomingCallsFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreIncomingCallsFunc) History() []LSIFStoreIncomingCallsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreIncomingCallsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreIncomingCallsFuncCall is an object that describes an invocation
+// of method IncomingCalls on an instance of MockLSIFStore.
+type LSIFStoreIncomingCallsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []lsifstore.Location
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []lsifstore.CallHierarchyCall
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreIncomingCallsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreIncomingCallsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // LSIFStoreMonikersByPositionFunc describes the behavior when the
 // MonikersByPosition method of the parent MockLSIFStore instance is
 // invoked.
@@ -7449,6 +7744,127 @@ func (c LSIFStoreMonikersByPositionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// LSIFStoreOutgoingCallsFunc describes the behavior when the OutgoingCalls
+// method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreOutgoingCallsFunc struct {
+	defaultHook func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, []lsifstore.Location, error)
+	hooks       []func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, []lsifstore.Location, error)
+	history     []LSIFStoreOutgoingCallsFuncCall
+	mutex       sync.Mutex
+}
+
+// OutgoingCalls delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockLSIFStore) OutgoingCalls(v0 context.Context, v1 int, v2 string, v3 int, v4 int) ([]lsifstore.CallHierarchyCall, []lsifstore.Location, error) {
+	r0, r1, r2 := m.OutgoingCallsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.OutgoingCallsFunc.appendCall(LSIFStoreOutgoingCallsFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the OutgoingCalls method
+// of the parent MockLSIFStore instance is invoked and the hook queue is
+// empty.
+func (f *LSIFStoreOutgoingCallsFunc) SetDefaultHook(hook func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, []lsifstore.Location, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// OutgoingCalls method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreOutgoingCallsFunc) PushHook(hook func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, []lsifstore.Location, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *LSIFStoreOutgoingCallsFunc) SetDefaultReturn(r0 []lsifstore.CallHierarchyCall, r1 []lsifstore.Location, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, []lsifstore.Location, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *LSIFStoreOutgoingCallsFunc) PushReturn(r0 []lsifstore.CallHierarchyCall, r1 []lsifstore.Location, r2 error) {
+	f.PushHook(func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, []lsifstore.Location, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *LSIFStoreOutgoingCallsFunc) nextHook() func(context.Context, int, string, int, int) ([]lsifstore.CallHierarchyCall, []lsifstore.Location, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreOutgoingCallsFunc) appendCall(r0 LSIFStoreOutgoingCallsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreOutgoingCallsFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreOutgoingCallsFunc) History() []LSIFStoreOutgoingCallsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreOutgoingCallsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreOutgoingCallsFuncCall is an object that describes an invocation
+// of method OutgoingCalls on an instance of MockLSIFStore.
+type LSIFStoreOutgoingCallsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []lsifstore.CallHierarchyCall
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 []lsifstore.Location
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreOutgoingCallsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreOutgoingCallsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // LSIFStorePackageInformationFunc describes the behavior when the
 // PackageInformation method of the parent MockLSIFStore instance is
 // invoked.
@@ -7922,6 +8338,133 @@ func (c LSIFStoreStencilFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// LSIFStoreTypeDefinitionsFunc describes the behavior when the
+// TypeDefinitions method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreTypeDefinitionsFunc struct {
+	defaultHook func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error)
+	hooks       []func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error)
+	history     []LSIFStoreTypeDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// TypeDefinitions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) TypeDefinitions(v0 context.Context, v1 int, v2 string, v3 int, v4 int, v5 int, v6 int) ([]lsifstore.Location, int, error) {
+	r0, r1, r2 := m.TypeDefinitionsFunc.nextHook()(v0, v1, v2, v3, v4, v5, v6)
+	m.TypeDefinitionsFunc.appendCall(LSIFStoreTypeDefinitionsFuncCall{v0, v1, v2, v3, v4, v5, v6, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the TypeDefinitions
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreTypeDefinitionsFunc) SetDefaultHook(hook func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// TypeDefinitions method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreTypeDefinitionsFunc) PushHook(hook func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *LSIFStoreTypeDefinitionsFunc) SetDefaultReturn(r0 []lsifstore.Location, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *LSIFStoreTypeDefinitionsFunc) PushReturn(r0 []lsifstore.Location, r1 int, r2 error) {
+	f.PushHook(func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *LSIFStoreTypeDefinitionsFunc) nextHook() func(context.Context, int, string, int, int, int, int) ([]lsifstore.Location, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreTypeDefinitionsFunc) appendCall(r0 LSIFStoreTypeDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreTypeDefinitionsFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreTypeDefinitionsFunc) History() []LSIFStoreTypeDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreTypeDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreTypeDefinitionsFuncCall is an object that describes an
+// invocation of method TypeDefinitions on an instance of MockLSIFStore.
+type LSIFStoreTypeDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 int
+	// Arg6 is the value of the 7th argument passed to this method
+	// invocation.
+	Arg6 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []lsifstore.Location
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreTypeDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5, c.Arg6}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreTypeDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // MockRepoUpdaterClient is a mock implementation of the RepoUpdaterClient
 // interface (from the package
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
@@ -24,11 +24,14 @@ type operations struct {
 	documentationReferences   *observation.Operation
 	documentationSearch       *observation.Operation
 	hover                     *observation.Operation
+	incomingCalls             *observation.Operation
+	outgoingCalls             *observation.Operation
 	queryResolver             *observation.Operation
 	ranges                    *observation.Operation
 	references                *observation.Operation
 	implementations           *observation.Operation
 	stencil                   *observation.Operation
+	typeDefinitions           *observation.Operation
 
 	findClosestDumps *observation.Operation
 }
@@ -71,11 +74,14 @@ func newOperations(observationContext *observation.Context) *operations {
 		documentationReferences:   op("DocumentationReferences"),
 		documentationSearch:       op("DocumentationSearch"),
 		hover:                     op("Hover"),
+		incomingCalls:             op("IncomingCalls"),
+		outgoingCalls:             op("OutgoingCalls"),
 		queryResolver:             op("QueryResolver"),
 		ranges:                    op("Ranges"),
 		references:                op("References"),
 		implementations:           op("Implementations"),
 		stencil:                   op("Stencil"),
+		typeDefinitions:           op("TypeDefinitions"),
 
 		findClosestDumps: subOp("findClosestDumps"),
 	}
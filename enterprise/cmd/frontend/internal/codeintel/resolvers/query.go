@@ -5,6 +5,7 @@ import (
 
 	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 )
 
@@ -38,6 +39,22 @@ type AdjustedCodeIntelligenceRange struct {
 	DocumentationPathID string
 }
 
+// AdjustedCallHierarchyItem is a callable symbol. The location of its name and its full range have
+// been adjusted to fit the target (originally requested) commit.
+type AdjustedCallHierarchyItem struct {
+	Name      string
+	Kind      protocol.SymbolKind
+	Location  AdjustedLocation
+	FullRange lsifstore.Range
+}
+
+// AdjustedCallHierarchyCall pairs a caller or callee with the locations of the calls between the two
+// symbols. The locations have been adjusted to fit the target (originally requested) commit.
+type AdjustedCallHierarchyCall struct {
+	Item      AdjustedCallHierarchyItem
+	Locations []AdjustedLocation
+}
+
 func (a *AdjustedCodeIntelligenceRange) ToDocumentation() *Documentation {
 	if a.DocumentationPathID == "" {
 		return nil
@@ -55,6 +72,9 @@ type QueryResolver interface {
 	Definitions(ctx context.Context, line, character int) ([]AdjustedLocation, error)
 	References(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error)
 	Implementations(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error)
+	TypeDefinitions(ctx context.Context, line, character int) ([]AdjustedLocation, error)
+	IncomingCalls(ctx context.Context, line, character int) ([]AdjustedCallHierarchyCall, error)
+	OutgoingCalls(ctx context.Context, line, character int) ([]AdjustedCallHierarchyCall, error)
 	Hover(ctx context.Context, line, character int) (string, lsifstore.Range, bool, error)
 	Diagnostics(ctx context.Context, limit int) ([]AdjustedDiagnostic, int, error)
 	DocumentationPage(ctx context.Context, pathID string) (*precise.DocumentationPageData, error)
@@ -0,0 +1,222 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+const slowCallHierarchyRequestThreshold = time.Second
+
+// CallHierarchyReferencesLimit is the maximum number of references to the requested symbol that are
+// grouped into incoming calls.
+const CallHierarchyReferencesLimit = 1000
+
+// ImportedCallSitesLimit is the maximum number of call sites for which the callee is resolved via a
+// moniker search in a single OutgoingCalls request.
+const ImportedCallSitesLimit = 100
+
+// IncomingCalls returns the calls to the callable symbol at the given position, grouped by the
+// innermost callable symbol enclosing each call. Calls from other repositories are found by the
+// same moniker search used to resolve references.
+func (r *queryResolver) IncomingCalls(ctx context.Context, line, character int) (_ []AdjustedCallHierarchyCall, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, "IncomingCalls", r.operations.incomingCalls, slowCallHierarchyRequestThreshold, observation.Args{
+		LogFields: []log.Field{
+			log.Int("repositoryID", r.repositoryID),
+			log.String("commit", r.commit),
+			log.String("path", r.path),
+			log.Int("numUploads", len(r.uploads)),
+			log.String("uploads", uploadIDsToString(r.uploads)),
+			log.Int("line", line),
+			log.Int("character", character),
+		},
+	})
+	defer endObservation()
+
+	locations, _, err := r.referenceLocations(ctx, line, character, CallHierarchyReferencesLimit, "", trace)
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numReferences", len(locations)))
+
+	// Group the references by upload so that the documents of each upload are read once
+	var uploadIDs []int
+	locationsByUploadID := map[int][]lsifstore.Location{}
+	for _, location := range locations {
+		if _, ok := locationsByUploadID[location.DumpID]; !ok {
+			uploadIDs = append(uploadIDs, location.DumpID)
+		}
+		locationsByUploadID[location.DumpID] = append(locationsByUploadID[location.DumpID], location)
+	}
+
+	var calls []lsifstore.CallHierarchyCall
+	for _, uploadID := range uploadIDs {
+		uploadCalls, err := r.lsifStore.IncomingCalls(ctx, uploadID, locationsByUploadID[uploadID])
+		if err != nil {
+			return nil, errors.Wrap(err, "lsifStore.IncomingCalls")
+		}
+		calls = append(calls, uploadCalls...)
+	}
+	trace.Log(log.Int("numCalls", len(calls)))
+
+	return r.adjustCalls(ctx, calls)
+}
+
+// OutgoingCalls returns the calls made from the body of the callable symbol at the given position,
+// grouped by callee. The position may be the definition of the symbol or any reference to it. Calls
+// to symbols defined in other repositories are resolved by a moniker search.
+func (r *queryResolver) OutgoingCalls(ctx context.Context, line, character int) (_ []AdjustedCallHierarchyCall, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, "OutgoingCalls", r.operations.outgoingCalls, slowCallHierarchyRequestThreshold, observation.Args{
+		LogFields: []log.Field{
+			log.Int("repositoryID", r.repositoryID),
+			log.String("commit", r.commit),
+			log.String("path", r.path),
+			log.Int("numUploads", len(r.uploads)),
+			log.String("uploads", uploadIDsToString(r.uploads)),
+			log.Int("line", line),
+			log.Int("character", character),
+		},
+	})
+	defer endObservation()
+
+	definitions, err := r.definitionLocations(ctx, line, character, trace)
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numDefinitions", len(definitions)))
+
+	var calls []lsifstore.CallHierarchyCall
+	var unresolvedCallSites []lsifstore.Location
+	for _, definition := range definitions {
+		definitionCalls, unresolved, err := r.lsifStore.OutgoingCalls(
+			ctx,
+			definition.DumpID,
+			definition.Path,
+			definition.Range.Start.Line,
+			definition.Range.Start.Character,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "lsifStore.OutgoingCalls")
+		}
+
+		calls = append(calls, definitionCalls...)
+		unresolvedCallSites = append(unresolvedCallSites, unresolved...)
+	}
+	trace.Log(
+		log.Int("numCalls", len(calls)),
+		log.Int("numUnresolvedCallSites", len(unresolvedCallSites)),
+	)
+
+	// Resolve the callees that are not defined in the same index as the caller via the import
+	// monikers attached to the call site.
+
+	if len(unresolvedCallSites) > ImportedCallSitesLimit {
+		unresolvedCallSites = unresolvedCallSites[:ImportedCallSitesLimit]
+	}
+
+	for _, callSite := range unresolvedCallSites {
+		callees, err := r.importedCallees(ctx, callSite, trace)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, callee := range callees {
+			calls = append(calls, lsifstore.CallHierarchyCall{
+				Item:      callee,
+				Locations: []lsifstore.Location{callSite},
+			})
+		}
+	}
+
+	return r.adjustCalls(ctx, mergeCalls(calls))
+}
+
+// importedCallees returns the callable symbols called at the given call site, which are defined in
+// the indexes providing an import moniker attached to the call site.
+func (r *queryResolver) importedCallees(ctx context.Context, callSite lsifstore.Location, trace observation.TraceLogger) ([]lsifstore.CallHierarchyItem, error) {
+	definitions, err := r.monikerDefinitionLocations(ctx, []adjustedUpload{r.adjustedUploadFromLocation(callSite)}, trace)
+	if err != nil {
+		return nil, err
+	}
+
+	var callees []lsifstore.CallHierarchyItem
+	for _, definition := range definitions {
+		items, err := r.lsifStore.CallHierarchyItems(
+			ctx,
+			definition.DumpID,
+			definition.Path,
+			definition.Range.Start.Line,
+			definition.Range.Start.Character,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "lsifStore.CallHierarchyItems")
+		}
+
+		callees = append(callees, items...)
+	}
+
+	return callees, nil
+}
+
+// mergeCalls merges the locations of calls with the same item. The order of the first occurrence
+// of each item is preserved.
+func mergeCalls(calls []lsifstore.CallHierarchyCall) []lsifstore.CallHierarchyCall {
+	merged := make([]lsifstore.CallHierarchyCall, 0, len(calls))
+	indexes := make(map[lsifstore.Location]int, len(calls))
+
+	for _, call := range calls {
+		if index, ok := indexes[call.Item.Location]; ok {
+			merged[index].Locations = append(merged[index].Locations, call.Locations...)
+			continue
+		}
+
+		indexes[call.Item.Location] = len(merged)
+		merged = append(merged, lsifstore.CallHierarchyCall{
+			Item:      call.Item,
+			Locations: append([]lsifstore.Location(nil), call.Locations...),
+		})
+	}
+
+	return merged
+}
+
+// adjustCalls translates the items and locations of the given calls into equivalent items and
+// locations in the requested commit.
+func (r *queryResolver) adjustCalls(ctx context.Context, calls []lsifstore.CallHierarchyCall) ([]AdjustedCallHierarchyCall, error) {
+	adjustedCalls := make([]AdjustedCallHierarchyCall, 0, len(calls))
+	for _, call := range calls {
+		dump := r.uploadCache[call.Item.DumpID]
+
+		adjustedLocation, err := r.adjustLocation(ctx, dump, call.Item.Location)
+		if err != nil {
+			return nil, err
+		}
+
+		_, adjustedFullRange, _, err := r.adjustRange(ctx, dump.RepositoryID, dump.Commit, dump.Root+call.Item.Path, call.Item.FullRange)
+		if err != nil {
+			return nil, err
+		}
+
+		adjustedLocations, err := r.adjustLocations(ctx, call.Locations)
+		if err != nil {
+			return nil, err
+		}
+
+		adjustedCalls = append(adjustedCalls, AdjustedCallHierarchyCall{
+			Item: AdjustedCallHierarchyItem{
+				Name:      call.Item.Name,
+				Kind:      call.Item.Kind,
+				Location:  adjustedLocation,
+				FullRange: adjustedFullRange,
+			},
+			Locations: adjustedLocations,
+		})
+	}
+
+	return adjustedCalls, nil
+}
@@ -0,0 +1,227 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestIncomingCalls(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockPositionAdjuster := noopPositionAdjuster()
+
+	// Empty result set (prevents nil pointer as scanner is always non-nil)
+	mockDBStore.ReferenceIDsAndFiltersFunc.PushReturn(dbstore.PackageReferenceScannerFromSlice(), 0, nil)
+
+	references := []lsifstore.Location{
+		{DumpID: 50, Path: "a.go", Range: testRange1},
+		{DumpID: 51, Path: "b.go", Range: testRange2},
+		{DumpID: 51, Path: "c.go", Range: testRange3},
+	}
+	mockLSIFStore.ReferencesFunc.PushReturn(references[:1], 1, nil)
+	mockLSIFStore.ReferencesFunc.PushReturn(references[1:], 2, nil)
+
+	caller1 := lsifstore.CallHierarchyItem{
+		Location:  lsifstore.Location{DumpID: 50, Path: "a.go", Range: testRange4},
+		Name:      "caller1",
+		Kind:      protocol.Function,
+		FullRange: testRange4,
+	}
+	caller2 := lsifstore.CallHierarchyItem{
+		Location:  lsifstore.Location{DumpID: 51, Path: "b.go", Range: testRange5},
+		Name:      "caller2",
+		Kind:      protocol.Method,
+		FullRange: testRange5,
+	}
+	mockLSIFStore.IncomingCallsFunc.PushReturn([]lsifstore.CallHierarchyCall{{Item: caller1, Locations: references[:1]}}, nil)
+	mockLSIFStore.IncomingCallsFunc.PushReturn([]lsifstore.CallHierarchyCall{{Item: caller2, Locations: references[1:]}}, nil)
+
+	uploads := []dbstore.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+	}
+	resolver := newQueryResolver(
+		mockDBStore,
+		mockLSIFStore,
+		newCachedCommitChecker(mockGitserverClient),
+		mockPositionAdjuster,
+		42,
+		"deadbeef",
+		"s1/main.go",
+		uploads,
+		newOperations(&observation.TestContext),
+	)
+	calls, err := resolver.IncomingCalls(context.Background(), 10, 20)
+	if err != nil {
+		t.Fatalf("unexpected error querying incoming calls: %s", err)
+	}
+
+	expectedCalls := []AdjustedCallHierarchyCall{
+		{
+			Item: AdjustedCallHierarchyItem{
+				Name:      "caller1",
+				Kind:      protocol.Function,
+				Location:  AdjustedLocation{Dump: uploads[0], Path: "sub1/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange4},
+				FullRange: testRange4,
+			},
+			Locations: []AdjustedLocation{
+				{Dump: uploads[0], Path: "sub1/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1},
+			},
+		},
+		{
+			Item: AdjustedCallHierarchyItem{
+				Name:      "caller2",
+				Kind:      protocol.Method,
+				Location:  AdjustedLocation{Dump: uploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange5},
+				FullRange: testRange5,
+			},
+			Locations: []AdjustedLocation{
+				{Dump: uploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange2},
+				{Dump: uploads[1], Path: "sub2/c.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange3},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedCalls, calls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+
+	if history := mockLSIFStore.IncomingCallsFunc.History(); len(history) != 2 {
+		t.Fatalf("unexpected call count for lsifstore.IncomingCalls. want=%d have=%d", 2, len(history))
+	} else {
+		if diff := cmp.Diff(references[:1], history[0].Arg2); diff != "" {
+			t.Errorf("unexpected references for upload 50 (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(references[1:], history[1].Arg2); diff != "" {
+			t.Errorf("unexpected references for upload 51 (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestOutgoingCalls(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockPositionAdjuster := noopPositionAdjuster()
+
+	remoteUploads := []dbstore.Dump{
+		{ID: 151, Commit: "deadbeef2", Root: "sub2/"},
+	}
+	mockDBStore.DefinitionDumpsFunc.PushReturn(remoteUploads, nil)
+	mockGitserverClient.CommitExistsFunc.SetDefaultReturn(true, nil)
+
+	definition := lsifstore.Location{DumpID: 50, Path: "a.go", Range: testRange1}
+	mockLSIFStore.DefinitionsFunc.PushReturn([]lsifstore.Location{definition}, 1, nil)
+
+	helper := lsifstore.CallHierarchyItem{
+		Location:  lsifstore.Location{DumpID: 50, Path: "b.go", Range: testRange2},
+		Name:      "helper",
+		Kind:      protocol.Function,
+		FullRange: testRange2,
+	}
+	localCallSite := lsifstore.Location{DumpID: 50, Path: "a.go", Range: testRange3}
+	importedCallSite := lsifstore.Location{DumpID: 50, Path: "a.go", Range: testRange4}
+	mockLSIFStore.OutgoingCallsFunc.PushReturn(
+		[]lsifstore.CallHierarchyCall{{Item: helper, Locations: []lsifstore.Location{localCallSite}}},
+		[]lsifstore.Location{importedCallSite},
+		nil,
+	)
+
+	moniker := precise.MonikerData{Kind: "import", Scheme: "gomod", Identifier: "leftpad:Pad", PackageInformationID: "51"}
+	mockLSIFStore.MonikersByPositionFunc.PushReturn([][]precise.MonikerData{{moniker}}, nil)
+	mockLSIFStore.PackageInformationFunc.PushReturn(precise.PackageInformationData{Name: "leftpad", Version: "0.1.0"}, true, nil)
+
+	remoteDefinition := lsifstore.Location{DumpID: 151, Path: "pad.go", Range: testRange5}
+	mockLSIFStore.BulkMonikerResultsFunc.PushReturn([]lsifstore.Location{remoteDefinition}, 1, nil)
+
+	pad := lsifstore.CallHierarchyItem{
+		Location:  remoteDefinition,
+		Name:      "Pad",
+		Kind:      protocol.Function,
+		FullRange: testRange5,
+	}
+	mockLSIFStore.CallHierarchyItemsFunc.PushReturn([]lsifstore.CallHierarchyItem{pad}, nil)
+
+	uploads := []dbstore.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+	}
+	resolver := newQueryResolver(
+		mockDBStore,
+		mockLSIFStore,
+		newCachedCommitChecker(mockGitserverClient),
+		mockPositionAdjuster,
+		42,
+		"deadbeef",
+		"s1/main.go",
+		uploads,
+		newOperations(&observation.TestContext),
+	)
+	calls, err := resolver.OutgoingCalls(context.Background(), 10, 20)
+	if err != nil {
+		t.Fatalf("unexpected error querying outgoing calls: %s", err)
+	}
+
+	expectedCalls := []AdjustedCallHierarchyCall{
+		{
+			Item: AdjustedCallHierarchyItem{
+				Name:      "helper",
+				Kind:      protocol.Function,
+				Location:  AdjustedLocation{Dump: uploads[0], Path: "sub1/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange2},
+				FullRange: testRange2,
+			},
+			Locations: []AdjustedLocation{
+				{Dump: uploads[0], Path: "sub1/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange3},
+			},
+		},
+		{
+			Item: AdjustedCallHierarchyItem{
+				Name:      "Pad",
+				Kind:      protocol.Function,
+				Location:  AdjustedLocation{Dump: remoteUploads[0], Path: "sub2/pad.go", AdjustedCommit: "deadbeef2", AdjustedRange: testRange5},
+				FullRange: testRange5,
+			},
+			Locations: []AdjustedLocation{
+				{Dump: uploads[0], Path: "sub1/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange4},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedCalls, calls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+
+	if history := mockLSIFStore.OutgoingCallsFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected call count for lsifstore.OutgoingCalls. want=%d have=%d", 1, len(history))
+	} else if history[0].Arg1 != 50 || history[0].Arg2 != "a.go" || history[0].Arg3 != testRange1.Start.Line || history[0].Arg4 != testRange1.Start.Character {
+		t.Errorf("unexpected definition position: %d %s:%d:%d", history[0].Arg1, history[0].Arg2, history[0].Arg3, history[0].Arg4)
+	}
+}
+
+func TestMergeCalls(t *testing.T) {
+	item1 := lsifstore.CallHierarchyItem{Location: lsifstore.Location{DumpID: 50, Path: "a.go", Range: testRange1}, Name: "f"}
+	item2 := lsifstore.CallHierarchyItem{Location: lsifstore.Location{DumpID: 50, Path: "b.go", Range: testRange2}, Name: "g"}
+	location1 := lsifstore.Location{DumpID: 50, Path: "c.go", Range: testRange3}
+	location2 := lsifstore.Location{DumpID: 50, Path: "c.go", Range: testRange4}
+	location3 := lsifstore.Location{DumpID: 50, Path: "c.go", Range: testRange5}
+
+	calls := mergeCalls([]lsifstore.CallHierarchyCall{
+		{Item: item1, Locations: []lsifstore.Location{location1}},
+		{Item: item2, Locations: []lsifstore.Location{location2}},
+		{Item: item1, Locations: []lsifstore.Location{location3}},
+	})
+
+	expected := []lsifstore.CallHierarchyCall{
+		{Item: item1, Locations: []lsifstore.Location{location1, location3}},
+		{Item: item2, Locations: []lsifstore.Location{location2}},
+	}
+	if diff := cmp.Diff(expected, calls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/opentracing/opentracing-go/log"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
 
@@ -30,6 +31,28 @@ func (r *queryResolver) Definitions(ctx context.Context, line, character int) (_
 	})
 	defer endObservation()
 
+	locations, err := r.definitionLocations(ctx, line, character, trace)
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numLocations", len(locations)))
+
+	// Adjust the locations back to the appropriate range in the target commits. This adjusts
+	// locations within the repository the user is browsing so that it appears all definitions
+	// are occurring at the same commit they are looking at.
+
+	adjustedLocations, err := r.adjustLocations(ctx, locations)
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numAdjustedLocations", len(adjustedLocations)))
+
+	return adjustedLocations, nil
+}
+
+// definitionLocations returns the unadjusted locations that define the symbol at the given position.
+// Local definitions are preferred over definitions found by a moniker search in other indexes.
+func (r *queryResolver) definitionLocations(ctx context.Context, line, character int, trace observation.TraceLogger) ([]lsifstore.Location, error) {
 	// Adjust the path and position for each visible upload based on its git difference to
 	// the target commit.
 
@@ -59,10 +82,16 @@ func (r *queryResolver) Definitions(ctx context.Context, line, character int) (_
 		}
 		if len(locations) > 0 {
 			// If we have a local definition, we won't find a better one and can exit early
-			return r.adjustLocations(ctx, locations)
+			return locations, nil
 		}
 	}
 
+	return r.monikerDefinitionLocations(ctx, adjustedUploads, trace)
+}
+
+// monikerDefinitionLocations returns the unadjusted locations that define the symbol at the given
+// adjusted uploads found by a moniker search over the indexes providing an attached import moniker.
+func (r *queryResolver) monikerDefinitionLocations(ctx context.Context, adjustedUploads []adjustedUpload, trace observation.TraceLogger) ([]lsifstore.Location, error) {
 	// Gather all import monikers attached to the ranges enclosing the requested position
 	orderedMonikers, err := r.orderedMonikers(ctx, adjustedUploads, "import")
 	if err != nil {
@@ -72,6 +101,9 @@ func (r *queryResolver) Definitions(ctx context.Context, line, character int) (_
 		log.Int("numMonikers", len(orderedMonikers)),
 		log.String("monikers", monikersToString(orderedMonikers)),
 	)
+	if len(orderedMonikers) == 0 {
+		return nil, nil
+	}
 
 	// Determine the set of uploads over which we need to perform a moniker search. This will
 	// include all all indexes which define one of the ordered monikers. This should not include
@@ -90,17 +122,6 @@ func (r *queryResolver) Definitions(ctx context.Context, line, character int) (_
 	if err != nil {
 		return nil, err
 	}
-	trace.Log(log.Int("numLocations", len(locations)))
-
-	// Adjust the locations back to the appropriate range in the target commits. This adjusts
-	// locations within the repository the user is browsing so that it appears all definitions
-	// are occurring at the same commit they are looking at.
-
-	adjustedLocations, err := r.adjustLocations(ctx, locations)
-	if err != nil {
-		return nil, err
-	}
-	trace.Log(log.Int("numAdjustedLocations", len(adjustedLocations)))
 
-	return adjustedLocations, nil
+	return locations, nil
 }
@@ -34,6 +34,30 @@ func (r *queryResolver) References(ctx context.Context, line, character, limit i
 	})
 	defer endObservation()
 
+	locations, nextCursor, err := r.referenceLocations(ctx, line, character, limit, rawCursor, trace)
+	if err != nil {
+		return nil, "", err
+	}
+	trace.Log(log.Int("numLocations", len(locations)))
+
+	// Adjust the locations back to the appropriate range in the target commits. This adjusts
+	// locations within the repository the user is browsing so that it appears all references
+	// are occurring at the same commit they are looking at.
+
+	adjustedLocations, err := r.adjustLocations(ctx, locations)
+	if err != nil {
+		return nil, "", err
+	}
+	trace.Log(log.Int("numAdjustedLocations", len(adjustedLocations)))
+
+	return adjustedLocations, nextCursor, nil
+}
+
+// referenceLocations returns a page of the unadjusted locations that reference the symbol at the
+// given position, as well as the cursor of the next page. The cursor is empty if there are no more
+// locations. Local references are returned before references found by a moniker search in other
+// indexes.
+func (r *queryResolver) referenceLocations(ctx context.Context, line, character, limit int, rawCursor string, trace observation.TraceLogger) ([]lsifstore.Location, string, error) {
 	// Decode cursor given from previous response or create a new one with default values.
 	// We use the cursor state track offsets with the result set and cache initial data that
 	// is used to resolve each page. This cursor will be modified in-place to become the
@@ -131,24 +155,12 @@ func (r *queryResolver) References(ctx context.Context, line, character, limit i
 		}
 	}
 
-	trace.Log(log.Int("numLocations", len(locations)))
-
-	// Adjust the locations back to the appropriate range in the target commits. This adjusts
-	// locations within the repository the user is browsing so that it appears all references
-	// are occurring at the same commit they are looking at.
-
-	adjustedLocations, err := r.adjustLocations(ctx, locations)
-	if err != nil {
-		return nil, "", err
-	}
-	trace.Log(log.Int("numAdjustedLocations", len(adjustedLocations)))
-
 	nextCursor := ""
 	if cursor.Phase != "done" {
 		nextCursor = encodeReferencesCursor(cursor)
 	}
 
-	return adjustedLocations, nextCursor, nil
+	return locations, nextCursor, nil
 }
 
 // ErrConcurrentModification occurs when a page of a references request cannot be resolved as
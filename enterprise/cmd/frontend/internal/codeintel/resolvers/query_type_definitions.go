@@ -0,0 +1,98 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+const slowTypeDefinitionsRequestThreshold = time.Second
+
+// TypeDefinitionsLimit is maximum the number of locations returned from TypeDefinitions.
+const TypeDefinitionsLimit = 100
+
+// TypeDefinitions returns the list of source locations that define the type of the symbol at the given position.
+func (r *queryResolver) TypeDefinitions(ctx context.Context, line, character int) (_ []AdjustedLocation, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, "TypeDefinitions", r.operations.typeDefinitions, slowTypeDefinitionsRequestThreshold, observation.Args{
+		LogFields: []log.Field{
+			log.Int("repositoryID", r.repositoryID),
+			log.String("commit", r.commit),
+			log.String("path", r.path),
+			log.Int("numUploads", len(r.uploads)),
+			log.String("uploads", uploadIDsToString(r.uploads)),
+			log.Int("line", line),
+			log.Int("character", character),
+		},
+	})
+	defer endObservation()
+
+	// Adjust the path and position for each visible upload based on its git difference to
+	// the target commit.
+
+	adjustedUploads, err := r.adjustUploads(ctx, line, character)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gather the "local" type definition locations that are reachable via a typeDefinitionResult
+	// vertex. Unlike definitions, there is no moniker attached to the requested position that
+	// identifies the type of the symbol, so the type definition must be reachable within an index.
+
+	var locations []lsifstore.Location
+	for i := range adjustedUploads {
+		trace.Log(log.Int("uploadID", adjustedUploads[i].Upload.ID))
+
+		locations, _, err = r.lsifStore.TypeDefinitions(
+			ctx,
+			adjustedUploads[i].Upload.ID,
+			adjustedUploads[i].AdjustedPathInBundle,
+			adjustedUploads[i].AdjustedPosition.Line,
+			adjustedUploads[i].AdjustedPosition.Character,
+			TypeDefinitionsLimit,
+			0,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "lsifStore.TypeDefinitions")
+		}
+		if len(locations) > 0 {
+			break
+		}
+	}
+	trace.Log(log.Int("numLocations", len(locations)))
+
+	// A type declared in a dependency is referred to by a range with an attached import moniker
+	// within the index (e.g., the declaration of an imported type). Resolve these locations to
+	// the definition of the type in the index of the dependency.
+
+	resolvedLocations := make([]lsifstore.Location, 0, len(locations))
+	for _, location := range locations {
+		definitions, err := r.monikerDefinitionLocations(ctx, []adjustedUpload{r.adjustedUploadFromLocation(location)}, trace)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(definitions) > 0 {
+			resolvedLocations = append(resolvedLocations, definitions...)
+		} else {
+			resolvedLocations = append(resolvedLocations, location)
+		}
+	}
+	trace.Log(log.Int("numResolvedLocations", len(resolvedLocations)))
+
+	// Adjust the locations back to the appropriate range in the target commits. This adjusts
+	// locations within the repository the user is browsing so that it appears all definitions
+	// are occurring at the same commit they are looking at.
+
+	adjustedLocations, err := r.adjustLocations(ctx, resolvedLocations)
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numAdjustedLocations", len(adjustedLocations)))
+
+	return adjustedLocations, nil
+}
@@ -0,0 +1,124 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestTypeDefinitions(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockPositionAdjuster := noopPositionAdjuster()
+
+	locations := []lsifstore.Location{
+		{DumpID: 51, Path: "a.go", Range: testRange1},
+		{DumpID: 51, Path: "b.go", Range: testRange2},
+	}
+	mockLSIFStore.TypeDefinitionsFunc.PushReturn(nil, 0, nil)
+	mockLSIFStore.TypeDefinitionsFunc.PushReturn(locations, len(locations), nil)
+
+	uploads := []dbstore.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+		{ID: 52, Commit: "deadbeef", Root: "sub3/"},
+	}
+	resolver := newQueryResolver(
+		mockDBStore,
+		mockLSIFStore,
+		newCachedCommitChecker(mockGitserverClient),
+		mockPositionAdjuster,
+		42,
+		"deadbeef",
+		"s1/main.go",
+		uploads,
+		newOperations(&observation.TestContext),
+	)
+	adjustedLocations, err := resolver.TypeDefinitions(context.Background(), 10, 20)
+	if err != nil {
+		t.Fatalf("unexpected error querying type definitions: %s", err)
+	}
+
+	expectedLocations := []AdjustedLocation{
+		{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1},
+		{Dump: uploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange2},
+	}
+	if diff := cmp.Diff(expectedLocations, adjustedLocations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+
+	if history := mockLSIFStore.TypeDefinitionsFunc.History(); len(history) != 2 {
+		t.Errorf("unexpected call count for lsifstore.TypeDefinitions. want=%d have=%d", 2, len(history))
+	}
+	if history := mockDBStore.DefinitionDumpsFunc.History(); len(history) != 0 {
+		t.Errorf("unexpected call count for dbstore.DefinitionDumps. want=%d have=%d", 0, len(history))
+	}
+}
+
+func TestTypeDefinitionsRemote(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockPositionAdjuster := noopPositionAdjuster()
+
+	remoteUploads := []dbstore.Dump{
+		{ID: 151, Commit: "deadbeef2", Root: "sub2/"},
+	}
+	mockDBStore.DefinitionDumpsFunc.PushReturn(remoteUploads, nil)
+	mockGitserverClient.CommitExistsFunc.SetDefaultReturn(true, nil)
+
+	// The type is declared by an imported range in the local index
+	mockLSIFStore.TypeDefinitionsFunc.PushReturn([]lsifstore.Location{{DumpID: 50, Path: "a.go", Range: testRange1}}, 1, nil)
+
+	moniker := precise.MonikerData{Kind: "import", Scheme: "gomod", Identifier: "leftpad:Options", PackageInformationID: "51"}
+	mockLSIFStore.MonikersByPositionFunc.PushReturn([][]precise.MonikerData{{moniker}}, nil)
+
+	packageInformation := precise.PackageInformationData{Name: "leftpad", Version: "0.1.0"}
+	mockLSIFStore.PackageInformationFunc.PushReturn(packageInformation, true, nil)
+
+	locations := []lsifstore.Location{
+		{DumpID: 151, Path: "options.go", Range: testRange2},
+	}
+	mockLSIFStore.BulkMonikerResultsFunc.PushReturn(locations, len(locations), nil)
+
+	uploads := []dbstore.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+	}
+	resolver := newQueryResolver(
+		mockDBStore,
+		mockLSIFStore,
+		newCachedCommitChecker(mockGitserverClient),
+		mockPositionAdjuster,
+		42,
+		"deadbeef",
+		"s1/main.go",
+		uploads,
+		newOperations(&observation.TestContext),
+	)
+	adjustedLocations, err := resolver.TypeDefinitions(context.Background(), 10, 20)
+	if err != nil {
+		t.Fatalf("unexpected error querying type definitions: %s", err)
+	}
+
+	expectedLocations := []AdjustedLocation{
+		{Dump: remoteUploads[0], Path: "sub2/options.go", AdjustedCommit: "deadbeef2", AdjustedRange: testRange2},
+	}
+	if diff := cmp.Diff(expectedLocations, adjustedLocations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+
+	if history := mockLSIFStore.MonikersByPositionFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected call count for lsifstore.MonikersByPosition. want=%d have=%d", 1, len(history))
+	} else {
+		if history[0].Arg1 != 50 || history[0].Arg2 != "a.go" || history[0].Arg3 != testRange1.Start.Line || history[0].Arg4 != testRange1.Start.Character {
+			t.Errorf("unexpected moniker position: %d %s:%d:%d", history[0].Arg1, history[0].Arg2, history[0].Arg3, history[0].Arg4)
+		}
+	}
+}
@@ -62,6 +62,19 @@ func (r *queryResolver) adjustUpload(ctx context.Context, line, character int, u
 	}, true, nil
 }
 
+// adjustedUploadFromLocation returns an adjusted upload targeting the start of the given location.
+// The location is relative to the indexed commit of its upload, so no adjustment is necessary.
+func (r *queryResolver) adjustedUploadFromLocation(location lsifstore.Location) adjustedUpload {
+	upload := r.uploadCache[location.DumpID]
+
+	return adjustedUpload{
+		Upload:               upload,
+		AdjustedPath:         upload.Root + location.Path,
+		AdjustedPosition:     location.Range.Start,
+		AdjustedPathInBundle: location.Path,
+	}
+}
+
 // definitionUploads returns the set of uploads that provide any of the given monikers. This method will
 // not return uploads for commits which are unknown to gitserver.
 func (r *queryResolver) definitionUploads(ctx context.Context, orderedMonikers []precise.QualifiedMonikerData) ([]store.Dump, error) {
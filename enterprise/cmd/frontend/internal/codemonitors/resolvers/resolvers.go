@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -144,7 +145,7 @@ func (r *Resolver) CreateCodeMonitor(ctx context.Context, args *graphqlbackend.C
 	}
 
 	// Create trigger.
-	_, err = tx.store.CreateQueryTrigger(ctx, m.ID, args.Trigger.Query)
+	_, err = tx.store.CreateQueryTrigger(ctx, m.ID, args.Trigger.Query, triggerMode(args.Trigger))
 	if err != nil {
 		return nil, err
 	}
@@ -486,7 +487,7 @@ func (r *Resolver) updateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 	}
 
 	// Update trigger.
-	err = r.store.UpdateQueryTrigger(ctx, triggerID, args.Trigger.Update.Query)
+	err = r.store.UpdateQueryTrigger(ctx, triggerID, args.Trigger.Update.Query, triggerMode(args.Trigger.Update))
 	if err != nil {
 		return nil, err
 	}
@@ -681,6 +682,15 @@ func unmarshalAfter(after *string) (*int, error) {
 	return &a, err
 }
 
+// triggerMode returns the mode of the given trigger, which defaults to
+// COMMITS.
+func triggerMode(args *graphqlbackend.CreateTriggerArgs) edb.QueryTriggerMode {
+	if args.Mode == nil {
+		return edb.QueryTriggerModeCommits
+	}
+	return edb.QueryTriggerMode(strings.ToLower(*args.Mode))
+}
+
 //
 // Monitor
 //
@@ -817,6 +827,10 @@ func (q *monitorQuery) Query() string {
 	return q.QueryString
 }
 
+func (q *monitorQuery) Mode() string {
+	return strings.ToUpper(string(q.QueryTrigger.Mode))
+}
+
 func (q *monitorQuery) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorTriggerEventConnectionResolver, error) {
 	after, err := unmarshalAfter(args.After)
 	if err != nil {
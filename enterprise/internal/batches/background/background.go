@@ -39,5 +39,14 @@ func Routines(ctx context.Context, batchesStore *store.Store, cf *httpcli.Factor
 
 		newBatchSpecWorkspaceExecutionWorkerResetter(batchSpecWorkspaceExecutionWorkerStore, metrics),
 	}
+
+	if changesetRebaseInterval > 0 {
+		routines = append(routines, newChangesetRebaserJob(ctx, batchesStore, gitserver.DefaultClient, sourcer))
+	}
+
+	if changesetAutoMergeInterval > 0 {
+		routines = append(routines, newChangesetAutoMergerJob(ctx, batchesStore))
+	}
+
 	return routines
 }
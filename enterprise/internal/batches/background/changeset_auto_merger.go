@@ -0,0 +1,230 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/window"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+var changesetAutoMergeInterval = env.MustGetDuration(
+	"SRC_BATCH_CHANGES_AUTO_MERGE_INTERVAL",
+	1*time.Minute,
+	"Interval at which the auto-merge policies of batch changes are evaluated. Set to 0 to disable automatic merging.",
+)
+
+const changesetAutoMergeBatchSize = 100
+
+// newChangesetAutoMergerJob creates a job that periodically evaluates the
+// auto-merge policies of open batch changes and enqueues merge jobs for the
+// changesets that satisfy them. The merges themselves are done by the bulk
+// operation worker.
+func newChangesetAutoMergerJob(ctx context.Context, s *store.Store) goroutine.BackgroundRoutine {
+	m := &changesetAutoMerger{store: s}
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		changesetAutoMergeInterval,
+		goroutine.NewHandlerWithErrorMessage("auto-merging changesets", m.autoMerge),
+	)
+}
+
+type changesetAutoMerger struct {
+	store *store.Store
+}
+
+func (m *changesetAutoMerger) autoMerge(ctx context.Context) error {
+	var errs *multierror.Error
+
+	opts := store.ListBatchChangesOpts{
+		State:     btypes.BatchChangeStateOpen,
+		LimitOpts: store.LimitOpts{Limit: changesetAutoMergeBatchSize},
+	}
+	for {
+		batchChanges, next, err := m.store.ListBatchChanges(ctx, opts)
+		if err != nil {
+			return errors.Wrap(err, "ListBatchChanges")
+		}
+
+		for _, batchChange := range batchChanges {
+			if err := m.autoMergeBatchChange(ctx, batchChange); err != nil {
+				log15.Error("Auto-merging changesets", "batchChange", batchChange.ID, "err", err)
+				errs = multierror.Append(errs, errors.Wrapf(err, "auto-merging changesets of batch change %d", batchChange.ID))
+			}
+		}
+
+		if next == 0 {
+			break
+		}
+		opts.Cursor = next
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// autoMergeBatchChange enqueues merge jobs for the changesets of the given
+// batch change that satisfy its auto-merge policy, if it has one, as long as
+// one of its rollout windows is open and it hasn't reached its hourly limit.
+func (m *changesetAutoMerger) autoMergeBatchChange(ctx context.Context, batchChange *btypes.BatchChange) error {
+	if batchChange.IsDraft() {
+		return nil
+	}
+
+	spec, err := m.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+	if err != nil {
+		return errors.Wrap(err, "loading batch spec")
+	}
+	if spec.Spec == nil || spec.Spec.AutoMerge == nil {
+		return nil
+	}
+	policy := spec.Spec.AutoMerge
+
+	now := m.store.Clock()()
+	windows, err := autoMergeWindows(policy)
+	if err != nil {
+		return errors.Wrap(err, "parsing rollout windows")
+	}
+	if !windows.IsOpen(now) {
+		return nil
+	}
+
+	// remaining is the number of merges that can still be enqueued in this
+	// run, or -1 if there's no limit.
+	remaining := -1
+	if policy.MaxMergesPerHour > 0 {
+		merged, err := m.store.CountChangesetJobs(ctx, store.CountChangesetJobsOpts{
+			BatchChangeID: batchChange.ID,
+			JobType:       btypes.ChangesetJobTypeMerge,
+			OnlyAutoMerge: true,
+			CreatedAfter:  now.Add(-1 * time.Hour),
+			States: []btypes.ChangesetJobState{
+				btypes.ChangesetJobStateQueued,
+				btypes.ChangesetJobStateProcessing,
+				btypes.ChangesetJobStateErrored,
+				btypes.ChangesetJobStateCompleted,
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, "counting recent merges")
+		}
+		if merged >= policy.MaxMergesPerHour {
+			return nil
+		}
+		remaining = policy.MaxMergesPerHour - merged
+	}
+
+	published := btypes.ChangesetPublicationStatePublished
+	opts := store.ListChangesetsOpts{
+		LimitOpts:            store.LimitOpts{Limit: changesetAutoMergeBatchSize},
+		OwnedByBatchChangeID: batchChange.ID,
+		PublicationState:     &published,
+		ReconcilerStates:     []btypes.ReconcilerState{btypes.ReconcilerStateCompleted},
+		ExternalStates:       []btypes.ChangesetExternalState{btypes.ChangesetExternalStateOpen},
+	}
+	if policy.RequiresPassedChecks() {
+		passed := btypes.ChangesetCheckStatePassed
+		opts.ExternalCheckState = &passed
+	}
+	if policy.RequiresApproval() {
+		approved := btypes.ChangesetReviewStateApproved
+		opts.ExternalReviewState = &approved
+	}
+
+	for remaining != 0 {
+		cs, next, err := m.store.ListChangesets(ctx, opts)
+		if err != nil {
+			return errors.Wrap(err, "listing changesets")
+		}
+
+		for _, c := range cs {
+			if remaining == 0 {
+				break
+			}
+
+			enqueued, err := m.maybeEnqueueMerge(ctx, batchChange, policy, c)
+			if err != nil {
+				return errors.Wrapf(err, "enqueueing merge of changeset %d", c.ID)
+			}
+			if enqueued && remaining > 0 {
+				remaining--
+			}
+		}
+
+		if next == 0 {
+			break
+		}
+		opts.Cursor = next
+	}
+
+	return nil
+}
+
+// maybeEnqueueMerge enqueues a merge job for the given changeset, unless its
+// code host reports that it conflicts with its base branch or a merge job for
+// it is already pending. The job is run on behalf of the user who last applied
+// the batch change, since they are the one who opted into the policy.
+func (m *changesetAutoMerger) maybeEnqueueMerge(ctx context.Context, batchChange *btypes.BatchChange, policy *batcheslib.AutoMerge, c *btypes.Changeset) (bool, error) {
+	if c.Mergeability() == btypes.ChangesetMergeabilityConflicting {
+		return false, nil
+	}
+
+	pending, err := m.store.CountChangesetJobs(ctx, store.CountChangesetJobsOpts{
+		ChangesetID: c.ID,
+		JobType:     btypes.ChangesetJobTypeMerge,
+		States: []btypes.ChangesetJobState{
+			btypes.ChangesetJobStateQueued,
+			btypes.ChangesetJobStateProcessing,
+			btypes.ChangesetJobStateErrored,
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	if pending > 0 {
+		return false, nil
+	}
+
+	bulkGroupID, err := store.RandomID()
+	if err != nil {
+		return false, errors.Wrap(err, "creating bulkGroupID failed")
+	}
+
+	return true, m.store.CreateChangesetJob(ctx, &btypes.ChangesetJob{
+		BulkGroup:     bulkGroupID,
+		BatchChangeID: batchChange.ID,
+		ChangesetID:   c.ID,
+		UserID:        batchChange.LastApplierID,
+		JobType:       btypes.ChangesetJobTypeMerge,
+		Payload: &btypes.ChangesetJobMergePayload{
+			Squash:    policy.Squash(),
+			AutoMerge: true,
+		},
+		State: btypes.ChangesetJobStateQueued,
+	})
+}
+
+// autoMergeWindows converts the rollout windows of the given policy into a
+// window configuration. The rate limit of a policy applies across all of its
+// windows, so every window has an unlimited rate.
+func autoMergeWindows(policy *batcheslib.AutoMerge) (*window.Configuration, error) {
+	raw := make([]*schema.BatchChangeRolloutWindow, 0, len(policy.RolloutWindows))
+	for _, w := range policy.RolloutWindows {
+		raw = append(raw, &schema.BatchChangeRolloutWindow{
+			Days:  w.Days,
+			Start: w.Start,
+			End:   w.End,
+			Rate:  "unlimited",
+		})
+	}
+	return window.NewConfiguration(&raw)
+}
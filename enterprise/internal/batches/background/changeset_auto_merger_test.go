@@ -0,0 +1,180 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestChangesetAutoMerger_autoMergeBatchChange(t *testing.T) {
+	ctx := context.Background()
+	db := database.NewDB(dbtest.NewDB(t))
+
+	// now is a Wednesday.
+	now := time.Date(2022, 1, 5, 12, 0, 0, 0, time.UTC)
+	s := store.NewWithClock(db, &observation.TestContext, nil, func() time.Time { return now })
+	m := &changesetAutoMerger{store: s}
+
+	user := ct.CreateTestUser(t, db, true)
+	repos, _ := ct.CreateTestRepos(t, ctx, db, 1)
+
+	passed := btypes.ChangesetCheckStatePassed
+	pending := btypes.ChangesetCheckStatePending
+	approved := btypes.ChangesetReviewStateApproved
+	reviewPending := btypes.ChangesetReviewStatePending
+
+	type changeset struct {
+		checkState  btypes.ChangesetCheckState
+		reviewState btypes.ChangesetReviewState
+		// jobs are the states of the auto-merge jobs of the changeset which
+		// were created in the last hour, before the run.
+		jobs []btypes.ChangesetJobState
+
+		wantMerge bool
+	}
+
+	for i, tc := range []struct {
+		name       string
+		policy     *batcheslib.AutoMerge
+		changesets []changeset
+	}{
+		{
+			name:       "no policy",
+			changesets: []changeset{{checkState: passed, reviewState: approved}},
+		},
+		{
+			name: "rollout window closed",
+			policy: &batcheslib.AutoMerge{
+				RolloutWindows: []batcheslib.AutoMergeRolloutWindow{{Days: []string{"monday"}, Start: "10:00", End: "14:00"}},
+			},
+			changesets: []changeset{{checkState: passed, reviewState: approved}},
+		},
+		{
+			name: "rollout window open",
+			policy: &batcheslib.AutoMerge{
+				RolloutWindows: []batcheslib.AutoMergeRolloutWindow{{Days: []string{"wednesday"}, Start: "10:00", End: "14:00"}},
+			},
+			changesets: []changeset{{checkState: passed, reviewState: approved, wantMerge: true}},
+		},
+		{
+			name:   "passed checks and approval required by default",
+			policy: &batcheslib.AutoMerge{},
+			changesets: []changeset{
+				{checkState: passed, reviewState: approved, wantMerge: true},
+				{checkState: pending, reviewState: approved},
+				{checkState: passed, reviewState: reviewPending},
+			},
+		},
+		{
+			name: "any check and review state",
+			policy: &batcheslib.AutoMerge{
+				RequiredCheckState:  batcheslib.AutoMergeCheckStateAny,
+				RequiredReviewState: batcheslib.AutoMergeReviewStateAny,
+			},
+			changesets: []changeset{
+				{checkState: pending, reviewState: reviewPending, wantMerge: true},
+			},
+		},
+		{
+			name:   "pending jobs are not duplicated",
+			policy: &batcheslib.AutoMerge{},
+			changesets: []changeset{
+				{checkState: passed, reviewState: approved, jobs: []btypes.ChangesetJobState{btypes.ChangesetJobStateQueued}},
+				{checkState: passed, reviewState: approved, jobs: []btypes.ChangesetJobState{btypes.ChangesetJobStateProcessing}},
+				{checkState: passed, reviewState: approved, jobs: []btypes.ChangesetJobState{btypes.ChangesetJobStateErrored}},
+				{checkState: passed, reviewState: approved, jobs: []btypes.ChangesetJobState{btypes.ChangesetJobStateFailed}, wantMerge: true},
+			},
+		},
+		{
+			name:   "max merges per hour counts errored jobs",
+			policy: &batcheslib.AutoMerge{MaxMergesPerHour: 2},
+			changesets: []changeset{
+				{checkState: passed, reviewState: approved, jobs: []btypes.ChangesetJobState{btypes.ChangesetJobStateErrored}},
+				{checkState: passed, reviewState: approved, wantMerge: true},
+				{checkState: passed, reviewState: approved},
+			},
+		},
+		{
+			name:   "max merges per hour reached",
+			policy: &batcheslib.AutoMerge{MaxMergesPerHour: 1},
+			changesets: []changeset{
+				{checkState: passed, reviewState: approved, jobs: []btypes.ChangesetJobState{btypes.ChangesetJobStateCompleted}},
+				{checkState: passed, reviewState: approved},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := &btypes.BatchSpec{
+				UserID:          user.ID,
+				NamespaceUserID: user.ID,
+				Spec:            &batcheslib.BatchSpec{Name: "auto-merge", AutoMerge: tc.policy},
+			}
+			if err := s.CreateBatchSpec(ctx, spec); err != nil {
+				t.Fatal(err)
+			}
+			batchChange := ct.CreateBatchChange(t, ctx, s, fmt.Sprintf("auto-merge-%d", i), user.ID, spec.ID)
+
+			changesets := make([]*btypes.Changeset, 0, len(tc.changesets))
+			for j, c := range tc.changesets {
+				cs := ct.CreateChangeset(t, ctx, s, ct.TestChangesetOpts{
+					Repo:                repos[0].ID,
+					BatchChange:         batchChange.ID,
+					OwnedByBatchChange:  batchChange.ID,
+					ExternalServiceType: extsvc.TypeGitHub,
+					ExternalID:          fmt.Sprintf("%d-%d", i, j),
+					ExternalState:       btypes.ChangesetExternalStateOpen,
+					ExternalCheckState:  c.checkState,
+					ExternalReviewState: c.reviewState,
+					PublicationState:    btypes.ChangesetPublicationStatePublished,
+					ReconcilerState:     btypes.ReconcilerStateCompleted,
+				})
+				changesets = append(changesets, cs)
+
+				for _, state := range c.jobs {
+					if err := s.CreateChangesetJob(ctx, &btypes.ChangesetJob{
+						BulkGroup:     "previous",
+						BatchChangeID: batchChange.ID,
+						ChangesetID:   cs.ID,
+						UserID:        user.ID,
+						JobType:       btypes.ChangesetJobTypeMerge,
+						Payload:       &btypes.ChangesetJobMergePayload{AutoMerge: true},
+						State:         state,
+						CreatedAt:     now.Add(-30 * time.Minute),
+					}); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			if err := m.autoMergeBatchChange(ctx, batchChange); err != nil {
+				t.Fatal(err)
+			}
+
+			for j, c := range tc.changesets {
+				enqueued, err := s.CountChangesetJobs(ctx, store.CountChangesetJobsOpts{
+					ChangesetID:   changesets[j].ID,
+					JobType:       btypes.ChangesetJobTypeMerge,
+					States:        []btypes.ChangesetJobState{btypes.ChangesetJobStateQueued},
+					CreatedAfter:  now.Add(-1 * time.Minute),
+					OnlyAutoMerge: true,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if have := enqueued == 1; have != c.wantMerge || enqueued > 1 {
+					t.Errorf("changeset %d: unexpected number of enqueued merge jobs %d, want merge: %t", j, enqueued, c.wantMerge)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/reconciler"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+var changesetRebaseInterval = env.MustGetDuration(
+	"SRC_BATCH_CHANGES_REBASE_INTERVAL",
+	10*time.Minute,
+	"Interval at which open changesets that conflict with their base branch are rebased onto it. Set to 0 to disable rebasing.",
+)
+
+const changesetRebaseBatchSize = 100
+
+// newChangesetRebaserJob creates a job that periodically rebases the open
+// changesets that their code host reports as conflicting with their base
+// branch onto the current head of the base branch.
+func newChangesetRebaserJob(ctx context.Context, s *store.Store, gitClient reconciler.GitserverClient, sourcer sources.Sourcer) goroutine.BackgroundRoutine {
+	r := &changesetRebaser{
+		store:           s,
+		gitserverClient: gitClient,
+		sourcer:         sourcer,
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		changesetRebaseInterval,
+		goroutine.NewHandlerWithErrorMessage("rebasing conflicting changesets", r.rebaseConflicting),
+	)
+}
+
+type changesetRebaser struct {
+	store           *store.Store
+	gitserverClient reconciler.GitserverClient
+	sourcer         sources.Sourcer
+}
+
+func (r *changesetRebaser) rebaseConflicting(ctx context.Context) error {
+	var errs *multierror.Error
+
+	opts := store.ListChangesetsToRebaseOpts{LimitOpts: store.LimitOpts{Limit: changesetRebaseBatchSize}}
+	for {
+		cs, next, err := r.store.ListChangesetsToRebase(ctx, opts)
+		if err != nil {
+			return errors.Wrap(err, "ListChangesetsToRebase")
+		}
+
+		for _, c := range cs {
+			if err := r.maybeRebase(ctx, c); err != nil {
+				log15.Error("Rebasing changeset", "changeset", c.ID, "err", err)
+				errs = multierror.Append(errs, errors.Wrapf(err, "rebasing changeset %d", c.ID))
+			}
+		}
+
+		if next == 0 {
+			break
+		}
+		opts.Cursor = next
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// maybeRebase rebases the given changeset if its code host reports that it
+// conflicts with its base branch, and if it hasn't already been rebased onto
+// the current head of the base branch.
+func (r *changesetRebaser) maybeRebase(ctx context.Context, c *btypes.Changeset) error {
+	if c.Mergeability() != btypes.ChangesetMergeabilityConflicting {
+		return nil
+	}
+
+	baseRef, err := c.BaseRef()
+	if err != nil {
+		return err
+	}
+
+	repo, err := r.store.Repos().Get(ctx, c.RepoID)
+	if err != nil {
+		return errors.Wrap(err, "failed to load repository")
+	}
+
+	baseRev, err := git.ResolveRevision(ctx, repo.Name, baseRef, git.ResolveRevisionOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "resolving base ref %q", baseRef)
+	}
+
+	// Either the last rebase onto this revision was successful and the code
+	// host hasn't caught up yet, or it conflicted and would conflict again.
+	if c.RebaseBaseRev == string(baseRev) {
+		return nil
+	}
+
+	return reconciler.Rebase(ctx, r.gitserverClient, r.sourcer, r.store, c, baseRev)
+}
@@ -3,6 +3,7 @@ package processor
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/cockroachdb/errors"
 	"github.com/inconshreveable/log15"
@@ -165,6 +166,14 @@ func (b *bulkProcessor) mergeChangeset(ctx context.Context, job *btypes.Changese
 		Changeset: b.ch,
 		Repo:      b.repo,
 	}
+	previousState := cs.Changeset.ExternalState
+	auditEvent := &btypes.AutoMergedEvent{
+		BatchChangeID: job.BatchChangeID,
+		UserID:        job.UserID,
+		Squash:        typedPayload.Squash,
+		CheckState:    cs.Changeset.ExternalCheckState,
+		ReviewState:   cs.Changeset.ExternalReviewState,
+	}
 	if err := b.css.MergeChangeset(ctx, cs, typedPayload.Squash); err != nil {
 		return err
 	}
@@ -176,6 +185,19 @@ func (b *bulkProcessor) mergeChangeset(ctx context.Context, job *btypes.Changese
 	}
 	state.SetDerivedState(ctx, b.tx.Repos(), cs.Changeset, events)
 
+	// Merges done on behalf of the auto-merge policy of a batch change are
+	// recorded as an event of their own, so they can be told apart from
+	// merges done by users.
+	if typedPayload.AutoMerge {
+		auditEvent.MergedAt = b.tx.Clock()()
+		events = append(events, &btypes.ChangesetEvent{
+			ChangesetID: cs.Changeset.ID,
+			Kind:        btypes.ChangesetEventKindBatchesAutoMerged,
+			Key:         strconv.FormatInt(job.ID, 10),
+			Metadata:    auditEvent,
+		})
+	}
+
 	if err := b.tx.UpsertChangesetEvents(ctx, events...); err != nil {
 		log15.Error("UpsertChangesetEvents", "err", err)
 		return errcode.MakeNonRetryable(err)
@@ -186,6 +208,13 @@ func (b *bulkProcessor) mergeChangeset(ctx context.Context, job *btypes.Changese
 		return errcode.MakeNonRetryable(err)
 	}
 
+	if previousState != btypes.ChangesetExternalStateMerged && cs.Changeset.ExternalState == btypes.ChangesetExternalStateMerged {
+		if err := b.tx.EnqueueChangesetsDependingOn(ctx, cs.Changeset); err != nil {
+			log15.Error("EnqueueChangesetsDependingOn", "err", err)
+			return errcode.MakeNonRetryable(err)
+		}
+	}
+
 	return nil
 }
 
@@ -192,6 +192,45 @@ func TestBulkProcessor(t *testing.T) {
 		}
 	})
 
+	t.Run("Auto-merge job", func(t *testing.T) {
+		fake := &sources.FakeChangesetSource{}
+		bp := &bulkProcessor{
+			tx:      bstore,
+			sourcer: sources.NewFakeSourcer(nil, fake),
+		}
+		job := &types.ChangesetJob{
+			ID:            4321,
+			JobType:       types.ChangesetJobTypeMerge,
+			BatchChangeID: batchChange.ID,
+			ChangesetID:   changeset.ID,
+			UserID:        user.ID,
+			Payload:       &btypes.ChangesetJobMergePayload{Squash: true, AutoMerge: true},
+		}
+		err := bp.Process(ctx, job)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !fake.MergeChangesetCalled {
+			t.Fatal("expected MergeChangeset to be called but wasn't")
+		}
+
+		event, err := bstore.GetChangesetEvent(ctx, store.GetChangesetEventOpts{
+			ChangesetID: changeset.ID,
+			Kind:        btypes.ChangesetEventKindBatchesAutoMerged,
+			Key:         "4321",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		meta, ok := event.Metadata.(*btypes.AutoMergedEvent)
+		if !ok {
+			t.Fatalf("unexpected metadata type %T", event.Metadata)
+		}
+		if meta.BatchChangeID != batchChange.ID || meta.UserID != user.ID || !meta.Squash {
+			t.Fatalf("unexpected audit event metadata %+v", meta)
+		}
+	})
+
 	t.Run("Close job", func(t *testing.T) {
 		fake := &sources.FakeChangesetSource{FakeMetadata: &github.PullRequest{}}
 		bp := &bulkProcessor{
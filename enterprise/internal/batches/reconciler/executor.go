@@ -19,8 +19,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
 // executePlan executes the given reconciler plan.
@@ -115,6 +118,7 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 		log15.Error("Events", "err", err)
 		return errcode.MakeNonRetryable(err)
 	}
+	previousState := e.ch.ExternalState
 	state.SetDerivedState(ctx, e.tx.Repos(), e.ch, events)
 
 	if err := e.tx.UpsertChangesetEvents(ctx, events...); err != nil {
@@ -122,6 +126,12 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 		return err
 	}
 
+	if previousState != btypes.ChangesetExternalStateMerged && e.ch.ExternalState == btypes.ChangesetExternalStateMerged {
+		if err := e.tx.EnqueueChangesetsDependingOn(ctx, e.ch); err != nil {
+			return err
+		}
+	}
+
 	return e.tx.UpdateChangeset(ctx, e.ch)
 }
 
@@ -157,7 +167,14 @@ func (e *executor) pushChangesetPatch(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
-	return e.pushCommit(ctx, opts)
+	if err := e.pushCommit(ctx, opts); err != nil {
+		return err
+	}
+
+	// The pushed commit is based on the base revision of the spec, so any
+	// earlier rebase of the changeset is void now.
+	e.ch.ResetRebaseState()
+	return nil
 }
 
 // publishChangeset creates the given changeset on its code host.
@@ -496,6 +513,15 @@ func buildCommitOpts(repo *types.Repo, spec *btypes.ChangesetSpec, pushOpts *pro
 		Push:         pushOpts,
 	}
 
+	// Gerrit creates and updates changes for commits pushed to a magic ref
+	// instead of branches. The Change-Id trailer identifies the change, and
+	// the head ref of the changeset is recorded as its topic.
+	if repo.ExternalRepo.ServiceType == extsvc.TypeGerrit {
+		opts.CommitInfo.Message = gerrit.WithChangeID(commitMessage, sources.GerritChangeID(repo, desc.HeadRef))
+		pushRef := gerrit.PushRef(git.AbbreviateRef(desc.BaseRef), git.AbbreviateRef(desc.HeadRef))
+		opts.PushRef = &pushRef
+	}
+
 	return opts, nil
 }
 
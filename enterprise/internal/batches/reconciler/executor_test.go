@@ -15,11 +15,13 @@ import (
 	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	et "github.com/sourcegraph/sourcegraph/internal/encryption/testing"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	gitprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -27,6 +29,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/lib/batches"
 )
 
 func TestExecutor_ExecutePlan(t *testing.T) {
@@ -1133,6 +1136,36 @@ func TestNamespaceURL(t *testing.T) {
 	}
 }
 
+func TestBuildCommitOpts_Gerrit(t *testing.T) {
+	t.Parallel()
+
+	repo := &types.Repo{
+		Name:         "gerrit.example.com/platform/build",
+		ExternalRepo: api.ExternalRepoSpec{ServiceType: extsvc.TypeGerrit},
+	}
+	spec := &btypes.ChangesetSpec{Spec: &batches.ChangesetSpec{
+		BaseRef: "refs/heads/main",
+		HeadRef: "refs/heads/batch-branch",
+		Commits: []batches.GitCommitDescription{{Message: "Fix the build", Diff: "diff"}},
+	}}
+
+	opts, err := buildCommitOpts(repo, spec, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changeID := sources.GerritChangeID(repo, "refs/heads/batch-branch")
+	if want := "Fix the build\n\nChange-Id: " + changeID + "\n"; opts.CommitInfo.Message != want {
+		t.Errorf("unexpected commit message: have=%q want=%q", opts.CommitInfo.Message, want)
+	}
+	if opts.PushRef == nil || *opts.PushRef != "refs/for/main%topic=batch-branch" {
+		t.Errorf("unexpected push ref: %v", opts.PushRef)
+	}
+	if opts.TargetRef != "refs/heads/batch-branch" {
+		t.Errorf("unexpected target ref: %q", opts.TargetRef)
+	}
+}
+
 type mockInternalClient struct {
 	externalURL string
 	err         error
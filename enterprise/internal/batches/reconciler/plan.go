@@ -8,6 +8,7 @@ import (
 	"github.com/cockroachdb/errors"
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 )
 
 var operationPrecedence = map[btypes.ReconcilerOperation]int{
@@ -102,6 +103,53 @@ type Plan struct {
 func (p *Plan) AddOp(op btypes.ReconcilerOperation) { p.Ops = append(p.Ops, op) }
 func (p *Plan) SetOp(op btypes.ReconcilerOperation) { p.Ops = Operations{op} }
 
+// MakesReadyForReview returns true if the plan publishes or undrafts the
+// changeset.
+func (p *Plan) MakesReadyForReview() bool {
+	for _, op := range p.Ops {
+		switch op {
+		case btypes.ReconcilerOperationPublish, btypes.ReconcilerOperationUndraft:
+			return true
+		}
+	}
+	return false
+}
+
+// Hold keeps the changeset from becoming ready for review, because it depends
+// on changesets that have not been merged yet. A changeset that would be
+// published is published as a draft instead if the code host supports drafts,
+// and not published at all otherwise. Drafts are not undrafted.
+func (p *Plan) Hold() {
+	ops := make(Operations, 0, len(p.Ops))
+	unpublished := false
+	for _, op := range p.Ops {
+		switch op {
+		case btypes.ReconcilerOperationPublish:
+			if p.Changeset.SupportsDraft() {
+				ops = append(ops, btypes.ReconcilerOperationPublishDraft)
+			} else {
+				unpublished = true
+			}
+		case btypes.ReconcilerOperationUndraft:
+		default:
+			ops = append(ops, op)
+		}
+	}
+
+	if unpublished {
+		// Without publishing there's no need to push the branch yet.
+		filtered := ops[:0]
+		for _, op := range ops {
+			if op != btypes.ReconcilerOperationPush {
+				filtered = append(filtered, op)
+			}
+		}
+		ops = filtered
+	}
+
+	p.Ops = ops
+}
+
 // DeterminePlan looks at the given changeset to determine what action the
 // reconciler should take.
 // It consumes the current and the previous changeset spec, if they exist. If
@@ -209,7 +257,10 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, ch *btypes.C
 		}
 
 		if delta.AttributesChanged() {
-			if delta.NeedCommitUpdate() {
+			// Gerrit changes can't be moved to a new base branch through
+			// the API without conflicting with the pushed commit, so the
+			// commit is pushed to the new base branch instead.
+			if delta.NeedCommitUpdate() || (delta.BaseRefChanged && ch.ExternalServiceType == extsvc.TypeGerrit) {
 				pl.AddOp(btypes.ReconcilerOperationPush)
 			}
 
@@ -206,6 +206,28 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 			},
 			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
 		},
+		{
+			name:         "base ref changed on published changeset",
+			previousSpec: &ct.TestSpecOpts{Published: true, BaseRef: "refs/heads/main"},
+			currentSpec:  &ct.TestSpecOpts{Published: true, BaseRef: "refs/heads/develop"},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:         "base ref changed on published Gerrit changeset",
+			previousSpec: &ct.TestSpecOpts{Published: true, BaseRef: "refs/heads/main"},
+			currentSpec:  &ct.TestSpecOpts{Published: true, BaseRef: "refs/heads/develop"},
+			changeset: ct.TestChangesetOpts{
+				PublicationState:    btypes.ChangesetPublicationStatePublished,
+				ExternalServiceType: extsvc.TypeGerrit,
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationUpdate,
+			},
+		},
 		{
 			name:         "commit diff changed on published changeset",
 			previousSpec: &ct.TestSpecOpts{Published: true, CommitDiff: "testDiff"},
@@ -423,3 +445,49 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 func uiPublicationStatePtr(state btypes.ChangesetUiPublicationState) *btypes.ChangesetUiPublicationState {
 	return &state
 }
+
+func TestPlanHold(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name           string
+		changeset      ct.TestChangesetOpts
+		ops            Operations
+		wantOperations Operations
+	}{
+		{
+			name:           "publish as draft on code host supporting drafts",
+			changeset:      ct.TestChangesetOpts{ExternalServiceType: extsvc.TypeGitHub},
+			ops:            Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublish},
+			wantOperations: Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublishDraft},
+		},
+		{
+			name:           "don't publish on code host not supporting drafts",
+			changeset:      ct.TestChangesetOpts{ExternalServiceType: extsvc.TypeBitbucketServer},
+			ops:            Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublish},
+			wantOperations: Operations{},
+		},
+		{
+			name:           "keep draft",
+			changeset:      ct.TestChangesetOpts{ExternalServiceType: extsvc.TypeGitHub},
+			ops:            Operations{btypes.ReconcilerOperationUndraft, btypes.ReconcilerOperationUpdate},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name:           "publish draft",
+			changeset:      ct.TestChangesetOpts{ExternalServiceType: extsvc.TypeGitHub},
+			ops:            Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublishDraft},
+			wantOperations: Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublishDraft},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := &Plan{Changeset: ct.BuildChangeset(tc.changeset), Ops: tc.ops}
+			plan.Hold()
+			if have, want := plan.Ops, tc.wantOperations; !have.Equal(want) {
+				t.Fatalf("incorrect plan, want=%v have=%v", want, have)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package reconciler
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+// Rebase re-applies the diff of the current spec of the given changeset onto
+// baseRev, the new head of its base branch, and force-pushes the resulting
+// commit to the branch of the changeset. If the diff doesn't apply cleanly,
+// nothing is pushed and the changeset is marked as conflicted, along with the
+// hunks that failed to apply.
+//
+// In both cases the rebase state of the changeset is updated in the database.
+//
+// The changeset is locked for the duration of the rebase, so that the
+// reconciler can't push a new spec at the same time. If the changeset changed
+// since it was loaded, for example because the reconciler picked it up or it
+// got a new spec, it's not rebased.
+func Rebase(ctx context.Context, gitserverClient GitserverClient, sourcer sources.Sourcer, s *store.Store, ch *btypes.Changeset, baseRev api.CommitID) (err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	locked, err := tx.GetChangeset(ctx, store.GetChangesetOpts{ID: ch.ID, ForUpdate: true})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil
+		}
+		return errors.Wrap(err, "locking changeset")
+	}
+	if !canRebase(locked, ch, baseRev) {
+		return nil
+	}
+	ch = locked
+
+	repo, err := tx.Repos().Get(ctx, ch.RepoID)
+	if err != nil {
+		return errors.Wrap(err, "failed to load repository")
+	}
+
+	spec, err := tx.GetChangesetSpecByID(ctx, ch.CurrentSpecID)
+	if err != nil {
+		return errors.Wrap(err, "failed to load changeset spec")
+	}
+
+	css, err := loadChangesetSource(ctx, tx, sourcer, ch, repo)
+	if err != nil {
+		return err
+	}
+	pushConf, err := css.GitserverPushConfig(ctx, tx.ExternalServices(), repo)
+	if err != nil {
+		return err
+	}
+	opts, err := buildCommitOpts(repo, spec, pushConf)
+	if err != nil {
+		return err
+	}
+	opts.BaseCommit = baseRev
+
+	ch.RebaseBaseRev = string(baseRev)
+	if _, err := gitserverClient.CreateCommitFromPatch(ctx, opts); err != nil {
+		var e *protocol.CreateCommitFromPatchError
+		if !errors.As(err, &e) || !strings.HasPrefix(e.Command, "git apply") {
+			return errors.Wrap(err, "creating commit from patch")
+		}
+
+		ch.RebaseState = btypes.ChangesetRebaseStateConflicted
+		ch.RebaseConflicts, err = parseRebaseConflicts(opts.Patch, e.CombinedOutput)
+		if err != nil {
+			return err
+		}
+	} else {
+		ch.RebaseState = btypes.ChangesetRebaseStateRebased
+		ch.RebaseConflicts = nil
+	}
+
+	return tx.UpdateChangesetRebaseState(ctx, ch)
+}
+
+// canRebase reports whether the locked changeset can still be rebased onto
+// baseRev, given that the rebase was decided on the loaded changeset.
+func canRebase(locked, loaded *btypes.Changeset, baseRev api.CommitID) bool {
+	switch {
+	case locked.CurrentSpecID == 0 || locked.CurrentSpecID != loaded.CurrentSpecID:
+		// The spec changed, so the reconciler pushes a new commit anyway.
+		return false
+	case locked.ReconcilerState != btypes.ReconcilerStateCompleted:
+		// The reconciler is about to process the changeset, or failed to.
+		return false
+	case !locked.Published():
+		return false
+	case locked.ExternalState != btypes.ChangesetExternalStateOpen && locked.ExternalState != btypes.ChangesetExternalStateDraft:
+		return false
+	case locked.RebaseBaseRev == string(baseRev):
+		// Another rebase onto the same revision got there first.
+		return false
+	}
+	return true
+}
+
+var (
+	// hunkFailedRegexp matches the error `git apply` prints for a hunk that
+	// doesn't apply. The line is the start of the hunk in the original file.
+	hunkFailedRegexp = regexp.MustCompile(`^error: patch failed: (.+):(\d+)$`)
+	// fileFailedRegexp matches the errors `git apply` prints for a file that
+	// can't be patched.
+	fileFailedRegexp = regexp.MustCompile(`^error: (.+): (?:patch does not apply|does not exist in index|already exists in index)$`)
+)
+
+// parseRebaseConflicts returns the hunks of patch that `git apply` reported as
+// failing in output. Files that couldn't be patched at all are returned
+// without a hunk.
+func parseRebaseConflicts(patch, output string) ([]btypes.ChangesetRebaseConflict, error) {
+	fileDiffs, err := diff.ParseMultiFileDiff([]byte(patch))
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing changeset diff")
+	}
+
+	findHunk := func(path string, line int32) (string, error) {
+		for _, fd := range fileDiffs {
+			if fd.OrigName != path && fd.NewName != path {
+				continue
+			}
+			for _, h := range fd.Hunks {
+				if h.OrigStartLine == line {
+					hunk, err := diff.PrintHunks([]*diff.Hunk{h})
+					return string(hunk), err
+				}
+			}
+		}
+		return "", nil
+	}
+
+	var conflicts []btypes.ChangesetRebaseConflict
+	hunkFailed := map[string]bool{}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+
+		if m := hunkFailedRegexp.FindStringSubmatch(line); m != nil {
+			start, err := strconv.ParseInt(m[2], 10, 32)
+			if err != nil {
+				return nil, errors.Wrapf(err, "parsing line of failed hunk %q", line)
+			}
+			hunk, err := findHunk(m[1], int32(start))
+			if err != nil {
+				return nil, errors.Wrap(err, "printing failed hunk")
+			}
+			hunkFailed[m[1]] = true
+			conflicts = append(conflicts, btypes.ChangesetRebaseConflict{Path: m[1], Hunk: hunk})
+			continue
+		}
+
+		// `git apply` reports the file of a failed hunk a second time, which
+		// we don't want to record as a conflict of the whole file.
+		if m := fileFailedRegexp.FindStringSubmatch(line); m != nil && !hunkFailed[m[1]] {
+			conflicts = append(conflicts, btypes.ChangesetRebaseConflict{Path: m[1]})
+		}
+	}
+
+	return conflicts, nil
+}
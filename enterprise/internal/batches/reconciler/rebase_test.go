@@ -0,0 +1,107 @@
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+func TestParseRebaseConflicts(t *testing.T) {
+	const patch = `diff --git README.md README.md
+index 671e50a..851b23a 100644
+--- README.md
++++ README.md
+@@ -1,2 +1,2 @@
+ # Welcome
+-Hello
++Hello World
+@@ -10,2 +10,2 @@
+ ## Usage
+-Run it
++Run it twice
+diff --git main.go main.go
+index 2b9c4d1..b5a0c2e 100644
+--- main.go
++++ main.go
+@@ -1,1 +1,1 @@
+-package foo
++package main
+`
+
+	tcs := map[string]struct {
+		output string
+		want   []btypes.ChangesetRebaseConflict
+	}{
+		"failed hunk": {
+			output: "error: patch failed: README.md:10\nerror: README.md: patch does not apply\n",
+			want: []btypes.ChangesetRebaseConflict{
+				{Path: "README.md", Hunk: "@@ -10,2 +10,2 @@\n ## Usage\n-Run it\n+Run it twice\n"},
+			},
+		},
+		"failed hunks in multiple files": {
+			output: "error: patch failed: README.md:1\nerror: README.md: patch does not apply\nerror: patch failed: main.go:1\nerror: main.go: patch does not apply\n",
+			want: []btypes.ChangesetRebaseConflict{
+				{Path: "README.md", Hunk: "@@ -1,2 +1,2 @@\n # Welcome\n-Hello\n+Hello World\n"},
+				{Path: "main.go", Hunk: "@@ -1,1 +1,1 @@\n-package foo\n+package main\n"},
+			},
+		},
+		"deleted file": {
+			output: "error: main.go: does not exist in index\n",
+			want: []btypes.ChangesetRebaseConflict{
+				{Path: "main.go"},
+			},
+		},
+		"unrelated output": {
+			output: "fatal: something else went wrong\n",
+			want:   nil,
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			have, err := parseRebaseConflicts(patch, tc.output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("wrong conflicts (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCanRebase(t *testing.T) {
+	loaded := &btypes.Changeset{
+		CurrentSpecID:    1,
+		ReconcilerState:  btypes.ReconcilerStateCompleted,
+		PublicationState: btypes.ChangesetPublicationStatePublished,
+		ExternalState:    btypes.ChangesetExternalStateOpen,
+		RebaseBaseRev:    "old",
+	}
+
+	for name, tc := range map[string]struct {
+		modify func(*btypes.Changeset)
+		want   bool
+	}{
+		"unchanged":       {modify: func(c *btypes.Changeset) {}, want: true},
+		"draft":           {modify: func(c *btypes.Changeset) { c.ExternalState = btypes.ChangesetExternalStateDraft }, want: true},
+		"new spec":        {modify: func(c *btypes.Changeset) { c.CurrentSpecID = 2 }, want: false},
+		"queued":          {modify: func(c *btypes.Changeset) { c.ReconcilerState = btypes.ReconcilerStateQueued }, want: false},
+		"processing":      {modify: func(c *btypes.Changeset) { c.ReconcilerState = btypes.ReconcilerStateProcessing }, want: false},
+		"closed":          {modify: func(c *btypes.Changeset) { c.ExternalState = btypes.ChangesetExternalStateClosed }, want: false},
+		"already rebased": {modify: func(c *btypes.Changeset) { c.RebaseBaseRev = "new" }, want: false},
+		"unpublished":     {modify: func(c *btypes.Changeset) { c.PublicationState = btypes.ChangesetPublicationStateUnpublished }, want: false},
+		"spec removed":    {modify: func(c *btypes.Changeset) { c.CurrentSpecID = 0 }, want: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			locked := loaded.Clone()
+			tc.modify(locked)
+
+			if have := canRebase(locked, loaded, "new"); have != tc.want {
+				t.Errorf("unexpected result: have=%v want=%v", have, tc.want)
+			}
+		})
+	}
+}
@@ -79,6 +79,18 @@ func (r *Reconciler) process(ctx context.Context, tx *store.Store, ch *btypes.Ch
 		return err
 	}
 
+	// Changesets that depend on other changesets of their batch change are
+	// held back until all of them are merged.
+	if curr != nil && ch.OwnedByBatchChangeID != 0 && plan.MakesReadyForReview() {
+		deps, err := tx.GetChangesetDependencies(ctx, ch.OwnedByBatchChangeID)
+		if err != nil {
+			return err
+		}
+		if deps.Blocked(ch.ID) {
+			plan.Hold()
+		}
+	}
+
 	log15.Info("Reconciler processing changeset", "changeset", ch.ID, "operations", plan.Ops)
 
 	return executePlan(
@@ -291,6 +291,18 @@ func (s *Service) CreateBatchSpec(ctx context.Context, opts CreateBatchSpecOpts)
 		}
 	}
 
+	// Reject dependencies between the changesets that form a cycle, since
+	// none of the changesets in it could ever be published.
+	if len(spec.Spec.ChangesetDependencies) > 0 {
+		repoNames := make([]string, 0, len(accessibleReposByID))
+		for _, repo := range accessibleReposByID {
+			repoNames = append(repoNames, string(repo.Name))
+		}
+		if _, err := batcheslib.NewChangesetDependencyGraph(spec.Spec.ChangesetDependencies, repoNames); err != nil {
+			return nil, batcheslib.NewValidationError(err)
+		}
+	}
+
 	tx, err := s.store.Transact(ctx)
 	if err != nil {
 		return nil, err
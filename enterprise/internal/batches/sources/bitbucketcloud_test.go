@@ -0,0 +1,246 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func newBitbucketCloudTestSource(t *testing.T, handler http.Handler) *BitbucketCloudSource {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	svc := &types.ExternalService{
+		Kind: extsvc.KindBitbucketCloud,
+		Config: marshalJSON(t, &schema.BitbucketCloudConnection{
+			Url:         "https://bitbucket.org",
+			ApiURL:      srv.URL,
+			Username:    "user",
+			AppPassword: "password",
+		}),
+	}
+
+	src, err := NewBitbucketCloudSource(svc, httpcli.NewFactory(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return src
+}
+
+func newBitbucketCloudTestChangeset(pr *bitbucketcloud.PullRequest) *Changeset {
+	cs := &Changeset{
+		Title:   "title",
+		Body:    "body",
+		HeadRef: "refs/heads/feature",
+		BaseRef: "refs/heads/main",
+		Repo: &types.Repo{
+			Metadata: &bitbucketcloud.Repo{FullName: "sglocal/mux"},
+		},
+		Changeset: &btypes.Changeset{},
+	}
+	if pr != nil {
+		cs.Changeset.Metadata = pr
+		cs.Changeset.ExternalID = "42"
+	}
+	return cs
+}
+
+const bitbucketCloudTestPullRequestPath = "/2.0/repositories/sglocal/mux/pullrequests/42"
+
+func TestBitbucketCloudSource_LoadChangeset(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(bitbucketCloudTestPullRequestPath, func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"id": 42, "state": "OPEN", "source": {"branch": {"name": "feature"}}}`)
+	})
+	mux.HandleFunc(bitbucketCloudTestPullRequestPath+"/statuses", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"values": [{"key": "ci", "state": "SUCCESSFUL"}]}`)
+	})
+	src := newBitbucketCloudTestSource(t, mux)
+
+	t.Run("found", func(t *testing.T) {
+		cs := newBitbucketCloudTestChangeset(nil)
+		cs.Changeset.ExternalID = "42"
+
+		if err := src.LoadChangeset(context.Background(), cs); err != nil {
+			t.Fatal(err)
+		}
+
+		pr := cs.Changeset.Metadata.(*bitbucketcloud.PullRequest)
+		if pr.ID != 42 || len(pr.Statuses) != 1 {
+			t.Errorf("unexpected pull request: %+v", pr)
+		}
+		if have, want := cs.Changeset.ExternalBranch, "refs/heads/feature"; have != want {
+			t.Errorf("unexpected external branch: have %q, want %q", have, want)
+		}
+		if have, want := cs.Changeset.ExternalServiceType, extsvc.TypeBitbucketCloud; have != want {
+			t.Errorf("unexpected external service type: have %q, want %q", have, want)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		cs := newBitbucketCloudTestChangeset(nil)
+		cs.Changeset.ExternalID = "999"
+
+		err := src.LoadChangeset(context.Background(), cs)
+		if !errors.As(err, &ChangesetNotFoundError{}) {
+			t.Fatalf("expected ChangesetNotFoundError, got %v", err)
+		}
+	})
+}
+
+func TestBitbucketCloudSource_CreateChangeset(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		status     int
+		wantExists bool
+	}{
+		{name: "new", status: http.StatusCreated, wantExists: false},
+		{name: "existing", status: http.StatusOK, wantExists: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/2.0/repositories/sglocal/mux/pullrequests", func(w http.ResponseWriter, r *http.Request) {
+				var body struct {
+					Source struct {
+						Branch struct{ Name string } `json:"branch"`
+					} `json:"source"`
+					Destination struct {
+						Branch struct{ Name string } `json:"branch"`
+					} `json:"destination"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Fatal(err)
+				}
+				if body.Source.Branch.Name != "feature" || body.Destination.Branch.Name != "main" {
+					t.Errorf("unexpected branches: %+v", body)
+				}
+
+				w.WriteHeader(tc.status)
+				io.WriteString(w, `{"id": 42, "state": "OPEN"}`)
+			})
+			mux.HandleFunc(bitbucketCloudTestPullRequestPath+"/statuses", func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, `{"values": []}`)
+			})
+			src := newBitbucketCloudTestSource(t, mux)
+
+			cs := newBitbucketCloudTestChangeset(nil)
+			exists, err := src.CreateChangeset(context.Background(), cs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if exists != tc.wantExists {
+				t.Errorf("unexpected exists: have %v, want %v", exists, tc.wantExists)
+			}
+			if cs.Changeset.ExternalID != "42" {
+				t.Errorf("unexpected external ID: %q", cs.Changeset.ExternalID)
+			}
+		})
+	}
+}
+
+func TestBitbucketCloudSource_CloseChangeset(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(bitbucketCloudTestPullRequestPath+"/decline", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"id": 42, "state": "DECLINED"}`)
+	})
+	src := newBitbucketCloudTestSource(t, mux)
+
+	cs := newBitbucketCloudTestChangeset(&bitbucketcloud.PullRequest{ID: 42, State: bitbucketcloud.PullRequestStateOpen})
+	if err := src.CloseChangeset(context.Background(), cs); err != nil {
+		t.Fatal(err)
+	}
+
+	if pr := cs.Changeset.Metadata.(*bitbucketcloud.PullRequest); pr.State != bitbucketcloud.PullRequestStateDeclined {
+		t.Errorf("unexpected state: %q", pr.State)
+	}
+}
+
+func TestBitbucketCloudSource_ReopenChangeset(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/2.0/repositories/sglocal/mux/pullrequests", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Title string `json:"title"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if body.Title != "declined title" {
+			t.Errorf("unexpected title: %q", body.Title)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"id": 43, "state": "OPEN"}`)
+	})
+	mux.HandleFunc("/2.0/repositories/sglocal/mux/pullrequests/43/statuses", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"values": []}`)
+	})
+	src := newBitbucketCloudTestSource(t, mux)
+
+	declined := &bitbucketcloud.PullRequest{ID: 42, Title: "declined title", State: bitbucketcloud.PullRequestStateDeclined}
+	declined.Source.Branch.Name = "feature"
+	declined.Destination.Branch.Name = "main"
+
+	cs := newBitbucketCloudTestChangeset(declined)
+	if err := src.ReopenChangeset(context.Background(), cs); err != nil {
+		t.Fatal(err)
+	}
+
+	if cs.Changeset.ExternalID != "43" {
+		t.Errorf("unexpected external ID: %q", cs.Changeset.ExternalID)
+	}
+}
+
+func TestBitbucketCloudSource_MergeChangeset(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(bitbucketCloudTestPullRequestPath+"/merge", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{"type": "error", "error": {"message": "You can't merge until you resolve all merge conflicts."}}`)
+	})
+	src := newBitbucketCloudTestSource(t, mux)
+
+	cs := newBitbucketCloudTestChangeset(&bitbucketcloud.PullRequest{ID: 42, State: bitbucketcloud.PullRequestStateOpen})
+	err := src.MergeChangeset(context.Background(), cs, true)
+
+	var e *ChangesetNotMergeableError
+	if !errors.As(err, &e) {
+		t.Fatalf("expected ChangesetNotMergeableError, got %v", err)
+	}
+}
+
+func TestBitbucketCloudSource_WithAuthenticator(t *testing.T) {
+	src := newBitbucketCloudTestSource(t, http.NotFoundHandler())
+
+	for _, a := range []auth.Authenticator{
+		&auth.BasicAuth{Username: "user", Password: "password"},
+		&auth.BasicAuthWithSSH{BasicAuth: auth.BasicAuth{Username: "user", Password: "password"}},
+	} {
+		if _, err := src.WithAuthenticator(a); err != nil {
+			t.Errorf("unexpected error for %T: %s", a, err)
+		}
+	}
+
+	for _, a := range []auth.Authenticator{
+		&auth.OAuthBearerToken{Token: "token"},
+		&auth.OAuthBearerTokenWithSSH{OAuthBearerToken: auth.OAuthBearerToken{Token: "token"}},
+	} {
+		_, err := src.WithAuthenticator(a)
+		if !errors.HasType(err, UnsupportedAuthenticatorError{}) {
+			t.Errorf("unexpected error for %T: %v", a, err)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package sources
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GerritSource is the ChangesetSource for Gerrit. Gerrit has no API to create
+// changes from a branch: changes are created by pushing a commit with a
+// Change-Id trailer to refs/for/<base branch> (see GerritChangeID and
+// gerrit.PushRef), so by the time a changeset is created on Gerrit, it already
+// exists and only needs to be loaded.
+//
+// The title and body of a change are the subject and body of its commit
+// message, which is why they can't be updated through the API either.
+type GerritSource struct {
+	client *gerrit.Client
+	au     auth.Authenticator
+}
+
+var _ DraftChangesetSource = &GerritSource{}
+
+// NewGerritSource returns a new GerritSource from the given external service.
+func NewGerritSource(svc *types.ExternalService, cf *httpcli.Factory) (*GerritSource, error) {
+	var c schema.GerritConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing Gerrit URL")
+	}
+	baseURL = extsvc.NormalizeBaseURL(baseURL)
+
+	au := &auth.BasicAuth{Username: c.Username, Password: c.Password}
+	client, err := gerrit.NewClient(baseURL, au, cli)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GerritSource{client: client, au: au}, nil
+}
+
+// GerritChangeID returns the Change-Id of the Gerrit change for the given head
+// ref in the given repo. The Change-Id is derived from both, so that every
+// push of the changeset updates the same change with a new patch set.
+func GerritChangeID(repo *types.Repo, headRef string) string {
+	return gerrit.GenerateChangeID(string(repo.Name) + "\x00" + git.AbbreviateRef(headRef))
+}
+
+func (s GerritSource) GitserverPushConfig(ctx context.Context, store database.ExternalServiceStore, repo *types.Repo) (*protocol.PushConfig, error) {
+	return gitserverPushConfig(ctx, store, repo, s.au)
+}
+
+func (s GerritSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	client, err := s.client.WithAuthenticator(a)
+	if err != nil {
+		return nil, newUnsupportedAuthenticatorError("GerritSource", a)
+	}
+
+	return &GerritSource{
+		client: client,
+		au:     a,
+	}, nil
+}
+
+// AuthenticatedUsername uses the underlying gerrit.Client to get the username
+// belonging to the credentials associated with the GerritSource.
+func (s GerritSource) AuthenticatedUsername(ctx context.Context) (string, error) {
+	account, err := s.client.GetAuthenticatedAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	return account.Username, nil
+}
+
+func (s GerritSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.GetAuthenticatedAccount(ctx)
+	return err
+}
+
+// CreateChangeset loads the change that was created by pushing the commit of
+// the given *Changeset. The change always exists at this point, but it's
+// reported as new, since it was created by this push.
+func (s GerritSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	id, err := newChangeID(c)
+	if err != nil {
+		return false, err
+	}
+
+	change, err := s.client.GetChange(ctx, id)
+	if err != nil {
+		return false, errors.Wrap(err, "loading pushed change")
+	}
+	if err := c.SetMetadata(change); err != nil {
+		return false, errors.Wrap(err, "setting changeset metadata")
+	}
+	return false, nil
+}
+
+// CreateDraftChangeset loads the change that was created by pushing the commit
+// of the given *Changeset and marks it as work in progress.
+func (s GerritSource) CreateDraftChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	id, err := newChangeID(c)
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.client.SetWorkInProgress(ctx, id); err != nil && !gerrit.IsConflict(err) {
+		// A conflict means that the change is already work in progress.
+		return false, errors.Wrap(err, "marking change as work in progress")
+	}
+
+	change, err := s.client.GetChange(ctx, id)
+	if err != nil {
+		return false, errors.Wrap(err, "loading pushed change")
+	}
+	if err := c.SetMetadata(change); err != nil {
+		return false, errors.Wrap(err, "setting changeset metadata")
+	}
+	return false, nil
+}
+
+// UndraftChangeset marks the change of the given *Changeset as ready for
+// review.
+func (s GerritSource) UndraftChangeset(ctx context.Context, c *Changeset) error {
+	change, ok := c.Changeset.Metadata.(*gerrit.Change)
+	if !ok {
+		return errors.New("Changeset is not a Gerrit change")
+	}
+
+	if err := s.client.SetReadyForReview(ctx, change.ID); err != nil && !gerrit.IsConflict(err) {
+		return err
+	}
+	return s.reload(ctx, c, change.ID)
+}
+
+// CloseChangeset abandons the change of the given *Changeset and updates the
+// Metadata column in the *batches.Changeset to the abandoned change.
+func (s GerritSource) CloseChangeset(ctx context.Context, c *Changeset) error {
+	change, ok := c.Changeset.Metadata.(*gerrit.Change)
+	if !ok {
+		return errors.New("Changeset is not a Gerrit change")
+	}
+
+	if err := s.client.AbandonChange(ctx, change.ID); err != nil {
+		return err
+	}
+	return s.reload(ctx, c, change.ID)
+}
+
+// LoadChangeset loads the latest state of the given Changeset from the codehost.
+func (s GerritSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	id := url.PathEscape(cs.ExternalID)
+	if change, ok := cs.Changeset.Metadata.(*gerrit.Change); ok {
+		id = change.ID
+	}
+
+	change, err := s.client.GetChange(ctx, id)
+	if err != nil {
+		if gerrit.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return err
+	}
+
+	if err := cs.SetMetadata(change); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+	return nil
+}
+
+// UpdateChangeset reloads the change of the given *Changeset. The title and
+// body of the change are taken from the commit message, which is updated by
+// pushing a new patch set.
+//
+// Changing the base branch works the same way: the commit is pushed to the new
+// base branch, which creates a new change with the same Change-Id there. The
+// change on the previous base branch is abandoned, so that only one of them
+// remains open.
+func (s GerritSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
+	change, ok := c.Changeset.Metadata.(*gerrit.Change)
+	if !ok {
+		return errors.New("Changeset is not a Gerrit change")
+	}
+
+	if git.AbbreviateRef(c.BaseRef) == change.Branch {
+		return s.reload(ctx, c, change.ID)
+	}
+
+	id, err := newChangeID(c)
+	if err != nil {
+		return err
+	}
+	if err := s.reload(ctx, c, id); err != nil {
+		return errors.Wrap(err, "loading change on new base branch")
+	}
+
+	if change.Status == gerrit.ChangeStatusNew {
+		// A conflict means that the change is no longer open.
+		if err := s.client.AbandonChange(ctx, change.ID); err != nil && !gerrit.IsConflict(err) {
+			return errors.Wrap(err, "abandoning change on previous base branch")
+		}
+	}
+	return nil
+}
+
+// ReopenChangeset restores the abandoned change of the given *Changeset and
+// updates the Metadata column in the *batches.Changeset.
+func (s GerritSource) ReopenChangeset(ctx context.Context, c *Changeset) error {
+	change, ok := c.Changeset.Metadata.(*gerrit.Change)
+	if !ok {
+		return errors.New("Changeset is not a Gerrit change")
+	}
+	if change.Status != gerrit.ChangeStatusAbandoned {
+		return nil
+	}
+
+	if err := s.client.RestoreChange(ctx, change.ID); err != nil {
+		return err
+	}
+	return s.reload(ctx, c, change.ID)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s GerritSource) CreateComment(ctx context.Context, c *Changeset, text string) error {
+	change, ok := c.Changeset.Metadata.(*gerrit.Change)
+	if !ok {
+		return errors.New("Changeset is not a Gerrit change")
+	}
+
+	return s.client.CreateChangeComment(ctx, change.ID, text)
+}
+
+// MergeChangeset submits the change of the given Changeset, if it is
+// submittable. Gerrit applies the submit type configured for the project, so
+// squash is ignored.
+func (s GerritSource) MergeChangeset(ctx context.Context, c *Changeset, squash bool) error {
+	change, ok := c.Changeset.Metadata.(*gerrit.Change)
+	if !ok {
+		return errors.New("Changeset is not a Gerrit change")
+	}
+
+	if err := s.client.SubmitChange(ctx, change.ID); err != nil {
+		if gerrit.IsConflict(err) {
+			return &ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return err
+	}
+	return s.reload(ctx, c, change.ID)
+}
+
+func (s GerritSource) reload(ctx context.Context, c *Changeset, id string) error {
+	change, err := s.client.GetChange(ctx, id)
+	if err != nil {
+		return err
+	}
+	return c.Changeset.SetMetadata(change)
+}
+
+// newChangeID returns the REST API ID of the change created by pushing the
+// commit of the given *Changeset.
+func newChangeID(c *Changeset) (string, error) {
+	project, ok := c.Repo.Metadata.(*gerrit.Project)
+	if !ok {
+		return "", errors.Errorf("repo %q is not a Gerrit project", c.Repo.Name)
+	}
+	return changeID(project.Name, git.AbbreviateRef(c.BaseRef), GerritChangeID(c.Repo, c.HeadRef)), nil
+}
+
+// changeID returns the REST API ID of the change with the given Change-Id in
+// the given project and branch.
+func changeID(project, branch, cid string) string {
+	return url.PathEscape(project) + "~" + url.PathEscape(branch) + "~" + cid
+}
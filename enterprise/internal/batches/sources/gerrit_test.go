@@ -0,0 +1,271 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func newGerritTestSource(t *testing.T, handler http.Handler) *GerritSource {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	svc := &types.ExternalService{
+		Kind: extsvc.KindGerrit,
+		Config: marshalJSON(t, &schema.GerritConnection{
+			Url:      srv.URL,
+			Username: "user",
+			Password: "password",
+		}),
+	}
+
+	src, err := NewGerritSource(svc, httpcli.NewFactory(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return src
+}
+
+func newGerritTestChangeset(change *gerrit.Change) *Changeset {
+	cs := &Changeset{
+		Title:   "title",
+		Body:    "body",
+		HeadRef: "refs/heads/feature",
+		BaseRef: "refs/heads/main",
+		Repo: &types.Repo{
+			Name:     "gerrit.example.com/platform/build",
+			Metadata: &gerrit.Project{ID: "platform%2Fbuild", Name: "platform/build"},
+		},
+		Changeset: &btypes.Changeset{},
+	}
+	if change != nil {
+		cs.Changeset.Metadata = change
+		cs.Changeset.ExternalID = change.ChangeID
+	}
+	return cs
+}
+
+const gerritTestChangeID = "platform%2Fbuild~main~I0123"
+
+// gerritTestChange writes a change with the given status to w.
+func gerritTestChange(w io.Writer, status gerrit.ChangeStatus, wip bool) {
+	io.WriteString(w, ")]}'\n")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"id":               gerritTestChangeID,
+		"project":          "platform/build",
+		"branch":           "main",
+		"topic":            "feature",
+		"change_id":        "I0123",
+		"subject":          "title",
+		"status":           status,
+		"created":          "2022-02-01 09:59:32.000000000",
+		"updated":          "2022-02-01 09:59:32.000000000",
+		"work_in_progress": wip,
+		"_number":          42,
+	})
+}
+
+func TestGerritSource_LoadChangeset(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a/changes/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/a/changes/"+gerritTestChangeID {
+			http.NotFound(w, r)
+			return
+		}
+		gerritTestChange(w, gerrit.ChangeStatusNew, false)
+	})
+	src := newGerritTestSource(t, mux)
+
+	t.Run("found", func(t *testing.T) {
+		cs := newGerritTestChangeset(&gerrit.Change{ID: gerritTestChangeID, ChangeID: "I0123"})
+
+		if err := src.LoadChangeset(context.Background(), cs); err != nil {
+			t.Fatal(err)
+		}
+
+		change := cs.Changeset.Metadata.(*gerrit.Change)
+		if change.Number != 42 {
+			t.Errorf("unexpected change: %+v", change)
+		}
+		if have, want := cs.Changeset.ExternalID, "I0123"; have != want {
+			t.Errorf("unexpected external ID: have %q, want %q", have, want)
+		}
+		if have, want := cs.Changeset.ExternalBranch, "refs/heads/feature"; have != want {
+			t.Errorf("unexpected external branch: have %q, want %q", have, want)
+		}
+		if have, want := cs.Changeset.ExternalServiceType, extsvc.TypeGerrit; have != want {
+			t.Errorf("unexpected external service type: have %q, want %q", have, want)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		cs := newGerritTestChangeset(nil)
+		cs.Changeset.ExternalID = "I9999"
+
+		err := src.LoadChangeset(context.Background(), cs)
+		if !errors.As(err, &ChangesetNotFoundError{}) {
+			t.Fatalf("expected ChangesetNotFoundError, got %v", err)
+		}
+	})
+}
+
+func TestGerritSource_CreateDraftChangeset(t *testing.T) {
+	cs := newGerritTestChangeset(nil)
+	changeID := "platform%2Fbuild~main~" + GerritChangeID(cs.Repo, cs.HeadRef)
+
+	var markedWIP bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a/changes/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/a/changes/" + changeID + "/wip":
+			markedWIP = true
+		case "/a/changes/" + changeID:
+			gerritTestChange(w, gerrit.ChangeStatusNew, markedWIP)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.EscapedPath())
+		}
+	})
+	src := newGerritTestSource(t, mux)
+
+	exists, err := src.CreateDraftChangeset(context.Background(), cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exists {
+		t.Error("unexpected existing changeset")
+	}
+	if change := cs.Changeset.Metadata.(*gerrit.Change); !markedWIP || !change.WorkInProgress {
+		t.Errorf("change not marked as work in progress: %+v", change)
+	}
+}
+
+func TestGerritSource_CloseChangeset(t *testing.T) {
+	var abandoned bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a/changes/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/a/changes/" + gerritTestChangeID + "/abandon":
+			abandoned = true
+		case "/a/changes/" + gerritTestChangeID:
+			gerritTestChange(w, gerrit.ChangeStatusAbandoned, false)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.EscapedPath())
+		}
+	})
+	src := newGerritTestSource(t, mux)
+
+	cs := newGerritTestChangeset(&gerrit.Change{ID: gerritTestChangeID, ChangeID: "I0123", Status: gerrit.ChangeStatusNew})
+	if err := src.CloseChangeset(context.Background(), cs); err != nil {
+		t.Fatal(err)
+	}
+
+	if change := cs.Changeset.Metadata.(*gerrit.Change); !abandoned || change.Status != gerrit.ChangeStatusAbandoned {
+		t.Errorf("unexpected status: %q", change.Status)
+	}
+}
+
+func TestGerritSource_UpdateChangeset(t *testing.T) {
+	cs := newGerritTestChangeset(&gerrit.Change{
+		ID:       "platform%2Fbuild~develop~I0123",
+		Project:  "platform/build",
+		Branch:   "develop",
+		ChangeID: "I0123",
+		Status:   gerrit.ChangeStatusNew,
+	})
+	// The commit was pushed to the new base branch, which created a change
+	// there.
+	changeID := "platform%2Fbuild~main~" + GerritChangeID(cs.Repo, cs.HeadRef)
+
+	var abandoned bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a/changes/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/a/changes/platform%2Fbuild~develop~I0123/abandon":
+			abandoned = true
+		case "/a/changes/" + changeID:
+			gerritTestChange(w, gerrit.ChangeStatusNew, false)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.EscapedPath())
+		}
+	})
+	src := newGerritTestSource(t, mux)
+
+	if err := src.UpdateChangeset(context.Background(), cs); err != nil {
+		t.Fatal(err)
+	}
+
+	if change := cs.Changeset.Metadata.(*gerrit.Change); change.Branch != "main" {
+		t.Errorf("change on new base branch not loaded: %+v", change)
+	}
+	if !abandoned {
+		t.Error("change on previous base branch not abandoned")
+	}
+}
+
+func TestGerritSource_CreateChangeset_notGerritProject(t *testing.T) {
+	src := newGerritTestSource(t, http.NotFoundHandler())
+
+	cs := newGerritTestChangeset(nil)
+	cs.Repo.Metadata = nil
+	if _, err := src.CreateChangeset(context.Background(), cs); err == nil {
+		t.Fatal("expected error for repo without Gerrit project metadata")
+	}
+}
+
+func TestGerritSource_MergeChangeset(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a/changes/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/a/changes/"+gerritTestChangeID+"/submit" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.EscapedPath())
+		}
+		w.WriteHeader(http.StatusConflict)
+		io.WriteString(w, "Change 42: needs Code-Review")
+	})
+	src := newGerritTestSource(t, mux)
+
+	cs := newGerritTestChangeset(&gerrit.Change{ID: gerritTestChangeID, ChangeID: "I0123", Status: gerrit.ChangeStatusNew})
+	err := src.MergeChangeset(context.Background(), cs, false)
+
+	var e *ChangesetNotMergeableError
+	if !errors.As(err, &e) {
+		t.Fatalf("expected ChangesetNotMergeableError, got %v", err)
+	}
+}
+
+func TestGerritSource_WithAuthenticator(t *testing.T) {
+	src := newGerritTestSource(t, http.NotFoundHandler())
+
+	for _, a := range []auth.Authenticator{
+		&auth.BasicAuth{Username: "user", Password: "password"},
+		&auth.BasicAuthWithSSH{BasicAuth: auth.BasicAuth{Username: "user", Password: "password"}},
+	} {
+		if _, err := src.WithAuthenticator(a); err != nil {
+			t.Errorf("unexpected error for %T: %s", a, err)
+		}
+	}
+
+	for _, a := range []auth.Authenticator{
+		&auth.OAuthBearerToken{Token: "token"},
+		&auth.OAuthBearerTokenWithSSH{OAuthBearerToken: auth.OAuthBearerToken{Token: "token"}},
+	} {
+		_, err := src.WithAuthenticator(a)
+		if !errors.HasType(err, UnsupportedAuthenticatorError{}) {
+			t.Errorf("unexpected error for %T: %v", a, err)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/inconshreveable/log15"
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 )
@@ -53,19 +54,29 @@ var RequiredEventTypesForHistory = []btypes.ChangesetEventKind{
 	btypes.ChangesetEventKindGitHubConvertToDraft,
 	btypes.ChangesetEventKindGitHubClosed,
 	btypes.ChangesetEventKindBitbucketServerDeclined,
+	btypes.ChangesetEventKindBitbucketCloudDeclined,
 	btypes.ChangesetEventKindGitLabClosed,
+	btypes.ChangesetEventKindGerritAbandoned,
 	btypes.ChangesetEventKindGitHubMerged,
 	btypes.ChangesetEventKindBitbucketServerMerged,
+	btypes.ChangesetEventKindBitbucketCloudMerged,
 	btypes.ChangesetEventKindGitLabMerged,
+	btypes.ChangesetEventKindGerritMerged,
 	btypes.ChangesetEventKindGitHubReopened,
 	btypes.ChangesetEventKindBitbucketServerReopened,
 	btypes.ChangesetEventKindGitLabReopened,
+	btypes.ChangesetEventKindGerritRestored,
 	btypes.ChangesetEventKindGitHubReviewed,
 	btypes.ChangesetEventKindBitbucketServerApproved,
 	btypes.ChangesetEventKindBitbucketServerReviewed,
+	btypes.ChangesetEventKindBitbucketCloudApproved,
+	btypes.ChangesetEventKindBitbucketCloudChangesRequested,
 	btypes.ChangesetEventKindGitLabApproved,
+	btypes.ChangesetEventKindGerritReviewed,
 	btypes.ChangesetEventKindBitbucketServerUnapproved,
 	btypes.ChangesetEventKindBitbucketServerDismissed,
+	btypes.ChangesetEventKindBitbucketCloudUnapproved,
+	btypes.ChangesetEventKindBitbucketCloudChangesRequestRemoved,
 	btypes.ChangesetEventKindGitLabUnapproved,
 }
 
@@ -121,7 +132,9 @@ func computeHistory(ch *btypes.Changeset, ce ChangesetEvents) (changesetHistory,
 		switch e.Kind {
 		case btypes.ChangesetEventKindGitHubClosed,
 			btypes.ChangesetEventKindBitbucketServerDeclined,
-			btypes.ChangesetEventKindGitLabClosed:
+			btypes.ChangesetEventKindBitbucketCloudDeclined,
+			btypes.ChangesetEventKindGitLabClosed,
+			btypes.ChangesetEventKindGerritAbandoned:
 			// Merged is a final state. We can ignore everything after.
 			if currentExtState != btypes.ChangesetExternalStateMerged {
 				currentExtState = btypes.ChangesetExternalStateClosed
@@ -130,7 +143,9 @@ func computeHistory(ch *btypes.Changeset, ce ChangesetEvents) (changesetHistory,
 
 		case btypes.ChangesetEventKindGitHubMerged,
 			btypes.ChangesetEventKindBitbucketServerMerged,
-			btypes.ChangesetEventKindGitLabMerged:
+			btypes.ChangesetEventKindBitbucketCloudMerged,
+			btypes.ChangesetEventKindGitLabMerged,
+			btypes.ChangesetEventKindGerritMerged:
 			currentExtState = btypes.ChangesetExternalStateMerged
 			pushStates(et)
 
@@ -161,7 +176,8 @@ func computeHistory(ch *btypes.Changeset, ce ChangesetEvents) (changesetHistory,
 
 		case btypes.ChangesetEventKindGitHubReopened,
 			btypes.ChangesetEventKindBitbucketServerReopened,
-			btypes.ChangesetEventKindGitLabReopened:
+			btypes.ChangesetEventKindGitLabReopened,
+			btypes.ChangesetEventKindGerritRestored:
 			// Merged is a final state. We can ignore everything after.
 			if currentExtState != btypes.ChangesetExternalStateMerged {
 				if isDraft {
@@ -175,7 +191,10 @@ func computeHistory(ch *btypes.Changeset, ce ChangesetEvents) (changesetHistory,
 		case btypes.ChangesetEventKindGitHubReviewed,
 			btypes.ChangesetEventKindBitbucketServerApproved,
 			btypes.ChangesetEventKindBitbucketServerReviewed,
-			btypes.ChangesetEventKindGitLabApproved:
+			btypes.ChangesetEventKindBitbucketCloudApproved,
+			btypes.ChangesetEventKindBitbucketCloudChangesRequested,
+			btypes.ChangesetEventKindGitLabApproved,
+			btypes.ChangesetEventKindGerritReviewed:
 
 			s, err := e.ReviewState()
 			if err != nil {
@@ -217,6 +236,8 @@ func computeHistory(ch *btypes.Changeset, ce ChangesetEvents) (changesetHistory,
 
 		case btypes.ChangesetEventKindBitbucketServerUnapproved,
 			btypes.ChangesetEventKindBitbucketServerDismissed,
+			btypes.ChangesetEventKindBitbucketCloudUnapproved,
+			btypes.ChangesetEventKindBitbucketCloudChangesRequestRemoved,
 			btypes.ChangesetEventKindGitLabUnapproved:
 			author := e.ReviewAuthor()
 			// If the user has been deleted, skip their reviews, as they don't count towards the final state anymore.
@@ -292,6 +313,14 @@ func initialExternalState(ch *btypes.Changeset, ce ChangesetEvents) btypes.Chang
 		if m.WorkInProgress {
 			open = false
 		}
+
+	case *gerrit.Change:
+		// Gerrit has no events for changes becoming work in progress, so
+		// the current state is the best guess.
+		if m.WorkInProgress {
+			return btypes.ChangesetExternalStateDraft
+		}
+		return btypes.ChangesetExternalStateOpen
 	default:
 		return btypes.ChangesetExternalStateOpen
 	}
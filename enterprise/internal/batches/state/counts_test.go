@@ -10,6 +10,7 @@ import (
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -1425,6 +1426,42 @@ func TestCalcCounts(t *testing.T) {
 				{Time: daysAgo(0), Total: 1, Draft: 1},
 			},
 		},
+		{
+			codehosts: extsvc.TypeGerrit,
+			name:      "Gerrit abandoned and restored",
+			changesets: []*btypes.Changeset{
+				gerritChangesetAt(1, daysAgo(2)),
+			},
+			start: daysAgo(2),
+			events: []*btypes.ChangesetEvent{
+				gerritStateEvent(1, daysAgo(1), gerrit.ChangeStatusAbandoned),
+				gerritStateEvent(1, daysAgo(0), gerrit.ChangeStatusNew),
+			},
+			want: []*ChangesetCounts{
+				{Time: daysAgo(2), Total: 1, Open: 1, OpenPending: 1},
+				{Time: daysAgo(1), Total: 1, Closed: 1},
+				{Time: daysAgo(0), Total: 1, Open: 1, OpenPending: 1},
+			},
+		},
+		{
+			codehosts: extsvc.TypeGerrit,
+			name:      "Gerrit approval taken back before merge",
+			changesets: []*btypes.Changeset{
+				gerritChangesetAt(1, daysAgo(3)),
+			},
+			start: daysAgo(3),
+			events: []*btypes.ChangesetEvent{
+				gerritReview(1, daysAgo(2), "user1", 2),
+				gerritReview(1, daysAgo(1), "user1", 1),
+				gerritStateEvent(1, daysAgo(0), gerrit.ChangeStatusMerged),
+			},
+			want: []*ChangesetCounts{
+				{Time: daysAgo(3), Total: 1, Open: 1, OpenPending: 1},
+				{Time: daysAgo(2), Total: 1, Open: 1, OpenApproved: 1},
+				{Time: daysAgo(1), Total: 1, Open: 1, OpenPending: 1},
+				{Time: daysAgo(0), Total: 1, Merged: 1},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -1482,6 +1519,31 @@ func glChangeset(id int64, t time.Time) *btypes.Changeset {
 	}
 }
 
+func gerritChangesetAt(id int64, t time.Time) *btypes.Changeset {
+	return &btypes.Changeset{
+		ID:       id,
+		Metadata: &gerrit.Change{Created: gerrit.Timestamp{Time: t}},
+	}
+}
+
+func gerritStateEvent(id int64, t time.Time, status gerrit.ChangeStatus) *btypes.ChangesetEvent {
+	e := &gerrit.ChangeStateEvent{Date: t, Status: status}
+	kind, _ := btypes.ChangesetEventKindFor(e)
+	return &btypes.ChangesetEvent{ChangesetID: id, Kind: kind, Metadata: e}
+}
+
+func gerritReview(id int64, t time.Time, username string, value int) *btypes.ChangesetEvent {
+	return &btypes.ChangesetEvent{
+		ChangesetID: id,
+		Kind:        btypes.ChangesetEventKindGerritReviewed,
+		Metadata: &gerrit.ReviewEvent{
+			Date:   t,
+			Author: gerrit.Account{Username: username},
+			Value:  value,
+		},
+	}
+}
+
 func setExternalDeletedAt(c *btypes.Changeset, t time.Time) *btypes.Changeset {
 	c.SetDeleted()
 	c.ExternalDeletedAt = t
@@ -9,7 +9,9 @@ import (
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -276,6 +278,107 @@ func TestComputeBitbucketBuildStatus(t *testing.T) {
 	}
 }
 
+func TestComputeBitbucketCloudBuildStatus(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+	lastSynced := now.Add(-1 * time.Minute)
+	statusEvent := func(key, state string) *btypes.ChangesetEvent {
+		return &btypes.ChangesetEvent{
+			Kind: btypes.ChangesetEventKindBitbucketCloudCommitStatus,
+			Metadata: &bitbucketcloud.PullRequestStatus{
+				BuildKey:  key,
+				State:     state,
+				UpdatedOn: now,
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		statuses []*bitbucketcloud.PullRequestStatus
+		events   []*btypes.ChangesetEvent
+		want     btypes.ChangesetCheckState
+	}{
+		{
+			name: "empty",
+			want: btypes.ChangesetCheckStateUnknown,
+		},
+		{
+			name:     "synced success",
+			statuses: []*bitbucketcloud.PullRequestStatus{{BuildKey: "ctx1", State: "SUCCESSFUL"}},
+			want:     btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name:     "synced stopped",
+			statuses: []*bitbucketcloud.PullRequestStatus{{BuildKey: "ctx1", State: "STOPPED"}},
+			want:     btypes.ChangesetCheckStateFailed,
+		},
+		{
+			name:     "synced pending + success",
+			statuses: []*bitbucketcloud.PullRequestStatus{{BuildKey: "ctx1", State: "INPROGRESS"}, {BuildKey: "ctx2", State: "SUCCESSFUL"}},
+			want:     btypes.ChangesetCheckStatePending,
+		},
+		{
+			name:     "events since sync have precedence",
+			statuses: []*bitbucketcloud.PullRequestStatus{{BuildKey: "ctx1", State: "INPROGRESS"}},
+			events:   []*btypes.ChangesetEvent{statusEvent("ctx1", "FAILED")},
+			want:     btypes.ChangesetCheckStateFailed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &bitbucketcloud.PullRequest{Statuses: tc.statuses}
+			have := computeBitbucketCloudBuildStatus(lastSynced, pr, tc.events)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func TestComputeGerritCheckState(t *testing.T) {
+	t.Parallel()
+
+	account := &gerrit.Account{ID: 1}
+
+	tests := []struct {
+		name   string
+		labels map[string]gerrit.Label
+		want   btypes.ChangesetCheckState
+	}{
+		{
+			name: "no verified label",
+			want: btypes.ChangesetCheckStateUnknown,
+		},
+		{
+			name:   "not voted",
+			labels: map[string]gerrit.Label{gerrit.LabelVerified: {}},
+			want:   btypes.ChangesetCheckStatePending,
+		},
+		{
+			name:   "verified",
+			labels: map[string]gerrit.Label{gerrit.LabelVerified: {Approved: account}},
+			want:   btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name:   "rejection has precedence",
+			labels: map[string]gerrit.Label{gerrit.LabelVerified: {Approved: account, Rejected: account}},
+			want:   btypes.ChangesetCheckStateFailed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have := computeGerritCheckState(&gerrit.Change{Labels: tc.labels})
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
 func TestComputeGitLabCheckState(t *testing.T) {
 	t.Parallel()
 
@@ -464,6 +567,26 @@ func TestComputeReviewState(t *testing.T) {
 			},
 			want: btypes.ChangesetReviewStateChangesRequested,
 		},
+		{
+			name:      "bitbucketcloud - no events, pending",
+			changeset: bitbucketCloudChangeset(daysAgo(10), bitbucketcloud.PullRequestStateOpen, ""),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name:      "bitbucketcloud - no events, approved",
+			changeset: bitbucketCloudChangeset(daysAgo(10), bitbucketcloud.PullRequestStateOpen, bitbucketcloud.ParticipantStateApproved),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name:      "bitbucketcloud - changeset older than events",
+			changeset: bitbucketCloudChangeset(daysAgo(10), bitbucketcloud.PullRequestStateOpen, bitbucketcloud.ParticipantStateChangesRequested),
+			history: []changesetStatesAtTime{
+				{t: daysAgo(0), reviewState: btypes.ChangesetReviewStateApproved},
+			},
+			want: btypes.ChangesetReviewStateApproved,
+		},
 		{
 			name:      "gitlab - no events, no approvals",
 			changeset: gitLabChangeset(daysAgo(0), gitlab.MergeRequestStateOpened, []*gitlab.Note{}),
@@ -535,6 +658,24 @@ func TestComputeReviewState(t *testing.T) {
 			},
 			want: btypes.ChangesetReviewStateChangesRequested,
 		},
+		{
+			name:      "gerrit - not voted",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, gerrit.Label{}),
+			history:   []changesetStatesAtTime{{t: daysAgo(10), reviewState: btypes.ChangesetReviewStatePending}},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name:      "gerrit - approved",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, gerrit.Label{Approved: &gerrit.Account{ID: 1}}),
+			history:   []changesetStatesAtTime{{t: daysAgo(10), reviewState: btypes.ChangesetReviewStatePending}},
+			want:      btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name:      "gerrit - rejected",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, gerrit.Label{Approved: &gerrit.Account{ID: 1}, Rejected: &gerrit.Account{ID: 2}}),
+			history:   []changesetStatesAtTime{{t: daysAgo(10), reviewState: btypes.ChangesetReviewStatePending}},
+			want:      btypes.ChangesetReviewStateChangesRequested,
+		},
 	}
 
 	for i, tc := range tests {
@@ -653,6 +794,32 @@ func TestComputeExternalState(t *testing.T) {
 			},
 			want: btypes.ChangesetExternalStateDeleted,
 		},
+		{
+			name:      "bitbucketcloud - no events, open",
+			changeset: bitbucketCloudChangeset(daysAgo(10), bitbucketcloud.PullRequestStateOpen, ""),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "bitbucketcloud - no events, declined",
+			changeset: bitbucketCloudChangeset(daysAgo(10), bitbucketcloud.PullRequestStateDeclined, ""),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "bitbucketcloud - no events, superseded",
+			changeset: bitbucketCloudChangeset(daysAgo(10), bitbucketcloud.PullRequestStateSuperseded, ""),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "bitbucketcloud - changeset older than events",
+			changeset: bitbucketCloudChangeset(daysAgo(10), bitbucketcloud.PullRequestStateOpen, ""),
+			history: []changesetStatesAtTime{
+				{t: daysAgo(0), externalState: btypes.ChangesetExternalStateMerged},
+			},
+			want: btypes.ChangesetExternalStateMerged,
+		},
 		{
 			name:      "gitlab - no events, opened",
 			changeset: gitLabChangeset(daysAgo(0), gitlab.MergeRequestStateOpened, nil),
@@ -715,6 +882,24 @@ func TestComputeExternalState(t *testing.T) {
 			},
 			want: btypes.ChangesetExternalStateDraft,
 		},
+		{
+			name:      "gerrit - no events, abandoned",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusAbandoned, gerrit.Label{}),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "gerrit - no events, merged",
+			changeset: gerritChangeset(daysAgo(0), gerrit.ChangeStatusMerged, gerrit.Label{}),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateMerged,
+		},
+		{
+			name:      "gerrit draft - no events",
+			changeset: setDraft(gerritChangeset(daysAgo(0), gerrit.ChangeStatusNew, gerrit.Label{})),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateDraft,
+		},
 	}
 
 	for i, tc := range tests {
@@ -846,6 +1031,19 @@ func bitbucketChangeset(updatedAt time.Time, state, reviewStatus string) *btypes
 	}
 }
 
+func bitbucketCloudChangeset(updatedAt time.Time, state bitbucketcloud.PullRequestState, participantState bitbucketcloud.ParticipantState) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeBitbucketCloud,
+		UpdatedAt:           updatedAt,
+		Metadata: &bitbucketcloud.PullRequest{
+			State: state,
+			Participants: []bitbucketcloud.Participant{
+				{Role: "REVIEWER", State: participantState},
+			},
+		},
+	}
+}
+
 func githubChangeset(updatedAt time.Time, state string) *btypes.Changeset {
 	return &btypes.Changeset{
 		ExternalServiceType: extsvc.TypeGitHub,
@@ -865,6 +1063,17 @@ func gitLabChangeset(updatedAt time.Time, state gitlab.MergeRequestState, notes
 	}
 }
 
+func gerritChangeset(updatedAt time.Time, status gerrit.ChangeStatus, codeReview gerrit.Label) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeGerrit,
+		UpdatedAt:           updatedAt,
+		Metadata: &gerrit.Change{
+			Status: status,
+			Labels: map[string]gerrit.Label{gerrit.LabelCodeReview: codeReview},
+		},
+	}
+}
+
 func setDeletedAt(c *btypes.Changeset, deletedAt time.Time) *btypes.Changeset {
 	c.ExternalDeletedAt = deletedAt
 	return c
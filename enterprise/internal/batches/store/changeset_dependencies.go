@@ -0,0 +1,120 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// GetChangesetDependencies resolves the changesetDependencies declared in the
+// current batch spec of the given batch change against the changesets owned
+// by it.
+func (s *Store) GetChangesetDependencies(ctx context.Context, batchChangeID int64) (deps *btypes.ChangesetDependencies, err error) {
+	ctx, endObservation := s.operations.getChangesetDependencies.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	batchChange, err := s.GetBatchChange(ctx, GetBatchChangeOpts{ID: batchChangeID})
+	if err != nil {
+		return nil, err
+	}
+
+	batchSpec, err := s.GetBatchSpec(ctx, GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+	if err != nil {
+		return nil, err
+	}
+	if batchSpec.Spec == nil || len(batchSpec.Spec.ChangesetDependencies) == 0 {
+		return &btypes.ChangesetDependencies{}, nil
+	}
+
+	cs, _, err := s.ListChangesets(ctx, ListChangesetsOpts{
+		BatchChangeID:        batchChangeID,
+		OwnedByBatchChangeID: batchChangeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	repoIDs := make([]api.RepoID, 0, len(cs))
+	for _, c := range cs {
+		repoIDs = append(repoIDs, c.RepoID)
+	}
+	repos, err := s.Repos().GetReposSetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return nil, err
+	}
+	repoNames := make(map[api.RepoID]string, len(repos))
+	for id, r := range repos {
+		repoNames[id] = string(r.Name)
+	}
+
+	return btypes.NewChangesetDependencies(batchSpec.Spec.ChangesetDependencies, cs, repoNames)
+}
+
+// EnqueueChangesetsDependingOn re-enqueues the changesets that could be held
+// back by the given changeset, so that the reconciler publishes or undrafts
+// them once all of their prerequisites are merged. It should be called when
+// the given changeset transitions to merged.
+//
+// Changesets are only re-enqueued if they're owned by the same batch change
+// as the given changeset, if the batch spec of that batch change declares
+// changesetDependencies, and if they're either unpublished or drafts.
+func (s *Store) EnqueueChangesetsDependingOn(ctx context.Context, cs *btypes.Changeset) (err error) {
+	ctx, endObservation := s.operations.enqueueChangesetsDependingOn.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(cs.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if cs.OwnedByBatchChangeID == 0 {
+		return nil
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		enqueueChangesetsDependingOnFmtstr,
+		btypes.ReconcilerStateQueued.ToDB(),
+		s.now(),
+		cs.OwnedByBatchChangeID,
+		cs.ID,
+		btypes.ReconcilerStateCompleted.ToDB(),
+		btypes.ChangesetPublicationStateUnpublished,
+		btypes.ChangesetExternalStateDraft,
+		cs.OwnedByBatchChangeID,
+	))
+}
+
+const enqueueChangesetsDependingOnFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_dependencies.go:EnqueueChangesetsDependingOn
+UPDATE changesets
+SET
+	reconciler_state = %s,
+	num_resets = 0,
+	num_failures = 0,
+	failure_message = NULL,
+	updated_at = %s
+WHERE
+	owned_by_batch_change_id = %s
+	AND
+	id != %s
+	AND
+	current_spec_id IS NOT NULL
+	AND
+	reconciler_state = %s
+	AND
+	(publication_state = %s OR external_state = %s)
+	AND
+	EXISTS (
+		SELECT 1
+		FROM batch_changes
+		JOIN batch_specs ON batch_specs.id = batch_changes.batch_spec_id
+		WHERE
+			batch_changes.id = %s
+			AND
+			jsonb_array_length(COALESCE(batch_specs.spec->'changesetDependencies', '[]'::jsonb)) > 0
+	)
+`
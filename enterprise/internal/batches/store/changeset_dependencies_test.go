@@ -0,0 +1,108 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func testStoreChangesetDependencies(t *testing.T, ctx context.Context, s *Store, clock ct.Clock) {
+	repoStore := database.ReposWith(s)
+	esStore := database.ExternalServicesWith(s)
+
+	lib := ct.TestRepo(t, esStore, extsvc.KindGitHub)
+	app := ct.TestRepo(t, esStore, extsvc.KindGitHub)
+	if err := repoStore.Create(ctx, lib, app); err != nil {
+		t.Fatal(err)
+	}
+
+	user := ct.CreateTestUser(t, s.DatabaseDB(), false)
+
+	batchSpec := &btypes.BatchSpec{
+		UserID:          user.ID,
+		NamespaceUserID: user.ID,
+		Spec: &batcheslib.BatchSpec{
+			Name: "dependencies",
+			ChangesetDependencies: []batcheslib.ChangesetDependency{
+				{Repository: string(app.Name), DependsOn: []string{string(lib.Name)}},
+			},
+		},
+	}
+	if err := s.CreateBatchSpec(ctx, batchSpec); err != nil {
+		t.Fatal(err)
+	}
+	batchChange := ct.CreateBatchChange(t, ctx, s, "dependencies", user.ID, batchSpec.ID)
+
+	libSpec := ct.CreateChangesetSpec(t, ctx, s, ct.TestSpecOpts{User: user.ID, Repo: lib.ID, BatchSpec: batchSpec.ID, HeadRef: "refs/heads/lib"})
+	appSpec := ct.CreateChangesetSpec(t, ctx, s, ct.TestSpecOpts{User: user.ID, Repo: app.ID, BatchSpec: batchSpec.ID, HeadRef: "refs/heads/app"})
+
+	libChangeset := ct.CreateChangeset(t, ctx, s, ct.TestChangesetOpts{
+		Repo:               lib.ID,
+		BatchChange:        batchChange.ID,
+		OwnedByBatchChange: batchChange.ID,
+		CurrentSpec:        libSpec.ID,
+		PublicationState:   btypes.ChangesetPublicationStatePublished,
+		ExternalState:      btypes.ChangesetExternalStateOpen,
+		ReconcilerState:    btypes.ReconcilerStateCompleted,
+	})
+	appChangeset := ct.CreateChangeset(t, ctx, s, ct.TestChangesetOpts{
+		Repo:               app.ID,
+		BatchChange:        batchChange.ID,
+		OwnedByBatchChange: batchChange.ID,
+		CurrentSpec:        appSpec.ID,
+		PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+		ReconcilerState:    btypes.ReconcilerStateCompleted,
+	})
+
+	t.Run("GetChangesetDependencies", func(t *testing.T) {
+		deps, err := s.GetChangesetDependencies(ctx, batchChange.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		prereqs := deps.Prerequisites(appChangeset.ID)
+		if len(prereqs) != 1 || prereqs[0].ID != libChangeset.ID {
+			t.Fatalf("wrong prerequisites: %+v", prereqs)
+		}
+		if !deps.Blocked(appChangeset.ID) {
+			t.Fatal("changeset not blocked by open prerequisite")
+		}
+		if deps.Blocked(libChangeset.ID) {
+			t.Fatal("changeset without prerequisites is blocked")
+		}
+	})
+
+	t.Run("EnqueueChangesetsDependingOn", func(t *testing.T) {
+		libChangeset.ExternalState = btypes.ChangesetExternalStateMerged
+		if err := s.UpdateChangeset(ctx, libChangeset); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := s.EnqueueChangesetsDependingOn(ctx, libChangeset); err != nil {
+			t.Fatal(err)
+		}
+
+		ct.ReloadAndAssertChangeset(t, ctx, s, appChangeset, ct.ChangesetAssertions{
+			Repo:               app.ID,
+			OwnedByBatchChange: batchChange.ID,
+			AttachedTo:         []int64{batchChange.ID},
+			CurrentSpec:        appSpec.ID,
+			PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+			ReconcilerState:    btypes.ReconcilerStateQueued,
+		})
+		ct.ReloadAndAssertChangeset(t, ctx, s, libChangeset, ct.ChangesetAssertions{
+			Repo:               lib.ID,
+			OwnedByBatchChange: batchChange.ID,
+			AttachedTo:         []int64{batchChange.ID},
+			CurrentSpec:        libSpec.ID,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ExternalState:      btypes.ChangesetExternalStateMerged,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+		})
+	})
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	"github.com/opentracing/opentracing-go/log"
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
@@ -169,6 +171,70 @@ func getChangesetJobQuery(opts *GetChangesetJobOpts) *sqlf.Query {
 	)
 }
 
+// CountChangesetJobsOpts captures the query options needed for counting
+// changeset jobs.
+type CountChangesetJobsOpts struct {
+	BatchChangeID int64
+	ChangesetID   int64
+	JobType       btypes.ChangesetJobType
+	States        []btypes.ChangesetJobState
+	CreatedAfter  time.Time
+	// OnlyAutoMerge restricts the count to the merge jobs created by the
+	// auto-merge policy of a batch change.
+	OnlyAutoMerge bool
+}
+
+// CountChangesetJobs returns the number of changeset jobs matching the given
+// options.
+func (s *Store) CountChangesetJobs(ctx context.Context, opts CountChangesetJobsOpts) (count int, err error) {
+	ctx, endObservation := s.operations.countChangesetJobs.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(opts.BatchChangeID)),
+		log.Int("changesetID", int(opts.ChangesetID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.queryCount(ctx, countChangesetJobsQuery(&opts))
+}
+
+var countChangesetJobsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_jobs.go:CountChangesetJobs
+SELECT COUNT(changeset_jobs.id)
+FROM changeset_jobs
+WHERE %s
+`
+
+func countChangesetJobsQuery(opts *CountChangesetJobsOpts) *sqlf.Query {
+	var preds []*sqlf.Query
+	if opts.BatchChangeID != 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_jobs.batch_change_id = %s", opts.BatchChangeID))
+	}
+	if opts.ChangesetID != 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_jobs.changeset_id = %s", opts.ChangesetID))
+	}
+	if opts.JobType != "" {
+		preds = append(preds, sqlf.Sprintf("changeset_jobs.job_type = %s", opts.JobType))
+	}
+	if len(opts.States) > 0 {
+		states := make([]string, len(opts.States))
+		for i, state := range opts.States {
+			states[i] = state.ToDB()
+		}
+		preds = append(preds, sqlf.Sprintf("changeset_jobs.state = ANY (%s)", pq.Array(states)))
+	}
+	if !opts.CreatedAfter.IsZero() {
+		preds = append(preds, sqlf.Sprintf("changeset_jobs.created_at > %s", opts.CreatedAfter))
+	}
+	if opts.OnlyAutoMerge {
+		preds = append(preds, sqlf.Sprintf("(changeset_jobs.payload->>'autoMerge')::boolean IS TRUE"))
+	}
+
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	return sqlf.Sprintf(countChangesetJobsQueryFmtstr, sqlf.Join(preds, "\n AND "))
+}
+
 func scanChangesetJob(c *btypes.ChangesetJob, s dbutil.Scanner) error {
 	var raw json.RawMessage
 	if err := s.Scan(
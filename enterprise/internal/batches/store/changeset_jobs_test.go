@@ -107,4 +107,66 @@ func testStoreChangesetJobs(t *testing.T, ctx context.Context, s *Store, clock c
 			}
 		})
 	})
+
+	t.Run("Count", func(t *testing.T) {
+		autoMergeJob := &btypes.ChangesetJob{
+			UserID:        1234,
+			BatchChangeID: 910,
+			ChangesetID:   changeset.ID,
+			JobType:       btypes.ChangesetJobTypeMerge,
+			Payload:       &btypes.ChangesetJobMergePayload{AutoMerge: true},
+			State:         btypes.ChangesetJobStateQueued,
+		}
+		manualMergeJob := &btypes.ChangesetJob{
+			UserID:        1234,
+			BatchChangeID: 910,
+			ChangesetID:   changeset.ID,
+			JobType:       btypes.ChangesetJobTypeMerge,
+			Payload:       &btypes.ChangesetJobMergePayload{},
+			State:         btypes.ChangesetJobStateCompleted,
+		}
+		if err := s.CreateChangesetJob(ctx, autoMergeJob, manualMergeJob); err != nil {
+			t.Fatal(err)
+		}
+
+		for name, tc := range map[string]struct {
+			opts CountChangesetJobsOpts
+			want int
+		}{
+			"all": {
+				opts: CountChangesetJobsOpts{},
+				want: len(jobs) + 2,
+			},
+			"by batch change": {
+				opts: CountChangesetJobsOpts{BatchChangeID: 910},
+				want: 3,
+			},
+			"by changeset and job type": {
+				opts: CountChangesetJobsOpts{ChangesetID: changeset.ID, JobType: btypes.ChangesetJobTypeMerge},
+				want: 2,
+			},
+			"by state": {
+				opts: CountChangesetJobsOpts{JobType: btypes.ChangesetJobTypeMerge, States: []btypes.ChangesetJobState{btypes.ChangesetJobStateQueued}},
+				want: 1,
+			},
+			"only auto merge": {
+				opts: CountChangesetJobsOpts{BatchChangeID: 910, OnlyAutoMerge: true},
+				want: 1,
+			},
+			"created after": {
+				opts: CountChangesetJobsOpts{CreatedAfter: clock.Now()},
+				want: 0,
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				have, err := s.CountChangesetJobs(ctx, tc.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if have != tc.want {
+					t.Fatalf("have count %d, want %d", have, tc.want)
+				}
+			})
+		}
+	})
 }
@@ -22,7 +22,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -64,6 +66,9 @@ var changesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.num_failures"),
 	sqlf.Sprintf("changesets.closing"),
 	sqlf.Sprintf("changesets.syncer_error"),
+	sqlf.Sprintf("changesets.rebase_state"),
+	sqlf.Sprintf("changesets.rebase_base_rev"),
+	sqlf.Sprintf("changesets.rebase_conflicts"),
 }
 
 // changesetInsertColumns is the list of changeset columns that are modified in
@@ -100,6 +105,9 @@ var changesetInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("num_failures"),
 	sqlf.Sprintf("closing"),
 	sqlf.Sprintf("syncer_error"),
+	sqlf.Sprintf("rebase_state"),
+	sqlf.Sprintf("rebase_base_rev"),
+	sqlf.Sprintf("rebase_conflicts"),
 	// We additionally store the result of changeset.Title() in a column, so
 	// the business logic for determining it is in one place and the field is
 	// indexable for searching.
@@ -148,6 +156,11 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 
 	uiPublicationState := uiPublicationStateColumn(c)
 
+	rebaseConflicts, err := rebaseConflictsColumn(c)
+	if err != nil {
+		return nil, err
+	}
+
 	vars := []interface{}{
 		sqlf.Join(changesetInsertColumns, ", "),
 		c.RepoID,
@@ -181,6 +194,9 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		c.NumFailures,
 		c.Closing,
 		c.SyncErrorMessage,
+		nullStringColumn(string(c.RebaseState)),
+		nullStringColumn(c.RebaseBaseRev),
+		rebaseConflicts,
 		nullStringColumn(title),
 	}
 
@@ -225,7 +241,7 @@ func (s *Store) CreateChangeset(ctx context.Context, c *btypes.Changeset) (err e
 var createChangesetQueryFmtstr = `
 -- source: enterprise/internal/batches/store.go:CreateChangeset
 INSERT INTO changesets (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -359,6 +375,8 @@ type GetChangesetOpts struct {
 	ExternalBranch      string
 	ReconcilerState     btypes.ReconcilerState
 	PublicationState    btypes.ChangesetPublicationState
+	// ForUpdate locks the changeset until the end of the transaction.
+	ForUpdate bool
 }
 
 // GetChangeset gets a changeset matching the given options.
@@ -389,6 +407,7 @@ SELECT %s FROM changesets
 INNER JOIN repo ON repo.id = changesets.repo_id
 WHERE %s
 LIMIT 1
+%s  -- optional FOR UPDATE
 `
 
 func getChangesetQuery(opts *GetChangesetOpts) *sqlf.Query {
@@ -419,10 +438,16 @@ func getChangesetQuery(opts *GetChangesetOpts) *sqlf.Query {
 		preds = append(preds, sqlf.Sprintf("changesets.publication_state = %s", opts.PublicationState))
 	}
 
+	forUpdate := &sqlf.Query{}
+	if opts.ForUpdate {
+		forUpdate = sqlf.Sprintf("FOR UPDATE OF changesets")
+	}
+
 	return sqlf.Sprintf(
 		getChangesetsQueryFmtstr,
 		sqlf.Join(changesetColumns, ", "),
 		sqlf.Join(preds, "\n AND "),
+		forUpdate,
 	)
 }
 
@@ -713,7 +738,7 @@ func (s *Store) UpdateChangeset(ctx context.Context, cs *btypes.Changeset) (err
 var updateChangesetQueryFmtstr = `
 -- source: enterprise/internal/batches/store_changesets.go:UpdateChangeset
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   %s
@@ -843,6 +868,103 @@ RETURNING
   %s
 `
 
+// UpdateChangesetRebaseState updates only the rebase_state, rebase_base_rev,
+// rebase_conflicts and updated_at columns of the given Changeset.
+func (s *Store) UpdateChangesetRebaseState(ctx context.Context, cs *btypes.Changeset) (err error) {
+	ctx, endObservation := s.operations.updateChangesetRebaseState.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(cs.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	cs.UpdatedAt = s.now()
+
+	rebaseConflicts, err := rebaseConflictsColumn(cs)
+	if err != nil {
+		return err
+	}
+
+	q := sqlf.Sprintf(
+		updateChangesetRebaseStateQueryFmtstr,
+		cs.UpdatedAt,
+		nullStringColumn(string(cs.RebaseState)),
+		nullStringColumn(cs.RebaseBaseRev),
+		rebaseConflicts,
+		cs.ID,
+		sqlf.Join(changesetColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) (err error) {
+		return scanChangeset(cs, sc)
+	})
+}
+
+var updateChangesetRebaseStateQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changesets.go:UpdateChangesetRebaseState
+UPDATE changesets
+SET (updated_at, rebase_state, rebase_base_rev, rebase_conflicts) = (%s, %s, %s, %s)
+WHERE id = %s
+RETURNING
+  %s
+`
+
+// ListChangesetsToRebaseOpts captures the query options needed for listing
+// the changesets that the changeset rebaser considers.
+type ListChangesetsToRebaseOpts struct {
+	LimitOpts
+	Cursor int64
+}
+
+// ListChangesetsToRebase lists the open and draft changesets that are owned
+// by a batch change that isn't closed, have been published, and aren't
+// currently being processed by the reconciler. Whether they need to be
+// rebased is up to the caller to decide.
+func (s *Store) ListChangesetsToRebase(ctx context.Context, opts ListChangesetsToRebaseOpts) (cs btypes.Changesets, next int64, err error) {
+	ctx, endObservation := s.operations.listChangesetsToRebase.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		listChangesetsToRebaseQueryFmtstr+opts.LimitOpts.ToDB(),
+		sqlf.Join(changesetColumns, ", "),
+		opts.Cursor,
+		btypes.ChangesetPublicationStatePublished,
+		pq.Array([]btypes.ChangesetExternalState{btypes.ChangesetExternalStateOpen, btypes.ChangesetExternalStateDraft}),
+		btypes.ReconcilerStateCompleted.ToDB(),
+	)
+
+	cs = make([]*btypes.Changeset, 0, opts.DBLimit())
+	err = s.query(ctx, q, func(sc dbutil.Scanner) (err error) {
+		var c btypes.Changeset
+		if err = scanChangeset(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(cs) == opts.DBLimit() {
+		next = cs[len(cs)-1].ID
+		cs = cs[:len(cs)-1]
+	}
+
+	return cs, next, err
+}
+
+var listChangesetsToRebaseQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changesets.go:ListChangesetsToRebase
+SELECT %s FROM changesets
+INNER JOIN repo ON repo.id = changesets.repo_id
+INNER JOIN batch_changes ON batch_changes.id = changesets.owned_by_batch_change_id
+WHERE
+	changesets.id >= %s
+	AND repo.deleted_at IS NULL
+	AND batch_changes.closed_at IS NULL
+	AND changesets.current_spec_id IS NOT NULL
+	AND changesets.publication_state = %s
+	AND changesets.external_state = ANY (%s)
+	AND changesets.reconciler_state = %s
+ORDER BY changesets.id ASC
+`
+
 // GetChangesetExternalIDs allows us to find the external ids for pull requests based on
 // a slice of head refs. We need this in order to match incoming webhooks to pull requests as
 // the only information they provide is the remote branch
@@ -1106,7 +1228,7 @@ func (n jsonBatchChangeChangesetSet) Value() (driver.Value, error) {
 }
 
 func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
-	var metadata, syncState json.RawMessage
+	var metadata, syncState, rebaseConflicts json.RawMessage
 
 	var (
 		externalState       string
@@ -1115,6 +1237,7 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		failureMessage      string
 		syncErrorMessage    string
 		reconcilerState     string
+		rebaseState         string
 	)
 	err := s.Scan(
 		&t.ID,
@@ -1149,6 +1272,9 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		&t.NumFailures,
 		&t.Closing,
 		&dbutil.NullString{S: &syncErrorMessage},
+		&dbutil.NullString{S: &rebaseState},
+		&dbutil.NullString{S: &t.RebaseBaseRev},
+		&rebaseConflicts,
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset")
@@ -1164,14 +1290,19 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		t.SyncErrorMessage = &syncErrorMessage
 	}
 	t.ReconcilerState = btypes.ReconcilerState(strings.ToUpper(reconcilerState))
+	t.RebaseState = btypes.ChangesetRebaseState(rebaseState)
 
 	switch t.ExternalServiceType {
 	case extsvc.TypeGitHub:
 		t.Metadata = new(github.PullRequest)
 	case extsvc.TypeBitbucketServer:
 		t.Metadata = new(bitbucketserver.PullRequest)
+	case extsvc.TypeBitbucketCloud:
+		t.Metadata = new(bitbucketcloud.PullRequest)
 	case extsvc.TypeGitLab:
 		t.Metadata = new(gitlab.MergeRequest)
+	case extsvc.TypeGerrit:
+		t.Metadata = new(gerrit.Change)
 	default:
 		return errors.New("unknown external service type")
 	}
@@ -1182,6 +1313,11 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 	if err = json.Unmarshal(syncState, &t.SyncState); err != nil {
 		return errors.Wrapf(err, "scanChangeset: failed to unmarshal sync state: %s", syncState)
 	}
+	if len(rebaseConflicts) > 0 {
+		if err = json.Unmarshal(rebaseConflicts, &t.RebaseConflicts); err != nil {
+			return errors.Wrapf(err, "scanChangeset: failed to unmarshal rebase conflicts: %s", rebaseConflicts)
+		}
+	}
 
 	return nil
 }
@@ -1443,3 +1579,14 @@ func uiPublicationStateColumn(c *btypes.Changeset) *string {
 	}
 	return uiPublicationState
 }
+
+func rebaseConflictsColumn(c *btypes.Changeset) (*string, error) {
+	if len(c.RebaseConflicts) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(c.RebaseConflicts)
+	if err != nil {
+		return nil, err
+	}
+	return nullStringColumn(string(data)), nil
+}
@@ -989,6 +989,24 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, clock ct.C
 				}
 			}
 		})
+
+		t.Run("ForUpdate", func(t *testing.T) {
+			tx, err := s.Transact(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = tx.Done(nil) }()
+
+			want := changesets[0]
+			have, err := tx.GetChangeset(ctx, GetChangesetOpts{ID: want.ID, ForUpdate: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
 	})
 
 	t.Run("Update", func(t *testing.T) {
@@ -1167,6 +1185,105 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, clock ct.C
 		}
 	})
 
+	t.Run("UpdateChangesetRebaseState", func(t *testing.T) {
+		cs := ct.CreateChangeset(t, ctx, s, ct.TestChangesetOpts{
+			Repo:             repo.ID,
+			ExternalState:    btypes.ChangesetExternalStateOpen,
+			PublicationState: btypes.ChangesetPublicationStatePublished,
+			ReconcilerState:  btypes.ReconcilerStateCompleted,
+			Metadata:         &github.PullRequest{Title: "Se titel"},
+		})
+
+		cs.RebaseState = btypes.ChangesetRebaseStateConflicted
+		cs.RebaseBaseRev = "deadbeef"
+		cs.RebaseConflicts = []btypes.ChangesetRebaseConflict{
+			{Path: "README.md", Hunk: "@@ -1,1 +1,1 @@\n-foo\n+bar\n"},
+			{Path: "deleted.go"},
+		}
+		want := cs.Clone()
+
+		// These should not be updated.
+		cs.ExternalState = btypes.ChangesetExternalStateMerged
+		cs.ReconcilerState = btypes.ReconcilerStateQueued
+
+		if err := s.UpdateChangesetRebaseState(ctx, cs); err != nil {
+			t.Fatal(err)
+		}
+		have, err := s.GetChangesetByID(ctx, cs.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want.UpdatedAt = have.UpdatedAt
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatalf("invalid changeset state in DB: %s", diff)
+		}
+
+		// Resetting the rebase state clears all columns.
+		have.ResetRebaseState()
+		if err := s.UpdateChangesetRebaseState(ctx, have); err != nil {
+			t.Fatal(err)
+		}
+		if have.RebaseState != "" || have.RebaseBaseRev != "" || have.RebaseConflicts != nil {
+			t.Fatalf("rebase state not reset: %+v", have)
+		}
+	})
+
+	t.Run("ListChangesetsToRebase", func(t *testing.T) {
+		batchSpec := ct.CreateBatchSpec(t, ctx, s, "rebase", user.ID)
+		batchChange := ct.CreateBatchChange(t, ctx, s, "rebase", user.ID, batchSpec.ID)
+
+		baseOpts := ct.TestChangesetOpts{
+			Repo:               repo.ID,
+			BatchChange:        batchChange.ID,
+			OwnedByBatchChange: batchChange.ID,
+			CurrentSpec:        1,
+			ExternalState:      btypes.ChangesetExternalStateOpen,
+			PublicationState:   btypes.ChangesetPublicationStatePublished,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+		}
+
+		open := ct.CreateChangeset(t, ctx, s, baseOpts)
+
+		draftOpts := baseOpts
+		draftOpts.ExternalState = btypes.ChangesetExternalStateDraft
+		draft := ct.CreateChangeset(t, ctx, s, draftOpts)
+
+		mergedOpts := baseOpts
+		mergedOpts.ExternalState = btypes.ChangesetExternalStateMerged
+		ct.CreateChangeset(t, ctx, s, mergedOpts)
+
+		processingOpts := baseOpts
+		processingOpts.ReconcilerState = btypes.ReconcilerStateProcessing
+		ct.CreateChangeset(t, ctx, s, processingOpts)
+
+		importedOpts := baseOpts
+		importedOpts.OwnedByBatchChange = 0
+		importedOpts.CurrentSpec = 0
+		ct.CreateChangeset(t, ctx, s, importedOpts)
+
+		var have []int64
+		var cursor int64
+		for {
+			cs, next, err := s.ListChangesetsToRebase(ctx, ListChangesetsToRebaseOpts{LimitOpts: LimitOpts{Limit: 1}, Cursor: cursor})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range cs {
+				if c.OwnedByBatchChangeID == batchChange.ID {
+					have = append(have, c.ID)
+				}
+			}
+			if next == 0 {
+				break
+			}
+			cursor = next
+		}
+
+		if diff := cmp.Diff([]int64{open.ID, draft.ID}, have); diff != "" {
+			t.Fatalf("wrong changesets listed (-want +got):\n%s", diff)
+		}
+	})
+
 	t.Run("GetChangesetsStats", func(t *testing.T) {
 		var batchChangeID int64 = 191918
 		currentBatchChangeStats, err := s.GetChangesetsStats(ctx, batchChangeID)
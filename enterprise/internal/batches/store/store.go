@@ -203,6 +203,7 @@ type operations struct {
 
 	createChangesetJob *observation.Operation
 	getChangesetJob    *observation.Operation
+	countChangesetJobs *observation.Operation
 
 	createChangesetSpec                      *observation.Operation
 	updateChangesetSpecBatchSpecID           *observation.Operation
@@ -226,6 +227,8 @@ type operations struct {
 	updateChangesetBatchChanges       *observation.Operation
 	updateChangesetUIPublicationState *observation.Operation
 	updateChangesetCodeHostState      *observation.Operation
+	updateChangesetRebaseState        *observation.Operation
+	listChangesetsToRebase            *observation.Operation
 	getChangesetExternalIDs           *observation.Operation
 	cancelQueuedBatchChangeChangesets *observation.Operation
 	enqueueChangesetsToClose          *observation.Operation
@@ -233,6 +236,8 @@ type operations struct {
 	getRepoChangesetsStats            *observation.Operation
 	enqueueNextScheduledChangeset     *observation.Operation
 	getChangesetPlaceInSchedulerQueue *observation.Operation
+	getChangesetDependencies          *observation.Operation
+	enqueueChangesetsDependingOn      *observation.Operation
 
 	listCodeHosts         *observation.Operation
 	getExternalServiceIDs *observation.Operation
@@ -336,6 +341,7 @@ func newOperations(observationContext *observation.Context) *operations {
 
 			createChangesetJob: op("CreateChangesetJob"),
 			getChangesetJob:    op("GetChangesetJob"),
+			countChangesetJobs: op("CountChangesetJobs"),
 
 			createChangesetSpec:                      op("CreateChangesetSpec"),
 			updateChangesetSpecBatchSpecID:           op("UpdateChangesetSpecBatchSpecID"),
@@ -359,6 +365,8 @@ func newOperations(observationContext *observation.Context) *operations {
 			updateChangesetBatchChanges:       op("UpdateChangesetBatchChanges"),
 			updateChangesetUIPublicationState: op("UpdateChangesetUIPublicationState"),
 			updateChangesetCodeHostState:      op("UpdateChangesetCodeHostState"),
+			updateChangesetRebaseState:        op("UpdateChangesetRebaseState"),
+			listChangesetsToRebase:            op("ListChangesetsToRebase"),
 			getChangesetExternalIDs:           op("GetChangesetExternalIDs"),
 			cancelQueuedBatchChangeChangesets: op("CancelQueuedBatchChangeChangesets"),
 			enqueueChangesetsToClose:          op("EnqueueChangesetsToClose"),
@@ -366,6 +374,8 @@ func newOperations(observationContext *observation.Context) *operations {
 			getRepoChangesetsStats:            op("GetRepoChangesetsStats"),
 			enqueueNextScheduledChangeset:     op("EnqueueNextScheduledChangeset"),
 			getChangesetPlaceInSchedulerQueue: op("GetChangesetPlaceInSchedulerQueue"),
+			getChangesetDependencies:          op("GetChangesetDependencies"),
+			enqueueChangesetsDependingOn:      op("EnqueueChangesetsDependingOn"),
 
 			listCodeHosts:         op("ListCodeHosts"),
 			getExternalServiceIDs: op("GetExternalServiceIDs"),
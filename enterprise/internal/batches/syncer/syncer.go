@@ -475,6 +475,7 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, source sources.Chan
 	if err != nil {
 		return err
 	}
+	previousState := c.ExternalState
 	state.SetDerivedState(ctx, syncStore.Repos(), c, events)
 
 	tx, err := syncStore.Transact(ctx)
@@ -491,6 +492,12 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, source sources.Chan
 		return err
 	}
 
+	if previousState != btypes.ChangesetExternalStateMerged && c.ExternalState == btypes.ChangesetExternalStateMerged {
+		if err := tx.EnqueueChangesetsDependingOn(ctx, c); err != nil {
+			return err
+		}
+	}
+
 	return tx.UpsertChangesetEvents(ctx, events...)
 }
 
@@ -11,7 +11,9 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
@@ -229,6 +231,49 @@ func (s ChangesetCheckState) Valid() bool {
 	}
 }
 
+// ChangesetMergeability defines whether a Changeset can be merged into its
+// base branch without conflicts, as reported by the code host.
+type ChangesetMergeability string
+
+// ChangesetMergeability constants.
+const (
+	ChangesetMergeabilityUnknown     ChangesetMergeability = "UNKNOWN"
+	ChangesetMergeabilityMergeable   ChangesetMergeability = "MERGEABLE"
+	ChangesetMergeabilityConflicting ChangesetMergeability = "CONFLICTING"
+)
+
+// ChangesetRebaseState defines the outcome of the last attempt to rebase a
+// Changeset onto the moved head of its base branch.
+type ChangesetRebaseState string
+
+// ChangesetRebaseState constants.
+const (
+	ChangesetRebaseStateRebased    ChangesetRebaseState = "REBASED"
+	ChangesetRebaseStateConflicted ChangesetRebaseState = "CONFLICTED"
+)
+
+// Valid returns true if the given ChangesetRebaseState is valid.
+func (s ChangesetRebaseState) Valid() bool {
+	switch s {
+	case ChangesetRebaseStateRebased,
+		ChangesetRebaseStateConflicted:
+		return true
+	default:
+		return false
+	}
+}
+
+// ChangesetRebaseConflict is a hunk of the diff of a Changeset that couldn't
+// be applied to the new head of its base branch.
+type ChangesetRebaseConflict struct {
+	// Path is the path of the file the hunk belongs to.
+	Path string `json:"path"`
+	// Hunk is the hunk that failed to apply, including its header. It is
+	// empty if the whole file couldn't be patched, for example because it was
+	// deleted on the base branch.
+	Hunk string `json:"hunk,omitempty"`
+}
+
 // BatchChangeAssoc stores the details of a association to a BatchChange.
 type BatchChangeAssoc struct {
 	BatchChangeID int64 `json:"-"`
@@ -284,6 +329,14 @@ type Changeset struct {
 	// Closing is set to true (along with the ReocncilerState) when the
 	// reconciler should close the changeset.
 	Closing bool
+
+	// The following fields are set by the changeset rebaser when it rebases
+	// the changeset onto the moved head of its base branch. RebaseBaseRev is
+	// the commit of the base branch the last rebase was attempted onto, and
+	// RebaseConflicts are the hunks that couldn't be applied to it.
+	RebaseState     ChangesetRebaseState
+	RebaseBaseRev   string
+	RebaseConflicts []ChangesetRebaseConflict
 }
 
 // RecordID is needed to implement the workerutil.Record interface.
@@ -373,12 +426,24 @@ func (c *Changeset) SetMetadata(meta interface{}) error {
 		c.ExternalServiceType = extsvc.TypeBitbucketServer
 		c.ExternalBranch = git.EnsureRefPrefix(pr.FromRef.ID)
 		c.ExternalUpdatedAt = unixMilliToTime(int64(pr.UpdatedDate))
+	case *bitbucketcloud.PullRequest:
+		c.Metadata = pr
+		c.ExternalID = strconv.FormatInt(int64(pr.ID), 10)
+		c.ExternalServiceType = extsvc.TypeBitbucketCloud
+		c.ExternalBranch = git.EnsureRefPrefix(pr.Source.Branch.Name)
+		c.ExternalUpdatedAt = pr.UpdatedOn
 	case *gitlab.MergeRequest:
 		c.Metadata = pr
 		c.ExternalID = strconv.FormatInt(int64(pr.IID), 10)
 		c.ExternalServiceType = extsvc.TypeGitLab
 		c.ExternalBranch = git.EnsureRefPrefix(pr.SourceBranch)
 		c.ExternalUpdatedAt = pr.UpdatedAt.Time
+	case *gerrit.Change:
+		c.Metadata = pr
+		c.ExternalID = pr.ChangeID
+		c.ExternalServiceType = extsvc.TypeGerrit
+		c.ExternalBranch = git.EnsureRefPrefix(pr.Topic)
+		c.ExternalUpdatedAt = pr.Updated.Time
 	default:
 		return errors.New("unknown changeset type")
 	}
@@ -402,13 +467,59 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *bitbucketserver.PullRequest:
 		return m.Title, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Title, nil
 	case *gitlab.MergeRequest:
 		return m.Title, nil
+	case *gerrit.Change:
+		return m.Subject, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
 }
 
+// Mergeability returns whether the Changeset can be merged into its base
+// branch without conflicts, as last reported by the code host. Code hosts
+// compute this lazily, so ChangesetMergeabilityUnknown is returned until they
+// have done so, and for code hosts that don't report it at all.
+func (c *Changeset) Mergeability() ChangesetMergeability {
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		switch m.Mergeable {
+		case "MERGEABLE":
+			return ChangesetMergeabilityMergeable
+		case "CONFLICTING":
+			return ChangesetMergeabilityConflicting
+		}
+	case *bitbucketserver.PullRequest:
+		if m.Properties == nil || m.Properties.MergeResult == nil || !m.Properties.MergeResult.Current {
+			break
+		}
+		switch m.Properties.MergeResult.Outcome {
+		case "CLEAN":
+			return ChangesetMergeabilityMergeable
+		case "CONFLICTED":
+			return ChangesetMergeabilityConflicting
+		}
+	case *gitlab.MergeRequest:
+		switch m.MergeStatus {
+		case "can_be_merged":
+			return ChangesetMergeabilityMergeable
+		case "cannot_be_merged":
+			if m.HasConflicts {
+				return ChangesetMergeabilityConflicting
+			}
+		}
+	case *gerrit.Change:
+		// Gerrit only reports whether a change is submittable, which also
+		// depends on its reviews, so only a positive answer is meaningful.
+		if m.Submittable {
+			return ChangesetMergeabilityMergeable
+		}
+	}
+	return ChangesetMergeabilityUnknown
+}
+
 // AuthorName of the Changeset.
 func (c *Changeset) AuthorName() (string, error) {
 	switch m := c.Metadata.(type) {
@@ -419,8 +530,12 @@ func (c *Changeset) AuthorName() (string, error) {
 			return "", nil
 		}
 		return m.Author.User.Name, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Author.Nickname, nil
 	case *gitlab.MergeRequest:
 		return m.Author.Username, nil
+	case *gerrit.Change:
+		return m.Owner.Username, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -442,8 +557,13 @@ func (c *Changeset) AuthorEmail() (string, error) {
 			return "", nil
 		}
 		return m.Author.User.EmailAddress, nil
+	case *bitbucketcloud.PullRequest:
+		// Bitbucket Cloud doesn't expose the email addresses of users.
+		return "", nil
 	case *gitlab.MergeRequest:
 		return m.Author.Email, nil
+	case *gerrit.Change:
+		return m.Owner.Email, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -458,8 +578,12 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreatedAt
 	case *bitbucketserver.PullRequest:
 		return unixMilliToTime(int64(m.CreatedDate))
+	case *bitbucketcloud.PullRequest:
+		return m.CreatedOn
 	case *gitlab.MergeRequest:
 		return m.CreatedAt.Time
+	case *gerrit.Change:
+		return m.Created.Time
 	default:
 		return time.Time{}
 	}
@@ -472,8 +596,12 @@ func (c *Changeset) Body() (string, error) {
 		return m.Body, nil
 	case *bitbucketserver.PullRequest:
 		return m.Description, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Description, nil
 	case *gitlab.MergeRequest:
 		return m.Description, nil
+	case *gerrit.Change:
+		return m.Description(), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -509,8 +637,12 @@ func (c *Changeset) URL() (s string, err error) {
 		}
 		selfLink := m.Links.Self[0]
 		return selfLink.Href, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Links.HTML.Href, nil
 	case *gitlab.MergeRequest:
 		return m.WebURL, nil
+	case *gerrit.Change:
+		return m.URL, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -600,6 +732,40 @@ func (c *Changeset) Events() (events []*ChangesetEvent, err error) {
 			}
 		}
 
+	case *bitbucketcloud.PullRequest:
+		participantEvents := m.ParticipantStatusEvents()
+		events = make([]*ChangesetEvent, 0, len(participantEvents)+len(m.Statuses)+1)
+
+		addEvent := func(e Keyer) error {
+			kind, err := ChangesetEventKindFor(e)
+			if err != nil {
+				return err
+			}
+
+			appendEvent(&ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         e.Key(),
+				Kind:        kind,
+				Metadata:    e,
+			})
+			return nil
+		}
+		for _, e := range participantEvents {
+			if err = addEvent(e); err != nil {
+				return
+			}
+		}
+		for _, s := range m.Statuses {
+			if err = addEvent(s); err != nil {
+				return
+			}
+		}
+		if e := m.StateEvent(); e != nil {
+			if err = addEvent(e); err != nil {
+				return
+			}
+		}
+
 	case *gitlab.MergeRequest:
 		events = make([]*ChangesetEvent, 0, len(m.Notes)+len(m.ResourceStateEvents)+len(m.Pipelines))
 		var kind ChangesetEventKind
@@ -643,6 +809,10 @@ func (c *Changeset) Events() (events []*ChangesetEvent, err error) {
 				Metadata:    pipeline,
 			})
 		}
+
+	case *gerrit.Change:
+		// Gerrit doesn't expose a timeline of events for changes in its
+		// REST API, so events are only received through webhooks.
 	}
 	return events, nil
 }
@@ -656,8 +826,13 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return m.HeadRefOid, nil
 	case *bitbucketserver.PullRequest:
 		return "", nil
+	case *bitbucketcloud.PullRequest:
+		// Bitbucket Cloud only returns abbreviated commit hashes.
+		return "", nil
 	case *gitlab.MergeRequest:
 		return m.DiffRefs.HeadSHA, nil
+	case *gerrit.Change:
+		return m.CurrentRevision, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -671,8 +846,13 @@ func (c *Changeset) HeadRef() (string, error) {
 		return "refs/heads/" + m.HeadRefName, nil
 	case *bitbucketserver.PullRequest:
 		return m.FromRef.ID, nil
+	case *bitbucketcloud.PullRequest:
+		return "refs/heads/" + m.Source.Branch.Name, nil
 	case *gitlab.MergeRequest:
 		return "refs/heads/" + m.SourceBranch, nil
+	case *gerrit.Change:
+		// Changes are pushed with the head branch of the changeset as topic.
+		return "refs/heads/" + m.Topic, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -687,8 +867,13 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return m.BaseRefOid, nil
 	case *bitbucketserver.PullRequest:
 		return "", nil
+	case *bitbucketcloud.PullRequest:
+		// Bitbucket Cloud only returns abbreviated commit hashes.
+		return "", nil
 	case *gitlab.MergeRequest:
 		return m.DiffRefs.BaseSHA, nil
+	case *gerrit.Change:
+		return m.BaseRevision(), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -702,8 +887,12 @@ func (c *Changeset) BaseRef() (string, error) {
 		return "refs/heads/" + m.BaseRefName, nil
 	case *bitbucketserver.PullRequest:
 		return m.ToRef.ID, nil
+	case *bitbucketcloud.PullRequest:
+		return "refs/heads/" + m.Destination.Branch.Name, nil
 	case *gitlab.MergeRequest:
 		return "refs/heads/" + m.TargetBranch, nil
+	case *gerrit.Change:
+		return "refs/heads/" + m.Branch, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -827,6 +1016,14 @@ func (c *Changeset) ResetReconcilerState(state ReconcilerState) {
 	c.SyncErrorMessage = nil
 }
 
+// ResetRebaseState clears the outcome of the last rebase, which is outdated
+// once a new commit has been pushed for the changeset.
+func (c *Changeset) ResetRebaseState() {
+	c.RebaseState = ""
+	c.RebaseBaseRev = ""
+	c.RebaseConflicts = nil
+}
+
 // Changesets is a slice of *Changesets.
 type Changesets []*Changeset
 
@@ -964,6 +1161,12 @@ func ChangesetEventKindFor(e interface{}) (ChangesetEventKind, error) {
 		return ChangesetEventKind("bitbucketserver:participant_status:" + strings.ToLower(string(e.Action))), nil
 	case *bitbucketserver.CommitStatus:
 		return ChangesetEventKindBitbucketServerCommitStatus, nil
+	case *bitbucketcloud.ParticipantStatusEvent:
+		return ChangesetEventKind("bitbucketcloud:participant_status:" + string(e.Action)), nil
+	case *bitbucketcloud.PullRequestStateEvent:
+		return ChangesetEventKind("bitbucketcloud:" + strings.ToLower(string(e.State))), nil
+	case *bitbucketcloud.PullRequestStatus:
+		return ChangesetEventKindBitbucketCloudCommitStatus, nil
 	case *gitlab.Pipeline:
 		return ChangesetEventKindGitLabPipeline, nil
 	case *gitlab.ReviewApprovedEvent:
@@ -981,6 +1184,18 @@ func ChangesetEventKindFor(e interface{}) (ChangesetEventKind, error) {
 		return ChangesetEventKindGitLabReopened, nil
 	case *gitlab.MergeRequestMergedEvent:
 		return ChangesetEventKindGitLabMerged, nil
+
+	case *gerrit.ChangeStateEvent:
+		switch e.Status {
+		case gerrit.ChangeStatusMerged:
+			return ChangesetEventKindGerritMerged, nil
+		case gerrit.ChangeStatusAbandoned:
+			return ChangesetEventKindGerritAbandoned, nil
+		case gerrit.ChangeStatusNew:
+			return ChangesetEventKindGerritRestored, nil
+		}
+	case *gerrit.ReviewEvent:
+		return ChangesetEventKindGerritReviewed, nil
 	}
 
 	return ChangesetEventKindInvalid, errors.Errorf("unknown changeset event kind for %T", e)
@@ -999,6 +1214,15 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (interface{}, error) {
 		default:
 			return new(bitbucketserver.Activity), nil
 		}
+	case strings.HasPrefix(string(k), "bitbucketcloud"):
+		switch {
+		case k == ChangesetEventKindBitbucketCloudCommitStatus:
+			return new(bitbucketcloud.PullRequestStatus), nil
+		case strings.HasPrefix(string(k), "bitbucketcloud:participant_status"):
+			return new(bitbucketcloud.ParticipantStatusEvent), nil
+		default:
+			return new(bitbucketcloud.PullRequestStateEvent), nil
+		}
 	case strings.HasPrefix(string(k), "github"):
 		switch k {
 		case ChangesetEventKindGitHubAssigned:
@@ -1042,6 +1266,15 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (interface{}, error) {
 		case ChangesetEventKindCheckRun:
 			return new(github.CheckRun), nil
 		}
+	case strings.HasPrefix(string(k), "gerrit"):
+		switch k {
+		case ChangesetEventKindGerritReviewed:
+			return new(gerrit.ReviewEvent), nil
+		default:
+			return new(gerrit.ChangeStateEvent), nil
+		}
+	case k == ChangesetEventKindBatchesAutoMerged:
+		return new(AutoMergedEvent), nil
 	case strings.HasPrefix(string(k), "gitlab"):
 		switch k {
 		case ChangesetEventKindGitLabApproved:
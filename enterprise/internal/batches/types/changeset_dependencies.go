@@ -0,0 +1,106 @@
+package types
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+// ChangesetDependency is an edge in the dependency graph of the changesets
+// owned by a batch change: Dependent is held back until DependsOn is merged.
+type ChangesetDependency struct {
+	Dependent *Changeset
+	DependsOn *Changeset
+}
+
+// ChangesetDependencies are the dependencies between the changesets owned by
+// a batch change, resolved from the changesetDependencies of its batch spec.
+//
+// The zero value, and a nil pointer, represent a batch change without any
+// dependencies.
+type ChangesetDependencies struct {
+	edges         []ChangesetDependency
+	prerequisites map[int64][]*Changeset
+	dependents    map[int64][]*Changeset
+}
+
+// NewChangesetDependencies resolves the given dependencies against the given
+// changesets. repoNames maps the repository IDs of the changesets to their
+// names; changesets in repositories missing from repoNames are ignored.
+func NewChangesetDependencies(deps []batcheslib.ChangesetDependency, cs Changesets, repoNames map[api.RepoID]string) (*ChangesetDependencies, error) {
+	d := &ChangesetDependencies{
+		prerequisites: map[int64][]*Changeset{},
+		dependents:    map[int64][]*Changeset{},
+	}
+	if len(deps) == 0 {
+		return d, nil
+	}
+
+	byRepo := map[string][]*Changeset{}
+	names := []string{}
+	for _, c := range cs {
+		name, ok := repoNames[c.RepoID]
+		if !ok {
+			continue
+		}
+		if _, ok := byRepo[name]; !ok {
+			names = append(names, name)
+		}
+		byRepo[name] = append(byRepo[name], c)
+	}
+
+	g, err := batcheslib.NewChangesetDependencyGraph(deps, names)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		for _, prereq := range g.Prerequisites(name) {
+			for _, dependent := range byRepo[name] {
+				for _, dependsOn := range byRepo[prereq] {
+					d.edges = append(d.edges, ChangesetDependency{Dependent: dependent, DependsOn: dependsOn})
+					d.prerequisites[dependent.ID] = append(d.prerequisites[dependent.ID], dependsOn)
+					d.dependents[dependsOn.ID] = append(d.dependents[dependsOn.ID], dependent)
+				}
+			}
+		}
+	}
+
+	return d, nil
+}
+
+// Edges returns all dependencies between the changesets.
+func (d *ChangesetDependencies) Edges() []ChangesetDependency {
+	if d == nil {
+		return nil
+	}
+	return d.edges
+}
+
+// Prerequisites returns the changesets the changeset with the given ID
+// depends on.
+func (d *ChangesetDependencies) Prerequisites(id int64) []*Changeset {
+	if d == nil {
+		return nil
+	}
+	return d.prerequisites[id]
+}
+
+// Dependents returns the changesets that depend on the changeset with the
+// given ID.
+func (d *ChangesetDependencies) Dependents(id int64) []*Changeset {
+	if d == nil {
+		return nil
+	}
+	return d.dependents[id]
+}
+
+// Blocked returns true if the changeset with the given ID depends on at least
+// one changeset that has not been merged yet.
+func (d *ChangesetDependencies) Blocked(id int64) bool {
+	for _, c := range d.Prerequisites(id) {
+		if c.ExternalState != ChangesetExternalStateMerged {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestChangesetDependencies(t *testing.T) {
+	lib := &Changeset{ID: 1, RepoID: 1, ExternalState: ChangesetExternalStateOpen}
+	api1 := &Changeset{ID: 2, RepoID: 2, PublicationState: ChangesetPublicationStateUnpublished}
+	api2 := &Changeset{ID: 3, RepoID: 2, PublicationState: ChangesetPublicationStateUnpublished}
+	hidden := &Changeset{ID: 4, RepoID: 3}
+
+	repoNames := map[api.RepoID]string{
+		1: "github.com/sourcegraph/lib",
+		2: "github.com/sourcegraph/api",
+	}
+	deps := []batcheslib.ChangesetDependency{
+		{Repository: "github.com/sourcegraph/api", DependsOn: []string{"github.com/sourcegraph/*"}},
+	}
+
+	d, err := NewChangesetDependencies(deps, Changesets{lib, api1, api2, hidden}, repoNames)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantEdges := []ChangesetDependency{
+		{Dependent: api1, DependsOn: lib},
+		{Dependent: api2, DependsOn: lib},
+	}
+	if diff := cmp.Diff(wantEdges, d.Edges()); diff != "" {
+		t.Errorf("wrong edges (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]*Changeset{api1, api2}, d.Dependents(lib.ID)); diff != "" {
+		t.Errorf("wrong dependents (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]*Changeset{lib}, d.Prerequisites(api1.ID)); diff != "" {
+		t.Errorf("wrong prerequisites (-want +got):\n%s", diff)
+	}
+
+	if d.Blocked(lib.ID) {
+		t.Error("changeset without prerequisites is blocked")
+	}
+	if !d.Blocked(api1.ID) {
+		t.Error("changeset with open prerequisite is not blocked")
+	}
+
+	lib.ExternalState = ChangesetExternalStateMerged
+	if d.Blocked(api1.ID) {
+		t.Error("changeset with merged prerequisite is blocked")
+	}
+
+	var none *ChangesetDependencies
+	if none.Blocked(api1.ID) || len(none.Edges()) != 0 {
+		t.Error("nil dependencies are not empty")
+	}
+}
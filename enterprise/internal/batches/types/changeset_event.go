@@ -9,7 +9,9 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/inconshreveable/log15"
 
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
@@ -69,6 +71,14 @@ const (
 	// clearly convey that it only occurs when a request for changes has been dismissed.
 	ChangesetEventKindBitbucketServerDismissed ChangesetEventKind = "bitbucketserver:participant_status:unapproved"
 
+	ChangesetEventKindBitbucketCloudApproved              ChangesetEventKind = "bitbucketcloud:participant_status:approved"
+	ChangesetEventKindBitbucketCloudUnapproved            ChangesetEventKind = "bitbucketcloud:participant_status:unapproved"
+	ChangesetEventKindBitbucketCloudChangesRequested      ChangesetEventKind = "bitbucketcloud:participant_status:changes_requested"
+	ChangesetEventKindBitbucketCloudChangesRequestRemoved ChangesetEventKind = "bitbucketcloud:participant_status:changes_request_removed"
+	ChangesetEventKindBitbucketCloudDeclined              ChangesetEventKind = "bitbucketcloud:declined"
+	ChangesetEventKindBitbucketCloudMerged                ChangesetEventKind = "bitbucketcloud:merged"
+	ChangesetEventKindBitbucketCloudCommitStatus          ChangesetEventKind = "bitbucketcloud:commit_status"
+
 	ChangesetEventKindGitLabApproved             ChangesetEventKind = "gitlab:approved"
 	ChangesetEventKindGitLabClosed               ChangesetEventKind = "gitlab:closed"
 	ChangesetEventKindGitLabMerged               ChangesetEventKind = "gitlab:merged"
@@ -78,6 +88,16 @@ const (
 	ChangesetEventKindGitLabMarkWorkInProgress   ChangesetEventKind = "gitlab:mark_wip"
 	ChangesetEventKindGitLabUnmarkWorkInProgress ChangesetEventKind = "gitlab:unmark_wip"
 
+	ChangesetEventKindGerritMerged    ChangesetEventKind = "gerrit:merged"
+	ChangesetEventKindGerritAbandoned ChangesetEventKind = "gerrit:abandoned"
+	ChangesetEventKindGerritRestored  ChangesetEventKind = "gerrit:restored"
+	ChangesetEventKindGerritReviewed  ChangesetEventKind = "gerrit:reviewed"
+
+	// ChangesetEventKindBatchesAutoMerged is recorded by Sourcegraph, not the
+	// code host, when a changeset is merged by the auto-merge policy of its
+	// batch change.
+	ChangesetEventKindBatchesAutoMerged ChangesetEventKind = "batches:auto_merged"
+
 	ChangesetEventKindInvalid ChangesetEventKind = "invalid"
 )
 
@@ -93,6 +113,18 @@ type ChangesetEvent struct {
 	Metadata    interface{}
 }
 
+// AutoMergedEvent is the metadata of a ChangesetEventKindBatchesAutoMerged
+// event. It records on whose behalf and in which state the changeset was
+// merged, so that automatic merges can be audited.
+type AutoMergedEvent struct {
+	BatchChangeID int64                `json:"batchChangeID"`
+	UserID        int32                `json:"userID"`
+	Squash        bool                 `json:"squash"`
+	CheckState    ChangesetCheckState  `json:"checkState"`
+	ReviewState   ChangesetReviewState `json:"reviewState"`
+	MergedAt      time.Time            `json:"mergedAt"`
+}
+
 // Clone returns a clone of a ChangesetEvent.
 func (e *ChangesetEvent) Clone() *ChangesetEvent {
 	ee := *e
@@ -115,12 +147,18 @@ func (e *ChangesetEvent) ReviewAuthor() string {
 	case *bitbucketserver.ParticipantStatusEvent:
 		return meta.User.Name
 
+	case *bitbucketcloud.ParticipantStatusEvent:
+		return meta.User.UUID
+
 	case *gitlab.ReviewApprovedEvent:
 		return meta.Author.Username
 
 	case *gitlab.ReviewUnapprovedEvent:
 		return meta.Author.Username
 
+	case *gerrit.ReviewEvent:
+		return meta.Author.Username
+
 	default:
 		return ""
 	}
@@ -130,9 +168,13 @@ func (e *ChangesetEvent) ReviewAuthor() string {
 func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 	switch e.Kind {
 	case ChangesetEventKindBitbucketServerApproved,
+		ChangesetEventKindBitbucketCloudApproved,
 		ChangesetEventKindGitLabApproved:
 		return ChangesetReviewStateApproved, nil
 
+	case ChangesetEventKindBitbucketCloudChangesRequested:
+		return ChangesetReviewStateChangesRequested, nil
+
 	// BitbucketServer's "REVIEWED" activity is created when someone clicks
 	// the "Needs work" button in the UI, which is why we map it to "Changes Requested"
 	case ChangesetEventKindBitbucketServerReviewed:
@@ -152,9 +194,28 @@ func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 		}
 		return s, nil
 
+	case ChangesetEventKindGerritReviewed:
+		review, ok := e.Metadata.(*gerrit.ReviewEvent)
+		if !ok {
+			return "", errors.New("ChangesetEvent metadata event not ReviewEvent")
+		}
+
+		// Only the extremes of the Code-Review label approve or block a
+		// change. Any other vote takes back the previous one.
+		switch {
+		case review.Value >= 2:
+			return ChangesetReviewStateApproved, nil
+		case review.Value <= -2:
+			return ChangesetReviewStateChangesRequested, nil
+		default:
+			return ChangesetReviewStateDismissed, nil
+		}
+
 	case ChangesetEventKindGitHubReviewDismissed,
 		ChangesetEventKindBitbucketServerUnapproved,
 		ChangesetEventKindBitbucketServerDismissed,
+		ChangesetEventKindBitbucketCloudUnapproved,
+		ChangesetEventKindBitbucketCloudChangesRequestRemoved,
 		ChangesetEventKindGitLabUnapproved:
 		return ChangesetReviewStateDismissed, nil
 
@@ -221,6 +282,12 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		t = unixMilliToTime(int64(ev.CreatedDate))
 	case *bitbucketserver.CommitStatus:
 		t = unixMilliToTime(ev.Status.DateAdded)
+	case *bitbucketcloud.ParticipantStatusEvent:
+		t = ev.Date
+	case *bitbucketcloud.PullRequestStateEvent:
+		t = ev.Date
+	case *bitbucketcloud.PullRequestStatus:
+		t = ev.UpdatedOn
 	case *gitlab.ReviewApprovedEvent:
 		t = ev.CreatedAt.Time
 	case *gitlab.ReviewUnapprovedEvent:
@@ -235,11 +302,17 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		t = ev.CreatedAt.Time
 	case *gitlab.MergeRequestMergedEvent:
 		t = ev.CreatedAt.Time
+	case *gerrit.ChangeStateEvent:
+		t = ev.Date
+	case *gerrit.ReviewEvent:
+		t = ev.Date
 	case *gitlabwebhooks.PipelineEvent:
 		// These events do not inherently have timestamps from GitLab, so we
 		// fall back to the event record we created when we received the
 		// webhook.
 		t = e.CreatedAt
+	case *AutoMergedEvent:
+		t = ev.MergedAt
 	}
 
 	return t
@@ -602,6 +675,38 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) error {
 		// We always get the full event, so safe to replace it
 		*e = *o
 
+	case *bitbucketcloud.ParticipantStatusEvent:
+		o := o.Metadata.(*bitbucketcloud.ParticipantStatusEvent)
+		// We always get the full event, so safe to replace it
+		*e = *o
+
+	case *bitbucketcloud.PullRequestStateEvent:
+		o := o.Metadata.(*bitbucketcloud.PullRequestStateEvent)
+
+		if e.Date.IsZero() {
+			e.Date = o.Date
+		}
+
+		// The actor is only known from webhook events.
+		if e.Actor == (bitbucketcloud.Account{}) {
+			e.Actor = o.Actor
+		}
+
+	case *bitbucketcloud.PullRequestStatus:
+		o := o.Metadata.(*bitbucketcloud.PullRequestStatus)
+		// We always get the full status, so safe to replace it
+		*e = *o
+
+	case *gerrit.ChangeStateEvent:
+		o := o.Metadata.(*gerrit.ChangeStateEvent)
+		// We always get the full event, so safe to replace it
+		*e = *o
+
+	case *gerrit.ReviewEvent:
+		o := o.Metadata.(*gerrit.ReviewEvent)
+		// We always get the full event, so safe to replace it
+		*e = *o
+
 	case *github.CheckRun:
 		o := o.Metadata.(*github.CheckRun)
 		if e.Status == "" {
@@ -722,6 +827,10 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) error {
 		// We always get the full event, so safe to replace it
 		*e = *o
 
+	case *AutoMergedEvent:
+		o := o.Metadata.(*AutoMergedEvent)
+		*e = *o
+
 	default:
 		return errors.Errorf("unknown changeset event metadata %T", e)
 	}
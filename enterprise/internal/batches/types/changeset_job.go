@@ -59,6 +59,9 @@ type ChangesetJobReenqueuePayload struct{}
 
 type ChangesetJobMergePayload struct {
 	Squash bool `json:"squash,omitempty"`
+	// AutoMerge is true if the job was created by the auto-merge policy of the
+	// batch change, rather than by a user.
+	AutoMerge bool `json:"autoMerge,omitempty"`
 }
 
 type ChangesetJobClosePayload struct{}
@@ -184,6 +184,61 @@ func TestChangeset_Title(t *testing.T) {
 	})
 }
 
+func TestChangeset_Mergeability(t *testing.T) {
+	for name, tc := range map[string]struct {
+		meta interface{}
+		want ChangesetMergeability
+	}{
+		"GitHub mergeable": {
+			meta: &github.PullRequest{Mergeable: "MERGEABLE"},
+			want: ChangesetMergeabilityMergeable,
+		},
+		"GitHub conflicting": {
+			meta: &github.PullRequest{Mergeable: "CONFLICTING"},
+			want: ChangesetMergeabilityConflicting,
+		},
+		"GitHub not computed": {
+			meta: &github.PullRequest{Mergeable: "UNKNOWN"},
+			want: ChangesetMergeabilityUnknown,
+		},
+		"bitbucketserver conflicted": {
+			meta: &bitbucketserver.PullRequest{Properties: &bitbucketserver.PullRequestProperties{
+				MergeResult: &bitbucketserver.PullRequestMergeResult{Outcome: "CONFLICTED", Current: true},
+			}},
+			want: ChangesetMergeabilityConflicting,
+		},
+		"bitbucketserver outdated merge result": {
+			meta: &bitbucketserver.PullRequest{Properties: &bitbucketserver.PullRequestProperties{
+				MergeResult: &bitbucketserver.PullRequestMergeResult{Outcome: "CONFLICTED", Current: false},
+			}},
+			want: ChangesetMergeabilityUnknown,
+		},
+		"bitbucketserver without merge result": {
+			meta: &bitbucketserver.PullRequest{},
+			want: ChangesetMergeabilityUnknown,
+		},
+		"GitLab mergeable": {
+			meta: &gitlab.MergeRequest{MergeStatus: "can_be_merged"},
+			want: ChangesetMergeabilityMergeable,
+		},
+		"GitLab conflicting": {
+			meta: &gitlab.MergeRequest{MergeStatus: "cannot_be_merged", HasConflicts: true},
+			want: ChangesetMergeabilityConflicting,
+		},
+		"GitLab unchecked": {
+			meta: &gitlab.MergeRequest{MergeStatus: "unchecked", HasConflicts: true},
+			want: ChangesetMergeabilityUnknown,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := &Changeset{Metadata: tc.meta}
+			if have := c.Mergeability(); have != tc.want {
+				t.Errorf("unexpected mergeability: have %s; want %s", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestChangeset_ExternalCreatedAt(t *testing.T) {
 	want := time.Unix(10, 0)
 	for name, meta := range map[string]interface{}{
@@ -157,6 +157,56 @@ func TestConfiguration_Estimate(t *testing.T) {
 	})
 }
 
+func TestConfiguration_IsOpen(t *testing.T) {
+	// Thursday, 2021-01-07 at 12:00 UTC.
+	now := time.Date(2021, 1, 7, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		cfg  *Configuration
+		want bool
+	}{
+		"no windows": {
+			cfg:  &Configuration{},
+			want: true,
+		},
+		"open window": {
+			cfg: &Configuration{windows: []Window{
+				{days: newWeekdaySet(time.Thursday), rate: makeUnlimitedRate()},
+			}},
+			want: true,
+		},
+		"zero rate window": {
+			cfg: &Configuration{windows: []Window{
+				{days: newWeekdaySet(time.Thursday), rate: rate{n: 0}},
+			}},
+			want: false,
+		},
+		"window on another day": {
+			cfg: &Configuration{windows: []Window{
+				{days: newWeekdaySet(time.Friday), rate: makeUnlimitedRate()},
+			}},
+			want: false,
+		},
+		"window at another time": {
+			cfg: &Configuration{windows: []Window{
+				{
+					days:  newWeekdaySet(time.Thursday),
+					start: timeOfDayPtr(14, 0),
+					end:   timeOfDayPtr(16, 0),
+					rate:  makeUnlimitedRate(),
+				},
+			}},
+			want: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := tc.cfg.IsOpen(now); have != tc.want {
+				t.Errorf("unexpected result: have=%v want=%v", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestConfiguration_Schedule(t *testing.T) {
 	// We have other tests to test the actual implementation of scheduleAt();
 	// this is purely to ensure that we do the special case handling of not
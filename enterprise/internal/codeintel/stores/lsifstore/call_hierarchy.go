@@ -0,0 +1,397 @@
+package lsifstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// MaximumCallHierarchyLocations is the maximum number of callee definition locations resolved for
+// an OutgoingCalls request.
+const MaximumCallHierarchyLocations = 10000
+
+// CallHierarchyItems returns the callable symbols defined at the given position. Only ranges tagged
+// as definitions with a full range by the indexer can be the source of a call hierarchy item.
+func (s *Store) CallHierarchyItems(ctx context.Context, bundleID int, path string, line, character int) (_ []CallHierarchyItem, err error) {
+	ctx, trace, endObservation := s.operations.callHierarchyItems.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.String("path", path),
+		log.Int("line", line),
+		log.Int("character", character),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	documentData, exists, err := s.scanFirstDocumentData(s.Store.Query(ctx, sqlf.Sprintf(callHierarchyDocumentQuery, bundleID, path)))
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	trace.Log(log.Int("numRanges", len(documentData.Document.Ranges)))
+	ranges := precise.FindRanges(documentData.Document.Ranges, line, character)
+	trace.Log(log.Int("numIntersectingRanges", len(ranges)))
+
+	var items []CallHierarchyItem
+	for _, r := range ranges {
+		if isCallable(r) {
+			items = append(items, newCallHierarchyItem(bundleID, path, r))
+		}
+	}
+
+	return items, nil
+}
+
+// OutgoingCalls returns the calls made from the body of the callable symbol defined at the given
+// position, grouped by callee. A call is a range enclosed by the full range of the caller that has
+// a definition result.
+//
+// Calls to symbols that are not defined within this bundle cannot be resolved here. The locations of
+// such calls that have an attached import moniker are returned separately, so that the caller can
+// resolve them via a moniker search over other indexes.
+func (s *Store) OutgoingCalls(ctx context.Context, bundleID int, path string, line, character int) (_ []CallHierarchyCall, _ []Location, err error) {
+	ctx, trace, endObservation := s.operations.outgoingCalls.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.String("path", path),
+		log.Int("line", line),
+		log.Int("character", character),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	documentData, exists, err := s.scanFirstDocumentData(s.Store.Query(ctx, sqlf.Sprintf(callHierarchyDocumentQuery, bundleID, path)))
+	if err != nil || !exists {
+		return nil, nil, err
+	}
+	document := documentData.Document
+
+	caller, ok := innermostCallable(precise.FindRanges(document.Ranges, line, character))
+	if !ok {
+		return nil, nil, nil
+	}
+
+	callSites := callSitesWithin(document.Ranges, caller)
+	trace.Log(log.Int("numCallSites", len(callSites)))
+
+	definitionResultIDs := extractResultIDs(callSites, func(r precise.RangeData) precise.ID { return r.DefinitionResultID })
+	definitionLocations, _, err := s.locations(ctx, bundleID, definitionResultIDs, MaximumCallHierarchyLocations, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Read the documents containing the callee definitions so that we can determine the
+	// names, kinds, and full ranges of the callees.
+	definitionDocuments, err := s.documentsByPath(ctx, bundleID, pathsFromLocationMap(definitionLocations))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	calls := newCallHierarchyCallSet()
+	var unresolved []Location
+	for _, r := range callSites {
+		callSite := Location{
+			DumpID: bundleID,
+			Path:   path,
+			Range:  newRange(r.StartLine, r.StartCharacter, r.EndLine, r.EndCharacter),
+		}
+
+		definitions := definitionLocations[r.DefinitionResultID]
+		if len(definitions) == 0 {
+			if hasMonikerOfKind(document, r, "import") {
+				unresolved = append(unresolved, callSite)
+			}
+			continue
+		}
+
+		for _, definition := range definitions {
+			for _, callee := range callablesAt(definitionDocuments[definition.Path].Ranges, definition.Range) {
+				calls.add(newCallHierarchyItem(bundleID, definition.Path, callee), callSite)
+			}
+		}
+	}
+	trace.Log(
+		log.Int("numCalls", len(calls.calls)),
+		log.Int("numUnresolvedCallSites", len(unresolved)),
+	)
+
+	return calls.sorted(), unresolved, nil
+}
+
+// IncomingCalls groups the given reference locations by the innermost callable symbol whose full
+// range encloses them. References that do not occur within the body of a callable symbol (e.g.,
+// the definition of the symbol, or a reference from a top-level declaration) are skipped. All of
+// the given locations must belong to this bundle.
+func (s *Store) IncomingCalls(ctx context.Context, bundleID int, locations []Location) (_ []CallHierarchyCall, err error) {
+	ctx, trace, endObservation := s.operations.incomingCalls.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.Int("numLocations", len(locations)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	documents, err := s.documentsByPath(ctx, bundleID, pathsFromLocations(locations))
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numDocuments", len(documents)))
+
+	calls := newCallHierarchyCallSet()
+	for _, location := range locations {
+		document, ok := documents[location.Path]
+		if !ok {
+			continue
+		}
+
+		if len(callablesAt(document.Ranges, location.Range)) > 0 {
+			// Skip the definition of the symbol itself
+			continue
+		}
+
+		if caller, ok := enclosingCallable(document.Ranges, location.Range.Start); ok {
+			calls.add(newCallHierarchyItem(bundleID, location.Path, caller), location)
+		}
+	}
+	trace.Log(log.Int("numCalls", len(calls.calls)))
+
+	return calls.sorted(), nil
+}
+
+const callHierarchyDocumentQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/call_hierarchy.go:{CallHierarchyItems,OutgoingCalls}
+SELECT
+	dump_id,
+	path,
+	data,
+	ranges,
+	NULL AS hovers,
+	monikers,
+	NULL AS packages,
+	NULL AS diagnostics
+FROM
+	lsif_data_documents
+WHERE
+	dump_id = %s AND
+	path = %s
+LIMIT 1
+`
+
+// documentsByPath returns the range data of the documents with the given paths.
+func (s *Store) documentsByPath(ctx context.Context, bundleID int, paths []string) (map[string]precise.DocumentData, error) {
+	documents := make(map[string]precise.DocumentData, len(paths))
+	visitDocuments := s.makeDocumentVisitor(func(path string, document precise.DocumentData) {
+		documents[path] = document
+	})
+
+	for len(paths) > 0 {
+		var batch []string
+		if len(paths) <= documentBatchSize {
+			batch, paths = paths, nil
+		} else {
+			batch, paths = paths[:documentBatchSize], paths[documentBatchSize:]
+		}
+
+		pathQueries := make([]*sqlf.Query, 0, len(batch))
+		for _, path := range batch {
+			pathQueries = append(pathQueries, sqlf.Sprintf("%s", path))
+		}
+		if err := visitDocuments(s.Store.Query(ctx, sqlf.Sprintf(readRangesFromDocumentsQuery, bundleID, sqlf.Join(pathQueries, ",")))); err != nil {
+			return nil, err
+		}
+	}
+
+	return documents, nil
+}
+
+// isCallable returns true if the given range is the definition of a function, method, or constructor.
+func isCallable(r precise.RangeData) bool {
+	if r.Symbol == nil {
+		return false
+	}
+
+	switch r.Symbol.Kind {
+	case protocol.Function, protocol.Method, protocol.Constructor:
+		return true
+	}
+
+	return false
+}
+
+// innermostCallable returns the last callable range of the given "outside-in" ordered ranges.
+func innermostCallable(ranges []precise.RangeData) (precise.RangeData, bool) {
+	for i := len(ranges) - 1; i >= 0; i-- {
+		if isCallable(ranges[i]) {
+			return ranges[i], true
+		}
+	}
+
+	return precise.RangeData{}, false
+}
+
+// enclosingCallable returns the callable range with the innermost full range that encloses the
+// given position.
+func enclosingCallable(ranges map[precise.ID]precise.RangeData, position Position) (precise.RangeData, bool) {
+	var enclosing precise.RangeData
+	found := false
+
+	for _, r := range ranges {
+		if !isCallable(r) || !symbolContains(r.Symbol, position.Line, position.Character) {
+			continue
+		}
+
+		// Nested definitions start after their enclosing definition
+		if !found || compareSymbolStarts(enclosing.Symbol, r.Symbol) < 0 {
+			enclosing = r
+			found = true
+		}
+	}
+
+	return enclosing, found
+}
+
+// callSitesWithin returns the ranges enclosed by the full range of the given caller which have a
+// definition result, in reading order. Ranges that are themselves definitions are not call sites.
+func callSitesWithin(ranges map[precise.ID]precise.RangeData, caller precise.RangeData) []precise.RangeData {
+	var callSites []precise.RangeData
+	for _, r := range ranges {
+		if r.Symbol != nil || r.DefinitionResultID == "" {
+			continue
+		}
+
+		if symbolContains(caller.Symbol, r.StartLine, r.StartCharacter) && symbolContains(caller.Symbol, r.EndLine, r.EndCharacter) {
+			callSites = append(callSites, r)
+		}
+	}
+
+	sort.Slice(callSites, func(i, j int) bool {
+		return precise.CompareRanges(callSites[i], callSites[j]) < 0
+	})
+
+	return callSites
+}
+
+// callablesAt returns the callable ranges of the given document which match the given range exactly.
+func callablesAt(ranges map[precise.ID]precise.RangeData, rn Range) []precise.RangeData {
+	var callables []precise.RangeData
+	for _, r := range precise.FindRanges(ranges, rn.Start.Line, rn.Start.Character) {
+		if isCallable(r) && newRange(r.StartLine, r.StartCharacter, r.EndLine, r.EndCharacter) == rn {
+			callables = append(callables, r)
+		}
+	}
+
+	return callables
+}
+
+// hasMonikerOfKind returns true if the given range has an attached moniker of the given kind.
+func hasMonikerOfKind(document precise.DocumentData, r precise.RangeData, kind string) bool {
+	for _, monikerID := range r.MonikerIDs {
+		if moniker, ok := document.Monikers[monikerID]; ok && moniker.Kind == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// symbolContains returns true if the full range of the given symbol contains the given position.
+func symbolContains(symbol *precise.SymbolData, line, character int) bool {
+	if line < symbol.FullStartLine || (line == symbol.FullStartLine && character < symbol.FullStartCharacter) {
+		return false
+	}
+	if line > symbol.FullEndLine || (line == symbol.FullEndLine && character > symbol.FullEndCharacter) {
+		return false
+	}
+
+	return true
+}
+
+// compareSymbolStarts returns a negative value if the full range of a starts before the full
+// range of b, a positive value if it starts after, and zero otherwise.
+func compareSymbolStarts(a, b *precise.SymbolData) int {
+	if cmp := a.FullStartLine - b.FullStartLine; cmp != 0 {
+		return cmp
+	}
+
+	return a.FullStartCharacter - b.FullStartCharacter
+}
+
+func newCallHierarchyItem(bundleID int, path string, r precise.RangeData) CallHierarchyItem {
+	return CallHierarchyItem{
+		Location: Location{
+			DumpID: bundleID,
+			Path:   path,
+			Range:  newRange(r.StartLine, r.StartCharacter, r.EndLine, r.EndCharacter),
+		},
+		Name:      r.Symbol.Name,
+		Kind:      r.Symbol.Kind,
+		FullRange: newRange(r.Symbol.FullStartLine, r.Symbol.FullStartCharacter, r.Symbol.FullEndLine, r.Symbol.FullEndCharacter),
+	}
+}
+
+// callHierarchyCallSet groups call locations by the caller or callee item.
+type callHierarchyCallSet struct {
+	calls   []CallHierarchyCall
+	indexes map[Location]int
+}
+
+func newCallHierarchyCallSet() *callHierarchyCallSet {
+	return &callHierarchyCallSet{indexes: map[Location]int{}}
+}
+
+// add appends the given location to the call of the given item.
+func (s *callHierarchyCallSet) add(item CallHierarchyItem, location Location) {
+	index, ok := s.indexes[item.Location]
+	if !ok {
+		index = len(s.calls)
+		s.indexes[item.Location] = index
+		s.calls = append(s.calls, CallHierarchyCall{Item: item})
+	}
+
+	s.calls[index].Locations = append(s.calls[index].Locations, location)
+}
+
+// sorted returns the calls ordered by the location of their item. The locations of each call
+// are sorted by document, then by offset within a document.
+func (s *callHierarchyCallSet) sorted() []CallHierarchyCall {
+	for _, call := range s.calls {
+		sortLocations(call.Locations)
+	}
+
+	sort.Slice(s.calls, func(i, j int) bool {
+		if s.calls[i].Item.Path == s.calls[j].Item.Path {
+			return compareBundleRanges(s.calls[i].Item.Range, s.calls[j].Item.Range)
+		}
+
+		return strings.Compare(s.calls[i].Item.Path, s.calls[j].Item.Path) < 0
+	})
+
+	return s.calls
+}
+
+// pathsFromLocationMap returns a deduplicated and sorted set of document paths present in the given map.
+func pathsFromLocationMap(locationsByResultID map[precise.ID][]Location) []string {
+	var locations []Location
+	for _, ls := range locationsByResultID {
+		locations = append(locations, ls...)
+	}
+
+	return pathsFromLocations(locations)
+}
+
+// pathsFromLocations returns a deduplicated and sorted set of document paths of the given locations.
+func pathsFromLocations(locations []Location) []string {
+	pathMap := map[string]struct{}{}
+	for _, location := range locations {
+		pathMap[location.Path] = struct{}{}
+	}
+
+	paths := make([]string, 0, len(pathMap))
+	for path := range pathMap {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
@@ -0,0 +1,140 @@
+package lsifstore
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// callHierarchyTestRanges models the following document:
+//
+//	func outer() {    // 0
+//	    inner := func() {
+//	        helper()  // 2
+//	    }
+//	    helper()      // 4
+//	    x := 1        // 5
+//	}                 // 6
+//	var y = helper()  // 7
+//	func helper() {}  // 8
+var callHierarchyTestRanges = map[precise.ID]precise.RangeData{
+	"outer": {
+		StartLine: 0, StartCharacter: 5, EndLine: 0, EndCharacter: 10,
+		DefinitionResultID: "d1",
+		Symbol:             &precise.SymbolData{Name: "outer", Kind: protocol.Function, FullStartLine: 0, FullEndLine: 6, FullEndCharacter: 1},
+	},
+	"inner": {
+		StartLine: 1, StartCharacter: 4, EndLine: 1, EndCharacter: 9,
+		DefinitionResultID: "d2",
+		Symbol:             &precise.SymbolData{Name: "inner", Kind: protocol.Function, FullStartLine: 1, FullStartCharacter: 4, FullEndLine: 3, FullEndCharacter: 5},
+	},
+	"call1": {StartLine: 2, StartCharacter: 8, EndLine: 2, EndCharacter: 14, DefinitionResultID: "d3"},
+	"call2": {StartLine: 4, StartCharacter: 4, EndLine: 4, EndCharacter: 10, DefinitionResultID: "d3"},
+	"x": {
+		StartLine: 5, StartCharacter: 4, EndLine: 5, EndCharacter: 5,
+		DefinitionResultID: "d4",
+		Symbol:             &precise.SymbolData{Name: "x", Kind: protocol.Variable, FullStartLine: 5, FullStartCharacter: 4, FullEndLine: 5, FullEndCharacter: 10},
+	},
+	"call3": {StartLine: 7, StartCharacter: 8, EndLine: 7, EndCharacter: 14, DefinitionResultID: "d3"},
+	"helper": {
+		StartLine: 8, StartCharacter: 5, EndLine: 8, EndCharacter: 11,
+		DefinitionResultID: "d3",
+		Symbol:             &precise.SymbolData{Name: "helper", Kind: protocol.Function, FullStartLine: 8, FullEndLine: 8, FullEndCharacter: 16},
+	},
+}
+
+func TestCallSitesWithin(t *testing.T) {
+	ranges := callHierarchyTestRanges
+
+	expected := []precise.RangeData{ranges["call1"], ranges["call2"]}
+	if diff := cmp.Diff(expected, callSitesWithin(ranges, ranges["outer"])); diff != "" {
+		t.Errorf("unexpected call sites (-want +got):\n%s", diff)
+	}
+
+	expected = []precise.RangeData{ranges["call1"]}
+	if diff := cmp.Diff(expected, callSitesWithin(ranges, ranges["inner"])); diff != "" {
+		t.Errorf("unexpected call sites (-want +got):\n%s", diff)
+	}
+}
+
+func TestEnclosingCallable(t *testing.T) {
+	ranges := callHierarchyTestRanges
+
+	testCases := []struct {
+		position Position
+		expected string
+	}{
+		{Position{Line: 2, Character: 8}, "inner"},
+		{Position{Line: 4, Character: 4}, "outer"},
+		{Position{Line: 7, Character: 8}, ""},
+	}
+
+	for _, testCase := range testCases {
+		caller, ok := enclosingCallable(ranges, testCase.position)
+		if testCase.expected == "" {
+			if ok {
+				t.Errorf("unexpected caller of %v: %s", testCase.position, caller.Symbol.Name)
+			}
+			continue
+		}
+
+		if !ok || caller.Symbol.Name != testCase.expected {
+			t.Errorf("unexpected caller of %v: want %s, got %v", testCase.position, testCase.expected, caller.Symbol)
+		}
+	}
+}
+
+func TestCallablesAt(t *testing.T) {
+	ranges := callHierarchyTestRanges
+
+	if diff := cmp.Diff([]precise.RangeData{ranges["helper"]}, callablesAt(ranges, newRange(8, 5, 8, 11))); diff != "" {
+		t.Errorf("unexpected callables (-want +got):\n%s", diff)
+	}
+	if callables := callablesAt(ranges, newRange(5, 4, 5, 5)); len(callables) != 0 {
+		t.Errorf("unexpected callables at variable definition: %v", callables)
+	}
+	if callables := callablesAt(ranges, newRange(8, 5, 8, 9)); len(callables) != 0 {
+		t.Errorf("unexpected callables at partially overlapping range: %v", callables)
+	}
+}
+
+func TestCallHierarchyCallSet(t *testing.T) {
+	ranges := callHierarchyTestRanges
+	helper := newCallHierarchyItem(42, "b.go", ranges["helper"])
+	outer := newCallHierarchyItem(42, "a.go", ranges["outer"])
+
+	calls := newCallHierarchyCallSet()
+	calls.add(helper, Location{DumpID: 42, Path: "a.go", Range: newRange(4, 4, 4, 10)})
+	calls.add(outer, Location{DumpID: 42, Path: "a.go", Range: newRange(9, 0, 9, 5)})
+	calls.add(helper, Location{DumpID: 42, Path: "a.go", Range: newRange(2, 8, 2, 14)})
+
+	expected := []CallHierarchyCall{
+		{
+			Item: CallHierarchyItem{
+				Location:  Location{DumpID: 42, Path: "a.go", Range: newRange(0, 5, 0, 10)},
+				Name:      "outer",
+				Kind:      protocol.Function,
+				FullRange: newRange(0, 0, 6, 1),
+			},
+			Locations: []Location{{DumpID: 42, Path: "a.go", Range: newRange(9, 0, 9, 5)}},
+		},
+		{
+			Item: CallHierarchyItem{
+				Location:  Location{DumpID: 42, Path: "b.go", Range: newRange(8, 5, 8, 11)},
+				Name:      "helper",
+				Kind:      protocol.Function,
+				FullRange: newRange(8, 0, 8, 16),
+			},
+			Locations: []Location{
+				{DumpID: 42, Path: "a.go", Range: newRange(2, 8, 2, 14)},
+				{DumpID: 42, Path: "a.go", Range: newRange(4, 4, 4, 10)},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, calls.sorted()); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 type operations struct {
 	bulkMonikerResults              *observation.Operation
+	callHierarchyItems              *observation.Operation
 	clear                           *observation.Operation
 	definitions                     *observation.Operation
 	deleteOldSearchRecords          *observation.Operation
@@ -26,12 +27,15 @@ type operations struct {
 	exists                          *observation.Operation
 	hover                           *observation.Operation
 	implementations                 *observation.Operation
+	incomingCalls                   *observation.Operation
 	monikerResults                  *observation.Operation
 	monikersByPosition              *observation.Operation
+	outgoingCalls                   *observation.Operation
 	packageInformation              *observation.Operation
 	ranges                          *observation.Operation
 	references                      *observation.Operation
 	stencil                         *observation.Operation
+	typeDefinitions                 *observation.Operation
 	writeDefinitions                *observation.Operation
 	writeDocumentationMappings      *observation.Operation
 	writeDocumentationPages         *observation.Operation
@@ -75,6 +79,7 @@ func newOperations(observationContext *observation.Context) *operations {
 
 	return &operations{
 		bulkMonikerResults:              op("BulkMonikerResults"),
+		callHierarchyItems:              op("CallHierarchyItems"),
 		clear:                           op("Clear"),
 		definitions:                     op("Definitions"),
 		deleteOldSearchRecords:          op("DeleteOldSearchRecords"),
@@ -92,12 +97,15 @@ func newOperations(observationContext *observation.Context) *operations {
 		exists:                          op("Exists"),
 		hover:                           op("Hover"),
 		implementations:                 op("Implementations"),
+		incomingCalls:                   op("IncomingCalls"),
 		monikerResults:                  op("MonikerResults"),
 		monikersByPosition:              op("MonikersByPosition"),
+		outgoingCalls:                   op("OutgoingCalls"),
 		packageInformation:              op("PackageInformation"),
 		ranges:                          op("Ranges"),
 		references:                      op("References"),
 		stencil:                         op("Stencil"),
+		typeDefinitions:                 op("TypeDefinitions"),
 		writeDefinitions:                op("WriteDefinitions"),
 		writeDocumentationMappings:      op("WriteDocumentationMappings"),
 		writeDocumentationPages:         op("WriteDocumentationPages"),
@@ -1,6 +1,9 @@
 package lsifstore
 
-import "github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+import (
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
 
 // Location is an LSP-like location scoped to a dump.
 type Location struct {
@@ -38,3 +41,19 @@ type CodeIntelligenceRange struct {
 	HoverText           string
 	DocumentationPathID string
 }
+
+// CallHierarchyItem is a callable symbol defined within a particular dump. The location denotes
+// the name of the symbol, and the full range encloses its entire definition (including the body).
+type CallHierarchyItem struct {
+	Location
+	Name      string
+	Kind      protocol.SymbolKind
+	FullRange Range
+}
+
+// CallHierarchyCall pairs a caller or callee with the locations of the calls between the two
+// symbols. The locations are always within the body of the caller.
+type CallHierarchyCall struct {
+	Item      CallHierarchyItem
+	Locations []Location
+}
@@ -34,6 +34,12 @@ const gqlSearchQuery = `query CodeMonitorSearch(
 			results {
 				__typename
 				... on FileMatch {
+					repository {
+						name
+					}
+					file {
+						path
+					}
 					limitHit
 					lineMatches {
 						preview
@@ -90,6 +96,9 @@ const gqlSearchQuery = `query CodeMonitorSearch(
 						message
 					}
 				}
+				... on Repository {
+					name
+				}
 			}
 			alert {
 				title
@@ -112,6 +121,7 @@ type gqlSearchResponse struct {
 		Search struct {
 			Results struct {
 				ApproximateResultCount string
+				LimitHit               bool
 				Cloning                []*api.Repo
 				Timedout               []*api.Repo
 				Results                []interface{}
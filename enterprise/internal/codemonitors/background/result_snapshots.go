@@ -0,0 +1,190 @@
+package background
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"runtime"
+	"sort"
+
+	"github.com/cockroachdb/errors"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// resultKeysByRepo returns the snapshot keys of the given search results,
+// grouped by repository and sorted.
+func resultKeysByRepo(v *gqlSearchResponse) (map[api.RepoName][]string, error) {
+	keys := make(map[api.RepoName]map[string]struct{})
+	for _, r := range v.Data.Search.Results.Results {
+		k, err := extractResultKey(r)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := keys[k.Repo]; !ok {
+			keys[k.Repo] = make(map[string]struct{})
+		}
+		for _, key := range snapshotKeys(k, lineMatchPreviews(r)) {
+			keys[k.Repo][key] = struct{}{}
+		}
+	}
+
+	byRepo := make(map[api.RepoName][]string, len(keys))
+	for repo, set := range keys {
+		sorted := make([]string, 0, len(set))
+		for k := range set {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		byRepo[repo] = sorted
+	}
+	return byRepo, nil
+}
+
+// snapshotKey serializes the given key for storage in a result snapshot. The
+// repository is left out, since snapshots are stored per repository.
+func snapshotKey(k result.Key) string {
+	return fmt.Sprintf("%s:%s:%s", k.Rev, k.Commit, k.Path)
+}
+
+// snapshotKeys returns the snapshot keys of a search result with the given key
+// and matched lines. Every matched line gets its own key, made up of the key of
+// the result and a hash of the line, so that a new match in a file that
+// matched before is reported too. Lines are identified by their content
+// rather than their line number, so that lines that only moved aren't
+// reported as new. Identical lines are numbered to tell them apart.
+func snapshotKeys(k result.Key, lines []string) []string {
+	if len(lines) == 0 {
+		return []string{snapshotKey(k)}
+	}
+
+	keys := make([]string, 0, len(lines))
+	occurrences := make(map[string]int, len(lines))
+	for _, line := range lines {
+		sum := sha256.Sum256([]byte(line))
+		hash := hex.EncodeToString(sum[:8])
+		keys = append(keys, fmt.Sprintf("%s:%s:%d", snapshotKey(k), hash, occurrences[hash]))
+		occurrences[hash]++
+	}
+	return keys
+}
+
+// searchLimitHit reports whether the given search results are incomplete
+// because the search or one of its file matches hit a result limit.
+func searchLimitHit(v *gqlSearchResponse) bool {
+	if v.Data.Search.Results.LimitHit {
+		return true
+	}
+	for _, r := range v.Data.Search.Results.Results {
+		if m, ok := r.(map[string]interface{}); ok && m["__typename"] == "FileMatch" && m["limitHit"] == true {
+			return true
+		}
+	}
+	return false
+}
+
+// lineMatchPreviews returns the previews of the line matches of the given
+// search result, if it's a file match.
+func lineMatchPreviews(r interface{}) []string {
+	m, ok := r.(map[string]interface{})
+	if !ok || m["__typename"] != "FileMatch" {
+		return nil
+	}
+	lineMatches, _ := m["lineMatches"].([]interface{})
+	previews := make([]string, 0, len(lineMatches))
+	for _, lm := range lineMatches {
+		if lm, ok := lm.(map[string]interface{}); ok {
+			preview, _ := lm["preview"].(string)
+			previews = append(previews, preview)
+		}
+	}
+	return previews
+}
+
+// newResultSnapshots returns the result snapshots to store for the current
+// run of a content query, along with the number of results that weren't in
+// the previous snapshots. Repositories that were cloning or timed out during
+// the search keep their previous snapshot, so that their results aren't
+// reported as new once they're searchable again.
+func newResultSnapshots(previous []*edb.ResultSnapshot, current map[api.RepoName][]string, unsearched []*api.Repo) (snapshots []*edb.ResultSnapshot, numNew int) {
+	previousByRepo := make(map[api.RepoName]*edb.ResultSnapshot, len(previous))
+	for _, s := range previous {
+		previousByRepo[api.RepoName(s.RepoName)] = s
+	}
+
+	for repo, keys := range current {
+		seen := make(map[string]struct{})
+		if s, ok := previousByRepo[repo]; ok {
+			for _, k := range s.ResultKeys {
+				seen[k] = struct{}{}
+			}
+		}
+		for _, k := range keys {
+			if _, ok := seen[k]; !ok {
+				numNew++
+			}
+		}
+		snapshots = append(snapshots, &edb.ResultSnapshot{RepoName: string(repo), ResultKeys: keys})
+	}
+
+	for _, repo := range unsearched {
+		if repo == nil {
+			continue
+		}
+		if _, ok := current[repo.Name]; ok {
+			continue
+		}
+		if s, ok := previousByRepo[repo.Name]; ok {
+			snapshots = append(snapshots, s)
+		}
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].RepoName < snapshots[j].RepoName })
+	return snapshots, numNew
+}
+
+// extractResultKey extracts the key of the given search result. The commit of
+// file matches is left out on purpose: it changes whenever the repository
+// does, which would make every file match look new.
+func extractResultKey(r interface{}) (k result.Key, err error) {
+	// Use recover because we assume the data structure here a lot, for less
+	// error checking.
+	defer func() {
+		if r := recover(); r != nil {
+			// Same as net/http
+			const size = 64 << 10
+			buf := make([]byte, size)
+			buf = buf[:runtime.Stack(buf, false)]
+			log.Printf("failed to extract key from search result: %v\n%s", r, buf)
+			err = errors.Errorf("failed to extract key from search result")
+		}
+	}()
+
+	m := r.(map[string]interface{})
+	typeName := m["__typename"].(string)
+	switch typeName {
+	case "FileMatch":
+		repo := m["repository"].(map[string]interface{})
+		file := m["file"].(map[string]interface{})
+		return result.Key{
+			Repo: api.RepoName(repo["name"].(string)),
+			Path: file["path"].(string),
+		}, nil
+	case "CommitSearchResult":
+		commit := m["commit"].(map[string]interface{})
+		repo := commit["repository"].(map[string]interface{})
+		return result.Key{
+			Repo:   api.RepoName(repo["name"].(string)),
+			Commit: api.CommitID(commit["oid"].(string)),
+		}, nil
+	case "Repository":
+		return result.Key{
+			Repo: api.RepoName(m["name"].(string)),
+		}, nil
+	default:
+		return result.Key{}, errors.Errorf("unexpected result __typename %q", typeName)
+	}
+}
@@ -0,0 +1,111 @@
+package background
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestResultKeysByRepo(t *testing.T) {
+	t.Parallel()
+
+	var v gqlSearchResponse
+	err := json.Unmarshal([]byte(`{"data": {"search": {"results": {"results": [
+		{"__typename": "FileMatch", "repository": {"name": "github.com/a/a"}, "file": {"path": "secrets.env"}, "lineMatches": [
+			{"preview": "AWS_SECRET_ACCESS_KEY=foo", "lineNumber": 1},
+			{"preview": "AWS_SECRET_ACCESS_KEY=bar", "lineNumber": 4},
+			{"preview": "AWS_SECRET_ACCESS_KEY=foo", "lineNumber": 9}
+		]},
+		{"__typename": "FileMatch", "repository": {"name": "github.com/a/a"}, "file": {"path": "config.yaml"}},
+		{"__typename": "FileMatch", "repository": {"name": "github.com/a/a"}, "file": {"path": "config.yaml"}},
+		{"__typename": "CommitSearchResult", "commit": {"repository": {"name": "github.com/b/b"}, "oid": "deadbeef"}},
+		{"__typename": "Repository", "name": "github.com/c/c"}
+	]}}}}`), &v)
+	require.NoError(t, err)
+
+	got, err := resultKeysByRepo(&v)
+	require.NoError(t, err)
+
+	want := map[api.RepoName][]string{
+		"github.com/a/a": {
+			"::config.yaml",
+			"::secrets.env:08fd58d844be4824:0",
+			"::secrets.env:690f502ba979d0d5:0",
+			"::secrets.env:690f502ba979d0d5:1",
+		},
+		"github.com/b/b": {":deadbeef:"},
+		"github.com/c/c": {"::"},
+	}
+	require.Equal(t, want, got)
+
+	t.Run("unexpected result type", func(t *testing.T) {
+		v.Data.Search.Results.Results = []interface{}{map[string]interface{}{"__typename": "Symbol"}}
+		_, err := resultKeysByRepo(&v)
+		require.Error(t, err)
+	})
+}
+
+func TestSearchLimitHit(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		body string
+		want bool
+	}{
+		"complete": {
+			body: `{"data": {"search": {"results": {"results": [{"__typename": "FileMatch", "limitHit": false}]}}}}`,
+			want: false,
+		},
+		"search limit hit": {
+			body: `{"data": {"search": {"results": {"limitHit": true, "results": []}}}}`,
+			want: true,
+		},
+		"file match limit hit": {
+			body: `{"data": {"search": {"results": {"results": [{"__typename": "FileMatch", "limitHit": true}]}}}}`,
+			want: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var v gqlSearchResponse
+			require.NoError(t, json.Unmarshal([]byte(tc.body), &v))
+			require.Equal(t, tc.want, searchLimitHit(&v))
+		})
+	}
+}
+
+func TestNewResultSnapshots(t *testing.T) {
+	t.Parallel()
+
+	previous := []*edb.ResultSnapshot{
+		{QueryID: 1, RepoName: "github.com/a/a", ResultKeys: []string{"::config.yaml"}},
+		{QueryID: 1, RepoName: "github.com/b/b", ResultKeys: []string{"::main.go"}},
+		{QueryID: 1, RepoName: "github.com/c/c", ResultKeys: []string{"::README.md"}},
+		{QueryID: 1, RepoName: "github.com/d/d", ResultKeys: []string{"::go.mod"}},
+	}
+	current := map[api.RepoName][]string{
+		// One new match.
+		"github.com/a/a": {"::config.yaml", "::secrets.env"},
+		// One match less, which isn't reported.
+		"github.com/b/b": {},
+		// A repository that didn't match before.
+		"github.com/e/e": {"::a", "::b"},
+	}
+	// github.com/c/c timed out, so its snapshot is kept. github.com/d/d
+	// doesn't match anymore, so its snapshot is dropped.
+	unsearched := []*api.Repo{{Name: "github.com/c/c"}}
+
+	snapshots, numNew := newResultSnapshots(previous, current, unsearched)
+	require.Equal(t, 3, numNew)
+
+	want := []*edb.ResultSnapshot{
+		{RepoName: "github.com/a/a", ResultKeys: []string{"::config.yaml", "::secrets.env"}},
+		{RepoName: "github.com/b/b", ResultKeys: []string{}},
+		{QueryID: 1, RepoName: "github.com/c/c", ResultKeys: []string{"::README.md"}},
+		{RepoName: "github.com/e/e", ResultKeys: []string{"::a", "::b"}},
+	}
+	require.Equal(t, want, snapshots)
+}
@@ -3,6 +3,7 @@ package background
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -12,13 +13,15 @@ import (
 
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 )
 
 const (
-	eventRetentionInDays int = 7
+	eventRetentionInDays    int = 7
+	snapshotRetentionInDays int = 7
 )
 
 func newTriggerQueryRunner(ctx context.Context, s edb.CodeMonitorStore, metrics codeMonitorsMetrics) *workerutil.Worker {
@@ -72,6 +75,12 @@ func newTriggerJobsLogDeleter(ctx context.Context, store edb.CodeMonitorStore) g
 			if err != nil {
 				return err
 			}
+			// Delete the result snapshots of content queries that haven't
+			// run in a while.
+			err = store.DeleteOldResultSnapshots(ctx, snapshotRetentionInDays)
+			if err != nil {
+				return err
+			}
 			return nil
 		})
 	return goroutine.NewPeriodicGoroutine(ctx, 60*time.Minute, deleteLogs)
@@ -162,6 +171,10 @@ func (r *queryRunner) Handle(ctx context.Context, record workerutil.Record) (err
 		return err
 	}
 
+	if q.Mode == edb.QueryTriggerModeContent {
+		return runContentQuery(ctx, s, triggerJob, q, m)
+	}
+
 	newQuery := newQueryWithAfterFilter(q)
 
 	// Search.
@@ -193,6 +206,67 @@ func (r *queryRunner) Handle(ctx context.Context, record workerutil.Record) (err
 	return nil
 }
 
+// runContentQuery runs the query of a content trigger with count:all and
+// compares the keys of its results with the snapshots taken by the previous
+// run. Only results that weren't in the snapshots count as new. The first run
+// after the trigger was created or changed only takes the snapshots. Runs that
+// hit a result limit fail, since a truncated result set can't be compared with
+// the snapshots.
+func runContentQuery(ctx context.Context, s edb.CodeMonitorStore, triggerJob *edb.TriggerJob, q *edb.QueryTrigger, m *edb.Monitor) error {
+	query := withCountAll(q.QueryString)
+	results, err := search(ctx, query, m.UserID)
+	if err != nil {
+		return err
+	}
+
+	if searchLimitHit(results) {
+		return errors.Errorf("content query of code monitor %d hit a result limit", m.ID)
+	}
+
+	current, err := resultKeysByRepo(results)
+	if err != nil {
+		return err
+	}
+
+	previous, err := s.ListResultSnapshots(ctx, q.ID)
+	if err != nil {
+		return errors.Wrap(err, "ListResultSnapshots")
+	}
+	unsearched := append(results.Data.Search.Results.Cloning, results.Data.Search.Results.Timedout...)
+	snapshots, numResults := newResultSnapshots(previous, current, unsearched)
+	if q.SnapshotAt == nil {
+		numResults = 0
+	}
+
+	if numResults > 0 {
+		_, err := s.EnqueueActionJobsForMonitor(ctx, m.ID, triggerJob.ID)
+		if err != nil {
+			return errors.Wrap(err, "store.EnqueueActionJobsForQuery")
+		}
+	}
+	err = s.ReplaceResultSnapshots(ctx, q.ID, snapshots)
+	if err != nil {
+		return errors.Wrap(err, "ReplaceResultSnapshots")
+	}
+
+	now := s.Clock()()
+	// Log next_run and latest_result to table cm_queries.
+	newLatestResult := now
+	if numResults == 0 && q.LatestResult != nil {
+		newLatestResult = *q.LatestResult
+	}
+	err = s.SetQueryTriggerNextRun(ctx, q.ID, now.Add(5*time.Minute), newLatestResult.UTC())
+	if err != nil {
+		return err
+	}
+	// Log the query we ran and the number of new results.
+	err = s.UpdateTriggerJobWithResults(ctx, triggerJob.ID, query, numResults)
+	if err != nil {
+		return errors.Wrap(err, "UpdateTriggerJobWithResults")
+	}
+	return nil
+}
+
 type actionRunner struct {
 	edb.CodeMonitorStore
 }
@@ -371,6 +445,54 @@ func newQueryWithAfterFilter(q *edb.QueryTrigger) string {
 	return strings.Join([]string{q.QueryString, fmt.Sprintf(`after:"%s"`, afterTime)}, " ")
 }
 
+// withCountAll returns the given query with count:all, replacing any count:
+// set by the user. Content queries must return all results, otherwise results
+// beyond the count would be reported as new whenever the order of the results
+// changes.
+func withCountAll(q string) string {
+	nodes, err := query.Parse(q, searchType(q))
+	if err != nil {
+		// Let the search report the error.
+		return q
+	}
+
+	var ranges []query.Range
+	query.VisitField(query.LowercaseFieldNames(nodes), query.FieldCount, func(_ string, _ bool, annotation query.Annotation) {
+		ranges = append(ranges, annotation.Range)
+	})
+	if len(ranges) == 0 {
+		return q + " count:all"
+	}
+
+	// Replace the parameters from last to first, so that the ranges of the
+	// remaining ones stay valid.
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].Start.Column > ranges[j].Start.Column })
+	for _, r := range ranges {
+		q = q[:r.Start.Column] + "count:all" + q[r.End.Column:]
+	}
+	return q
+}
+
+// searchType returns the search type the search API uses for the given query.
+// Code monitors don't set a version or patternType when searching, so it's
+// regexp unless the query has a patternType: field.
+func searchType(q string) query.SearchType {
+	searchType := query.SearchTypeRegex
+	nodes, err := query.Parse(q, query.SearchTypeLiteral)
+	if err != nil {
+		return searchType
+	}
+	query.VisitField(query.LowercaseFieldNames(nodes), query.FieldPatternType, func(value string, _ bool, _ query.Annotation) {
+		switch value {
+		case "literal":
+			searchType = query.SearchTypeLiteral
+		case "structural":
+			searchType = query.SearchTypeStructural
+		}
+	})
+	return searchType
+}
+
 func latestResultTime(previousLastResult *time.Time, v *gqlSearchResponse, searchErr error) time.Time {
 	if searchErr != nil || len(v.Data.Search.Results.Results) == 0 {
 		// Error performing the search, or there were no results. Assume the
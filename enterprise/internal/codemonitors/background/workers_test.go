@@ -91,3 +91,20 @@ func TestActionRunner(t *testing.T) {
 		})
 	}
 }
+
+func TestWithCountAll(t *testing.T) {
+	t.Parallel()
+
+	for query, want := range map[string]string{
+		"AWS_SECRET_ACCESS_KEY":            "AWS_SECRET_ACCESS_KEY count:all",
+		"AWS_SECRET_ACCESS_KEY count:10":   "AWS_SECRET_ACCESS_KEY count:all",
+		"count:all AWS_SECRET_ACCESS_KEY":  "count:all AWS_SECRET_ACCESS_KEY",
+		"COUNT:10 AWS_SECRET_ACCESS_KEY":   "count:all AWS_SECRET_ACCESS_KEY",
+		`"count:10" count:5 file:x`:        `"count:10" count:all file:x`,
+		`"a  count:10" patterntype:regexp`: `"a  count:10" patterntype:regexp count:all`,
+		`(a or b) count:10`:                `(a or b) count:all`,
+		`foo count:10 patternType:literal`: `foo count:all patternType:literal`,
+	} {
+		require.Equal(t, want, withCountAll(query))
+	}
+}
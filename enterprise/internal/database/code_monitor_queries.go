@@ -14,22 +14,38 @@ type QueryTrigger struct {
 	ID           int64
 	Monitor      int64
 	QueryString  string
+	Mode         QueryTriggerMode
 	NextRun      time.Time
 	LatestResult *time.Time
+	SnapshotAt   *time.Time
 	CreatedBy    int32
 	CreatedAt    time.Time
 	ChangedBy    int32
 	ChangedAt    time.Time
 }
 
+// QueryTriggerMode determines how a query trigger detects new results.
+type QueryTriggerMode string
+
+const (
+	// QueryTriggerModeCommits only runs the query against commits made since
+	// the last run. This only works for type:commit and type:diff queries.
+	QueryTriggerModeCommits QueryTriggerMode = "commits"
+	// QueryTriggerModeContent runs the query as is and compares the keys of
+	// its results with the result snapshots taken by the last run.
+	QueryTriggerModeContent QueryTriggerMode = "content"
+)
+
 // queryColumns is the set of columns in cm_queries
 // It must be kept in sync with scanTriggerQuery
 var queryColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_queries.id"),
 	sqlf.Sprintf("cm_queries.monitor"),
 	sqlf.Sprintf("cm_queries.query"),
+	sqlf.Sprintf("cm_queries.mode"),
 	sqlf.Sprintf("cm_queries.next_run"),
 	sqlf.Sprintf("cm_queries.latest_result"),
+	sqlf.Sprintf("cm_queries.snapshot_at"),
 	sqlf.Sprintf("cm_queries.created_by"),
 	sqlf.Sprintf("cm_queries.created_at"),
 	sqlf.Sprintf("cm_queries.changed_by"),
@@ -38,18 +54,19 @@ var queryColumns = []*sqlf.Query{
 
 const createTriggerQueryFmtStr = `
 INSERT INTO cm_queries
-(monitor, query, created_by, created_at, changed_by, changed_at, next_run, latest_result)
-VALUES (%s,%s,%s,%s,%s,%s,%s,%s)
+(monitor, query, mode, created_by, created_at, changed_by, changed_at, next_run, latest_result)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s)
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) CreateQueryTrigger(ctx context.Context, monitorID int64, query string) (*QueryTrigger, error) {
+func (s *codeMonitorStore) CreateQueryTrigger(ctx context.Context, monitorID int64, query string, mode QueryTriggerMode) (*QueryTrigger, error) {
 	now := s.Now()
 	a := actor.FromContext(ctx)
 	q := sqlf.Sprintf(
 		createTriggerQueryFmtStr,
 		monitorID,
 		query,
+		mode,
 		a.UID,
 		now,
 		a.UID,
@@ -63,21 +80,32 @@ func (s *codeMonitorStore) CreateQueryTrigger(ctx context.Context, monitorID int
 }
 
 const updateTriggerQueryFmtStr = `
+WITH deleted_snapshots AS (
+	DELETE FROM cm_result_snapshots
+	WHERE query = %s
+)
 UPDATE cm_queries
 SET query = %s,
+	mode = %s,
 	changed_by = %s,
 	changed_at = %s,
-	latest_result = %s
+	latest_result = %s,
+	snapshot_at = NULL
 WHERE id = %s
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) UpdateQueryTrigger(ctx context.Context, id int64, query string) error {
+// UpdateQueryTrigger updates the query and mode of a query trigger. The result
+// snapshots of the trigger are deleted, since they don't apply to the new
+// query, so the next run of a content trigger only takes a new snapshot.
+func (s *codeMonitorStore) UpdateQueryTrigger(ctx context.Context, id int64, query string, mode QueryTriggerMode) error {
 	now := s.Now()
 	a := actor.FromContext(ctx)
 	q := sqlf.Sprintf(
 		updateTriggerQueryFmtStr,
+		id,
 		query,
+		mode,
 		a.UID,
 		now,
 		now,
@@ -104,14 +132,21 @@ func (s *codeMonitorStore) GetQueryTriggerForMonitor(ctx context.Context, monito
 }
 
 const resetTriggerQueryTimestamps = `
+WITH deleted_snapshots AS (
+	DELETE FROM cm_result_snapshots
+	WHERE query = %s
+)
 UPDATE cm_queries
 SET latest_result = null,
     next_run = %s
 WHERE id = %s;
 `
 
+// ResetQueryTriggerTimestamps makes the query trigger run as soon as possible
+// and treat all of its current results as new. For content triggers, this
+// means deleting the result snapshots without resetting snapshot_at.
 func (s *codeMonitorStore) ResetQueryTriggerTimestamps(ctx context.Context, queryID int64) error {
-	return s.Exec(ctx, sqlf.Sprintf(resetTriggerQueryTimestamps, s.Now(), queryID))
+	return s.Exec(ctx, sqlf.Sprintf(resetTriggerQueryTimestamps, queryID, s.Now(), queryID))
 }
 
 const getQueryByRecordIDFmtStr = `
@@ -156,8 +191,10 @@ func scanTriggerQuery(scanner dbutil.Scanner) (*QueryTrigger, error) {
 		&m.ID,
 		&m.Monitor,
 		&m.QueryString,
+		&m.Mode,
 		&m.NextRun,
 		&m.LatestResult,
+		&m.SnapshotAt,
 		&m.CreatedBy,
 		&m.CreatedAt,
 		&m.ChangedBy,
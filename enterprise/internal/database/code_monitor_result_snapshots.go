@@ -0,0 +1,135 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// ResultSnapshot is the set of result keys a content query trigger returned
+// for a single repository the last time it ran.
+type ResultSnapshot struct {
+	QueryID    int64
+	RepoName   string
+	ResultKeys []string
+	CreatedAt  time.Time
+}
+
+var resultSnapshotColumns = []*sqlf.Query{
+	sqlf.Sprintf("cm_result_snapshots.query"),
+	sqlf.Sprintf("cm_result_snapshots.repo_name"),
+	sqlf.Sprintf("cm_result_snapshots.result_keys"),
+	sqlf.Sprintf("cm_result_snapshots.created_at"),
+}
+
+const listResultSnapshotsFmtStr = `
+SELECT %s -- resultSnapshotColumns
+FROM cm_result_snapshots
+WHERE query = %s
+ORDER BY repo_name ASC;
+`
+
+// ListResultSnapshots returns the result snapshots of the given query trigger.
+func (s *codeMonitorStore) ListResultSnapshots(ctx context.Context, queryID int64) ([]*ResultSnapshot, error) {
+	q := sqlf.Sprintf(
+		listResultSnapshotsFmtStr,
+		sqlf.Join(resultSnapshotColumns, ","),
+		queryID,
+	)
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanResultSnapshots(rows)
+}
+
+const deleteResultSnapshotsFmtStr = `
+DELETE FROM cm_result_snapshots
+WHERE query = %s;
+`
+
+const insertResultSnapshotsFmtStr = `
+INSERT INTO cm_result_snapshots (query, repo_name, result_keys, created_at)
+VALUES %s;
+`
+
+const setSnapshotAtFmtStr = `
+UPDATE cm_queries
+SET snapshot_at = %s
+WHERE id = %s;
+`
+
+// ReplaceResultSnapshots replaces the result snapshots of the given query
+// trigger and records when they were taken.
+func (s *codeMonitorStore) ReplaceResultSnapshots(ctx context.Context, queryID int64, snapshots []*ResultSnapshot) (err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(deleteResultSnapshotsFmtStr, queryID)); err != nil {
+		return err
+	}
+
+	now := s.Now()
+	if len(snapshots) > 0 {
+		values := make([]*sqlf.Query, 0, len(snapshots))
+		for _, snapshot := range snapshots {
+			values = append(values, sqlf.Sprintf("(%s,%s,%s,%s)", queryID, snapshot.RepoName, pq.Array(snapshot.ResultKeys), now))
+		}
+		if err := tx.Exec(ctx, sqlf.Sprintf(insertResultSnapshotsFmtStr, sqlf.Join(values, ","))); err != nil {
+			return err
+		}
+	}
+
+	return tx.Exec(ctx, sqlf.Sprintf(setSnapshotAtFmtStr, now, queryID))
+}
+
+const deleteOldResultSnapshotsFmtStr = `
+WITH expired AS (
+	UPDATE cm_queries
+	SET snapshot_at = NULL
+	WHERE snapshot_at < (NOW() - (%s * '1 day'::interval))
+	RETURNING id
+)
+DELETE FROM cm_result_snapshots
+WHERE query IN (SELECT id FROM expired);
+`
+
+// DeleteOldResultSnapshots deletes the result snapshots of query triggers
+// that haven't taken a snapshot in 'retention' days, for example because their
+// monitor was disabled. The next run of such a trigger only takes a new
+// snapshot, rather than reporting everything that changed in the meantime.
+func (s *codeMonitorStore) DeleteOldResultSnapshots(ctx context.Context, retentionInDays int) error {
+	return s.Store.Exec(ctx, sqlf.Sprintf(deleteOldResultSnapshotsFmtStr, retentionInDays))
+}
+
+func scanResultSnapshots(rows *sql.Rows) ([]*ResultSnapshot, error) {
+	var rs []*ResultSnapshot
+	for rows.Next() {
+		r, err := scanResultSnapshot(rows)
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, r)
+	}
+	return rs, rows.Err()
+}
+
+func scanResultSnapshot(scanner dbutil.Scanner) (*ResultSnapshot, error) {
+	var r ResultSnapshot
+	err := scanner.Scan(
+		&r.QueryID,
+		&r.RepoName,
+		pq.Array(&r.ResultKeys),
+		&r.CreatedAt,
+	)
+	return &r, err
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceResultSnapshots(t *testing.T) {
+	ctx, db, s := newTestStore(t)
+	_, _, _, userCTX := newTestUser(ctx, t, db)
+	fixtures, err := s.insertTestMonitor(userCTX, t)
+	require.NoError(t, err)
+	queryID := fixtures.query.ID
+
+	err = s.ReplaceResultSnapshots(ctx, queryID, []*ResultSnapshot{
+		{RepoName: "github.com/a/a", ResultKeys: []string{"::config.yaml"}},
+		{RepoName: "github.com/b/b", ResultKeys: []string{"::main.go"}},
+	})
+	require.NoError(t, err)
+
+	err = s.ReplaceResultSnapshots(ctx, queryID, []*ResultSnapshot{
+		{RepoName: "github.com/a/a", ResultKeys: []string{"::config.yaml", "::secrets.env"}},
+	})
+	require.NoError(t, err)
+
+	got, err := s.ListResultSnapshots(ctx, queryID)
+	require.NoError(t, err)
+	want := []*ResultSnapshot{
+		{QueryID: queryID, RepoName: "github.com/a/a", ResultKeys: []string{"::config.yaml", "::secrets.env"}, CreatedAt: s.Now()},
+	}
+	require.Equal(t, want, got)
+
+	q, err := s.GetQueryTriggerForMonitor(ctx, fixtures.monitor.ID)
+	require.NoError(t, err)
+	require.NotNil(t, q.SnapshotAt)
+	require.Equal(t, s.Now(), *q.SnapshotAt)
+
+	// Changing the query deletes the snapshots.
+	err = s.UpdateQueryTrigger(userCTX, queryID, testQuery, QueryTriggerModeContent)
+	require.NoError(t, err)
+
+	got, err = s.ListResultSnapshots(ctx, queryID)
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	q, err = s.GetQueryTriggerForMonitor(ctx, fixtures.monitor.ID)
+	require.NoError(t, err)
+	require.Equal(t, QueryTriggerModeContent, q.Mode)
+	require.Nil(t, q.SnapshotAt)
+}
+
+const setSnapshotAtForTestFmtStr = `
+UPDATE cm_queries
+SET snapshot_at = %s
+WHERE id = %s;
+`
+
+func TestDeleteOldResultSnapshots(t *testing.T) {
+	retentionInDays := 7
+	ctx, db, s := newTestStore(t)
+	_, _, _, userCTX := newTestUser(ctx, t, db)
+	fixtures, err := s.insertTestMonitor(userCTX, t)
+	require.NoError(t, err)
+	queryID := fixtures.query.ID
+
+	err = s.ReplaceResultSnapshots(ctx, queryID, []*ResultSnapshot{
+		{RepoName: "github.com/a/a", ResultKeys: []string{"::config.yaml"}},
+	})
+	require.NoError(t, err)
+
+	// Recent snapshots are kept.
+	err = s.DeleteOldResultSnapshots(ctx, retentionInDays)
+	require.NoError(t, err)
+
+	got, err := s.ListResultSnapshots(ctx, queryID)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+
+	// Old snapshots are deleted.
+	longTimeAgo := s.Now().AddDate(0, 0, -(retentionInDays + 1))
+	err = s.Exec(ctx, sqlf.Sprintf(setSnapshotAtForTestFmtStr, longTimeAgo, queryID))
+	require.NoError(t, err)
+
+	err = s.DeleteOldResultSnapshots(ctx, retentionInDays)
+	require.NoError(t, err)
+
+	got, err = s.ListResultSnapshots(ctx, queryID)
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	q, err := s.GetQueryTriggerForMonitor(ctx, fixtures.monitor.ID)
+	require.NoError(t, err)
+	require.Nil(t, q.SnapshotAt)
+}
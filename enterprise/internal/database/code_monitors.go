@@ -35,8 +35,8 @@ type CodeMonitorStore interface {
 	ListMonitors(context.Context, ListMonitorsOpts) ([]*Monitor, error)
 	CountMonitors(ctx context.Context, userID int32) (int32, error)
 
-	CreateQueryTrigger(ctx context.Context, monitorID int64, query string) (*QueryTrigger, error)
-	UpdateQueryTrigger(ctx context.Context, id int64, query string) error
+	CreateQueryTrigger(ctx context.Context, monitorID int64, query string, mode QueryTriggerMode) (*QueryTrigger, error)
+	UpdateQueryTrigger(ctx context.Context, id int64, query string, mode QueryTriggerMode) error
 	GetQueryTriggerForMonitor(ctx context.Context, monitorID int64) (*QueryTrigger, error)
 	ResetQueryTriggerTimestamps(ctx context.Context, queryID int64) error
 	SetQueryTriggerNextRun(ctx context.Context, triggerQueryID int64, next time.Time, latestResults time.Time) error
@@ -45,6 +45,10 @@ type CodeMonitorStore interface {
 	ListQueryTriggerJobs(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error)
 	CountQueryTriggerJobs(ctx context.Context, queryID int64) (int32, error)
 
+	ListResultSnapshots(ctx context.Context, queryID int64) ([]*ResultSnapshot, error)
+	ReplaceResultSnapshots(ctx context.Context, queryID int64, snapshots []*ResultSnapshot) error
+	DeleteOldResultSnapshots(ctx context.Context, retentionInDays int) error
+
 	DeleteObsoleteTriggerJobs(ctx context.Context) error
 	UpdateTriggerJobWithResults(ctx context.Context, triggerJobID int32, queryString string, numResults int) error
 	DeleteOldTriggerJobs(ctx context.Context, retentionInDays int) error
@@ -191,7 +195,7 @@ func (s *TestStore) InsertTestMonitor(ctx context.Context, t *testing.T) (*Monit
 	}
 
 	// Create trigger.
-	_, err = s.CreateQueryTrigger(ctx, m.ID, testQuery)
+	_, err = s.CreateQueryTrigger(ctx, m.ID, testQuery, QueryTriggerModeCommits)
 	if err != nil {
 		return nil, err
 	}
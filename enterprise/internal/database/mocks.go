@@ -71,6 +71,9 @@ type MockCodeMonitorStore struct {
 	// object controlling the behavior of the method
 	// DeleteObsoleteTriggerJobs.
 	DeleteObsoleteTriggerJobsFunc *CodeMonitorStoreDeleteObsoleteTriggerJobsFunc
+	// DeleteOldResultSnapshotsFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteOldResultSnapshots.
+	DeleteOldResultSnapshotsFunc *CodeMonitorStoreDeleteOldResultSnapshotsFunc
 	// DeleteOldTriggerJobsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteOldTriggerJobs.
 	DeleteOldTriggerJobsFunc *CodeMonitorStoreDeleteOldTriggerJobsFunc
@@ -140,6 +143,9 @@ type MockCodeMonitorStore struct {
 	// ListRecipientsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListRecipients.
 	ListRecipientsFunc *CodeMonitorStoreListRecipientsFunc
+	// ListResultSnapshotsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListResultSnapshots.
+	ListResultSnapshotsFunc *CodeMonitorStoreListResultSnapshotsFunc
 	// ListSlackWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListSlackWebhookActions.
 	ListSlackWebhookActionsFunc *CodeMonitorStoreListSlackWebhookActionsFunc
@@ -149,6 +155,9 @@ type MockCodeMonitorStore struct {
 	// NowFunc is an instance of a mock function object controlling the
 	// behavior of the method Now.
 	NowFunc *CodeMonitorStoreNowFunc
+	// ReplaceResultSnapshotsFunc is an instance of a mock function object
+	// controlling the behavior of the method ReplaceResultSnapshots.
+	ReplaceResultSnapshotsFunc *CodeMonitorStoreReplaceResultSnapshotsFunc
 	// ResetQueryTriggerTimestampsFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// ResetQueryTriggerTimestamps.
@@ -234,7 +243,7 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		CreateQueryTriggerFunc: &CodeMonitorStoreCreateQueryTriggerFunc{
-			defaultHook: func(context.Context, int64, string) (*QueryTrigger, error) {
+			defaultHook: func(context.Context, int64, string, QueryTriggerMode) (*QueryTrigger, error) {
 				return nil, nil
 			},
 		},
@@ -268,6 +277,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return nil
 			},
 		},
+		DeleteOldResultSnapshotsFunc: &CodeMonitorStoreDeleteOldResultSnapshotsFunc{
+			defaultHook: func(context.Context, int) error {
+				return nil
+			},
+		},
 		DeleteOldTriggerJobsFunc: &CodeMonitorStoreDeleteOldTriggerJobsFunc{
 			defaultHook: func(context.Context, int) error {
 				return nil
@@ -378,6 +392,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return nil, nil
 			},
 		},
+		ListResultSnapshotsFunc: &CodeMonitorStoreListResultSnapshotsFunc{
+			defaultHook: func(context.Context, int64) ([]*ResultSnapshot, error) {
+				return nil, nil
+			},
+		},
 		ListSlackWebhookActionsFunc: &CodeMonitorStoreListSlackWebhookActionsFunc{
 			defaultHook: func(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error) {
 				return nil, nil
@@ -393,6 +412,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return time.Time{}
 			},
 		},
+		ReplaceResultSnapshotsFunc: &CodeMonitorStoreReplaceResultSnapshotsFunc{
+			defaultHook: func(context.Context, int64, []*ResultSnapshot) error {
+				return nil
+			},
+		},
 		ResetQueryTriggerTimestampsFunc: &CodeMonitorStoreResetQueryTriggerTimestampsFunc{
 			defaultHook: func(context.Context, int64) error {
 				return nil
@@ -424,7 +448,7 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		UpdateQueryTriggerFunc: &CodeMonitorStoreUpdateQueryTriggerFunc{
-			defaultHook: func(context.Context, int64, string) error {
+			defaultHook: func(context.Context, int64, string, QueryTriggerMode) error {
 				return nil
 			},
 		},
@@ -496,7 +520,7 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		CreateQueryTriggerFunc: &CodeMonitorStoreCreateQueryTriggerFunc{
-			defaultHook: func(context.Context, int64, string) (*QueryTrigger, error) {
+			defaultHook: func(context.Context, int64, string, QueryTriggerMode) (*QueryTrigger, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateQueryTrigger")
 			},
 		},
@@ -530,6 +554,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteObsoleteTriggerJobs")
 			},
 		},
+		DeleteOldResultSnapshotsFunc: &CodeMonitorStoreDeleteOldResultSnapshotsFunc{
+			defaultHook: func(context.Context, int) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteOldResultSnapshots")
+			},
+		},
 		DeleteOldTriggerJobsFunc: &CodeMonitorStoreDeleteOldTriggerJobsFunc{
 			defaultHook: func(context.Context, int) error {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteOldTriggerJobs")
@@ -640,6 +669,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.ListRecipients")
 			},
 		},
+		ListResultSnapshotsFunc: &CodeMonitorStoreListResultSnapshotsFunc{
+			defaultHook: func(context.Context, int64) ([]*ResultSnapshot, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.ListResultSnapshots")
+			},
+		},
 		ListSlackWebhookActionsFunc: &CodeMonitorStoreListSlackWebhookActionsFunc{
 			defaultHook: func(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.ListSlackWebhookActions")
@@ -655,6 +689,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.Now")
 			},
 		},
+		ReplaceResultSnapshotsFunc: &CodeMonitorStoreReplaceResultSnapshotsFunc{
+			defaultHook: func(context.Context, int64, []*ResultSnapshot) error {
+				panic("unexpected invocation of MockCodeMonitorStore.ReplaceResultSnapshots")
+			},
+		},
 		ResetQueryTriggerTimestampsFunc: &CodeMonitorStoreResetQueryTriggerTimestampsFunc{
 			defaultHook: func(context.Context, int64) error {
 				panic("unexpected invocation of MockCodeMonitorStore.ResetQueryTriggerTimestamps")
@@ -686,7 +725,7 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		UpdateQueryTriggerFunc: &CodeMonitorStoreUpdateQueryTriggerFunc{
-			defaultHook: func(context.Context, int64, string) error {
+			defaultHook: func(context.Context, int64, string, QueryTriggerMode) error {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateQueryTrigger")
 			},
 		},
@@ -761,6 +800,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		DeleteObsoleteTriggerJobsFunc: &CodeMonitorStoreDeleteObsoleteTriggerJobsFunc{
 			defaultHook: i.DeleteObsoleteTriggerJobs,
 		},
+		DeleteOldResultSnapshotsFunc: &CodeMonitorStoreDeleteOldResultSnapshotsFunc{
+			defaultHook: i.DeleteOldResultSnapshots,
+		},
 		DeleteOldTriggerJobsFunc: &CodeMonitorStoreDeleteOldTriggerJobsFunc{
 			defaultHook: i.DeleteOldTriggerJobs,
 		},
@@ -827,6 +869,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		ListRecipientsFunc: &CodeMonitorStoreListRecipientsFunc{
 			defaultHook: i.ListRecipients,
 		},
+		ListResultSnapshotsFunc: &CodeMonitorStoreListResultSnapshotsFunc{
+			defaultHook: i.ListResultSnapshots,
+		},
 		ListSlackWebhookActionsFunc: &CodeMonitorStoreListSlackWebhookActionsFunc{
 			defaultHook: i.ListSlackWebhookActions,
 		},
@@ -836,6 +881,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		NowFunc: &CodeMonitorStoreNowFunc{
 			defaultHook: i.Now,
 		},
+		ReplaceResultSnapshotsFunc: &CodeMonitorStoreReplaceResultSnapshotsFunc{
+			defaultHook: i.ReplaceResultSnapshots,
+		},
 		ResetQueryTriggerTimestampsFunc: &CodeMonitorStoreResetQueryTriggerTimestampsFunc{
 			defaultHook: i.ResetQueryTriggerTimestamps,
 		},
@@ -1872,24 +1920,24 @@ func (c CodeMonitorStoreCreateMonitorFuncCall) Results() []interface{} {
 // CreateQueryTrigger method of the parent MockCodeMonitorStore instance is
 // invoked.
 type CodeMonitorStoreCreateQueryTriggerFunc struct {
-	defaultHook func(context.Context, int64, string) (*QueryTrigger, error)
-	hooks       []func(context.Context, int64, string) (*QueryTrigger, error)
+	defaultHook func(context.Context, int64, string, QueryTriggerMode) (*QueryTrigger, error)
+	hooks       []func(context.Context, int64, string, QueryTriggerMode) (*QueryTrigger, error)
 	history     []CodeMonitorStoreCreateQueryTriggerFuncCall
 	mutex       sync.Mutex
 }
 
 // CreateQueryTrigger delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) CreateQueryTrigger(v0 context.Context, v1 int64, v2 string) (*QueryTrigger, error) {
-	r0, r1 := m.CreateQueryTriggerFunc.nextHook()(v0, v1, v2)
-	m.CreateQueryTriggerFunc.appendCall(CodeMonitorStoreCreateQueryTriggerFuncCall{v0, v1, v2, r0, r1})
+func (m *MockCodeMonitorStore) CreateQueryTrigger(v0 context.Context, v1 int64, v2 string, v3 QueryTriggerMode) (*QueryTrigger, error) {
+	r0, r1 := m.CreateQueryTriggerFunc.nextHook()(v0, v1, v2, v3)
+	m.CreateQueryTriggerFunc.appendCall(CodeMonitorStoreCreateQueryTriggerFuncCall{v0, v1, v2, v3, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the CreateQueryTrigger
 // method of the parent MockCodeMonitorStore instance is invoked and the
 // hook queue is empty.
-func (f *CodeMonitorStoreCreateQueryTriggerFunc) SetDefaultHook(hook func(context.Context, int64, string) (*QueryTrigger, error)) {
+func (f *CodeMonitorStoreCreateQueryTriggerFunc) SetDefaultHook(hook func(context.Context, int64, string, QueryTriggerMode) (*QueryTrigger, error)) {
 	f.defaultHook = hook
 }
 
@@ -1898,7 +1946,7 @@ func (f *CodeMonitorStoreCreateQueryTriggerFunc) SetDefaultHook(hook func(contex
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *CodeMonitorStoreCreateQueryTriggerFunc) PushHook(hook func(context.Context, int64, string) (*QueryTrigger, error)) {
+func (f *CodeMonitorStoreCreateQueryTriggerFunc) PushHook(hook func(context.Context, int64, string, QueryTriggerMode) (*QueryTrigger, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1907,7 +1955,7 @@ func (f *CodeMonitorStoreCreateQueryTriggerFunc) PushHook(hook func(context.Cont
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
 func (f *CodeMonitorStoreCreateQueryTriggerFunc) SetDefaultReturn(r0 *QueryTrigger, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, string) (*QueryTrigger, error) {
+	f.SetDefaultHook(func(context.Context, int64, string, QueryTriggerMode) (*QueryTrigger, error) {
 		return r0, r1
 	})
 }
@@ -1915,12 +1963,12 @@ func (f *CodeMonitorStoreCreateQueryTriggerFunc) SetDefaultReturn(r0 *QueryTrigg
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
 func (f *CodeMonitorStoreCreateQueryTriggerFunc) PushReturn(r0 *QueryTrigger, r1 error) {
-	f.PushHook(func(context.Context, int64, string) (*QueryTrigger, error) {
+	f.PushHook(func(context.Context, int64, string, QueryTriggerMode) (*QueryTrigger, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreCreateQueryTriggerFunc) nextHook() func(context.Context, int64, string) (*QueryTrigger, error) {
+func (f *CodeMonitorStoreCreateQueryTriggerFunc) nextHook() func(context.Context, int64, string, QueryTriggerMode) (*QueryTrigger, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1963,6 +2011,9 @@ type CodeMonitorStoreCreateQueryTriggerFuncCall struct {
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
 	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 QueryTriggerMode
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 *QueryTrigger
@@ -1974,7 +2025,7 @@ type CodeMonitorStoreCreateQueryTriggerFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c CodeMonitorStoreCreateQueryTriggerFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
@@ -2665,6 +2716,116 @@ func (c CodeMonitorStoreDeleteObsoleteTriggerJobsFuncCall) Results() []interface
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreDeleteOldResultSnapshotsFunc describes the behavior when
+// the DeleteOldResultSnapshots method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreDeleteOldResultSnapshotsFunc struct {
+	defaultHook func(context.Context, int) error
+	hooks       []func(context.Context, int) error
+	history     []CodeMonitorStoreDeleteOldResultSnapshotsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteOldResultSnapshots delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteOldResultSnapshots(v0 context.Context, v1 int) error {
+	r0 := m.DeleteOldResultSnapshotsFunc.nextHook()(v0, v1)
+	m.DeleteOldResultSnapshotsFunc.appendCall(CodeMonitorStoreDeleteOldResultSnapshotsFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteOldResultSnapshots method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreDeleteOldResultSnapshotsFunc) SetDefaultHook(hook func(context.Context, int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteOldResultSnapshots method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreDeleteOldResultSnapshotsFunc) PushHook(hook func(context.Context, int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *CodeMonitorStoreDeleteOldResultSnapshotsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *CodeMonitorStoreDeleteOldResultSnapshotsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteOldResultSnapshotsFunc) nextHook() func(context.Context, int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteOldResultSnapshotsFunc) appendCall(r0 CodeMonitorStoreDeleteOldResultSnapshotsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreDeleteOldResultSnapshotsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreDeleteOldResultSnapshotsFunc) History() []CodeMonitorStoreDeleteOldResultSnapshotsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteOldResultSnapshotsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteOldResultSnapshotsFuncCall is an object that
+// describes an invocation of method DeleteOldResultSnapshots on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreDeleteOldResultSnapshotsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreDeleteOldResultSnapshotsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteOldResultSnapshotsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreDeleteOldTriggerJobsFunc describes the behavior when the
 // DeleteOldTriggerJobs method of the parent MockCodeMonitorStore instance
 // is invoked.
@@ -5110,6 +5271,118 @@ func (c CodeMonitorStoreListRecipientsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreListResultSnapshotsFunc describes the behavior when the
+// ListResultSnapshots method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreListResultSnapshotsFunc struct {
+	defaultHook func(context.Context, int64) ([]*ResultSnapshot, error)
+	hooks       []func(context.Context, int64) ([]*ResultSnapshot, error)
+	history     []CodeMonitorStoreListResultSnapshotsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListResultSnapshots delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListResultSnapshots(v0 context.Context, v1 int64) ([]*ResultSnapshot, error) {
+	r0, r1 := m.ListResultSnapshotsFunc.nextHook()(v0, v1)
+	m.ListResultSnapshotsFunc.appendCall(CodeMonitorStoreListResultSnapshotsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListResultSnapshots
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreListResultSnapshotsFunc) SetDefaultHook(hook func(context.Context, int64) ([]*ResultSnapshot, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListResultSnapshots method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreListResultSnapshotsFunc) PushHook(hook func(context.Context, int64) ([]*ResultSnapshot, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *CodeMonitorStoreListResultSnapshotsFunc) SetDefaultReturn(r0 []*ResultSnapshot, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]*ResultSnapshot, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *CodeMonitorStoreListResultSnapshotsFunc) PushReturn(r0 []*ResultSnapshot, r1 error) {
+	f.PushHook(func(context.Context, int64) ([]*ResultSnapshot, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreListResultSnapshotsFunc) nextHook() func(context.Context, int64) ([]*ResultSnapshot, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreListResultSnapshotsFunc) appendCall(r0 CodeMonitorStoreListResultSnapshotsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreListResultSnapshotsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreListResultSnapshotsFunc) History() []CodeMonitorStoreListResultSnapshotsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreListResultSnapshotsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreListResultSnapshotsFuncCall is an object that describes
+// an invocation of method ListResultSnapshots on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreListResultSnapshotsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*ResultSnapshot
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreListResultSnapshotsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreListResultSnapshotsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreListSlackWebhookActionsFunc describes the behavior when
 // the ListSlackWebhookActions method of the parent MockCodeMonitorStore
 // instance is invoked.
@@ -5435,6 +5708,119 @@ func (c CodeMonitorStoreNowFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreReplaceResultSnapshotsFunc describes the behavior when
+// the ReplaceResultSnapshots method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreReplaceResultSnapshotsFunc struct {
+	defaultHook func(context.Context, int64, []*ResultSnapshot) error
+	hooks       []func(context.Context, int64, []*ResultSnapshot) error
+	history     []CodeMonitorStoreReplaceResultSnapshotsFuncCall
+	mutex       sync.Mutex
+}
+
+// ReplaceResultSnapshots delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ReplaceResultSnapshots(v0 context.Context, v1 int64, v2 []*ResultSnapshot) error {
+	r0 := m.ReplaceResultSnapshotsFunc.nextHook()(v0, v1, v2)
+	m.ReplaceResultSnapshotsFunc.appendCall(CodeMonitorStoreReplaceResultSnapshotsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// ReplaceResultSnapshots method of the parent MockCodeMonitorStore instance
+// is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreReplaceResultSnapshotsFunc) SetDefaultHook(hook func(context.Context, int64, []*ResultSnapshot) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ReplaceResultSnapshots method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreReplaceResultSnapshotsFunc) PushHook(hook func(context.Context, int64, []*ResultSnapshot) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *CodeMonitorStoreReplaceResultSnapshotsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, []*ResultSnapshot) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *CodeMonitorStoreReplaceResultSnapshotsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, []*ResultSnapshot) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreReplaceResultSnapshotsFunc) nextHook() func(context.Context, int64, []*ResultSnapshot) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreReplaceResultSnapshotsFunc) appendCall(r0 CodeMonitorStoreReplaceResultSnapshotsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreReplaceResultSnapshotsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreReplaceResultSnapshotsFunc) History() []CodeMonitorStoreReplaceResultSnapshotsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreReplaceResultSnapshotsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreReplaceResultSnapshotsFuncCall is an object that
+// describes an invocation of method ReplaceResultSnapshots on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreReplaceResultSnapshotsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []*ResultSnapshot
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreReplaceResultSnapshotsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreReplaceResultSnapshotsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreResetQueryTriggerTimestampsFunc describes the behavior
 // when the ResetQueryTriggerTimestamps method of the parent
 // MockCodeMonitorStore instance is invoked.
@@ -6116,24 +6502,24 @@ func (c CodeMonitorStoreUpdateMonitorEnabledFuncCall) Results() []interface{} {
 // UpdateQueryTrigger method of the parent MockCodeMonitorStore instance is
 // invoked.
 type CodeMonitorStoreUpdateQueryTriggerFunc struct {
-	defaultHook func(context.Context, int64, string) error
-	hooks       []func(context.Context, int64, string) error
+	defaultHook func(context.Context, int64, string, QueryTriggerMode) error
+	hooks       []func(context.Context, int64, string, QueryTriggerMode) error
 	history     []CodeMonitorStoreUpdateQueryTriggerFuncCall
 	mutex       sync.Mutex
 }
 
 // UpdateQueryTrigger delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) UpdateQueryTrigger(v0 context.Context, v1 int64, v2 string) error {
-	r0 := m.UpdateQueryTriggerFunc.nextHook()(v0, v1, v2)
-	m.UpdateQueryTriggerFunc.appendCall(CodeMonitorStoreUpdateQueryTriggerFuncCall{v0, v1, v2, r0})
+func (m *MockCodeMonitorStore) UpdateQueryTrigger(v0 context.Context, v1 int64, v2 string, v3 QueryTriggerMode) error {
+	r0 := m.UpdateQueryTriggerFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdateQueryTriggerFunc.appendCall(CodeMonitorStoreUpdateQueryTriggerFuncCall{v0, v1, v2, v3, r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the UpdateQueryTrigger
 // method of the parent MockCodeMonitorStore instance is invoked and the
 // hook queue is empty.
-func (f *CodeMonitorStoreUpdateQueryTriggerFunc) SetDefaultHook(hook func(context.Context, int64, string) error) {
+func (f *CodeMonitorStoreUpdateQueryTriggerFunc) SetDefaultHook(hook func(context.Context, int64, string, QueryTriggerMode) error) {
 	f.defaultHook = hook
 }
 
@@ -6142,7 +6528,7 @@ func (f *CodeMonitorStoreUpdateQueryTriggerFunc) SetDefaultHook(hook func(contex
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *CodeMonitorStoreUpdateQueryTriggerFunc) PushHook(hook func(context.Context, int64, string) error) {
+func (f *CodeMonitorStoreUpdateQueryTriggerFunc) PushHook(hook func(context.Context, int64, string, QueryTriggerMode) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -6151,7 +6537,7 @@ func (f *CodeMonitorStoreUpdateQueryTriggerFunc) PushHook(hook func(context.Cont
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
 func (f *CodeMonitorStoreUpdateQueryTriggerFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int64, string) error {
+	f.SetDefaultHook(func(context.Context, int64, string, QueryTriggerMode) error {
 		return r0
 	})
 }
@@ -6159,12 +6545,12 @@ func (f *CodeMonitorStoreUpdateQueryTriggerFunc) SetDefaultReturn(r0 error) {
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
 func (f *CodeMonitorStoreUpdateQueryTriggerFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int64, string) error {
+	f.PushHook(func(context.Context, int64, string, QueryTriggerMode) error {
 		return r0
 	})
 }
 
-func (f *CodeMonitorStoreUpdateQueryTriggerFunc) nextHook() func(context.Context, int64, string) error {
+func (f *CodeMonitorStoreUpdateQueryTriggerFunc) nextHook() func(context.Context, int64, string, QueryTriggerMode) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -6207,6 +6593,9 @@ type CodeMonitorStoreUpdateQueryTriggerFuncCall struct {
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
 	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 QueryTriggerMode
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -6215,7 +6604,7 @@ type CodeMonitorStoreUpdateQueryTriggerFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c CodeMonitorStoreUpdateQueryTriggerFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
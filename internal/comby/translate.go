@@ -144,6 +144,26 @@ func parseTemplate(buf []byte) []Term {
 	return result
 }
 
+var holeName = lazyregexp.New(`^:\[\[?\s*(\w+)`)
+
+// HoleNames returns the names of the holes in a comby pattern, in the order
+// in which they appear.
+//
+// Example:
+// "foo(:[x], :[[y]])" -> ["x", "y"]
+func HoleNames(pattern string) []string {
+	var names []string
+	for _, term := range parseTemplate([]byte(pattern)) {
+		if _, ok := term.(Hole); !ok {
+			continue
+		}
+		if m := holeName.FindStringSubmatch(term.String()); m != nil {
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
 var onMatchWhitespace = lazyregexp.New(`[\s]+`)
 
 // StructuralPatToRegexpQuery converts a comby pattern to an approximate regular
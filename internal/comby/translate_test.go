@@ -119,3 +119,11 @@ func TestStructuralPatToRegexpQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestHoleNames(t *testing.T) {
+	got := HoleNames(`foo(:[x], :[[y]]) :[z.] :[w~[a-z]+] :[ ] ...`)
+	want := []string{"x", "y", "z", "w"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -66,8 +66,16 @@ type Range struct {
 
 // Match represents a range of matched characters and the matched content
 type Match struct {
-	Range   Range  `json:"range"`
-	Matched string `json:"matched"`
+	Range       Range     `json:"range"`
+	Environment []Binding `json:"environment"`
+	Matched     string    `json:"matched"`
+}
+
+// Binding is the value and range of a hole bound by a match
+type Binding struct {
+	Variable string `json:"variable"`
+	Value    string `json:"value"`
+	Range    Range  `json:"range"`
 }
 
 type Result interface {
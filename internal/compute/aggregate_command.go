@@ -0,0 +1,120 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/comby"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// Aggregate counts the distinct values of a group-by template across all
+// matches of MatchPattern. The template may refer to capture groups (e.g.,
+// $1) or structural holes (e.g., :[x]) and to metavariables such as $repo,
+// $author, $path or $lang.
+type Aggregate struct {
+	MatchPattern MatchPattern
+	GroupBy      string
+}
+
+func (c *Aggregate) String() string {
+	return fmt.Sprintf("Aggregate: (%s) by: (%s)", c.MatchPattern.String(), c.GroupBy)
+}
+
+func aggregate(ctx context.Context, fragment string, matchPattern MatchPattern, groupBy string) (*Table, error) {
+	counts := make(map[string]int)
+	switch match := matchPattern.(type) {
+	case *Regexp:
+		for _, submatches := range match.Value.FindAllStringSubmatchIndex(fragment, -1) {
+			key := string(match.Value.ExpandString([]byte{}, groupBy, fragment, submatches))
+			if key != "" {
+				counts[key]++
+			}
+		}
+	case *Comby:
+		outputs, err := comby.Outputs(ctx, comby.Args{
+			Input:           comby.FileContent(fragment),
+			MatchTemplate:   match.Value,
+			RewriteTemplate: groupBy,
+			Matcher:         ".generic", // values are grouped across files of all languages
+			ResultKind:      comby.NewlineSeparatedOutput,
+			NumWorkers:      0,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range strings.Split(outputs, "\n") {
+			if key != "" {
+				counts[key]++
+			}
+		}
+	}
+	return newTable(counts), nil
+}
+
+func (c *Aggregate) Run(ctx context.Context, r result.Match) (Result, error) {
+	content, ok, err := resultContent(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	env := NewMetaEnvironment(r, content)
+	groupBy, err := substituteMetaVariables(substituteHoles(c.GroupBy, c.MatchPattern), env)
+	if err != nil {
+		return nil, err
+	}
+	return aggregate(ctx, content, c.MatchPattern, groupBy)
+}
+
+// Aggregator folds the tables produced by running an Aggregate command on each
+// result of a search. It is safe for concurrent use.
+//
+// If send is non-nil, Add calls it with a snapshot of the partial table at
+// most once per interval, so that callers may stream intermediate results.
+type Aggregator struct {
+	interval time.Duration
+	send     func(*Table)
+
+	mu       sync.Mutex
+	counts   map[string]int
+	lastSent time.Time
+}
+
+func NewAggregator(interval time.Duration, send func(*Table)) *Aggregator {
+	return &Aggregator{
+		interval: interval,
+		send:     send,
+		counts:   make(map[string]int),
+		lastSent: time.Now(),
+	}
+}
+
+// Add folds the rows of t into the aggregate.
+func (a *Aggregator) Add(t *Table) {
+	a.mu.Lock()
+	for _, row := range t.Rows {
+		a.counts[row.Value] += row.Count
+	}
+	var snapshot *Table
+	if a.send != nil && time.Since(a.lastSent) >= a.interval {
+		snapshot = newTable(a.counts)
+		a.lastSent = time.Now()
+	}
+	a.mu.Unlock()
+
+	if snapshot != nil {
+		a.send(snapshot)
+	}
+}
+
+// Table returns the current aggregate.
+func (a *Aggregator) Table() *Table {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return newTable(a.counts)
+}
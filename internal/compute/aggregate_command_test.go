@@ -0,0 +1,109 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func tableString(t *Table) string {
+	var rows []string
+	for _, row := range t.Rows {
+		rows = append(rows, fmt.Sprintf("%s:%d", row.Value, row.Count))
+	}
+	return strings.Join(rows, " ")
+}
+
+func Test_aggregate(t *testing.T) {
+	test := func(input string, cmd *Aggregate) string {
+		table, err := aggregate(context.Background(), input, cmd.MatchPattern, cmd.GroupBy)
+		if err != nil {
+			return err.Error()
+		}
+		return tableString(table)
+	}
+
+	autogold.Want(
+		"aggregate capture group values",
+		"fmt:2 os:1").
+		Equal(t, test("import fmt\nimport os\nimport fmt", &Aggregate{
+			MatchPattern: &Regexp{Value: regexp.MustCompile(`import (\w+)`)},
+			GroupBy:      "$1",
+		}))
+
+	autogold.Want(
+		"aggregate ties ordered by value",
+		"a:1 b:1").
+		Equal(t, test("b a", &Aggregate{
+			MatchPattern: &Regexp{Value: regexp.MustCompile(`\w`)},
+			GroupBy:      "$0",
+		}))
+}
+
+func TestAggregateRun(t *testing.T) {
+	defer git.ResetMocks()
+
+	cmd, err := Parse(`content:aggregate(\d, by:$repo)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	aggregator := NewAggregator(0, nil)
+	for _, repo := range []string{"a", "b", "a"} {
+		m := fileMatch("1 2").(*result.FileMatch)
+		m.Repo = types.MinimalRepo{Name: api.RepoName("github.com/" + repo)}
+		res, err := cmd.Command.Run(context.Background(), m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		aggregator.Add(res.(*Table))
+	}
+
+	autogold.Want(
+		"aggregate by repo",
+		"github.com/a:4 github.com/b:2").
+		Equal(t, tableString(aggregator.Table()))
+}
+
+func TestAggregator(t *testing.T) {
+	var partials []string
+	aggregator := NewAggregator(0, func(t *Table) {
+		partials = append(partials, tableString(t))
+	})
+	aggregator.Add(newTable(map[string]int{"x": 1}))
+	aggregator.Add(newTable(map[string]int{"x": 1, "y": 3}))
+
+	autogold.Want(
+		"partial tables are sent as results are folded",
+		[]string{"x:1", "y:3 x:2"}).
+		Equal(t, partials)
+	autogold.Want(
+		"tables are folded",
+		"y:3 x:2").
+		Equal(t, tableString(aggregator.Table()))
+
+	t.Run("partial tables are sent at most once per interval", func(t *testing.T) {
+		var sent int
+		aggregator := NewAggregator(time.Hour, func(*Table) { sent++ })
+		aggregator.Add(newTable(map[string]int{"x": 1}))
+		aggregator.Add(newTable(map[string]int{"x": 1}))
+		if sent != 0 {
+			t.Fatalf("expected no partial tables within the interval, got %d", sent)
+		}
+		aggregator.lastSent = time.Now().Add(-time.Hour)
+		aggregator.Add(newTable(map[string]int{"x": 1}))
+		if sent != 1 {
+			t.Fatalf("expected a partial table after the interval, got %d", sent)
+		}
+	})
+}
@@ -6,7 +6,9 @@ import (
 	"regexp"
 	"strconv"
 
+	"github.com/sourcegraph/sourcegraph/internal/comby"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
 type MatchOnly struct {
@@ -66,10 +68,53 @@ func matchOnly(fm *result.FileMatch, r *regexp.Regexp) *MatchContext {
 	return &MatchContext{Matches: matches, Path: fm.Path}
 }
 
-func (c *MatchOnly) Run(_ context.Context, r result.Match) (Result, error) {
+// fromStructuralRange converts a comby range to a compute range. Comby lines
+// and columns start at 1, while those of the regexp path start at 0.
+func fromStructuralRange(r comby.Range) Range {
+	return newRange(r.Start.Line-1, r.End.Line-1, r.Start.Column-1, r.End.Column-1)
+}
+
+func fromStructuralMatch(m comby.Match) Match {
+	env := make(Environment)
+	for _, b := range m.Environment {
+		env[b.Variable] = Data{Value: b.Value, Range: fromStructuralRange(b.Range)}
+	}
+	return Match{Value: m.Matched, Range: fromStructuralRange(m.Range), Environment: env}
+}
+
+func matchOnlyStructural(ctx context.Context, fm *result.FileMatch, matchTemplate string) (*MatchContext, error) {
+	content, err := git.ReadFile(ctx, fm.Repo.Name, fm.CommitID, fm.Path, 0)
+	if err != nil {
+		return nil, err
+	}
+	fileMatches, err := comby.Matches(ctx, comby.Args{
+		Input:         comby.FileContent(content),
+		MatchTemplate: matchTemplate,
+		Matcher:       ".generic", // TODO(rvantonder): use language or file filter
+		ResultKind:    comby.MatchOnly,
+		NumWorkers:    0, // Just a single file's content.
+	})
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]Match, 0, len(fm.LineMatches))
+	for _, fileMatch := range fileMatches {
+		for _, m := range fileMatch.Matches {
+			matches = append(matches, fromStructuralMatch(m))
+		}
+	}
+	return &MatchContext{Matches: matches, Path: fm.Path}, nil
+}
+
+func (c *MatchOnly) Run(ctx context.Context, r result.Match) (Result, error) {
 	switch m := r.(type) {
 	case *result.FileMatch:
-		return matchOnly(m, c.MatchPattern.(*Regexp).Value), nil
+		switch match := c.MatchPattern.(type) {
+		case *Regexp:
+			return matchOnly(m, match.Value), nil
+		case *Comby:
+			return matchOnlyStructural(ctx, m, match.Value)
+		}
 	}
 	return nil, nil
 }
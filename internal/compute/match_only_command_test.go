@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hexops/autogold"
+	"github.com/sourcegraph/sourcegraph/internal/comby"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
@@ -138,3 +139,24 @@ func Test_matchOnly(t *testing.T) {
   "path": "bedge"
 }`).Equal(t, test("a(b(c))(de)f(g)h", match))
 }
+
+func Test_fromStructuralMatch(t *testing.T) {
+	location := func(line, column int) comby.Location {
+		return comby.Location{Offset: 42, Line: line, Column: column}
+	}
+
+	m := fromStructuralMatch(comby.Match{
+		Range:   comby.Range{Start: location(2, 1), End: location(2, 9)},
+		Matched: "foo(bar)",
+		Environment: []comby.Binding{{
+			Variable: "x",
+			Value:    "bar",
+			Range:    comby.Range{Start: location(2, 5), End: location(2, 8)},
+		}},
+	})
+	v, _ := json.Marshal(m)
+
+	autogold.Want("structural match ranges start at 0 like regexp matches",
+		`{"value":"foo(bar)","range":{"start":{"offset":-1,"line":1,"column":0},"end":{"offset":-1,"line":1,"column":8}},"environment":{"x":{"value":"bar","range":{"start":{"offset":-1,"line":1,"column":4},"end":{"offset":-1,"line":1,"column":7}}}}}`).
+		Equal(t, string(v))
+}
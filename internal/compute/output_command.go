@@ -69,6 +69,8 @@ func resultContent(ctx context.Context, r result.Match) (string, bool, error) {
 			content = string(m.Commit.Message)
 		}
 		return content, true, nil
+	case *result.RangeDiffMatch:
+		return m.DiffPreview.Value, true, nil
 	default:
 		return "", false, nil
 	}
@@ -83,7 +85,7 @@ func (c *Output) Run(ctx context.Context, r result.Match) (Result, error) {
 		return nil, nil
 	}
 	env := NewMetaEnvironment(r, content)
-	outputPattern, err := substituteMetaVariables(c.OutputPattern, env)
+	outputPattern, err := substituteMetaVariables(substituteHoles(c.OutputPattern, c.MatchPattern), env)
 	if err != nil {
 		return nil, err
 	}
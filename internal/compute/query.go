@@ -32,6 +32,8 @@ func (q Query) ToSearchQuery() (string, error) {
 		searchPattern = c.MatchPattern.String()
 	case *Output:
 		searchPattern = c.MatchPattern.String()
+	case *Aggregate:
+		searchPattern = c.MatchPattern.String()
 	default:
 		return "", errors.Errorf("unsupported query conversion for compute command %T", c)
 	}
@@ -104,12 +106,15 @@ func toRegexpPattern(value string) (MatchPattern, error) {
 
 var ComputePredicateRegistry = query.PredicateRegistry{
 	query.FieldContent: {
-		"replace":            func() query.Predicate { return query.EmptyPredicate{} },
-		"replace.regexp":     func() query.Predicate { return query.EmptyPredicate{} },
-		"replace.structural": func() query.Predicate { return query.EmptyPredicate{} },
-		"output":             func() query.Predicate { return query.EmptyPredicate{} },
-		"output.regexp":      func() query.Predicate { return query.EmptyPredicate{} },
-		"output.structural":  func() query.Predicate { return query.EmptyPredicate{} },
+		"replace":              func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.regexp":       func() query.Predicate { return query.EmptyPredicate{} },
+		"replace.structural":   func() query.Predicate { return query.EmptyPredicate{} },
+		"output":               func() query.Predicate { return query.EmptyPredicate{} },
+		"output.regexp":        func() query.Predicate { return query.EmptyPredicate{} },
+		"output.structural":    func() query.Predicate { return query.EmptyPredicate{} },
+		"aggregate":            func() query.Predicate { return query.EmptyPredicate{} },
+		"aggregate.regexp":     func() query.Predicate { return query.EmptyPredicate{} },
+		"aggregate.structural": func() query.Predicate { return query.EmptyPredicate{} },
 	},
 }
 
@@ -195,7 +200,59 @@ func parseOutput(pattern *query.Pattern) (Command, bool, error) {
 	return &Output{MatchPattern: matchPattern, OutputPattern: right, Separator: "\n"}, true, nil
 }
 
+var groupBySyntax = lazyregexp.New(`,\s*by:\s*`)
+
+// parseGroupBy splits args of the form `pattern, by:template` into its
+// pattern and template. The last `, by:` is the separator so that patterns may
+// contain commas.
+func parseGroupBy(args string) (string, string) {
+	locs := groupBySyntax.FindAllIndex([]byte(args), -1)
+	if len(locs) == 0 {
+		return args, ""
+	}
+	last := locs[len(locs)-1]
+	return args[:last[0]], args[last[1]:]
+}
+
+func parseAggregate(pattern *query.Pattern) (Command, bool, error) {
+	name, args, ok := parseContentPredicate(pattern)
+	if !ok {
+		return nil, false, nil
+	}
+	left, groupBy := parseGroupBy(args)
+
+	var matchPattern MatchPattern
+	switch name {
+	case "aggregate", "aggregate.regexp":
+		var err error
+		matchPattern, err = toRegexpPattern(left)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "aggregate command")
+		}
+		if groupBy == "" {
+			// Group by the entire match by default.
+			groupBy = "$0"
+		}
+	case "aggregate.structural":
+		// structural search doesn't do any match pattern validation
+		matchPattern = &Comby{Value: left}
+		if groupBy == "" {
+			// Rewriting a match with its own template yields the match.
+			groupBy = left
+		}
+	default:
+		// unrecognized name
+		return nil, false, nil
+	}
+
+	return &Aggregate{MatchPattern: matchPattern, GroupBy: groupBy}, true, nil
+}
+
 func parseMatchOnly(pattern *query.Pattern) (Command, bool, error) {
+	if pattern.Annotation.Labels.IsSet(query.Structural) {
+		// structural search doesn't do any match pattern validation
+		return &MatchOnly{MatchPattern: &Comby{Value: pattern.Value}}, true, nil
+	}
 	rp, err := toRegexpPattern(pattern.Value)
 	if err != nil {
 		return nil, false, err
@@ -222,6 +279,7 @@ func first(parsers ...commandParser) commandParser {
 }
 
 var parseCommand = first(
+	parseAggregate,
 	parseReplace,
 	parseOutput,
 	parseMatchOnly,
@@ -46,6 +46,22 @@ func TestParse(t *testing.T) {
 	autogold.Want("replace no left hand side",
 		"Command: `Replace in place: () -> (b)`").
 		Equal(t, test("content:replace(->b)"))
+
+	autogold.Want("structural match only",
+		"Command: `Match only: foo(:[x])`, Parameters: `\"patterntype:structural\"`").
+		Equal(t, test("patterntype:structural foo(:[x])"))
+
+	autogold.Want("aggregate",
+		"Command: `Aggregate: (import (\\w+)) by: ($1)`").
+		Equal(t, test(`content:aggregate(import (\w+), by:$1)`))
+
+	autogold.Want("aggregate defaults to grouping by match",
+		"Command: `Aggregate: (a,b) by: ($0)`").
+		Equal(t, test("content:aggregate(a,b)"))
+
+	autogold.Want("aggregate structural",
+		"Command: `Aggregate: (foo(:[x])) by: ($repo :[x])`").
+		Equal(t, test("content:aggregate.structural(foo(:[x]), by:$repo :[x])"))
 }
 
 func TestToSearchQuery(t *testing.T) {
@@ -65,4 +81,12 @@ func TestToSearchQuery(t *testing.T) {
 	autogold.Want("convert replace-in-place to search query",
 		"repo:foo file:bar colarado").
 		Equal(t, test("content:replace(colarado -> colorodo) repo:foo file:bar"))
+
+	autogold.Want("convert structural match-only to search query",
+		"patterntype:structural foo(:[x])").
+		Equal(t, test("patterntype:structural foo(:[x])"))
+
+	autogold.Want("convert aggregate to search query",
+		"repo:foo (\\d+)").
+		Equal(t, test(`content:aggregate((\d+), by:$1) repo:foo`))
 }
@@ -0,0 +1,29 @@
+package compute
+
+import "sort"
+
+// Table is a histogram of values and the number of times each value occurs.
+// Rows are ordered by descending count.
+type Table struct {
+	Rows []Row  `json:"rows"`
+	Kind string `json:"kind"`
+}
+
+type Row struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+func newTable(counts map[string]int) *Table {
+	rows := make([]Row, 0, len(counts))
+	for value, count := range counts {
+		rows = append(rows, Row{Value: value, Count: count})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Count != rows[j].Count {
+			return rows[i].Count > rows[j].Count
+		}
+		return rows[i].Value < rows[j].Value
+	})
+	return &Table{Rows: rows, Kind: "aggregate"}
+}
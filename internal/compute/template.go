@@ -6,6 +6,8 @@ import (
 	"text/template"
 	"unicode/utf8"
 
+	"github.com/sourcegraph/sourcegraph/internal/comby"
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
@@ -188,6 +190,7 @@ type MetaEnvironment struct {
 	Author  string
 	Date    string
 	Email   string
+	Lang    string
 }
 
 var empty = struct{}{}
@@ -200,6 +203,7 @@ var builtinVariables = map[string]struct{}{
 	"author":  empty,
 	"date":    empty,
 	"email":   empty,
+	"lang":    empty,
 }
 
 func templatize(pattern string) (string, error) {
@@ -226,6 +230,50 @@ func templatize(pattern string) (string, error) {
 	return strings.Join(templatized, ""), nil
 }
 
+// substituteHoles rewrites references to the holes of a structural match
+// pattern that use the syntax of regexp capture groups, like $x, to the comby
+// hole syntax :[x]. This lets output templates refer to holes and capture
+// groups alike. Metavariables take precedence over holes of the same name.
+func substituteHoles(pattern string, matchPattern MatchPattern) string {
+	c, ok := matchPattern.(*Comby)
+	if !ok {
+		return pattern
+	}
+	holes := make(map[string]struct{})
+	for _, name := range comby.HoleNames(c.Value) {
+		if _, ok := builtinVariables[name]; !ok {
+			holes[name] = empty
+		}
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			// Preserve escape sequences, like \$, for template substitution.
+			b.WriteByte('\\')
+			if i+1 < len(pattern) {
+				i++
+				b.WriteByte(pattern[i])
+			}
+		case '$':
+			j := i + 1
+			for j < len(pattern) && strings.IndexByte(varAllowed, pattern[j]) >= 0 {
+				j++
+			}
+			if _, ok := holes[pattern[i+1:j]]; ok {
+				b.WriteString(":[" + pattern[i+1:j] + "]")
+				i = j - 1
+			} else {
+				b.WriteByte('$')
+			}
+		default:
+			b.WriteByte(pattern[i])
+		}
+	}
+	return b.String()
+}
+
 func substituteMetaVariables(pattern string, env *MetaEnvironment) (string, error) {
 	templated, err := templatize(pattern)
 	if err != nil {
@@ -247,11 +295,13 @@ func substituteMetaVariables(pattern string, env *MetaEnvironment) (string, erro
 func NewMetaEnvironment(r result.Match, content string) *MetaEnvironment {
 	switch m := r.(type) {
 	case *result.FileMatch:
+		lang, _ := inventory.GetLanguageByFilename(m.Path)
 		return &MetaEnvironment{
 			Repo:    string(m.Repo.Name),
 			Path:    m.Path,
 			Commit:  string(m.CommitID),
 			Content: content,
+			Lang:    lang,
 		}
 	case *result.CommitMatch:
 		return &MetaEnvironment{
@@ -262,6 +312,12 @@ func NewMetaEnvironment(r result.Match, content string) *MetaEnvironment {
 			Email:   m.Commit.Author.Email,
 			Content: content,
 		}
+	case *result.RangeDiffMatch:
+		return &MetaEnvironment{
+			Repo:    string(m.Repo.Name),
+			Commit:  string(m.Head),
+			Content: content,
+		}
 	}
 	return &MetaEnvironment{}
 }
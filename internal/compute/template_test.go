@@ -2,6 +2,7 @@ package compute
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hexops/autogold"
@@ -99,3 +100,24 @@ func Test_substituteMetaVariables(t *testing.T) {
 			&MetaEnvironment{Author: "hi"},
 		))
 }
+
+func Test_substituteHoles(t *testing.T) {
+	test := func(input string, matchPattern MatchPattern) string {
+		return substituteHoles(input, matchPattern)
+	}
+
+	autogold.Want(
+		"substitute holes referenced like capture groups",
+		"train(:[y], :[x]) $1 $repo").
+		Equal(t, test("train($y, $x) $1 $repo", &Comby{Value: "train(:[x], :[[y]])"}))
+
+	autogold.Want(
+		"metavariables and escapes take precedence over holes",
+		`$repo \$x :[x]`).
+		Equal(t, test(`$repo \$x $x`, &Comby{Value: ":[repo] :[x]"}))
+
+	autogold.Want(
+		"regexp patterns are unchanged",
+		"($x)").
+		Equal(t, test("($x)", &Regexp{Value: regexp.MustCompile(`(?P<x>\d)`)}))
+}
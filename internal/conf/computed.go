@@ -369,6 +369,25 @@ func GitMaxConcurrentClones() int {
 	return v
 }
 
+// GitServerReplicationFactor returns the number of gitservers each repository
+// is cloned to.
+func GitServerReplicationFactor() int {
+	v := Get().GitServerReplicationFactor
+	if v < 1 {
+		return 1
+	}
+	return v
+}
+
+// GitServerEvictionPolicy returns the order in which gitservers remove
+// repositories to free up space.
+func GitServerEvictionPolicy() string {
+	if e := Get().GitServerEviction; e != nil && e.Policy != "" {
+		return e.Policy
+	}
+	return "lru"
+}
+
 func UserReposMaxPerUser() int {
 	v := Get().UserReposMaxPerUser
 	if v == 0 {
@@ -0,0 +1,105 @@
+package reposource
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// A GoModule is a module served by a Go module proxy, see
+// https://go.dev/ref/mod#goproxy-protocol
+type GoModule struct {
+	// Path is the module path, such as "golang.org/x/mod".
+	Path string
+}
+
+// NewGoModule returns the module with the given module path.
+func NewGoModule(path string) (*GoModule, error) {
+	if err := module.CheckPath(path); err != nil {
+		return nil, errors.Wrapf(err, "illegal Go module path %q", path)
+	}
+	return &GoModule{Path: path}, nil
+}
+
+// ParseGoModule parses a string in a 'go/path' format into a Go module.
+func ParseGoModule(urlPath string) (*GoModule, error) {
+	path := strings.TrimPrefix(urlPath, "go/")
+	if path == urlPath {
+		return nil, errors.Errorf("expected path in go/path format but found %s", urlPath)
+	}
+	return NewGoModule(path)
+}
+
+// RepoName provides a name that is "globally unique" for a Sourcegraph instance.
+//
+// The returned value is used for repo:... in queries.
+func (m *GoModule) RepoName() api.RepoName {
+	return api.RepoName("go/" + m.Path)
+}
+
+// CloneURL returns a "URL" that can later be used to download a repo.
+func (m *GoModule) CloneURL() string {
+	return string(m.RepoName())
+}
+
+// MatchesDependencyString checks if a dependency (= module + version pair)
+// refers to the same module as m.
+func (m GoModule) MatchesDependencyString(depPackageSyntax string) bool {
+	return strings.HasPrefix(depPackageSyntax, m.Path+"@")
+}
+
+// GoDependency is a "versioned package" for use by go commands, such as
+// `go get`.
+//
+// See also: [NOTE: Dependency-terminology]
+type GoDependency struct {
+	GoModule
+
+	// Version is the canonical semantic version of the module, such as
+	// "v1.2.3" or a pseudo-version.
+	Version string
+}
+
+// ParseGoDependency parses a string in a 'path@version' format into a
+// GoDependency. Only exact versions are supported, queries like "latest" are
+// not.
+func ParseGoDependency(dependency string) (*GoDependency, error) {
+	i := strings.LastIndexByte(dependency, '@')
+	if i < 0 {
+		return nil, errors.Errorf("expected dependency in path@version format but found %s", dependency)
+	}
+	path, version := dependency[:i], dependency[i+1:]
+	if err := module.Check(path, version); err != nil {
+		return nil, err
+	}
+	return &GoDependency{GoModule: GoModule{Path: path}, Version: version}, nil
+}
+
+// PackageManagerSyntax returns the dependency in Go syntax. The returned
+// string can (for example) be passed to `go get`.
+func (d GoDependency) PackageManagerSyntax() string {
+	return fmt.Sprintf("%s@%s", d.Path, d.Version)
+}
+
+// GitTagFromVersion returns the version, which Go already prefixes with "v".
+func (d GoDependency) GitTagFromVersion() string {
+	return d.Version
+}
+
+// SortGoDependencies sorts the dependencies by semantic version in descending
+// order. The latest version of a dependency becomes the first element of the
+// slice.
+func SortGoDependencies(dependencies []GoDependency) {
+	sort.Slice(dependencies, func(i, j int) bool {
+		if dependencies[i].Path == dependencies[j].Path {
+			return semver.Compare(dependencies[i].Version, dependencies[j].Version) > 0
+		}
+		return dependencies[i].Path > dependencies[j].Path
+	})
+}
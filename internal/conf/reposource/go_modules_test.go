@@ -0,0 +1,74 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGoDependency(t *testing.T) {
+	table := []struct {
+		testName string
+		expect   bool
+	}{
+		{"golang.org/x/mod@v0.5.1", true},
+		{"github.com/google/uuid@v1.3.0", true},
+		{"github.com/Masterminds/semver/v3@v3.1.1", true},
+		{"golang.org/x/net@v0.0.0-20211209124913-491a49abca63", true},
+		{"golang.org/x/mod", false},
+		{"golang.org/x/mod@latest", false},
+		{"golang.org/x/mod@0.5.1", false},
+		{"github.com/Masterminds/semver/v3@v1.5.0", false},
+		{"@v1.0.0", false},
+	}
+	for _, entry := range table {
+		dep, err := ParseGoDependency(entry.testName)
+		if entry.expect && (err != nil) {
+			t.Errorf("expected success but got error '%s' when parsing %s",
+				err.Error(), entry.testName)
+		} else if !entry.expect && err == nil {
+			t.Errorf("expected error but successfully parsed %s into %+v", entry.testName, dep)
+		}
+	}
+}
+
+func TestGoModule(t *testing.T) {
+	dep := parseGoDependencyOrPanic(t, "golang.org/x/mod@v0.5.1")
+	assert.Equal(t, "go/golang.org/x/mod", string(dep.RepoName()))
+	assert.Equal(t, "v0.5.1", dep.GitTagFromVersion())
+	assert.True(t, dep.MatchesDependencyString("golang.org/x/mod@v0.4.0"))
+	assert.False(t, dep.MatchesDependencyString("golang.org/x/mod/v2@v2.0.0"))
+
+	mod, err := ParseGoModule("go/golang.org/x/mod")
+	assert.Nil(t, err)
+	assert.Equal(t, dep.GoModule, *mod)
+}
+
+func TestSortGoDependencies(t *testing.T) {
+	dependencies := []GoDependency{
+		parseGoDependencyOrPanic(t, "example.com/c@v1.2.0"),
+		parseGoDependencyOrPanic(t, "example.com/a@v1.2.0"),
+		parseGoDependencyOrPanic(t, "example.com/b@v1.2.0"),
+		parseGoDependencyOrPanic(t, "example.com/b@v1.11.0"),
+		parseGoDependencyOrPanic(t, "example.com/b@v1.2.0-rc.1"),
+		parseGoDependencyOrPanic(t, "example.com/b@v1.1.0"),
+	}
+	expected := []GoDependency{
+		parseGoDependencyOrPanic(t, "example.com/c@v1.2.0"),
+		parseGoDependencyOrPanic(t, "example.com/b@v1.11.0"),
+		parseGoDependencyOrPanic(t, "example.com/b@v1.2.0"),
+		parseGoDependencyOrPanic(t, "example.com/b@v1.2.0-rc.1"),
+		parseGoDependencyOrPanic(t, "example.com/b@v1.1.0"),
+		parseGoDependencyOrPanic(t, "example.com/a@v1.2.0"),
+	}
+	SortGoDependencies(dependencies)
+	assert.Equal(t, expected, dependencies)
+}
+
+func parseGoDependencyOrPanic(t *testing.T, value string) GoDependency {
+	dependency, err := ParseGoDependency(value)
+	if err != nil {
+		t.Fatalf("error=%s", err)
+	}
+	return *dependency
+}
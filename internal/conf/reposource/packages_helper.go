@@ -27,3 +27,5 @@ type PackageDependency interface {
 
 var _ PackageDependency = MavenDependency{}
 var _ PackageDependency = NPMDependency{}
+var _ PackageDependency = PythonDependency{}
+var _ PackageDependency = GoDependency{}
@@ -0,0 +1,117 @@
+package reposource
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+var (
+	pythonPackageNameRegex    = lazyregexp.New(`^[a-zA-Z0-9]([a-zA-Z0-9._-]*[a-zA-Z0-9])?$`)
+	pythonNameSeparatorsRegex = lazyregexp.New(`[-_.]+`)
+	pythonVersionRegex        = lazyregexp.New(`^[a-zA-Z0-9][a-zA-Z0-9._+!-]*$`)
+)
+
+// A PythonPackage is a project on a Python package index such as PyPI.
+type PythonPackage struct {
+	// Name is the normalized name of the project, see
+	// https://www.python.org/dev/peps/pep-0503/#normalized-names
+	Name string
+}
+
+// NewPythonPackage returns the package with the given project name, which is
+// normalized.
+func NewPythonPackage(name string) (*PythonPackage, error) {
+	if !pythonPackageNameRegex.MatchString(name) {
+		return nil, errors.Errorf("illegal Python package name %q (allowed characters: 0-9, a-z, A-Z, ., _, -)", name)
+	}
+	return &PythonPackage{Name: NormalizePythonPackageName(name)}, nil
+}
+
+// NormalizePythonPackageName normalizes a Python project name as described in
+// PEP 503: runs of ".", "_" and "-" are replaced by a single "-", and the name
+// is lowercased. Different spellings of a name refer to the same project.
+func NormalizePythonPackageName(name string) string {
+	return strings.ToLower(pythonNameSeparatorsRegex.ReplaceAllLiteralString(name, "-"))
+}
+
+// ParsePythonPackage parses a string in a 'python/name' format into a Python
+// package.
+func ParsePythonPackage(urlPath string) (*PythonPackage, error) {
+	name := strings.TrimPrefix(urlPath, "python/")
+	if name == urlPath {
+		return nil, errors.Errorf("expected path in python/name format but found %s", urlPath)
+	}
+	return NewPythonPackage(name)
+}
+
+// RepoName provides a name that is "globally unique" for a Sourcegraph instance.
+//
+// The returned value is used for repo:... in queries.
+func (pkg *PythonPackage) RepoName() api.RepoName {
+	return api.RepoName("python/" + pkg.Name)
+}
+
+// CloneURL returns a "URL" that can later be used to download a repo.
+func (pkg *PythonPackage) CloneURL() string {
+	return string(pkg.RepoName())
+}
+
+// MatchesDependencyString checks if a dependency (= package + version pair)
+// refers to the same package as pkg.
+func (pkg PythonPackage) MatchesDependencyString(depPackageSyntax string) bool {
+	i := strings.Index(depPackageSyntax, "==")
+	return i >= 0 && NormalizePythonPackageName(depPackageSyntax[:i]) == pkg.Name
+}
+
+// PythonDependency is a "versioned package" for use by pip commands, such as
+// `pip install`.
+//
+// See also: [NOTE: Dependency-terminology]
+type PythonDependency struct {
+	PythonPackage
+
+	// Version is the exact version of the dependency, such as "1.22.3".
+	Version string
+}
+
+// ParsePythonDependency parses a string in a 'name==version' format into a
+// PythonDependency. Only exact versions are supported.
+func ParsePythonDependency(dependency string) (*PythonDependency, error) {
+	parts := strings.Split(dependency, "==")
+	if len(parts) != 2 || !pythonVersionRegex.MatchString(parts[1]) {
+		return nil, errors.Errorf("expected dependency in name==version format but found %s", dependency)
+	}
+	pkg, err := NewPythonPackage(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	return &PythonDependency{PythonPackage: *pkg, Version: parts[1]}, nil
+}
+
+// PackageManagerSyntax returns the dependency in pip syntax. The returned
+// string can (for example) be passed to `pip install`.
+func (d PythonDependency) PackageManagerSyntax() string {
+	return fmt.Sprintf("%s==%s", d.Name, d.Version)
+}
+
+func (d PythonDependency) GitTagFromVersion() string {
+	return "v" + d.Version
+}
+
+// SortPythonDependencies sorts the dependencies by version in descending
+// order. The latest version of a dependency becomes the first element of the
+// slice.
+func SortPythonDependencies(dependencies []PythonDependency) {
+	sort.Slice(dependencies, func(i, j int) bool {
+		if dependencies[i].Name == dependencies[j].Name {
+			return versionGreaterThan(dependencies[i].Version, dependencies[j].Version)
+		}
+		return dependencies[i].Name > dependencies[j].Name
+	})
+}
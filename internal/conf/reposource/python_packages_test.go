@@ -0,0 +1,77 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePythonDependency(t *testing.T) {
+	table := []struct {
+		testName string
+		expect   bool
+	}{
+		{"requests==2.27.1", true},
+		{"Django==4.0.3", true},
+		{"zope.interface==5.4.0", true},
+		{"ruamel.yaml==0.17.21", true},
+		{"numpy==1.22.0rc1", true},
+		{"requests", false},
+		{"requests==", false},
+		{"requests>=2.27.1", false},
+		{"requests==2.27.1==3", false},
+		{"-requests==2.27.1", false},
+		{"requests==2.27 .1", false},
+	}
+	for _, entry := range table {
+		dep, err := ParsePythonDependency(entry.testName)
+		if entry.expect && (err != nil) {
+			t.Errorf("expected success but got error '%s' when parsing %s",
+				err.Error(), entry.testName)
+		} else if !entry.expect && err == nil {
+			t.Errorf("expected error but successfully parsed %s into %+v", entry.testName, dep)
+		}
+	}
+}
+
+func TestPythonPackage_Normalization(t *testing.T) {
+	dep := parsePythonDependencyOrPanic(t, "Zope_Interface==5.4.0")
+	assert.Equal(t, "zope-interface", dep.Name)
+	assert.Equal(t, "python/zope-interface", string(dep.RepoName()))
+	assert.Equal(t, "zope-interface==5.4.0", dep.PackageManagerSyntax())
+	assert.Equal(t, "v5.4.0", dep.GitTagFromVersion())
+
+	assert.True(t, dep.MatchesDependencyString("zope.interface==5.4.0"))
+	assert.False(t, dep.MatchesDependencyString("zope==5.4.0"))
+
+	pkg, err := ParsePythonPackage("python/zope-interface")
+	assert.Nil(t, err)
+	assert.Equal(t, dep.PythonPackage, *pkg)
+}
+
+func TestSortPythonDependencies(t *testing.T) {
+	dependencies := []PythonDependency{
+		parsePythonDependencyOrPanic(t, "ac==1.2.0"),
+		parsePythonDependencyOrPanic(t, "aa==1.2.0"),
+		parsePythonDependencyOrPanic(t, "ab==1.2.0"),
+		parsePythonDependencyOrPanic(t, "ab==1.11.0"),
+		parsePythonDependencyOrPanic(t, "ab==1.1.0"),
+	}
+	expected := []PythonDependency{
+		parsePythonDependencyOrPanic(t, "ac==1.2.0"),
+		parsePythonDependencyOrPanic(t, "ab==1.11.0"),
+		parsePythonDependencyOrPanic(t, "ab==1.2.0"),
+		parsePythonDependencyOrPanic(t, "ab==1.1.0"),
+		parsePythonDependencyOrPanic(t, "aa==1.2.0"),
+	}
+	SortPythonDependencies(dependencies)
+	assert.Equal(t, expected, dependencies)
+}
+
+func parsePythonDependencyOrPanic(t *testing.T, value string) PythonDependency {
+	dependency, err := ParsePythonDependency(value)
+	if err != nil {
+		t.Fatalf("error=%s", err)
+	}
+	return *dependency
+}
@@ -2002,6 +2002,12 @@ func TestConfigurationHasWebhooks(t *testing.T) {
 					},
 				},
 			},
+			&schema.BitbucketCloudConnection{
+				WebhookSecret: "super secret",
+			},
+			&schema.GerritConnection{
+				WebhookSecret: "super secret",
+			},
 		} {
 			t.Run(fmt.Sprintf("%T", cfg), func(t *testing.T) {
 				assert.True(t, configurationHasWebhooks(cfg))
@@ -2014,6 +2020,8 @@ func TestConfigurationHasWebhooks(t *testing.T) {
 			&schema.GitHubConnection{},
 			&schema.GitLabConnection{},
 			&schema.BitbucketServerConnection{},
+			&schema.BitbucketCloudConnection{},
+			&schema.GerritConnection{},
 		} {
 			t.Run(fmt.Sprintf("%T", cfg), func(t *testing.T) {
 				assert.False(t, configurationHasWebhooks(cfg))
@@ -2024,7 +2032,6 @@ func TestConfigurationHasWebhooks(t *testing.T) {
 	t.Run("unsupported kinds", func(t *testing.T) {
 		for _, cfg := range []interface{}{
 			&schema.AWSCodeCommitConnection{},
-			&schema.BitbucketCloudConnection{},
 			&schema.GitoliteConnection{},
 			&schema.PerforceConnection{},
 			&schema.PhabricatorConnection{},
@@ -31,13 +31,16 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gomodules"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/jvmpackages"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/npmpackages"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/perforce"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/phabricator"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/pythonpackages"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
@@ -492,6 +495,8 @@ func scanRepo(rows *sql.Rows, r *types.Repo) (err error) {
 		r.Metadata = new(perforce.Depot)
 	case extsvc.TypePhabricator:
 		r.Metadata = new(phabricator.Repo)
+	case extsvc.TypeGerrit:
+		r.Metadata = new(gerrit.Project)
 	case extsvc.TypePagure:
 		r.Metadata = new(pagure.Project)
 	case extsvc.TypeOther:
@@ -500,6 +505,10 @@ func scanRepo(rows *sql.Rows, r *types.Repo) (err error) {
 		r.Metadata = new(jvmpackages.Metadata)
 	case extsvc.TypeNPMPackages:
 		r.Metadata = new(npmpackages.Metadata)
+	case extsvc.TypePythonPackages:
+		r.Metadata = new(pythonpackages.Metadata)
+	case extsvc.TypeGoModules:
+		r.Metadata = new(gomodules.Metadata)
 	default:
 		log15.Warn("scanRepo - unknown service type", "typ", typ)
 		return nil
@@ -603,6 +612,16 @@ type ReposListOptions struct {
 	// OnlyPrivate excludes non-private repositories from the list.
 	OnlyPrivate bool
 
+	// Topics, if non-empty, limits the list to repositories that are tagged
+	// with all of the given topics on their code host (GitHub topics, GitLab
+	// project topics). Topics are matched case-insensitively.
+	Topics []string
+
+	// Languages, if non-empty, limits the list to repositories whose primary
+	// language as reported by their code host is one of the given languages.
+	// Languages are matched case-insensitively.
+	Languages []string
+
 	// Index when set will only include repositories which should be indexed
 	// if true. If false it will exclude repositories which should be
 	// indexed. An example use case of this is for indexed search only
@@ -936,6 +955,12 @@ func (s *repoStore) listSQL(ctx context.Context, opt ReposListOptions) (*sqlf.Qu
 	if opt.OnlyPrivate {
 		where = append(where, sqlf.Sprintf("private"))
 	}
+	for _, topic := range opt.Topics {
+		where = append(where, repoTopicCond(topic))
+	}
+	if len(opt.Languages) > 0 {
+		where = append(where, repoLanguageCond(opt.Languages))
+	}
 
 	if len(opt.Names) > 0 {
 		lowerNames := make([]string, len(opt.Names))
@@ -1568,6 +1593,39 @@ func parsePattern(p string, caseSensitive bool) ([]*sqlf.Query, error) {
 	return []*sqlf.Query{sqlf.Sprintf("(%s)", sqlf.Join(conds, "OR"))}, nil
 }
 
+// repoTopicsJSONPaths are the paths to the topics a repository is tagged with
+// in the code host metadata we store for it.
+var repoTopicsJSONPaths = []string{
+	"$.RepositoryTopics.Nodes[*].Topic.Name", // GitHub
+	"$.topics[*]",                            // GitLab
+}
+
+// repoTopicCond returns a condition that matches repositories tagged with the
+// given topic on their code host.
+func repoTopicCond(topic string) *sqlf.Query {
+	paths := make([]*sqlf.Query, 0, len(repoTopicsJSONPaths))
+	for _, p := range repoTopicsJSONPaths {
+		paths = append(paths, sqlf.Sprintf("jsonb_path_query_array(repo.metadata, %s::jsonpath)", p))
+	}
+	return sqlf.Sprintf(
+		"EXISTS (SELECT 1 FROM jsonb_array_elements_text(%s) AS topic WHERE lower(topic) = %s)",
+		sqlf.Join(paths, " || "),
+		strings.ToLower(topic),
+	)
+}
+
+// repoLanguageCond returns a condition that matches repositories whose primary
+// language, as reported by GitHub, is one of languages. Other code hosts don't
+// report a primary language in the metadata we store, so their repositories
+// never match.
+func repoLanguageCond(languages []string) *sqlf.Query {
+	lower := make([]string, len(languages))
+	for i, l := range languages {
+		lower[i] = strings.ToLower(l)
+	}
+	return sqlf.Sprintf("lower(repo.metadata->'PrimaryLanguage'->>'Name') = ANY (%s)", pq.Array(lower))
+}
+
 // parseCursorConds returns the WHERE conditions for the given cursor
 func parseCursorConds(cs types.MultiCursor) (cond *sqlf.Query, err error) {
 	var (
@@ -22,6 +22,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/types/typestest"
 )
@@ -1195,6 +1196,59 @@ func TestRepos_List_useOr(t *testing.T) {
 	}
 }
 
+func TestRepos_List_metadata(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	db := dbtest.NewDB(t)
+	ctx := actor.WithInternalActor(context.Background())
+
+	githubRepo := typestest.MakeGithubRepo()
+	githubRepo.Metadata = &github.Repository{
+		PrimaryLanguage: &github.Language{Name: "Go"},
+		RepositoryTopics: &github.RepositoryTopics{Nodes: []github.RepositoryTopic{
+			{Topic: github.Topic{Name: "payments"}},
+			{Topic: github.Topic{Name: "backend"}},
+		}},
+	}
+	gitlabRepo := typestest.MakeGitlabRepo()
+	gitlabRepo.Metadata = &gitlab.Project{Topics: []string{"Payments"}}
+	otherRepo := typestest.MakeGitoliteRepo()
+
+	githubRepos := mustCreate(ctx, t, db, githubRepo)
+	gitlabRepos := mustCreate(ctx, t, db, gitlabRepo)
+	mustCreate(ctx, t, db, otherRepo)
+
+	both := append(types.Repos{}, githubRepos...)
+	both = append(both, gitlabRepos...)
+	sort.Sort(both)
+
+	tests := []struct {
+		name string
+		opt  ReposListOptions
+		want types.Repos
+	}{
+		{"topic", ReposListOptions{Topics: []string{"payments"}}, both},
+		{"topics", ReposListOptions{Topics: []string{"payments", "backend"}}, githubRepos},
+		{"unknown topic", ReposListOptions{Topics: []string{"frontend"}}, nil},
+		{"language", ReposListOptions{Languages: []string{"go"}}, githubRepos},
+		{"languages", ReposListOptions{Languages: []string{"Rust", "Go"}}, githubRepos},
+		{"unknown language", ReposListOptions{Languages: []string{"Rust"}}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repos, err := Repos(db).List(ctx, test.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertJSONEqual(t, []*types.Repo(test.want), repos)
+		})
+	}
+}
+
 func TestRepos_List_externalServiceID(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -304,13 +304,19 @@ func (s *store) Evict(maxCacheSizeBytes int64) (stats EvictStats, err error) {
 		return strings.HasSuffix(fi.Name(), ".zip")
 	}
 
-	list := []fs.FileInfo{}
+	// Keys with several components are stored in subdirectories of s.dir,
+	// so we record the path of every file.
+	type entry struct {
+		path string
+		fs.FileInfo
+	}
+	list := []entry{}
 	err = filepath.Walk(s.dir,
 		func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				return err
 			}
-			list = append(list, info)
+			list = append(list, entry{path: path, FileInfo: info})
 			return nil
 		})
 	if err != nil {
@@ -346,7 +352,7 @@ func (s *store) Evict(maxCacheSizeBytes int64) (stats EvictStats, err error) {
 		if !isZip(fi) {
 			continue
 		}
-		path := filepath.Join(s.dir, fi.Name())
+		path := fi.path
 		if s.beforeEvict != nil {
 			s.beforeEvict(path, trace)
 		}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
@@ -63,3 +64,45 @@ func TestOpen(t *testing.T) {
 		t.Fatal("Item was not properly evicted")
 	}
 }
+
+func TestEvict(t *testing.T) {
+	dir := t.TempDir()
+	store := &store{
+		dir:       dir,
+		component: "test",
+		observe:   newOperations(&observation.TestContext, "test"),
+	}
+
+	open := func(key ...string) string {
+		f, err := store.Open(context.Background(), key, func(ctx context.Context) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader([]byte("foobar"))), nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Close()
+		return f.Path
+	}
+
+	// Keys with several components are stored in subdirectories.
+	oldest := open("repo", "a")
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(oldest, old, old); err != nil {
+		t.Fatal(err)
+	}
+	newest := open("repo", "b")
+
+	stats, err := store.Evict(6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.CacheSize != 12 || stats.Evicted != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	if _, err := os.Stat(oldest); !os.IsNotExist(err) {
+		t.Errorf("expected %s to be evicted", oldest)
+	}
+	if _, err := os.Stat(newest); err != nil {
+		t.Errorf("expected %s to be kept: %s", newest, err)
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/opentracing-contrib/go-stdlib/nethttp"
 	"golang.org/x/time/rate"
 
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
@@ -50,6 +51,10 @@ type Client struct {
 	// The username and app password credentials for accessing the server.
 	Username, AppPassword string
 
+	// Auth, if set, is used to authenticate requests instead of the username
+	// and app password.
+	Auth auth.Authenticator
+
 	// RateLimit is the self-imposed rate limiter (since Bitbucket does not have a concept
 	// of rate limiting in HTTP response headers).
 	RateLimit *rate.Limiter
@@ -86,6 +91,25 @@ func NewClient(apiURL *url.URL, httpClient httpcli.Doer) *Client {
 	}
 }
 
+// WithAuthenticator returns a new Client that uses the same configuration as
+// the current Client, except authenticated with the given authenticator.
+// Bitbucket Cloud only supports basic authentication with an app password.
+func (c *Client) WithAuthenticator(a auth.Authenticator) (*Client, error) {
+	switch a.(type) {
+	case *auth.BasicAuth, *auth.BasicAuthWithSSH:
+		break
+	default:
+		return nil, errors.Errorf("authenticator type unsupported for Bitbucket Cloud clients: %T", a)
+	}
+
+	return &Client{
+		httpClient: c.httpClient,
+		URL:        c.URL,
+		RateLimit:  c.RateLimit,
+		Auth:       a,
+	}, nil
+}
+
 // Repos returns a list of repositories that are fetched and populated based on given account
 // name and pagination criteria. If the account requested is a team, results will be filtered
 // down to the ones that the app password's user has access to.
@@ -145,6 +169,13 @@ func (c *Client) reqPage(ctx context.Context, url string, results interface{}) (
 }
 
 func (c *Client) do(ctx context.Context, req *http.Request, result interface{}) error {
+	_, err := c.doWithStatus(ctx, req, result)
+	return err
+}
+
+// doWithStatus is like do, but additionally returns the HTTP status code of
+// successful responses.
+func (c *Client) doWithStatus(ctx context.Context, req *http.Request, result interface{}) (int, error) {
 	req.URL = c.URL.ResolveReference(req.URL)
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 
@@ -155,12 +186,12 @@ func (c *Client) do(ctx context.Context, req *http.Request, result interface{})
 	defer ht.Finish()
 
 	if err := c.authenticate(req); err != nil {
-		return err
+		return 0, err
 	}
 
 	startWait := time.Now()
 	if err := c.RateLimit.Wait(ctx); err != nil {
-		return err
+		return 0, err
 	}
 
 	if d := time.Since(startWait); d > 200*time.Millisecond {
@@ -169,18 +200,18 @@ func (c *Client) do(ctx context.Context, req *http.Request, result interface{})
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	defer resp.Body.Close()
 
 	bs, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return resp.StatusCode, err
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
-		return errors.WithStack(&httpError{
+		return resp.StatusCode, errors.WithStack(&httpError{
 			URL:        req.URL,
 			StatusCode: resp.StatusCode,
 			Body:       bs,
@@ -188,13 +219,16 @@ func (c *Client) do(ctx context.Context, req *http.Request, result interface{})
 	}
 
 	if result != nil {
-		return json.Unmarshal(bs, result)
+		return resp.StatusCode, json.Unmarshal(bs, result)
 	}
 
-	return nil
+	return resp.StatusCode, nil
 }
 
 func (c *Client) authenticate(req *http.Request) error {
+	if c.Auth != nil {
+		return c.Auth.Authenticate(req)
+	}
 	req.SetBasicAuth(c.Username, c.AppPassword)
 	return nil
 }
@@ -277,3 +311,16 @@ func (e *httpError) Unauthorized() bool {
 func (e *httpError) NotFound() bool {
 	return e.StatusCode == http.StatusNotFound
 }
+
+// IsNotFound reports whether err is a Bitbucket Cloud API not found error.
+func IsNotFound(err error) bool {
+	var e *httpError
+	return errors.As(err, &e) && e.NotFound()
+}
+
+// IsUnauthorized reports whether err is a Bitbucket Cloud API unauthorized
+// error.
+func IsUnauthorized(err error) bool {
+	var e *httpError
+	return errors.As(err, &e) && e.Unauthorized()
+}
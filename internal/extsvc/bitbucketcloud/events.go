@@ -0,0 +1,205 @@
+package bitbucketcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const eventTypeHeader = "X-Event-Key"
+
+// WebhookEventType returns the type of the webhook event sent in the given
+// request, such as "pullrequest:approved".
+func WebhookEventType(r *http.Request) string {
+	return r.Header.Get(eventTypeHeader)
+}
+
+// ParseWebhookEvent parses the payload of a webhook event of the given type.
+// Events of types that are known but carry no information relevant to pull
+// requests are returned as nil without an error.
+func ParseWebhookEvent(eventType string, payload []byte) (e interface{}, err error) {
+	switch eventType {
+	case "pullrequest:approved":
+		e = &PullRequestApprovedEvent{}
+	case "pullrequest:unapproved":
+		e = &PullRequestUnapprovedEvent{}
+	case "pullrequest:changes_request_created":
+		e = &PullRequestChangesRequestCreatedEvent{}
+	case "pullrequest:changes_request_removed":
+		e = &PullRequestChangesRequestRemovedEvent{}
+	case "pullrequest:fulfilled":
+		e = &PullRequestFulfilledEvent{}
+	case "pullrequest:rejected":
+		e = &PullRequestRejectedEvent{}
+	case "pullrequest:created", "pullrequest:updated", "pullrequest:comment_created",
+		"pullrequest:comment_updated", "pullrequest:comment_deleted":
+		return nil, nil
+	default:
+		return nil, errors.Errorf("unknown webhook event type: %q", eventType)
+	}
+
+	return e, json.Unmarshal(payload, e)
+}
+
+// PullRequestEvent holds the fields common to all pull request webhook events.
+type PullRequestEvent struct {
+	Actor       Account     `json:"actor"`
+	PullRequest PullRequest `json:"pullrequest"`
+	Repository  Repo        `json:"repository"`
+}
+
+// Participation is the approval or request for changes of a single user, as
+// sent in webhook events.
+type Participation struct {
+	Date time.Time `json:"date"`
+	User Account   `json:"user"`
+}
+
+type PullRequestApprovedEvent struct {
+	PullRequestEvent
+	Approval Participation `json:"approval"`
+}
+
+type PullRequestUnapprovedEvent struct {
+	PullRequestEvent
+	Approval Participation `json:"approval"`
+}
+
+type PullRequestChangesRequestCreatedEvent struct {
+	PullRequestEvent
+	ChangesRequest Participation `json:"changes_request"`
+}
+
+type PullRequestChangesRequestRemovedEvent struct {
+	PullRequestEvent
+	ChangesRequest Participation `json:"changes_request"`
+}
+
+// PullRequestFulfilledEvent is sent when a pull request is merged.
+type PullRequestFulfilledEvent struct {
+	PullRequestEvent
+}
+
+// PullRequestRejectedEvent is sent when a pull request is declined.
+type PullRequestRejectedEvent struct {
+	PullRequestEvent
+}
+
+// ParticipantStatusAction is the change of a participant's review of a pull
+// request.
+type ParticipantStatusAction string
+
+const (
+	ParticipantStatusApproved              ParticipantStatusAction = "approved"
+	ParticipantStatusUnapproved            ParticipantStatusAction = "unapproved"
+	ParticipantStatusChangesRequested      ParticipantStatusAction = "changes_requested"
+	ParticipantStatusChangesRequestRemoved ParticipantStatusAction = "changes_request_removed"
+)
+
+// ParticipantStatusEvent is a change of a participant's review of a pull
+// request. It is derived from the participants of a pull request when syncing
+// and from webhook events.
+type ParticipantStatusEvent struct {
+	Date   time.Time               `json:"date"`
+	User   Account                 `json:"user"`
+	Action ParticipantStatusAction `json:"action"`
+}
+
+// Key is a unique key identifying this event in the context of its pull
+// request.
+func (e *ParticipantStatusEvent) Key() string {
+	return fmt.Sprintf("%s:%s:%d", e.User.UUID, e.Action, e.Date.Unix())
+}
+
+// PullRequestStateEvent is the transition of a pull request into a final
+// state, i.e. MERGED or DECLINED.
+type PullRequestStateEvent struct {
+	Date  time.Time        `json:"date"`
+	Actor Account          `json:"actor"`
+	State PullRequestState `json:"state"`
+}
+
+// Key is a unique key identifying this event in the context of its pull
+// request.
+func (e *PullRequestStateEvent) Key() string {
+	return strings.ToLower(string(e.State))
+}
+
+// Key is a unique key identifying this status in the context of its pull
+// request.
+func (s *PullRequestStatus) Key() string {
+	return s.BuildKey
+}
+
+// ParticipantStatusEvents returns the review events derived from the
+// participants of the pull request.
+func (pr *PullRequest) ParticipantStatusEvents() []*ParticipantStatusEvent {
+	var events []*ParticipantStatusEvent
+	for _, p := range pr.Participants {
+		if p.ParticipatedOn == nil {
+			continue
+		}
+
+		var action ParticipantStatusAction
+		switch {
+		case p.State == ParticipantStateChangesRequested:
+			action = ParticipantStatusChangesRequested
+		case p.State == ParticipantStateApproved || p.Approved:
+			action = ParticipantStatusApproved
+		default:
+			continue
+		}
+
+		events = append(events, &ParticipantStatusEvent{
+			Date:   *p.ParticipatedOn,
+			User:   p.User,
+			Action: action,
+		})
+	}
+	return events
+}
+
+// StateEvent returns the event of the pull request transitioning into its
+// current state, if the state is final. Otherwise, nil is returned.
+func (pr *PullRequest) StateEvent() *PullRequestStateEvent {
+	switch pr.State {
+	case PullRequestStateMerged, PullRequestStateDeclined:
+		return &PullRequestStateEvent{Date: pr.UpdatedOn, State: pr.State}
+	default:
+		return nil
+	}
+}
+
+// ToEvent returns the changeset event metadata of the given webhook event.
+func (e *PullRequestApprovedEvent) ToEvent() *ParticipantStatusEvent {
+	return &ParticipantStatusEvent{Date: e.Approval.Date, User: e.Approval.User, Action: ParticipantStatusApproved}
+}
+
+// ToEvent returns the changeset event metadata of the given webhook event.
+func (e *PullRequestUnapprovedEvent) ToEvent() *ParticipantStatusEvent {
+	return &ParticipantStatusEvent{Date: e.Approval.Date, User: e.Approval.User, Action: ParticipantStatusUnapproved}
+}
+
+// ToEvent returns the changeset event metadata of the given webhook event.
+func (e *PullRequestChangesRequestCreatedEvent) ToEvent() *ParticipantStatusEvent {
+	return &ParticipantStatusEvent{Date: e.ChangesRequest.Date, User: e.ChangesRequest.User, Action: ParticipantStatusChangesRequested}
+}
+
+// ToEvent returns the changeset event metadata of the given webhook event.
+func (e *PullRequestChangesRequestRemovedEvent) ToEvent() *ParticipantStatusEvent {
+	return &ParticipantStatusEvent{Date: e.ChangesRequest.Date, User: e.ChangesRequest.User, Action: ParticipantStatusChangesRequestRemoved}
+}
+
+// ToEvent returns the changeset event metadata of the given webhook event.
+func (e *PullRequestFulfilledEvent) ToEvent() *PullRequestStateEvent {
+	return &PullRequestStateEvent{Date: e.PullRequest.UpdatedOn, Actor: e.Actor, State: PullRequestStateMerged}
+}
+
+// ToEvent returns the changeset event metadata of the given webhook event.
+func (e *PullRequestRejectedEvent) ToEvent() *PullRequestStateEvent {
+	return &PullRequestStateEvent{Date: e.PullRequest.UpdatedOn, Actor: e.Actor, State: PullRequestStateDeclined}
+}
@@ -0,0 +1,105 @@
+package bitbucketcloud
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type participantStatusEventer interface {
+	ToEvent() *ParticipantStatusEvent
+}
+
+type pullRequestStateEventer interface {
+	ToEvent() *PullRequestStateEvent
+}
+
+func TestParseWebhookEvent(t *testing.T) {
+	date := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	user := Account{DisplayName: "Alice", UUID: "{alice}"}
+
+	for _, tc := range []struct {
+		eventType string
+		payload   string
+		want      interface{}
+	}{
+		{
+			eventType: "pullrequest:approved",
+			payload:   `{"pullrequest": {"id": 1}, "approval": {"date": "2021-10-01T12:00:00Z", "user": {"display_name": "Alice", "uuid": "{alice}"}}}`,
+			want:      &ParticipantStatusEvent{Date: date, User: user, Action: ParticipantStatusApproved},
+		},
+		{
+			eventType: "pullrequest:unapproved",
+			payload:   `{"pullrequest": {"id": 1}, "approval": {"date": "2021-10-01T12:00:00Z", "user": {"display_name": "Alice", "uuid": "{alice}"}}}`,
+			want:      &ParticipantStatusEvent{Date: date, User: user, Action: ParticipantStatusUnapproved},
+		},
+		{
+			eventType: "pullrequest:changes_request_created",
+			payload:   `{"pullrequest": {"id": 1}, "changes_request": {"date": "2021-10-01T12:00:00Z", "user": {"display_name": "Alice", "uuid": "{alice}"}}}`,
+			want:      &ParticipantStatusEvent{Date: date, User: user, Action: ParticipantStatusChangesRequested},
+		},
+		{
+			eventType: "pullrequest:changes_request_removed",
+			payload:   `{"pullrequest": {"id": 1}, "changes_request": {"date": "2021-10-01T12:00:00Z", "user": {"display_name": "Alice", "uuid": "{alice}"}}}`,
+			want:      &ParticipantStatusEvent{Date: date, User: user, Action: ParticipantStatusChangesRequestRemoved},
+		},
+		{
+			eventType: "pullrequest:fulfilled",
+			payload:   `{"pullrequest": {"id": 1, "state": "MERGED", "updated_on": "2021-10-01T12:00:00Z"}, "actor": {"display_name": "Alice", "uuid": "{alice}"}}`,
+			want:      &PullRequestStateEvent{Date: date, Actor: user, State: PullRequestStateMerged},
+		},
+		{
+			eventType: "pullrequest:rejected",
+			payload:   `{"pullrequest": {"id": 1, "state": "DECLINED", "updated_on": "2021-10-01T12:00:00Z"}, "actor": {"display_name": "Alice", "uuid": "{alice}"}}`,
+			want:      &PullRequestStateEvent{Date: date, Actor: user, State: PullRequestStateDeclined},
+		},
+	} {
+		t.Run(tc.eventType, func(t *testing.T) {
+			e, err := ParseWebhookEvent(tc.eventType, []byte(tc.payload))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var have interface{}
+			switch e := e.(type) {
+			case participantStatusEventer:
+				have = e.ToEvent()
+			case pullRequestStateEventer:
+				have = e.ToEvent()
+			default:
+				t.Fatalf("unexpected event type %T", e)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Errorf("unexpected event (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if e, err := ParseWebhookEvent("pullrequest:updated", []byte(`{}`)); e != nil || err != nil {
+		t.Errorf("expected ignored event, got %v, %v", e, err)
+	}
+	if _, err := ParseWebhookEvent("repo:fork", []byte(`{}`)); err == nil {
+		t.Error("expected error for unknown event type")
+	}
+}
+
+func TestPullRequest_ParticipantStatusEvents(t *testing.T) {
+	date := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	pr := &PullRequest{
+		Participants: []Participant{
+			{User: Account{UUID: "{alice}"}, Role: "REVIEWER", Approved: true, State: ParticipantStateApproved, ParticipatedOn: &date},
+			{User: Account{UUID: "{bob}"}, Role: "REVIEWER", State: ParticipantStateChangesRequested, ParticipatedOn: &date},
+			{User: Account{UUID: "{carol}"}, Role: "REVIEWER"},
+			{User: Account{UUID: "{dave}"}, Role: "PARTICIPANT", ParticipatedOn: &date},
+		},
+	}
+
+	want := []*ParticipantStatusEvent{
+		{Date: date, User: Account{UUID: "{alice}"}, Action: ParticipantStatusApproved},
+		{Date: date, User: Account{UUID: "{bob}"}, Action: ParticipantStatusChangesRequested},
+	}
+	if diff := cmp.Diff(want, pr.ParticipantStatusEvents()); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,270 @@
+package bitbucketcloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// PullRequestState is the state of a Bitbucket Cloud pull request.
+type PullRequestState string
+
+const (
+	PullRequestStateOpen       PullRequestState = "OPEN"
+	PullRequestStateMerged     PullRequestState = "MERGED"
+	PullRequestStateDeclined   PullRequestState = "DECLINED"
+	PullRequestStateSuperseded PullRequestState = "SUPERSEDED"
+)
+
+// PullRequest is a Bitbucket Cloud pull request.
+type PullRequest struct {
+	ID                int                 `json:"id"`
+	Title             string              `json:"title"`
+	Description       string              `json:"description"`
+	State             PullRequestState    `json:"state"`
+	Author            Account             `json:"author"`
+	Source            PullRequestEndpoint `json:"source"`
+	Destination       PullRequestEndpoint `json:"destination"`
+	Participants      []Participant       `json:"participants"`
+	CloseSourceBranch bool                `json:"close_source_branch"`
+	CreatedOn         time.Time           `json:"created_on"`
+	UpdatedOn         time.Time           `json:"updated_on"`
+	Links             Links               `json:"links"`
+
+	// Statuses are the build statuses of the commits of the pull request.
+	// They are not returned by the pull request endpoints and have to be
+	// loaded with LoadPullRequestStatuses.
+	Statuses []*PullRequestStatus `json:"statuses,omitempty"`
+}
+
+// PullRequestEndpoint is the source or destination of a pull request.
+type PullRequestEndpoint struct {
+	Branch     PullRequestBranch  `json:"branch"`
+	Commit     *PullRequestCommit `json:"commit,omitempty"`
+	Repository Repo               `json:"repository"`
+}
+
+type PullRequestBranch struct {
+	Name string `json:"name"`
+}
+
+type PullRequestCommit struct {
+	Hash string `json:"hash"`
+}
+
+// Account is a Bitbucket Cloud user or team.
+type Account struct {
+	AccountID   string `json:"account_id"`
+	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
+	Username    string `json:"username,omitempty"`
+	UUID        string `json:"uuid"`
+}
+
+// ParticipantState is the review state of a pull request participant.
+type ParticipantState string
+
+const (
+	ParticipantStateApproved         ParticipantState = "approved"
+	ParticipantStateChangesRequested ParticipantState = "changes_requested"
+)
+
+// Participant is a reviewer of, or another user participating in, a pull
+// request.
+type Participant struct {
+	User           Account          `json:"user"`
+	Role           string           `json:"role"`
+	Approved       bool             `json:"approved"`
+	State          ParticipantState `json:"state"`
+	ParticipatedOn *time.Time       `json:"participated_on"`
+}
+
+// PullRequestStatus is the build status of a commit.
+type PullRequestStatus struct {
+	// BuildKey identifies the build that reported the status, which is unique
+	// per commit.
+	BuildKey    string    `json:"key"`
+	Name        string    `json:"name"`
+	URL         string    `json:"url"`
+	State       string    `json:"state"`
+	Description string    `json:"description"`
+	CreatedOn   time.Time `json:"created_on"`
+	UpdatedOn   time.Time `json:"updated_on"`
+}
+
+// PullRequestInput is the input for creating and updating pull requests.
+type PullRequestInput struct {
+	Title        string
+	Description  string
+	SourceBranch string
+	// DestinationBranch is the branch the pull request is merged into. If
+	// empty when creating a pull request, the main branch of the repository
+	// is used.
+	DestinationBranch string
+}
+
+// CreatePullRequest creates a pull request in the given repository. Bitbucket
+// Cloud does not reject pull requests with the same source and destination
+// branch as an existing open pull request, but updates the existing pull
+// request instead, in which case the returned boolean is true.
+func (c *Client) CreatePullRequest(ctx context.Context, repo *Repo, in PullRequestInput) (*PullRequest, bool, error) {
+	req, err := c.newJSONRequest("POST", fmt.Sprintf("/2.0/repositories/%s/pullrequests", repo.FullName), pullRequestInputJSON(in))
+	if err != nil {
+		return nil, false, err
+	}
+
+	var pr PullRequest
+	status, err := c.doWithStatus(ctx, req, &pr)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &pr, status == http.StatusOK, nil
+}
+
+// GetPullRequest retrieves a single pull request.
+func (c *Client) GetPullRequest(ctx context.Context, repo *Repo, id int) (*PullRequest, error) {
+	req, err := http.NewRequest("GET", pullRequestPath(repo, id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+
+	return &pr, nil
+}
+
+// UpdatePullRequest updates the title, description and destination branch of
+// a pull request.
+func (c *Client) UpdatePullRequest(ctx context.Context, repo *Repo, id int, in PullRequestInput) (*PullRequest, error) {
+	req, err := c.newJSONRequest("PUT", pullRequestPath(repo, id), pullRequestInputJSON(in))
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+
+	return &pr, nil
+}
+
+// DeclinePullRequest declines a pull request. Declined pull requests cannot be
+// reopened.
+func (c *Client) DeclinePullRequest(ctx context.Context, repo *Repo, id int) (*PullRequest, error) {
+	req, err := http.NewRequest("POST", pullRequestPath(repo, id)+"/decline", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+
+	return &pr, nil
+}
+
+// MergePullRequest merges a pull request, squashing its commits into a single
+// commit if squash is true.
+func (c *Client) MergePullRequest(ctx context.Context, repo *Repo, id int, squash bool) (*PullRequest, error) {
+	strategy := "merge_commit"
+	if squash {
+		strategy = "squash"
+	}
+
+	req, err := c.newJSONRequest("POST", pullRequestPath(repo, id)+"/merge", map[string]interface{}{
+		"merge_strategy": strategy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+
+	return &pr, nil
+}
+
+// CreatePullRequestComment adds a comment to a pull request.
+func (c *Client) CreatePullRequestComment(ctx context.Context, repo *Repo, id int, text string) error {
+	req, err := c.newJSONRequest("POST", pullRequestPath(repo, id)+"/comments", map[string]interface{}{
+		"content": map[string]string{"raw": text},
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, req, nil)
+}
+
+// LoadPullRequestStatuses loads the build statuses of the commits of the given
+// pull request into its Statuses field.
+func (c *Client) LoadPullRequestStatuses(ctx context.Context, repo *Repo, pr *PullRequest) error {
+	var statuses []*PullRequestStatus
+
+	next, err := c.page(ctx, pullRequestPath(repo, pr.ID)+"/statuses", nil, nil, &statuses)
+	if err != nil {
+		return err
+	}
+	for next.HasMore() {
+		var page []*PullRequestStatus
+		if next, err = c.reqPage(ctx, next.Next, &page); err != nil {
+			return err
+		}
+		statuses = append(statuses, page...)
+	}
+
+	pr.Statuses = statuses
+	return nil
+}
+
+// IsMergeConflict reports whether err is returned by MergePullRequest because
+// the pull request cannot be merged, e.g. due to conflicts or unmet merge
+// checks.
+func IsMergeConflict(err error) bool {
+	var e *httpError
+	return errors.As(err, &e) && (e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusConflict)
+}
+
+func (c *Client) newJSONRequest(method, path string, body interface{}) (*http.Request, error) {
+	bs, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return http.NewRequest(method, path, bytes.NewReader(bs))
+}
+
+func pullRequestPath(repo *Repo, id int) string {
+	return fmt.Sprintf("/2.0/repositories/%s/pullrequests/%d", repo.FullName, id)
+}
+
+func pullRequestInputJSON(in PullRequestInput) map[string]interface{} {
+	body := map[string]interface{}{
+		"title":       in.Title,
+		"description": in.Description,
+	}
+	if in.SourceBranch != "" {
+		body["source"] = map[string]interface{}{
+			"branch": map[string]string{"name": in.SourceBranch},
+		}
+	}
+	if in.DestinationBranch != "" {
+		body["destination"] = map[string]interface{}{
+			"branch": map[string]string{"name": in.DestinationBranch},
+		}
+	}
+	return body
+}
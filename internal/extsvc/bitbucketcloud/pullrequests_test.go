@@ -0,0 +1,186 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+)
+
+func newPullRequestTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cli := NewClient(u, srv.Client())
+	cli.Username = "user"
+	cli.AppPassword = "password"
+	return cli
+}
+
+func TestClient_CreatePullRequest(t *testing.T) {
+	repo := &Repo{FullName: "sglocal/mux"}
+	in := PullRequestInput{
+		Title:             "title",
+		Description:       "description",
+		SourceBranch:      "feature",
+		DestinationBranch: "main",
+	}
+
+	for _, tc := range []struct {
+		name       string
+		status     int
+		wantExists bool
+	}{
+		{name: "created", status: http.StatusCreated, wantExists: false},
+		{name: "existing pull request updated", status: http.StatusOK, wantExists: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cli := newPullRequestTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" || r.URL.Path != "/2.0/repositories/sglocal/mux/pullrequests" {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+
+				var body map[string]interface{}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Fatal(err)
+				}
+				want := map[string]interface{}{
+					"title":       "title",
+					"description": "description",
+					"source":      map[string]interface{}{"branch": map[string]interface{}{"name": "feature"}},
+					"destination": map[string]interface{}{"branch": map[string]interface{}{"name": "main"}},
+				}
+				if diff := cmp.Diff(want, body); diff != "" {
+					t.Errorf("unexpected request body (-want +got):\n%s", diff)
+				}
+
+				w.WriteHeader(tc.status)
+				io.WriteString(w, `{"id": 42, "title": "title", "state": "OPEN"}`)
+			})
+
+			pr, exists, err := cli.CreatePullRequest(context.Background(), repo, in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if exists != tc.wantExists {
+				t.Errorf("unexpected exists: want %v, got %v", tc.wantExists, exists)
+			}
+			if pr.ID != 42 || pr.State != PullRequestStateOpen {
+				t.Errorf("unexpected pull request: %+v", pr)
+			}
+		})
+	}
+}
+
+func TestClient_GetPullRequest_NotFound(t *testing.T) {
+	cli := newPullRequestTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := cli.GetPullRequest(context.Background(), &Repo{FullName: "sglocal/mux"}, 42)
+	if !IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestClient_MergePullRequest(t *testing.T) {
+	for _, tc := range []struct {
+		squash       bool
+		wantStrategy string
+	}{
+		{squash: false, wantStrategy: "merge_commit"},
+		{squash: true, wantStrategy: "squash"},
+	} {
+		cli := newPullRequestTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/2.0/repositories/sglocal/mux/pullrequests/42/merge" {
+				t.Errorf("unexpected path: %s", r.URL.Path)
+			}
+
+			var body struct {
+				MergeStrategy string `json:"merge_strategy"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body.MergeStrategy != tc.wantStrategy {
+				t.Errorf("unexpected merge strategy: want %q, got %q", tc.wantStrategy, body.MergeStrategy)
+			}
+
+			w.WriteHeader(http.StatusBadRequest)
+		})
+
+		_, err := cli.MergePullRequest(context.Background(), &Repo{FullName: "sglocal/mux"}, 42, tc.squash)
+		if !IsMergeConflict(err) {
+			t.Fatalf("expected merge conflict error, got %v", err)
+		}
+	}
+}
+
+func TestClient_LoadPullRequestStatuses(t *testing.T) {
+	var srvURL string
+	cli := newPullRequestTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			io.WriteString(w, `{"values": [{"key": "second", "state": "FAILED"}]}`)
+			return
+		}
+		io.WriteString(w, `{"values": [{"key": "first", "state": "SUCCESSFUL"}], "next": "`+srvURL+`/2.0/repositories/sglocal/mux/pullrequests/42/statuses?page=2"}`)
+	})
+	srvURL = cli.URL.String()
+
+	pr := &PullRequest{ID: 42}
+	if err := cli.LoadPullRequestStatuses(context.Background(), &Repo{FullName: "sglocal/mux"}, pr); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*PullRequestStatus{
+		{BuildKey: "first", State: "SUCCESSFUL"},
+		{BuildKey: "second", State: "FAILED"},
+	}
+	if diff := cmp.Diff(want, pr.Statuses); diff != "" {
+		t.Errorf("unexpected statuses (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_WithAuthenticator(t *testing.T) {
+	cli := newPullRequestTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if username, password, _ := r.BasicAuth(); username != "other" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		io.WriteString(w, `{"username": "other", "uuid": "{1}"}`)
+	})
+
+	if _, err := cli.CurrentUser(context.Background()); !IsUnauthorized(err) {
+		t.Fatalf("expected unauthorized error, got %v", err)
+	}
+
+	other, err := cli.WithAuthenticator(&auth.BasicAuth{Username: "other", Password: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := other.CurrentUser(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Username != "other" {
+		t.Errorf("unexpected account: %+v", account)
+	}
+
+	if _, err := cli.WithAuthenticator(&auth.OAuthBearerToken{Token: "token"}); err == nil {
+		t.Error("expected error for unsupported authenticator")
+	}
+}
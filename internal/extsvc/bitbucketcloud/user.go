@@ -0,0 +1,21 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"net/http"
+)
+
+// CurrentUser returns the account of the user the client is authenticated as.
+func (c *Client) CurrentUser(ctx context.Context) (*Account, error) {
+	req, err := http.NewRequest("GET", "/2.0/user", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var account Account
+	if err := c.do(ctx, req, &account); err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
@@ -1208,6 +1208,7 @@ type PullRequest struct {
 			Href string `json:"href"`
 		} `json:"self"`
 	} `json:"links"`
+	Properties *PullRequestProperties `json:"properties,omitempty"`
 
 	Activities   []*Activity     `json:"activities,omitempty"`
 	Commits      []*Commit       `json:"commits,omitempty"`
@@ -1217,6 +1218,22 @@ type PullRequest struct {
 	BuildStatuses []*BuildStatus `json:"buildstatuses,omitempty"`
 }
 
+// PullRequestProperties are the computed properties of a pull request.
+type PullRequestProperties struct {
+	// MergeResult is only returned once Bitbucket Server has computed whether
+	// the pull request can be merged.
+	MergeResult *PullRequestMergeResult `json:"mergeResult,omitempty"`
+}
+
+// PullRequestMergeResult is the result of a dry-run merge of a pull request.
+type PullRequestMergeResult struct {
+	// Outcome is one of CLEAN, CONFLICTED or UNKNOWN.
+	Outcome string `json:"outcome"`
+	// Current is false if the outcome was computed for an earlier commit of
+	// the source or target branch.
+	Current bool `json:"current"`
+}
+
 // PullRequestAuthor is the author of a pull request.
 type PullRequestAuthor struct {
 	User     *User  `json:"user"`
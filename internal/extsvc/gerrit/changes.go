@@ -0,0 +1,231 @@
+package gerrit
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChangeStatus is the status of a change.
+type ChangeStatus string
+
+// ChangeStatus constants.
+const (
+	ChangeStatusNew       ChangeStatus = "NEW"
+	ChangeStatusMerged    ChangeStatus = "MERGED"
+	ChangeStatusAbandoned ChangeStatus = "ABANDONED"
+)
+
+// Labels that are configured on most Gerrit instances. Code-Review records the
+// review state of a change, and Verified the result of CI builds.
+const (
+	LabelCodeReview = "Code-Review"
+	LabelVerified   = "Verified"
+)
+
+// Change is a change (the equivalent of a pull request) on Gerrit.
+//
+// See https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#change-info
+type Change struct {
+	// ID identifies the change in the REST API. It is either the triplet
+	// <project>~<branch>~<Change-Id> or <project>~<number>, depending on the
+	// version of Gerrit.
+	ID              string               `json:"id"`
+	Project         string               `json:"project"`
+	Branch          string               `json:"branch"`
+	Topic           string               `json:"topic,omitempty"`
+	ChangeID        string               `json:"change_id"`
+	Subject         string               `json:"subject"`
+	Status          ChangeStatus         `json:"status"`
+	Created         Timestamp            `json:"created"`
+	Updated         Timestamp            `json:"updated"`
+	Submittable     bool                 `json:"submittable,omitempty"`
+	WorkInProgress  bool                 `json:"work_in_progress,omitempty"`
+	Number          int                  `json:"_number"`
+	Owner           Account              `json:"owner"`
+	Labels          map[string]Label     `json:"labels,omitempty"`
+	CurrentRevision string               `json:"current_revision,omitempty"`
+	Revisions       map[string]*Revision `json:"revisions,omitempty"`
+
+	// URL is the URL of the change in the Gerrit UI. It is not part of the
+	// REST API, but filled in by the Client.
+	URL string `json:"url,omitempty"`
+}
+
+// Label is the state of a review label of a change.
+//
+// See https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#label-info
+type Label struct {
+	Approved    *Account `json:"approved,omitempty"`
+	Rejected    *Account `json:"rejected,omitempty"`
+	Recommended *Account `json:"recommended,omitempty"`
+	Disliked    *Account `json:"disliked,omitempty"`
+}
+
+// Revision is a patch set of a change.
+type Revision struct {
+	Number int    `json:"_number"`
+	Ref    string `json:"ref"`
+	Commit Commit `json:"commit"`
+}
+
+// Commit is the commit of a revision.
+type Commit struct {
+	Parents []struct {
+		Commit string `json:"commit"`
+	} `json:"parents"`
+	Subject string `json:"subject"`
+	Message string `json:"message"`
+}
+
+// Description returns the commit message of the current revision of the
+// change, without its subject and Change-Id trailer.
+func (c *Change) Description() string {
+	r, ok := c.Revisions[c.CurrentRevision]
+	if !ok {
+		return ""
+	}
+
+	message := stripChangeID(r.Commit.Message)
+	if i := strings.Index(message, "\n"); i >= 0 {
+		return strings.TrimSpace(message[i:])
+	}
+	return ""
+}
+
+// BaseRevision returns the parent commit of the current revision of the
+// change, or an empty string if it isn't known.
+func (c *Change) BaseRevision() string {
+	if r, ok := c.Revisions[c.CurrentRevision]; ok && len(r.Commit.Parents) > 0 {
+		return r.Commit.Parents[0].Commit
+	}
+	return ""
+}
+
+// timestampLayout is the layout of timestamps in the REST API, which are
+// always in UTC.
+const timestampLayout = "2006-01-02 15:04:05.000000000"
+
+// Timestamp is a timestamp as returned by the REST API.
+type Timestamp struct {
+	time.Time
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(t.UTC().Format(timestampLayout))), nil
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	s, err := strconv.Unquote(string(data))
+	if err != nil {
+		return err
+	}
+	t.Time, err = time.Parse(timestampLayout, s)
+	return err
+}
+
+// changeOptions are the additional fields requested for changes.
+var changeOptions = []string{"CURRENT_REVISION", "CURRENT_COMMIT", "DETAILED_LABELS", "SUBMITTABLE"}
+
+// GetChange returns the change with the given ID, which can be anything that
+// identifies a change in the REST API, such as its Change-Id or its ID field.
+//
+// Like the ID field, the IDs passed to the change methods of Client must be
+// URL encoded.
+func (c *Client) GetChange(ctx context.Context, id string) (*Change, error) {
+	qs := url.Values{"o": changeOptions}
+
+	var change Change
+	if err := c.get(ctx, "changes/"+id, qs, &change); err != nil {
+		return nil, err
+	}
+	change.URL = c.changeURL(&change)
+	return &change, nil
+}
+
+func (c *Client) changeURL(change *Change) string {
+	u := *c.URL
+	u.Path += "c/" + change.Project + "/+/" + strconv.Itoa(change.Number)
+	return u.String()
+}
+
+// AbandonChange abandons the change with the given ID.
+func (c *Client) AbandonChange(ctx context.Context, id string) error {
+	return c.post(ctx, "changes/"+id+"/abandon", nil, nil)
+}
+
+// RestoreChange restores the abandoned change with the given ID.
+func (c *Client) RestoreChange(ctx context.Context, id string) error {
+	return c.post(ctx, "changes/"+id+"/restore", nil, nil)
+}
+
+// SetWorkInProgress marks the change with the given ID as work in progress,
+// which hides it from reviewers.
+func (c *Client) SetWorkInProgress(ctx context.Context, id string) error {
+	return c.post(ctx, "changes/"+id+"/wip", nil, nil)
+}
+
+// SetReadyForReview marks the change with the given ID as ready for review.
+func (c *Client) SetReadyForReview(ctx context.Context, id string) error {
+	return c.post(ctx, "changes/"+id+"/ready", nil, nil)
+}
+
+// SubmitChange submits (that is, merges) the change with the given ID. A
+// conflict error is returned if the change isn't submittable.
+func (c *Client) SubmitChange(ctx context.Context, id string) error {
+	return c.post(ctx, "changes/"+id+"/submit", nil, nil)
+}
+
+// CreateChangeComment posts a comment on the current revision of the change
+// with the given ID.
+func (c *Client) CreateChangeComment(ctx context.Context, id, message string) error {
+	body := struct {
+		Message string `json:"message"`
+	}{message}
+	return c.post(ctx, "changes/"+id+"/revisions/current/review", body, nil)
+}
+
+// GenerateChangeID returns a Change-Id derived from the given key. Pushing
+// commits with the same Change-Id to the same branch creates new patch sets of
+// one change rather than new changes.
+func GenerateChangeID(key string) string {
+	sum := sha1.Sum([]byte(key))
+	return "I" + hex.EncodeToString(sum[:])
+}
+
+// WithChangeID returns the given commit message with a Change-Id trailer for
+// the given Change-Id. Existing Change-Id trailers are replaced, since Gerrit
+// rejects commits with more than one.
+func WithChangeID(message, changeID string) string {
+	return strings.TrimRight(stripChangeID(message), "\n") + "\n\nChange-Id: " + changeID + "\n"
+}
+
+func stripChangeID(message string) string {
+	lines := strings.Split(message, "\n")
+	kept := lines[:0]
+	for _, l := range lines {
+		if !strings.HasPrefix(l, "Change-Id: ") {
+			kept = append(kept, l)
+		}
+	}
+	return strings.TrimRight(strings.Join(kept, "\n"), "\n")
+}
+
+// PushRef returns the ref to push commits to for creating or updating a change
+// for the given target branch. The change is assigned the given topic, which
+// makes it possible to find the change by the branch it was created from. The
+// topic must not contain commas or percent signs, which separate and encode
+// push options.
+//
+// See https://gerrit-review.googlesource.com/Documentation/user-upload.html#push_create
+func PushRef(branch, topic string) string {
+	ref := "refs/for/" + branch
+	if topic != "" {
+		ref += "%topic=" + topic
+	}
+	return ref
+}
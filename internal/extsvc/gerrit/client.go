@@ -0,0 +1,264 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing-contrib/go-stdlib/nethttp"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
+)
+
+var requestCounter = metrics.NewRequestMeter("gerrit_requests_count", "Total number of requests sent to the Gerrit API.")
+
+// These fields define the self-imposed Gerrit rate limit, since Gerrit does
+// not report rate limits in HTTP response headers.
+const (
+	rateLimitRequestsPerSecond = 8 // 480/min or 28,800/hr
+	rateLimitMaxBurstRequests  = 500
+)
+
+// responsePrefix is prepended by Gerrit to all JSON responses to prevent
+// cross-site script inclusion.
+//
+// See https://gerrit-review.googlesource.com/Documentation/rest-api.html#output
+var responsePrefix = []byte(")]}'")
+
+// Client accesses a Gerrit instance via its REST API.
+type Client struct {
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// URL is the base URL of Gerrit.
+	URL *url.URL
+
+	// Auth is used to authenticate requests. Gerrit only accepts HTTP basic
+	// authentication with the HTTP password of an account.
+	Auth auth.Authenticator
+
+	// RateLimit is the self-imposed rate limiter (since Gerrit does not have a
+	// concept of rate limiting in HTTP response headers).
+	RateLimit *rate.Limiter
+}
+
+// NewClient returns a new Gerrit API client for the instance at the given
+// URL, authenticated with the given authenticator. If a nil httpClient is
+// provided, httpcli.ExternalDoer will be used.
+func NewClient(u *url.URL, a auth.Authenticator, httpClient httpcli.Doer) (*Client, error) {
+	if err := checkAuthenticator(a); err != nil {
+		return nil, err
+	}
+
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	httpClient = requestCounter.Doer(httpClient, func(u *url.URL) string {
+		// The second component of the path (after the /a/ prefix of
+		// authenticated requests) maps to the type of API request.
+		var category string
+		if parts := strings.SplitN(u.Path, "/", 4); len(parts) > 2 {
+			category = parts[2]
+		}
+		return category
+	})
+
+	// Normally our registry will return a default infinite limiter when
+	// nothing has been synced from config. However, we always want to ensure
+	// there is at least some form of rate limiting for Gerrit.
+	defaultLimiter := rate.NewLimiter(rateLimitRequestsPerSecond, rateLimitMaxBurstRequests)
+	l := ratelimit.DefaultRegistry.GetOrSet(u.String(), defaultLimiter)
+
+	// API paths are resolved relative to the base URL, which must end with a
+	// slash for Gerrit instances that aren't served from the root.
+	if !strings.HasSuffix(u.Path, "/") {
+		u = &url.URL{Scheme: u.Scheme, User: u.User, Host: u.Host, Path: u.Path + "/"}
+	}
+
+	return &Client{
+		httpClient: httpClient,
+		URL:        u,
+		Auth:       a,
+		RateLimit:  l,
+	}, nil
+}
+
+// WithAuthenticator returns a new Client that uses the same configuration as
+// the current Client, except authenticated with the given authenticator.
+func (c *Client) WithAuthenticator(a auth.Authenticator) (*Client, error) {
+	if err := checkAuthenticator(a); err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		httpClient: c.httpClient,
+		URL:        c.URL,
+		Auth:       a,
+		RateLimit:  c.RateLimit,
+	}, nil
+}
+
+func checkAuthenticator(a auth.Authenticator) error {
+	switch a.(type) {
+	case *auth.BasicAuth, *auth.BasicAuthWithSSH:
+		return nil
+	default:
+		return errors.Errorf("authenticator type unsupported for Gerrit clients: %T", a)
+	}
+}
+
+// Account is an account on Gerrit.
+//
+// See https://gerrit-review.googlesource.com/Documentation/rest-api-accounts.html#account-info
+type Account struct {
+	ID       int    `json:"_account_id"`
+	Name     string `json:"name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// GetAuthenticatedAccount returns the account the client is authenticated as.
+func (c *Client) GetAuthenticatedAccount(ctx context.Context) (*Account, error) {
+	var account Account
+	if err := c.get(ctx, "accounts/self", nil, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// get sends an authenticated GET request to the given path of the REST API
+// and decodes the response into result. Path segments that are names or IDs
+// must already be escaped with url.PathEscape.
+func (c *Client) get(ctx context.Context, path string, qs url.Values, result interface{}) error {
+	ref := "a/" + path
+	if len(qs) > 0 {
+		ref += "?" + qs.Encode()
+	}
+	req, err := http.NewRequest("GET", ref, nil)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, req, result)
+}
+
+// post sends an authenticated POST request with the given JSON body to the
+// given path of the REST API and decodes the response into result, if it is
+// not nil.
+func (c *Client) post(ctx context.Context, path string, body interface{}, result interface{}) error {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest("POST", "a/"+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	return c.do(ctx, req, result)
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, result interface{}) error {
+	req.URL = c.URL.ResolveReference(req.URL)
+	req.Header.Set("Accept", "application/json")
+
+	req, ht := nethttp.TraceRequest(ot.GetTracer(ctx),
+		req.WithContext(ctx),
+		nethttp.OperationName("Gerrit"),
+		nethttp.ClientTrace(false))
+	defer ht.Finish()
+
+	if err := c.Auth.Authenticate(req); err != nil {
+		return err
+	}
+
+	startWait := time.Now()
+	if err := c.RateLimit.Wait(ctx); err != nil {
+		return err
+	}
+
+	if d := time.Since(startWait); d > 200*time.Millisecond {
+		log15.Warn("Gerrit self-enforced API rate limit: request delayed longer than expected due to rate limit", "delay", d)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	bs = bytes.TrimPrefix(bs, responsePrefix)
+	if result == nil || len(bytes.TrimSpace(bs)) == 0 {
+		return nil
+	}
+	return json.Unmarshal(bs, result)
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Gerrit API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// IsNotFound reports whether err is a Gerrit API not found error.
+func IsNotFound(err error) bool {
+	var e *httpError
+	return errors.As(err, &e) && e.NotFound()
+}
+
+// IsUnauthorized reports whether err is a Gerrit API unauthorized error.
+func IsUnauthorized(err error) bool {
+	var e *httpError
+	return errors.As(err, &e) && e.Unauthorized()
+}
+
+// IsConflict reports whether err is a Gerrit API conflict error, which is
+// returned when the requested operation isn't possible in the current state of
+// a change, for example when submitting a change that isn't submittable.
+func IsConflict(err error) bool {
+	var e *httpError
+	return errors.As(err, &e) && e.StatusCode == http.StatusConflict
+}
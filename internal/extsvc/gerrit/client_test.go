@@ -0,0 +1,217 @@
+package gerrit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL + "/gerrit")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cli, err := NewClient(u, &auth.BasicAuth{Username: "admin", Password: "secret"}, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cli
+}
+
+func TestNewClient_UnsupportedAuthenticator(t *testing.T) {
+	u, _ := url.Parse("https://gerrit.example.com")
+	if _, err := NewClient(u, &auth.OAuthBearerToken{Token: "token"}, nil); err == nil {
+		t.Fatal("expected an error for an OAuth token")
+	}
+}
+
+func TestClient_GetChange(t *testing.T) {
+	cli := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); user != "admin" || pass != "secret" {
+			t.Errorf("unexpected credentials: %s:%s", user, pass)
+		}
+		if want := "/gerrit/a/changes/platform%2Fbuild~main~I0123"; r.URL.EscapedPath() != want {
+			t.Errorf("unexpected path: want %q, got %q", want, r.URL.EscapedPath())
+		}
+		if want := changeOptions; !cmp.Equal(want, r.URL.Query()["o"]) {
+			t.Errorf("unexpected options: want %v, got %v", want, r.URL.Query()["o"])
+		}
+
+		io.WriteString(w, `)]}'
+{
+  "id": "platform%2Fbuild~main~I0123",
+  "project": "platform/build",
+  "branch": "main",
+  "topic": "batch-branch",
+  "change_id": "I0123",
+  "subject": "Fix the build",
+  "status": "NEW",
+  "created": "2022-02-01 09:59:32.126000000",
+  "updated": "2022-02-02 10:00:00.000000000",
+  "_number": 42,
+  "current_revision": "abc",
+  "revisions": {
+    "abc": {
+      "_number": 2,
+      "ref": "refs/changes/42/42/2",
+      "commit": {
+        "parents": [{"commit": "def"}],
+        "subject": "Fix the build",
+        "message": "Fix the build\n\nIt was broken.\n\nChange-Id: I0123\n"
+      }
+    }
+  }
+}`)
+	})
+
+	change, err := cli.GetChange(context.Background(), "platform%2Fbuild~main~I0123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := time.Date(2022, 2, 1, 9, 59, 32, 126000000, time.UTC); !change.Created.Equal(want) {
+		t.Errorf("unexpected created time: want %s, got %s", want, change.Created)
+	}
+	if want := "It was broken."; change.Description() != want {
+		t.Errorf("unexpected description: want %q, got %q", want, change.Description())
+	}
+	if want := "def"; change.BaseRevision() != want {
+		t.Errorf("unexpected base revision: want %q, got %q", want, change.BaseRevision())
+	}
+	if want := cli.URL.String() + "c/platform/build/+/42"; change.URL != want {
+		t.Errorf("unexpected URL: want %q, got %q", want, change.URL)
+	}
+
+	// Changes are stored as changeset metadata, so they must survive a round
+	// trip through JSON.
+	data, err := json.Marshal(change)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Change
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(change, &got); diff != "" {
+		t.Errorf("unexpected change after round trip (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_ListProjects(t *testing.T) {
+	cli := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gerrit/a/projects/" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		switch r.URL.Query().Get("S") {
+		case "0":
+			io.WriteString(w, `)]}'
+{"b": {"id": "b"}, "a": {"id": "a", "description": "A"}, "c": {"id": "c"}}`)
+		case "2":
+			io.WriteString(w, `)]}'
+{"c": {"id": "c"}}`)
+		default:
+			t.Errorf("unexpected offset: %s", r.URL.Query().Get("S"))
+		}
+	})
+
+	projects, more, err := cli.ListProjects(context.Background(), 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Project{{ID: "a", Name: "a", Description: "A"}, {ID: "b", Name: "b"}}
+	if diff := cmp.Diff(want, projects); diff != "" || !more {
+		t.Errorf("unexpected first page (more=%v, -want +got):\n%s", more, diff)
+	}
+
+	projects, more, err = cli.ListProjects(context.Background(), 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []*Project{{ID: "c", Name: "c"}}
+	if diff := cmp.Diff(want, projects); diff != "" || more {
+		t.Errorf("unexpected second page (more=%v, -want +got):\n%s", more, diff)
+	}
+}
+
+func TestClient_SubmitChange(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		status       int
+		wantConflict bool
+	}{
+		{name: "submitted", status: http.StatusOK},
+		{name: "not submittable", status: http.StatusConflict, wantConflict: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cli := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" || r.URL.EscapedPath() != "/gerrit/a/changes/p~I0123/submit" {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.EscapedPath())
+				}
+				w.WriteHeader(tc.status)
+				io.WriteString(w, `)]}'
+{}`)
+			})
+
+			err := cli.SubmitChange(context.Background(), "p~I0123")
+			if tc.wantConflict {
+				if !IsConflict(err) {
+					t.Fatalf("expected a conflict error, got %v", err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestWithChangeID(t *testing.T) {
+	for _, tc := range []struct {
+		message string
+		want    string
+	}{
+		{
+			message: "Fix the build",
+			want:    "Fix the build\n\nChange-Id: I0123\n",
+		},
+		{
+			message: "Fix the build\n\nIt was broken.\n",
+			want:    "Fix the build\n\nIt was broken.\n\nChange-Id: I0123\n",
+		},
+		{
+			message: "Fix the build\n\nChange-Id: Iffff\n",
+			want:    "Fix the build\n\nChange-Id: I0123\n",
+		},
+	} {
+		if got := WithChangeID(tc.message, "I0123"); got != tc.want {
+			t.Errorf("WithChangeID(%q): want %q, got %q", tc.message, tc.want, got)
+		}
+	}
+}
+
+func TestGenerateChangeID(t *testing.T) {
+	id := GenerateChangeID("github.com/a/b\x00batch-branch")
+	if len(id) != 41 || id[0] != 'I' {
+		t.Fatalf("invalid Change-Id: %q", id)
+	}
+	if id != GenerateChangeID("github.com/a/b\x00batch-branch") {
+		t.Fatal("Change-Id is not stable")
+	}
+	if id == GenerateChangeID("github.com/a/b\x00other-branch") {
+		t.Fatal("Change-Id is the same for different keys")
+	}
+}
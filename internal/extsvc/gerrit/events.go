@@ -0,0 +1,168 @@
+package gerrit
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// ParseWebhookEvent parses the payload of an event, as sent by the webhooks
+// plugin or printed by the stream-events command. The type of the event is
+// part of its payload. Events of types that carry no information relevant to
+// changes are returned as nil without an error.
+//
+// See https://gerrit-review.googlesource.com/Documentation/cmd-stream-events.html#events
+func ParseWebhookEvent(payload []byte) (e interface{}, err error) {
+	var header struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(payload, &header); err != nil {
+		return nil, err
+	}
+
+	switch header.Type {
+	case "change-merged":
+		e = &ChangeMergedEvent{}
+	case "change-abandoned":
+		e = &ChangeAbandonedEvent{}
+	case "change-restored":
+		e = &ChangeRestoredEvent{}
+	case "comment-added":
+		e = &CommentAddedEvent{}
+	case "":
+		return nil, errors.New("missing event type")
+	default:
+		return nil, nil
+	}
+
+	return e, json.Unmarshal(payload, e)
+}
+
+// EventChange is the change an event refers to. Unlike Change, it's in the
+// format of events rather than the REST API.
+type EventChange struct {
+	Project string `json:"project"`
+	Branch  string `json:"branch"`
+	// ID is the Change-Id of the change.
+	ID     string       `json:"id"`
+	Number int          `json:"number"`
+	Topic  string       `json:"topic,omitempty"`
+	Status ChangeStatus `json:"status,omitempty"`
+}
+
+// ChangeEvent holds the fields common to all change events.
+type ChangeEvent struct {
+	Change EventChange `json:"change"`
+	// CreatedOn is the time of the event in seconds since the epoch.
+	CreatedOn int64 `json:"eventCreatedOn"`
+}
+
+// Date returns the time of the event.
+func (e *ChangeEvent) Date() time.Time {
+	return time.Unix(e.CreatedOn, 0).UTC()
+}
+
+// ChangeMergedEvent is sent when a change is submitted.
+type ChangeMergedEvent struct {
+	ChangeEvent
+	Submitter Account `json:"submitter"`
+}
+
+// ChangeAbandonedEvent is sent when a change is abandoned.
+type ChangeAbandonedEvent struct {
+	ChangeEvent
+	Abandoner Account `json:"abandoner"`
+}
+
+// ChangeRestoredEvent is sent when an abandoned change is restored.
+type ChangeRestoredEvent struct {
+	ChangeEvent
+	Restorer Account `json:"restorer"`
+}
+
+// CommentAddedEvent is sent when a review comment is posted on a change,
+// which may also vote on its labels.
+type CommentAddedEvent struct {
+	ChangeEvent
+	Author    Account    `json:"author"`
+	Comment   string     `json:"comment"`
+	Approvals []Approval `json:"approvals"`
+}
+
+// Approval is the vote on a label of a change in a CommentAddedEvent. Votes
+// are signed integers encoded as strings, and OldValue is only set if the
+// comment changed the vote.
+type Approval struct {
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	OldValue string `json:"oldValue,omitempty"`
+}
+
+// ChangeStateEvent is the transition of a change into a new status, i.e. it
+// being merged, abandoned or restored.
+type ChangeStateEvent struct {
+	Date   time.Time    `json:"date"`
+	Actor  Account      `json:"actor"`
+	Status ChangeStatus `json:"status"`
+}
+
+// Key is a unique key identifying this event in the context of its change.
+// Abandoned changes can be restored, so a change can have several events of
+// the same status.
+func (e *ChangeStateEvent) Key() string {
+	return fmt.Sprintf("%s:%d", e.Status, e.Date.Unix())
+}
+
+// ReviewEvent is a change of a reviewer's vote on the Code-Review label of a
+// change.
+type ReviewEvent struct {
+	Date     time.Time `json:"date"`
+	Author   Account   `json:"author"`
+	Value    int       `json:"value"`
+	OldValue int       `json:"oldValue"`
+}
+
+// Key is a unique key identifying this event in the context of its change.
+func (e *ReviewEvent) Key() string {
+	return fmt.Sprintf("%s:%d:%d", e.Author.Username, e.Value, e.Date.Unix())
+}
+
+// ToEvent returns the changeset event metadata of the given event.
+func (e *ChangeMergedEvent) ToEvent() *ChangeStateEvent {
+	return &ChangeStateEvent{Date: e.Date(), Actor: e.Submitter, Status: ChangeStatusMerged}
+}
+
+// ToEvent returns the changeset event metadata of the given event.
+func (e *ChangeAbandonedEvent) ToEvent() *ChangeStateEvent {
+	return &ChangeStateEvent{Date: e.Date(), Actor: e.Abandoner, Status: ChangeStatusAbandoned}
+}
+
+// ToEvent returns the changeset event metadata of the given event.
+func (e *ChangeRestoredEvent) ToEvent() *ChangeStateEvent {
+	return &ChangeStateEvent{Date: e.Date(), Actor: e.Restorer, Status: ChangeStatusNew}
+}
+
+// ToEvent returns the changeset event metadata of the given event, or nil if
+// the comment didn't change a vote on the Code-Review label.
+func (e *CommentAddedEvent) ToEvent() (*ReviewEvent, error) {
+	for _, a := range e.Approvals {
+		if a.Type != LabelCodeReview || a.OldValue == "" {
+			continue
+		}
+
+		value, err := strconv.Atoi(a.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing %s vote", a.Type)
+		}
+		oldValue, err := strconv.Atoi(a.OldValue)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing previous %s vote", a.Type)
+		}
+
+		return &ReviewEvent{Date: e.Date(), Author: e.Author, Value: value, OldValue: oldValue}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,79 @@
+package gerrit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseWebhookEvent(t *testing.T) {
+	date := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	alice := Account{Name: "Alice", Username: "alice"}
+	change := `"change": {"project": "platform/build", "branch": "main", "id": "I0123", "number": 1}, "eventCreatedOn": 1633089600`
+
+	for _, tc := range []struct {
+		name    string
+		payload string
+		want    interface{}
+	}{
+		{
+			name:    "change-merged",
+			payload: `{"type": "change-merged", "submitter": {"name": "Alice", "username": "alice"}, ` + change + `}`,
+			want:    &ChangeStateEvent{Date: date, Actor: alice, Status: ChangeStatusMerged},
+		},
+		{
+			name:    "change-abandoned",
+			payload: `{"type": "change-abandoned", "abandoner": {"name": "Alice", "username": "alice"}, ` + change + `}`,
+			want:    &ChangeStateEvent{Date: date, Actor: alice, Status: ChangeStatusAbandoned},
+		},
+		{
+			name:    "change-restored",
+			payload: `{"type": "change-restored", "restorer": {"name": "Alice", "username": "alice"}, ` + change + `}`,
+			want:    &ChangeStateEvent{Date: date, Actor: alice, Status: ChangeStatusNew},
+		},
+		{
+			name:    "comment-added with vote",
+			payload: `{"type": "comment-added", "author": {"name": "Alice", "username": "alice"}, "approvals": [{"type": "Verified", "value": "1", "oldValue": "0"}, {"type": "Code-Review", "value": "+2", "oldValue": "0"}], ` + change + `}`,
+			want:    &ReviewEvent{Date: date, Author: alice, Value: 2},
+		},
+		{
+			name:    "comment-added without vote change",
+			payload: `{"type": "comment-added", "author": {"name": "Alice", "username": "alice"}, "approvals": [{"type": "Code-Review", "value": "2"}], ` + change + `}`,
+			want:    (*ReviewEvent)(nil),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			e, err := ParseWebhookEvent([]byte(tc.payload))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var have interface{}
+			switch e := e.(type) {
+			case *ChangeMergedEvent:
+				have = e.ToEvent()
+			case *ChangeAbandonedEvent:
+				have = e.ToEvent()
+			case *ChangeRestoredEvent:
+				have = e.ToEvent()
+			case *CommentAddedEvent:
+				if have, err = e.ToEvent(); err != nil {
+					t.Fatal(err)
+				}
+			default:
+				t.Fatalf("unexpected event type %T", e)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Errorf("unexpected event (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if e, err := ParseWebhookEvent([]byte(`{"type": "patchset-created"}`)); e != nil || err != nil {
+		t.Errorf("expected ignored event, got %v, %v", e, err)
+	}
+	if _, err := ParseWebhookEvent([]byte(`{}`)); err == nil {
+		t.Error("expected error for missing event type")
+	}
+}
@@ -0,0 +1,62 @@
+package gerrit
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// Project is a project (that is, a repository) on Gerrit.
+//
+// See https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#project-info
+type Project struct {
+	// ID is the URL encoded name of the project.
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	State       string `json:"state,omitempty"`
+}
+
+// ProjectStateHidden is the state of projects that are only visible to their
+// owners.
+const ProjectStateHidden = "HIDDEN"
+
+// ListProjects returns a page of the projects visible to the authenticated
+// account, sorted by name, along with whether there are more pages. The page
+// starts at the given offset and contains at most limit projects.
+func (c *Client) ListProjects(ctx context.Context, offset, limit int) (projects []*Project, more bool, err error) {
+	qs := make(url.Values)
+	// Include the descriptions of the projects.
+	qs.Set("d", "")
+	qs.Set("S", strconv.Itoa(offset))
+	// Request one more project than needed to find out whether there are more.
+	qs.Set("n", strconv.Itoa(limit+1))
+
+	// The projects are returned as a map, keyed by their name.
+	var byName map[string]*Project
+	if err := c.get(ctx, "projects/", qs, &byName); err != nil {
+		return nil, false, err
+	}
+
+	projects = make([]*Project, 0, len(byName))
+	for name, p := range byName {
+		p.Name = name
+		projects = append(projects, p)
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+
+	if len(projects) > limit {
+		return projects[:limit], true, nil
+	}
+	return projects, false, nil
+}
+
+// GetProject returns the project with the given name.
+func (c *Client) GetProject(ctx context.Context, name string) (*Project, error) {
+	var p Project
+	if err := c.get(ctx, "projects/"+url.PathEscape(name), nil, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
@@ -173,6 +173,7 @@ type PullRequest struct {
 	TimelineItems []TimelineItem
 	Commits       struct{ Nodes []CommitWithChecks }
 	IsDraft       bool
+	Mergeable     string // MERGEABLE, CONFLICTING or UNKNOWN
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 }
@@ -1388,6 +1389,7 @@ fragment pr on PullRequest {
   baseRefOid
   headRefName
   baseRefName
+  mergeable
   %s
   author {
     ...actor
@@ -1663,12 +1665,48 @@ type Repository struct {
 	StargazerCount int `json:",omitempty"`
 	ForkCount      int `json:",omitempty"`
 
+	// Metadata retained for repository search predicates
+	PrimaryLanguage  *Language         `json:",omitempty"`
+	RepositoryTopics *RepositoryTopics `json:",omitempty"`
+
 	// This is available for GitHub Enterprise Cloud and GitHub Enterprise Server 3.3.0+ and is used
 	// to identify if a repository is public or private or internal.
 	// https://developer.github.com/changes/2019-12-03-internal-visibility-changes/#repository-visibility-fields
 	Visibility Visibility `json:",omitempty"`
 }
 
+// Language is a programming language as detected by GitHub.
+type Language struct {
+	Name string
+}
+
+// RepositoryTopics is the list of topics a repository is tagged with.
+type RepositoryTopics struct {
+	Nodes []RepositoryTopic
+}
+
+// RepositoryTopic is a topic a repository is tagged with.
+type RepositoryTopic struct {
+	Topic Topic
+}
+
+// Topic is a GitHub topic.
+type Topic struct {
+	Name string
+}
+
+// Topics returns the names of the topics the repository is tagged with.
+func (r *Repository) Topics() []string {
+	if r.RepositoryTopics == nil {
+		return nil
+	}
+	topics := make([]string, 0, len(r.RepositoryTopics.Nodes))
+	for _, n := range r.RepositoryTopics.Nodes {
+		topics = append(topics, n.Topic.Name)
+	}
+	return topics
+}
+
 func ownerNameCacheKey(owner, name string) string       { return "0:" + owner + "/" + name }
 func nameWithOwnerCacheKey(nameWithOwner string) string { return "0:" + nameWithOwner }
 func nodeIDCacheKey(id string) string                   { return "1:" + id }
@@ -1737,6 +1775,8 @@ type restRepository struct {
 	Stars       int                       `json:"stargazers_count"`
 	Forks       int                       `json:"forks_count"`
 	Visibility  string                    `json:"visibility"`
+	Language    string                    `json:"language"`
+	Topics      []string                  `json:"topics"`
 }
 
 // getRepositoryFromAPI attempts to fetch a repository from the GitHub API without use of the redis cache.
@@ -1778,6 +1818,17 @@ func convertRestRepo(restRepo restRepository) *Repository {
 		repo.Visibility = Visibility(restRepo.Visibility)
 	}
 
+	if restRepo.Language != "" {
+		repo.PrimaryLanguage = &Language{Name: restRepo.Language}
+	}
+
+	if len(restRepo.Topics) > 0 {
+		repo.RepositoryTopics = &RepositoryTopics{Nodes: make([]RepositoryTopic, 0, len(restRepo.Topics))}
+		for _, t := range restRepo.Topics {
+			repo.RepositoryTopics.Nodes = append(repo.RepositoryTopics.Nodes, RepositoryTopic{Topic: Topic{Name: t}})
+		}
+	}
+
 	return &repo
 }
 
@@ -545,6 +545,8 @@ fragment RepositoryFields on Repository {
 	viewerPermission
 	stargazerCount
 	forkCount
+	primaryLanguage { name }
+	repositoryTopics(first: 100) { nodes { topic { name } } }
 }
 	`
 	}
@@ -575,6 +577,8 @@ fragment RepositoryFields on Repository {
 	isLocked
 	isDisabled
 	forkCount
+	primaryLanguage { name }
+	repositoryTopics(first: 100) { nodes { topic { name } } }
 	%s
 }
 	`, strings.Join(conditionalGHEFields, "\n	"))
@@ -42,6 +42,10 @@ type MergeRequest struct {
 	WebURL         string            `json:"web_url"`
 	WorkInProgress bool              `json:"work_in_progress"`
 	Author         User              `json:"author"`
+	// MergeStatus is one of unchecked, checking, can_be_merged,
+	// cannot_be_merged and cannot_be_merged_recheck.
+	MergeStatus  string `json:"merge_status"`
+	HasConflicts bool   `json:"has_conflicts"`
 
 	DiffRefs DiffRefs `json:"diff_refs"`
 
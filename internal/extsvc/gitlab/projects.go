@@ -30,6 +30,11 @@ type Project struct {
 	Archived          bool           `json:"archived"`
 	StarCount         int            `json:"star_count"`
 	ForksCount        int            `json:"forks_count"`
+
+	// Topics is only returned by GitLab 14.5+. Older versions return the
+	// same data as TagList.
+	Topics  []string `json:"topics,omitempty"`
+	TagList []string `json:"tag_list,omitempty"`
 }
 
 type ProjectCommon struct {
@@ -47,6 +52,14 @@ func (p Project) RequiresAuthentication() bool {
 	return p.Visibility == "private" || p.Visibility == "internal"
 }
 
+// TopicNames returns the topics the project is tagged with.
+func (p Project) TopicNames() []string {
+	if len(p.Topics) > 0 {
+		return p.Topics
+	}
+	return p.TagList
+}
+
 func idCacheKey(id int) string                                  { return "1:" + strconv.Itoa(id) }
 func pathWithNamespaceCacheKey(pathWithNamespace string) string { return "1:" + pathWithNamespace }
 
@@ -0,0 +1,123 @@
+// Package gomodproxy implements a client for Go module proxies, see
+// https://go.dev/ref/mod#goproxy-protocol
+//
+// Proxies are configured by URL. Besides http(s) URLs, file:// URLs refer to a
+// local directory laid out like a proxy, such as $GOPATH/pkg/mod/cache/download.
+package gomodproxy
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/mod/module"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+// Client fetches modules from a list of proxies. Like with the GOPROXY
+// environment variable, the next proxy is only tried if a proxy responds that
+// a module does not exist.
+type Client struct {
+	urls []string
+	cli  httpcli.Doer
+}
+
+// NewClient returns a client for the proxies at urls.
+func NewClient(urls []string, cli httpcli.Doer) *Client {
+	return &Client{urls: urls, cli: cli}
+}
+
+// Info is the metadata of a module version.
+type Info struct {
+	Version string
+	Time    time.Time
+}
+
+// Info returns the metadata of the dependency, and an error if it doesn't
+// exist.
+func (c *Client) Info(ctx context.Context, dependency reposource.GoDependency) (*Info, error) {
+	body, err := c.get(ctx, dependency, "info")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var info Info
+	if err := json.NewDecoder(body).Decode(&info); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode info of %s", dependency.PackageManagerSyntax())
+	}
+	return &info, nil
+}
+
+// Zip returns the zip archive with the sources of the dependency. The caller
+// must close it. The files in the archive are prefixed with
+// "<module path>@<version>/".
+func (c *Client) Zip(ctx context.Context, dependency reposource.GoDependency) (io.ReadCloser, error) {
+	return c.get(ctx, dependency, "zip")
+}
+
+func (c *Client) get(ctx context.Context, dependency reposource.GoDependency, suffix string) (io.ReadCloser, error) {
+	escapedPath, err := module.EscapePath(dependency.Path)
+	if err != nil {
+		return nil, err
+	}
+	escapedVersion, err := module.EscapeVersion(dependency.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, proxy := range c.urls {
+		body, err := c.getURL(ctx, strings.TrimSuffix(proxy, "/")+"/"+escapedPath+"/@v/"+escapedVersion+"."+suffix)
+		if errcode.IsNotFound(err) {
+			continue
+		}
+		return body, err
+	}
+	return nil, &notFoundError{"go module " + dependency.PackageManagerSyntax() + " not found"}
+}
+
+func (c *Client) getURL(ctx context.Context, rawURL string) (io.ReadCloser, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme == "file" {
+		f, err := os.Open(u.Path)
+		if os.IsNotExist(err) {
+			return nil, &notFoundError{rawURL + " not found"}
+		}
+		return f, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+			return nil, &notFoundError{rawURL + " not found"}
+		}
+		return nil, errors.Errorf("unexpected status code %d for %s", resp.StatusCode, rawURL)
+	}
+	return resp.Body, nil
+}
+
+type notFoundError struct{ msg string }
+
+func (e *notFoundError) Error() string  { return e.msg }
+func (e *notFoundError) NotFound() bool { return true }
@@ -0,0 +1,89 @@
+package gomodproxy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+func TestClient(t *testing.T) {
+	dir := t.TempDir()
+	// Upper case letters in module paths are escaped as "!" followed by the
+	// lower case letter.
+	modDir := filepath.Join(dir, "github.com", "!burnt!sushi", "toml", "@v")
+	if err := os.MkdirAll(modDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(modDir, "v1.0.0.info"), []byte(`{"Version":"v1.0.0","Time":"2022-01-01T00:00:00Z"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(modDir, "v1.0.0.zip"), []byte("zip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/golang.org/x/mod/@v/v0.5.1.info":
+			_, _ = io.WriteString(w, `{"Version":"v0.5.1","Time":"2021-09-16T00:00:00Z"}`)
+		case "/example.com/broken/@v/v1.0.0.info":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusGone)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := NewClient([]string{srv.URL, "file://" + dir}, httpcli.ExternalDoer)
+
+	info, err := c.Info(ctx, parseDependency(t, "golang.org/x/mod@v0.5.1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Version != "v0.5.1" {
+		t.Errorf("got version %q, want %q", info.Version, "v0.5.1")
+	}
+
+	// Falls back to the file proxy because the HTTP proxy responds 410.
+	dep := parseDependency(t, "github.com/BurntSushi/toml@v1.0.0")
+	if _, err := c.Info(ctx, dep); err != nil {
+		t.Fatal(err)
+	}
+	zip, err := c.Zip(ctx, dep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(zip)
+	zip.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "zip" {
+		t.Errorf("got zip %q, want %q", b, "zip")
+	}
+
+	if _, err := c.Info(ctx, parseDependency(t, "golang.org/x/mod@v0.0.1")); !errcode.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	// Other errors don't fall back to the next proxy.
+	if _, err := c.Info(ctx, parseDependency(t, "example.com/broken@v1.0.0")); err == nil || errcode.IsNotFound(err) {
+		t.Errorf("expected error, got %v", err)
+	}
+}
+
+func parseDependency(t *testing.T, s string) reposource.GoDependency {
+	t.Helper()
+	dep, err := reposource.ParseGoDependency(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *dep
+}
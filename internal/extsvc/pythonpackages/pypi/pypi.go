@@ -0,0 +1,245 @@
+// Package pypi implements a client for Python package indexes which implement
+// the "simple" repository API described in PEP 503, such as PyPI.
+//
+// Indexes are configured by URL. Besides http(s) URLs, file:// URLs refer to a
+// local directory with a sub-directory per project, which contains either an
+// index.html page or the package files themselves.
+package pypi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/net/html"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+// Client fetches package files from a list of indexes. The indexes are tried
+// in order, and the first one which knows a project is used.
+type Client struct {
+	urls []string
+	cli  httpcli.Doer
+}
+
+// NewClient returns a client for the indexes at urls.
+func NewClient(urls []string, cli httpcli.Doer) *Client {
+	return &Client{urls: urls, cli: cli}
+}
+
+// File is a package file of a project, such as a source distribution or a
+// wheel.
+type File struct {
+	// Name is the file name, such as "requests-2.27.1.tar.gz".
+	Name string
+	// URL is the absolute URL of the file.
+	URL string
+}
+
+// Project returns the files of the project with the given name.
+func (c *Client) Project(ctx context.Context, name string) ([]File, error) {
+	name = reposource.NormalizePythonPackageName(name)
+	for _, index := range c.urls {
+		files, err := c.project(ctx, index, name)
+		if errcode.IsNotFound(err) {
+			continue
+		}
+		return files, err
+	}
+	return nil, &notFoundError{fmt.Sprintf("python package %s not found", name)}
+}
+
+func (c *Client) project(ctx context.Context, index, name string) ([]File, error) {
+	u, err := url.Parse(strings.TrimSuffix(index, "/") + "/" + name + "/")
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme == "file" {
+		entries, err := os.ReadDir(u.Path)
+		if os.IsNotExist(err) {
+			return nil, &notFoundError{u.String() + " not found"}
+		} else if err != nil {
+			return nil, err
+		}
+		var files []File
+		for _, entry := range entries {
+			if entry.Name() == "index.html" {
+				return c.parseProjectPage(ctx, u)
+			}
+			if entry.Type().IsRegular() {
+				files = append(files, File{
+					Name: entry.Name(),
+					URL:  (&url.URL{Scheme: "file", Path: filepath.Join(u.Path, entry.Name())}).String(),
+				})
+			}
+		}
+		return files, nil
+	}
+
+	return c.parseProjectPage(ctx, u)
+}
+
+// parseProjectPage returns the files linked from the project page at u.
+func (c *Client) parseProjectPage(ctx context.Context, u *url.URL) ([]File, error) {
+	page := *u
+	if page.Scheme == "file" {
+		page.Path = path.Join(page.Path, "index.html")
+	}
+	body, err := c.get(ctx, &page)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var files []File
+	var href string
+	var text strings.Builder
+	z := html.NewTokenizer(body)
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			if err := z.Err(); err != io.EOF {
+				return nil, errors.Wrapf(err, "failed to parse %s", u)
+			}
+			return files, nil
+		case html.StartTagToken:
+			name, hasAttr := z.TagName()
+			if string(name) != "a" {
+				continue
+			}
+			href = ""
+			text.Reset()
+			for hasAttr {
+				var key, val []byte
+				key, val, hasAttr = z.TagAttr()
+				if string(key) == "href" {
+					href = string(val)
+				}
+			}
+		case html.TextToken:
+			if href != "" {
+				text.Write(z.Text())
+			}
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) != "a" || href == "" {
+				continue
+			}
+			ref, err := u.Parse(href)
+			if err != nil {
+				href = ""
+				continue
+			}
+			// Fragments like #sha256=... are only used to verify the file.
+			ref.Fragment = ""
+			name := strings.TrimSpace(text.String())
+			if name == "" {
+				name = path.Base(ref.Path)
+			}
+			files = append(files, File{Name: name, URL: ref.String()})
+			href = ""
+		}
+	}
+}
+
+// Version returns the file to download for the given dependency. Source
+// distributions are preferred over wheels, since they contain all sources of
+// a package.
+func (c *Client) Version(ctx context.Context, dependency reposource.PythonDependency) (File, error) {
+	files, err := c.Project(ctx, dependency.Name)
+	if err != nil {
+		return File{}, err
+	}
+
+	best, bestRank := File{}, 0
+	for _, f := range files {
+		name, version, rank := parseFilename(f.Name)
+		if rank > bestRank && version == dependency.Version && reposource.NormalizePythonPackageName(name) == dependency.Name {
+			best, bestRank = f, rank
+		}
+	}
+	if bestRank == 0 {
+		return File{}, &notFoundError{fmt.Sprintf("python package %s not found", dependency.PackageManagerSyntax())}
+	}
+	return best, nil
+}
+
+// parseFilename returns the project name and version of a package file, and a
+// rank of how much the file is preferred. The rank is 0 for unsupported files.
+func parseFilename(filename string) (name, version string, rank int) {
+	if base := strings.TrimSuffix(filename, ".whl"); base != filename {
+		// {distribution}-{version}(-{build tag})?-{python tag}-{abi tag}-{platform tag}.whl
+		parts := strings.Split(base, "-")
+		if len(parts) < 5 {
+			return "", "", 0
+		}
+		rank = 1
+		if strings.HasSuffix(base, "-none-any") {
+			rank = 2
+		}
+		return parts[0], parts[1], rank
+	}
+
+	for rank, ext := range []string{".zip", ".tar.gz"} {
+		if base := strings.TrimSuffix(filename, ext); base != filename {
+			// {name}-{version}.tar.gz, where older names may contain "-".
+			i := strings.LastIndexByte(base, '-')
+			if i <= 0 {
+				return "", "", 0
+			}
+			return base[:i], base[i+1:], 3 + rank
+		}
+	}
+	return "", "", 0
+}
+
+// Download returns the contents of the file. The caller must close it.
+func (c *Client) Download(ctx context.Context, f File) (io.ReadCloser, error) {
+	u, err := url.Parse(f.URL)
+	if err != nil {
+		return nil, err
+	}
+	return c.get(ctx, u)
+}
+
+func (c *Client) get(ctx context.Context, u *url.URL) (io.ReadCloser, error) {
+	if u.Scheme == "file" {
+		f, err := os.Open(u.Path)
+		if os.IsNotExist(err) {
+			return nil, &notFoundError{u.String() + " not found"}
+		}
+		return f, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, &notFoundError{u.String() + " not found"}
+		}
+		return nil, errors.Errorf("unexpected status code %d for %s", resp.StatusCode, u)
+	}
+	return resp.Body, nil
+}
+
+type notFoundError struct{ msg string }
+
+func (e *notFoundError) Error() string  { return e.msg }
+func (e *notFoundError) NotFound() bool { return true }
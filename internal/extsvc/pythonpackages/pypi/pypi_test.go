@@ -0,0 +1,129 @@
+package pypi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+func TestClient_FileIndex(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "zope-interface", "zope.interface-5.4.0.tar.gz"), "sdist")
+	writeFile(t, filepath.Join(dir, "zope-interface", "zope.interface-5.4.0-cp39-cp39-manylinux1_x86_64.whl"), "wheel")
+	writeFile(t, filepath.Join(dir, "requests", "index.html"), `<html><body>
+<a href="files/requests-2.27.1-py2.py3-none-any.whl#sha256=abc">requests-2.27.1-py2.py3-none-any.whl</a><br/>
+<a href="files/requests-2.27.0.tar.gz">requests-2.27.0.tar.gz</a>
+</body></html>`)
+	writeFile(t, filepath.Join(dir, "requests", "files", "requests-2.27.1-py2.py3-none-any.whl"), "requests wheel")
+
+	ctx := context.Background()
+	c := NewClient([]string{"file://" + filepath.Join(dir, "missing"), "file://" + dir}, httpcli.ExternalDoer)
+
+	f, err := c.Version(ctx, parseDependency(t, "Zope.Interface==5.4.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "zope.interface-5.4.0.tar.gz"; f.Name != want {
+		t.Errorf("got file %q, want %q", f.Name, want)
+	}
+	if got := readFile(t, c, f); got != "sdist" {
+		t.Errorf("got contents %q, want %q", got, "sdist")
+	}
+
+	f, err = c.Version(ctx, parseDependency(t, "requests==2.27.1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "requests-2.27.1-py2.py3-none-any.whl"; f.Name != want {
+		t.Errorf("got file %q, want %q", f.Name, want)
+	}
+	if got := readFile(t, c, f); got != "requests wheel" {
+		t.Errorf("got contents %q, want %q", got, "requests wheel")
+	}
+
+	if _, err := c.Version(ctx, parseDependency(t, "requests==1.0.0")); !errcode.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	if _, err := c.Version(ctx, parseDependency(t, "numpy==1.22.3")); !errcode.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestClient_HTTPIndex(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/simple/numpy/":
+			_, _ = io.WriteString(w, `<!DOCTYPE html><html><body>
+<a href="/packages/numpy-1.22.3.zip#sha256=def">numpy-1.22.3.zip</a>
+<a href="https://files.example.com/numpy-1.22.3-cp39-cp39-win32.whl">numpy-1.22.3-cp39-cp39-win32.whl</a>
+</body></html>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient([]string{srv.URL + "/simple"}, httpcli.ExternalDoer)
+	files, err := c.Project(context.Background(), "NumPy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []File{
+		{Name: "numpy-1.22.3.zip", URL: srv.URL + "/packages/numpy-1.22.3.zip"},
+		{Name: "numpy-1.22.3-cp39-cp39-win32.whl", URL: "https://files.example.com/numpy-1.22.3-cp39-cp39-win32.whl"},
+	}
+	if diff := cmp.Diff(want, files); diff != "" {
+		t.Error(diff)
+	}
+
+	f, err := c.Version(context.Background(), parseDependency(t, "numpy==1.22.3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f != want[0] {
+		t.Errorf("got file %+v, want %+v", f, want[0])
+	}
+}
+
+func parseDependency(t *testing.T, s string) reposource.PythonDependency {
+	t.Helper()
+	dep, err := reposource.ParsePythonDependency(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *dep
+}
+
+func writeFile(t *testing.T, name, contents string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, c *Client, f File) string {
+	t.Helper()
+	body, err := c.Download(context.Background(), f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	b, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
@@ -77,6 +77,7 @@ const (
 	KindAWSCodeCommit   = "AWSCODECOMMIT"
 	KindBitbucketServer = "BITBUCKETSERVER"
 	KindBitbucketCloud  = "BITBUCKETCLOUD"
+	KindGerrit          = "GERRIT"
 	KindGitHub          = "GITHUB"
 	KindGitLab          = "GITLAB"
 	KindGitolite        = "GITOLITE"
@@ -85,6 +86,8 @@ const (
 	KindJVMPackages     = "JVMPACKAGES"
 	KindPagure          = "PAGURE"
 	KindNPMPackages     = "NPMPACKAGES"
+	KindPythonPackages  = "PYTHONPACKAGES"
+	KindGoModules       = "GOMODULES"
 	KindOther           = "OTHER"
 )
 
@@ -104,6 +107,10 @@ const (
 	// ServiceID value is the base URL to the Bitbucket Cloud.
 	TypeBitbucketCloud = "bitbucketCloud"
 
+	// TypeGerrit is the (api.ExternalRepoSpec).ServiceType value for Gerrit projects. The
+	// ServiceID value is the base URL to the Gerrit instance.
+	TypeGerrit = "gerrit"
+
 	// TypeGitHub is the (api.ExternalRepoSpec).ServiceType value for GitHub repositories. The ServiceID value
 	// is the base URL to the GitHub instance (https://github.com or the GitHub Enterprise URL).
 	TypeGitHub = "github"
@@ -130,6 +137,12 @@ const (
 	// TypeNPMPackages is the (api.ExternalRepoSpec).ServiceType value for NPM packages (JavaScript/TypeScript ecosystem libraries).
 	TypeNPMPackages = "npmPackages"
 
+	// TypePythonPackages is the (api.ExternalRepoSpec).ServiceType value for Python packages from PyPI-compatible indexes.
+	TypePythonPackages = "pythonPackages"
+
+	// TypeGoModules is the (api.ExternalRepoSpec).ServiceType value for Go modules from Go module proxies.
+	TypeGoModules = "goModules"
+
 	// TypeOther is the (api.ExternalRepoSpec).ServiceType value for other projects.
 	TypeOther = "other"
 
@@ -146,6 +159,8 @@ func KindToType(kind string) string {
 		return TypeBitbucketServer
 	case KindBitbucketCloud:
 		return TypeBitbucketCloud
+	case KindGerrit:
+		return TypeGerrit
 	case KindGitHub:
 		return TypeGitHub
 	case KindGitLab:
@@ -160,6 +175,10 @@ func KindToType(kind string) string {
 		return TypeJVMPackages
 	case KindPagure:
 		return TypePagure
+	case KindPythonPackages:
+		return TypePythonPackages
+	case KindGoModules:
+		return TypeGoModules
 	case KindOther:
 		return TypeOther
 	default:
@@ -177,6 +196,8 @@ func TypeToKind(t string) string {
 		return KindBitbucketServer
 	case TypeBitbucketCloud:
 		return KindBitbucketCloud
+	case TypeGerrit:
+		return KindGerrit
 	case TypeGitHub:
 		return KindGitHub
 	case TypeGitLab:
@@ -191,6 +212,10 @@ func TypeToKind(t string) string {
 		return KindJVMPackages
 	case TypePagure:
 		return KindPagure
+	case TypePythonPackages:
+		return KindPythonPackages
+	case TypeGoModules:
+		return KindGoModules
 	case TypeOther:
 		return KindOther
 	default:
@@ -204,6 +229,8 @@ var (
 	bbcLower = strings.ToLower(TypeBitbucketCloud)
 	jvmLower = strings.ToLower(TypeJVMPackages)
 	npmLower = strings.ToLower(TypeNPMPackages)
+	pyLower  = strings.ToLower(TypePythonPackages)
+	goLower  = strings.ToLower(TypeGoModules)
 )
 
 // ParseServiceType will return a ServiceType constant after doing a case insensitive match on s.
@@ -216,6 +243,8 @@ func ParseServiceType(s string) (string, bool) {
 		return TypeBitbucketServer, true
 	case bbcLower:
 		return TypeBitbucketCloud, true
+	case TypeGerrit:
+		return TypeGerrit, true
 	case TypeGitHub:
 		return TypeGitHub, true
 	case TypeGitLab:
@@ -230,6 +259,10 @@ func ParseServiceType(s string) (string, bool) {
 		return TypeJVMPackages, true
 	case npmLower:
 		return TypeNPMPackages, true
+	case pyLower:
+		return TypePythonPackages, true
+	case goLower:
+		return TypeGoModules, true
 	case TypePagure:
 		return TypePagure, true
 	case TypeOther:
@@ -249,6 +282,8 @@ func ParseServiceKind(s string) (string, bool) {
 		return KindBitbucketServer, true
 	case KindBitbucketCloud:
 		return KindBitbucketCloud, true
+	case KindGerrit:
+		return KindGerrit, true
 	case KindGitHub:
 		return KindGitHub, true
 	case KindGitLab:
@@ -263,6 +298,10 @@ func ParseServiceKind(s string) (string, bool) {
 		return KindJVMPackages, true
 	case KindPagure:
 		return KindPagure, true
+	case KindPythonPackages:
+		return KindPythonPackages, true
+	case KindGoModules:
+		return KindGoModules, true
 	case KindOther:
 		return KindOther, true
 	default:
@@ -292,6 +331,8 @@ func ParseConfig(kind, config string) (cfg interface{}, _ error) {
 		cfg = &schema.BitbucketServerConnection{}
 	case KindBitbucketCloud:
 		cfg = &schema.BitbucketCloudConnection{}
+	case KindGerrit:
+		cfg = &schema.GerritConnection{}
 	case KindGitHub:
 		cfg = &schema.GitHubConnection{}
 	case KindGitLab:
@@ -308,6 +349,10 @@ func ParseConfig(kind, config string) (cfg interface{}, _ error) {
 		cfg = &schema.PagureConnection{}
 	case KindNPMPackages:
 		cfg = &schema.NPMPackagesConnection{}
+	case KindPythonPackages:
+		cfg = &schema.PythonPackagesConnection{}
+	case KindGoModules:
+		cfg = &schema.GoModulesConnection{}
 	case KindOther:
 		cfg = &schema.OtherExternalServiceConnection{}
 	default:
@@ -325,6 +370,10 @@ func WebhookURL(kind string, externalServiceID int64, externalURL string) string
 		path = "github-webhooks"
 	case KindBitbucketServer:
 		path = "bitbucket-server-webhooks"
+	case KindBitbucketCloud:
+		path = "bitbucket-cloud-webhooks"
+	case KindGerrit:
+		path = "gerrit-webhooks"
 	case KindGitLab:
 		path = "gitlab-webhooks"
 	default:
@@ -525,6 +574,8 @@ func UniqueCodeHostIdentifier(kind, config string) (string, error) {
 		rawURL = c.Url
 	case *schema.BitbucketCloudConnection:
 		rawURL = c.Url
+	case *schema.GerritConnection:
+		rawURL = c.Url
 	case *schema.PhabricatorConnection:
 		rawURL = c.Url
 	case *schema.OtherExternalServiceConnection:
@@ -542,6 +593,10 @@ func UniqueCodeHostIdentifier(kind, config string) (string, error) {
 		return KindJVMPackages, nil
 	case *schema.NPMPackagesConnection:
 		return KindNPMPackages, nil
+	case *schema.PythonPackagesConnection:
+		return KindPythonPackages, nil
+	case *schema.GoModulesConnection:
+		return KindGoModules, nil
 	case *schema.PagureConnection:
 		rawURL = c.Url
 	default:
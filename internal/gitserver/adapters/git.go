@@ -1,11 +1,11 @@
 package adapters
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 
@@ -16,6 +16,11 @@ import (
 
 type Git struct {
 	ReposDir string // The root directory where repos are stored
+
+	// PrepareCommand, if set, is called with every git command before it is
+	// run against repo. The returned function is called with the output of the
+	// command, and returns the output to report.
+	PrepareCommand func(ctx context.Context, repo api.RepoName, cmd *exec.Cmd) func(output string) string
 }
 
 // RevParse will run rev-parse on the given rev
@@ -23,12 +28,12 @@ func (g *Git) RevParse(ctx context.Context, repo api.RepoName, rev string) (stri
 	cmd := exec.CommandContext(ctx, "git", "rev-parse", rev)
 	cmd.Dir = repoDir(repo, g.ReposDir)
 
-	out, err := cmd.CombinedOutput()
+	out, err := g.combinedOutput(ctx, repo, cmd)
 	if err != nil {
 		return "", errors.WithMessage(err, fmt.Sprintf("git command %v failed (output: %q)", cmd.Args, out))
 	}
 
-	return string(out), nil
+	return out, nil
 }
 
 // GetObjectType returns the object type given an objectID
@@ -36,15 +41,24 @@ func (g *Git) GetObjectType(ctx context.Context, repo api.RepoName, objectID str
 	cmd := exec.CommandContext(ctx, "git", "cat-file", "-t", "--", objectID)
 	cmd.Dir = repoDir(repo, g.ReposDir)
 
-	out, err := cmd.CombinedOutput()
+	out, err := g.combinedOutput(ctx, repo, cmd)
 	if err != nil {
 		return "", errors.WithMessage(err, fmt.Sprintf("git command %v failed (output: %q)", cmd.Args, out))
 	}
 
-	objectType := gitdomain.ObjectType(bytes.TrimSpace(out))
+	objectType := gitdomain.ObjectType(strings.TrimSpace(out))
 	return objectType, nil
 }
 
+func (g *Git) combinedOutput(ctx context.Context, repo api.RepoName, cmd *exec.Cmd) (string, error) {
+	done := func(output string) string { return output }
+	if g.PrepareCommand != nil {
+		done = g.PrepareCommand(ctx, repo, cmd)
+	}
+	out, err := cmd.CombinedOutput()
+	return done(string(out)), err
+}
+
 func repoDir(name api.RepoName, reposDir string) string {
 	path := string(protocol.NormalizeRepo(name))
 	return filepath.Join(reposDir, filepath.FromSlash(path), ".git")
@@ -65,8 +65,9 @@ func NewClient(cli httpcli.Doer) *Client {
 		Addrs: func() []string {
 			return conf.Get().ServiceConnections().GitServers
 		},
-		HTTPClient:  cli,
-		HTTPLimiter: parallel.NewRun(500),
+		ReplicationFactor: conf.GitServerReplicationFactor,
+		HTTPClient:        cli,
+		HTTPLimiter:       parallel.NewRun(500),
 		// Use the binary name for UserAgent. This should effectively identify
 		// which service is making the request (excluding requests proxied via the
 		// frontend internal API)
@@ -87,6 +88,11 @@ type Client struct {
 	// concurrent use. It may return different results at different times.
 	Addrs func() []string
 
+	// ReplicationFactor is a function which should return the number of
+	// gitservers each repository is cloned to. It is called each time a request
+	// is made. If nil, each repository lives on exactly one gitserver.
+	ReplicationFactor func() int
+
 	// UserAgent is a string identifying who the client is. It will be logged in
 	// the telemetry in gitserver.
 	UserAgent string
@@ -101,6 +107,44 @@ func (c *Client) AddrForRepo(repo api.RepoName) string {
 	return AddrForRepo(repo, addrs)
 }
 
+// AddrsForRepo returns the addresses of the gitservers holding a replica of the
+// given repo name. The first address is the one returned by AddrForRepo.
+func (c *Client) AddrsForRepo(repo api.RepoName) []string {
+	addrs := c.Addrs()
+	if len(addrs) == 0 {
+		panic("unexpected state: no gitserver addresses")
+	}
+	return AddrsForRepo(repo, addrs, c.replicationFactor())
+}
+
+func (c *Client) replicationFactor() int {
+	if c.ReplicationFactor == nil {
+		return 1
+	}
+	return c.ReplicationFactor()
+}
+
+// ClonedAddrForRepo returns the address of the first replica of the given repo
+// name which is reachable and has the repo cloned, falling back to the
+// address returned by AddrForRepo. It's meant for proxying requests to
+// gitserver, which can't fail over to another replica once they're sent.
+func (c *Client) ClonedAddrForRepo(ctx context.Context, repo api.RepoName) string {
+	if len(c.AddrsForRepo(repo)) == 1 {
+		return c.AddrForRepo(repo)
+	}
+
+	b, err := json.Marshal(&protocol.IsRepoClonedRequest{Repo: repo})
+	if err != nil {
+		return c.AddrForRepo(repo)
+	}
+	resp, err := c.doReplicas(ctx, repo, "POST", "/is-repo-cloned", b, true)
+	if err != nil {
+		return c.AddrForRepo(repo)
+	}
+	resp.Body.Close()
+	return resp.Request.URL.Host
+}
+
 // RendezvousAddrForRepo returns the gitserver address to use for the given repo name using the
 // Rendezvous hashing scheme.
 func (c *Client) RendezvousAddrForRepo(repo api.RepoName) string {
@@ -136,6 +180,17 @@ func AddrForRepo(repo api.RepoName, addrs []string) string {
 	return addrForKey(string(repo), addrs)
 }
 
+// AddrsForRepo returns the addresses of the gitservers holding a replica of the
+// given repo name, starting with the address returned by AddrForRepo. The
+// replicas live on the gitservers following it in addrs, so changing the
+// replication factor does not move the primary replica of a repo.
+//
+// It should never be called with an empty slice.
+func AddrsForRepo(repo api.RepoName, addrs []string, replicationFactor int) []string {
+	repo = protocol.NormalizeRepo(repo) // in case the caller didn't already normalize it
+	return addrsForKey(string(repo), addrs, replicationFactor)
+}
+
 // RendezvousAddrForRepo returns the gitserver address to use for the given repo name using the
 // Rendezvous hashing scheme.
 //
@@ -148,11 +203,41 @@ func RendezvousAddrForRepo(repo api.RepoName, addrs []string) string {
 // addrForKey returns the gitserver address to use for the given string key,
 // which is hashed for sharding purposes.
 func addrForKey(key string, addrs []string) string {
+	return addrsForKey(key, addrs, 1)[0]
+}
+
+// addrsForKey returns the n gitserver addresses to use for the given string
+// key. n is clamped to the range [1, len(addrs)].
+func addrsForKey(key string, addrs []string, n int) []string {
+	if n > len(addrs) {
+		n = len(addrs)
+	}
+	if n < 1 {
+		n = 1
+	}
+
 	sum := md5.Sum([]byte(key))
 	serverIndex := binary.BigEndian.Uint64(sum[:]) % uint64(len(addrs))
-	return addrs[serverIndex]
+
+	replicas := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		replicas = append(replicas, addrs[(serverIndex+uint64(i))%uint64(len(addrs))])
+	}
+	return replicas
 }
 
+var (
+	replicaFailoverCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_gitserver_client_replica_failover_total",
+		Help: "Number of requests which were retried against another replica of a repository.",
+	}, []string{"reason"})
+	replicaLag = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "src_gitserver_replica_lag_seconds",
+		Help:    "Time between the most and least recent fetch of a repository across its replicas, observed on repo updates.",
+		Buckets: []float64{1, 10, 60, 300, 900, 3600, 4 * 3600, 24 * 3600, 7 * 24 * 3600},
+	})
+)
+
 // ArchiveOptions contains options for the Archive func.
 type ArchiveOptions struct {
 	Treeish string   // the tree or commit to produce an archive for
@@ -226,7 +311,7 @@ func (c *Client) Archive(ctx context.Context, repo api.RepoName, opt ArchiveOpti
 	}
 
 	u := c.ArchiveURL(repo, opt)
-	resp, err := c.do(ctx, repo, "GET", u.String(), nil)
+	resp, err := c.doReplicas(ctx, repo, "GET", u.RequestURI(), nil, true)
 	if err != nil {
 		return nil, err
 	}
@@ -291,7 +376,7 @@ func (c *Cmd) sendExec(ctx context.Context) (_ io.ReadCloser, _ http.Header, err
 		EnsureRevision: c.EnsureRevision,
 		Args:           c.Args[1:],
 	}
-	resp, err := c.client.httpPost(ctx, repoName, "exec", req)
+	resp, err := c.client.httpPostCloned(ctx, repoName, "exec", req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -340,8 +425,7 @@ func (c *Client) Search(ctx context.Context, args *protocol.SearchRequest, onMat
 		return false, err
 	}
 
-	uri := "http://" + c.AddrForRepo(repoName) + "/search"
-	resp, err := c.do(ctx, repoName, "POST", uri, buf.Bytes())
+	resp, err := c.doReplicas(ctx, repoName, "POST", "/search", buf.Bytes(), true)
 	if err != nil {
 		return false, err
 	}
@@ -560,6 +644,8 @@ func (c *Client) ListCloned(ctx context.Context) ([]string, error) {
 		repos []string
 	)
 	addrs := c.Addrs()
+	replicationFactor := c.replicationFactor()
+	seen := make(map[string]struct{})
 	for _, addr := range addrs {
 		wg.Add(1)
 		go func(addr string) {
@@ -570,7 +656,7 @@ func (c *Client) ListCloned(ctx context.Context) ([]string, error) {
 			if len(r) > 0 {
 				filtered := r[:0]
 				for _, repo := range r {
-					if addrForKey(repo, addrs) == addr {
+					if containsAddr(addrsForKey(repo, addrs, replicationFactor), addr) {
 						filtered = append(filtered, repo)
 					}
 				}
@@ -580,7 +666,13 @@ func (c *Client) ListCloned(ctx context.Context) ([]string, error) {
 			if e != nil {
 				err = e
 			}
-			repos = append(repos, r...)
+			// A repo is listed by each gitserver holding a replica of it.
+			for _, repo := range r {
+				if _, ok := seen[repo]; !ok {
+					seen[repo] = struct{}{}
+					repos = append(repos, repo)
+				}
+			}
 			mu.Unlock()
 		}(addr)
 	}
@@ -588,6 +680,15 @@ func (c *Client) ListCloned(ctx context.Context) ([]string, error) {
 	return repos, err
 }
 
+func containsAddr(addrs []string, addr string) bool {
+	for _, a := range addrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
+
 // GetGitolitePhabricatorMetadata returns Phabricator metadata for a Gitolite repository fetched via
 // a user-provided command.
 func (c *Client) GetGitolitePhabricatorMetadata(ctx context.Context, gitoliteHost string, repoName api.RepoName) (*protocol.GitolitePhabricatorMetadataResponse, error) {
@@ -639,7 +740,35 @@ func (c *Client) RequestRepoUpdate(ctx context.Context, repo api.RepoName, since
 		Repo:  repo,
 		Since: since,
 	}
-	resp, err := c.httpPost(ctx, repo, "repo-update", req)
+
+	// Every replica of the repo is updated. We return the response of the
+	// first replica which is available, which is the primary one unless its
+	// gitserver is down.
+	addrs := c.AddrsForRepo(repo)
+	infos := make([]*protocol.RepoUpdateResponse, len(addrs))
+	errs := make([]error, len(addrs))
+	var wg sync.WaitGroup
+	for i, addr := range addrs {
+		wg.Add(1)
+		go func(i int, addr string) {
+			defer wg.Done()
+			infos[i], errs[i] = c.requestRepoUpdate(ctx, repo, addr, req)
+		}(i, addr)
+	}
+	wg.Wait()
+
+	observeReplicaLag(infos)
+
+	for i := range addrs {
+		if errs[i] == nil {
+			return infos[i], nil
+		}
+	}
+	return nil, errs[0]
+}
+
+func (c *Client) requestRepoUpdate(ctx context.Context, repo api.RepoName, addr string, req *protocol.RepoUpdateRequest) (*protocol.RepoUpdateResponse, error) {
+	resp, err := c.httpPostWithURI(ctx, repo, "http://"+addr+"/repo-update", req)
 	if err != nil {
 		return nil, err
 	}
@@ -654,6 +783,28 @@ func (c *Client) RequestRepoUpdate(ctx context.Context, repo api.RepoName, since
 	return info, err
 }
 
+// observeReplicaLag records how far the least recently fetched replica of a
+// repo is behind the most recently fetched one.
+func observeReplicaLag(infos []*protocol.RepoUpdateResponse) {
+	var oldest, newest time.Time
+	var n int
+	for _, info := range infos {
+		if info == nil || info.LastFetched == nil {
+			continue
+		}
+		if n == 0 || info.LastFetched.Before(oldest) {
+			oldest = *info.LastFetched
+		}
+		if n == 0 || info.LastFetched.After(newest) {
+			newest = *info.LastFetched
+		}
+		n++
+	}
+	if n > 1 {
+		replicaLag.Observe(newest.Sub(oldest).Seconds())
+	}
+}
+
 // RequestRepoMigrate is effectively RequestRepoUpdate but with some additional metadata to aid our
 // migration of gitserver repos to the rendezvous hashing scheme.
 func (c *Client) RequestRepoMigrate(ctx context.Context, repo api.RepoName) (*protocol.RepoUpdateResponse, error) {
@@ -755,7 +906,7 @@ func (c *Client) IsRepoCloned(ctx context.Context, repo api.RepoName) (bool, err
 	req := &protocol.IsRepoClonedRequest{
 		Repo: repo,
 	}
-	resp, err := c.httpPost(ctx, repo, "is-repo-cloned", req)
+	resp, err := c.httpPostCloned(ctx, repo, "is-repo-cloned", req)
 	if err != nil {
 		return false, err
 	}
@@ -769,73 +920,31 @@ func (c *Client) IsRepoCloned(ctx context.Context, repo api.RepoName) (bool, err
 }
 
 func (c *Client) RepoCloneProgress(ctx context.Context, repos ...api.RepoName) (*protocol.RepoCloneProgressResponse, error) {
-	numPossibleShards := len(c.Addrs())
-	shards := make(map[string]*protocol.RepoCloneProgressRequest, (len(repos)/numPossibleShards)*2) // 2x because it may not be a perfect division
-
-	for _, r := range repos {
-		addr := c.AddrForRepo(r)
-		shard := shards[addr]
-
-		if shard == nil {
-			shard = new(protocol.RepoCloneProgressRequest)
-			shards[addr] = shard
-		}
-
-		shard.Repos = append(shard.Repos, r)
-	}
-
-	type op struct {
-		req *protocol.RepoCloneProgressRequest
-		res *protocol.RepoCloneProgressResponse
-		err error
-	}
-
-	ch := make(chan op, len(shards))
-	for _, req := range shards {
-		go func(o op) {
-			var resp *http.Response
-			resp, o.err = c.httpPost(ctx, o.req.Repos[0], "repo-clone-progress", o.req)
-			if o.err != nil {
-				ch <- o
-				return
-			}
-
-			defer resp.Body.Close()
-			if resp.StatusCode != http.StatusOK {
-				o.err = &url.Error{
-					URL: resp.Request.URL.String(),
-					Op:  "RepoCloneProgress",
-					Err: errors.Errorf("RepoCloneProgress: http status %d", resp.StatusCode),
-				}
-				ch <- o
-				return // we never get an error status code AND result
-			}
-
-			o.res = new(protocol.RepoCloneProgressResponse)
-			o.err = json.NewDecoder(resp.Body).Decode(o.res)
-			ch <- o
-		}(op{req: req})
-	}
-
-	err := new(multierror.Error)
+	var mu sync.Mutex
 	res := protocol.RepoCloneProgressResponse{
 		Results: make(map[api.RepoName]*protocol.RepoCloneProgress),
 	}
 
-	for i := 0; i < cap(ch); i++ {
-		o := <-ch
-
-		if o.err != nil {
-			err = multierror.Append(err, o.err)
-			continue
+	err := c.doReplicaShards(repos, func(addr string, repos []api.RepoName) ([]api.RepoName, error) {
+		var shardRes protocol.RepoCloneProgressResponse
+		req := &protocol.RepoCloneProgressRequest{Repos: repos}
+		if err := c.postShard(ctx, addr, "repo-clone-progress", "RepoCloneProgress", repos, req, &shardRes); err != nil {
+			return repos, err
 		}
 
-		for repo, info := range o.res.Results {
+		mu.Lock()
+		defer mu.Unlock()
+		var notCloned []api.RepoName
+		for repo, info := range shardRes.Results {
 			res.Results[repo] = info
+			if !info.Cloned {
+				notCloned = append(notCloned, repo)
+			}
 		}
-	}
+		return notCloned, nil
+	})
 
-	return &res, err.ErrorOrNil()
+	return &res, err
 }
 
 // RepoInfo retrieves information about one or more repositories on gitserver.
@@ -846,73 +955,111 @@ func (c *Client) RepoCloneProgress(ctx context.Context, repos ...api.RepoName) (
 // If multiple errors occurred, an incomplete result is returned along with a
 // *multierror.Error.
 func (c *Client) RepoInfo(ctx context.Context, repos ...api.RepoName) (*protocol.RepoInfoResponse, error) {
-	numPossibleShards := len(c.Addrs())
-	shards := make(map[string]*protocol.RepoInfoRequest, (len(repos)/numPossibleShards)*2) // 2x because it may not be a perfect division
+	var mu sync.Mutex
+	res := protocol.RepoInfoResponse{
+		Results: make(map[api.RepoName]*protocol.RepoInfo),
+	}
 
-	for _, r := range repos {
-		addr := c.AddrForRepo(r)
-		shard := shards[addr]
+	err := c.doReplicaShards(repos, func(addr string, repos []api.RepoName) ([]api.RepoName, error) {
+		var shardRes protocol.RepoInfoResponse
+		if err := c.postShard(ctx, addr, "repos", "RepoInfo", repos, &protocol.RepoInfoRequest{Repos: repos}, &shardRes); err != nil {
+			return repos, err
+		}
 
-		if shard == nil {
-			shard = new(protocol.RepoInfoRequest)
-			shards[addr] = shard
+		mu.Lock()
+		defer mu.Unlock()
+		var notCloned []api.RepoName
+		for repo, info := range shardRes.Results {
+			res.Results[repo] = info
+			if !info.Cloned {
+				notCloned = append(notCloned, repo)
+			}
 		}
+		return notCloned, nil
+	})
 
-		shard.Repos = append(shard.Repos, r)
-	}
+	return &res, err
+}
 
-	type op struct {
-		req *protocol.RepoInfoRequest
-		res *protocol.RepoInfoResponse
-		err error
+// doReplicaShards calls send concurrently for the repos on each gitserver,
+// starting with the primary replicas of the repos. send returns the repos
+// which should be retried, because the gitserver couldn't be reached or the
+// repos aren't cloned on it yet, and those are sent to their next replica in
+// turn. Only the errors of the last replicas are returned, as a
+// *multierror.Error.
+func (c *Client) doReplicaShards(repos []api.RepoName, send func(addr string, repos []api.RepoName) (retry []api.RepoName, err error)) error {
+	if len(repos) == 0 {
+		return nil
 	}
 
-	ch := make(chan op, len(shards))
-	for _, req := range shards {
-		go func(o op) {
-			var resp *http.Response
-			resp, o.err = c.httpPost(ctx, o.req.Repos[0], "repos", o.req)
-			if o.err != nil {
+	err := new(multierror.Error)
+	numReplicas := len(c.AddrsForRepo(repos[0]))
+	for replica := 0; replica < numReplicas && len(repos) > 0; replica++ {
+		shards := make(map[string][]api.RepoName)
+		for _, r := range repos {
+			addr := c.AddrsForRepo(r)[replica]
+			shards[addr] = append(shards[addr], r)
+		}
+
+		type op struct {
+			addr  string
+			retry []api.RepoName
+			err   error
+		}
+
+		ch := make(chan op, len(shards))
+		for addr, shard := range shards {
+			go func(addr string, shard []api.RepoName) {
+				o := op{addr: addr}
+				o.retry, o.err = send(addr, shard)
 				ch <- o
-				return
-			}
+			}(addr, shard)
+		}
 
-			defer resp.Body.Close()
-			if resp.StatusCode != http.StatusOK {
-				o.err = &url.Error{
-					URL: resp.Request.URL.String(),
-					Op:  "RepoInfo",
-					Err: errors.Errorf("RepoInfo: http status %d", resp.StatusCode),
+		last := replica == numReplicas-1
+		repos = nil
+		for i := 0; i < cap(ch); i++ {
+			o := <-ch
+			if last {
+				if o.err != nil {
+					err = multierror.Append(err, o.err)
 				}
-				ch <- o
-				return // we never get an error status code AND result
+				continue
 			}
 
-			o.res = new(protocol.RepoInfoResponse)
-			o.err = json.NewDecoder(resp.Body).Decode(o.res)
-			ch <- o
-		}(op{req: req})
-	}
-
-	err := new(multierror.Error)
-	res := protocol.RepoInfoResponse{
-		Results: make(map[api.RepoName]*protocol.RepoInfo),
+			if len(o.retry) > 0 {
+				reason := "not_found"
+				if o.err != nil {
+					reason = "unavailable"
+				}
+				replicaFailoverCounter.WithLabelValues(reason).Add(float64(len(o.retry)))
+				log15.Debug("gitserver replica failover", "addr", o.addr, "repos", len(o.retry), "reason", reason, "error", o.err)
+			}
+			repos = append(repos, o.retry...)
+		}
 	}
 
-	for i := 0; i < cap(ch); i++ {
-		o := <-ch
+	return err.ErrorOrNil()
+}
 
-		if o.err != nil {
-			err = multierror.Append(err, o.err)
-			continue
-		}
+// postShard sends the request for repos to the gitserver at addr and decodes
+// its response into res.
+func (c *Client) postShard(ctx context.Context, addr, op, errOp string, repos []api.RepoName, req, res interface{}) error {
+	resp, err := c.httpPostWithURI(ctx, repos[0], "http://"+addr+"/"+op, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
 
-		for repo, info := range o.res.Results {
-			res.Results[repo] = info
+	if resp.StatusCode != http.StatusOK {
+		return &url.Error{
+			URL: resp.Request.URL.String(),
+			Op:  errOp,
+			Err: errors.Errorf("%s: http status %d", errOp, resp.StatusCode),
 		}
 	}
 
-	return &res, err.ErrorOrNil()
+	return json.NewDecoder(resp.Body).Decode(res)
 }
 
 // ReposStats will return a map of the ReposStats for each gitserver in a
@@ -962,7 +1109,19 @@ func (c *Client) Remove(ctx context.Context, repo api.RepoName) error {
 	req := &protocol.RepoDeleteRequest{
 		Repo: repo,
 	}
-	resp, err := c.httpPost(ctx, repo, "delete", req)
+
+	// The repo is removed from every replica.
+	var err error
+	for _, addr := range c.AddrsForRepo(repo) {
+		if e := c.remove(ctx, repo, addr, req); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	return err
+}
+
+func (c *Client) remove(ctx context.Context, repo api.RepoName, addr string, req *protocol.RepoDeleteRequest) error {
+	resp, err := c.httpPostWithURI(ctx, repo, "http://"+addr+"/delete", req)
 	if err != nil {
 		return err
 	}
@@ -978,14 +1137,54 @@ func (c *Client) Remove(ctx context.Context, repo api.RepoName) error {
 // httpPost will apply the MD5 hashing scheme on the repo name to determine the gitserver instance
 // to which the HTTP POST request is sent. To use the rendezvous hashing scheme, see
 // httpPostWithURI.
+//
+// If the gitserver is unreachable, the request is sent to the next replica of the repo.
 func (c *Client) httpPost(ctx context.Context, repo api.RepoName, op string, payload interface{}) (resp *http.Response, err error) {
 	b, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
 
-	uri := "http://" + c.AddrForRepo(repo) + "/" + op
-	return c.do(ctx, repo, "POST", uri, b)
+	return c.doReplicas(ctx, repo, "POST", "/"+op, b, false)
+}
+
+// httpPostCloned is like httpPost, but also sends the request to the next
+// replica of the repo if the repo is not cloned on a gitserver yet.
+func (c *Client) httpPostCloned(ctx context.Context, repo api.RepoName, op string, payload interface{}) (resp *http.Response, err error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doReplicas(ctx, repo, "POST", "/"+op, b, true)
+}
+
+// doReplicas performs a request against the replicas of repo in order, until a
+// gitserver is reachable. If failoverNotFound is true, a replica responding
+// with 404 Not Found is skipped as well. The response of the last replica is
+// returned as is.
+func (c *Client) doReplicas(ctx context.Context, repo api.RepoName, method, path string, payload []byte, failoverNotFound bool) (resp *http.Response, err error) {
+	addrs := c.AddrsForRepo(repo)
+	for i, addr := range addrs {
+		resp, err = c.do(ctx, repo, method, "http://"+addr+path, payload)
+		if i == len(addrs)-1 {
+			break
+		}
+
+		var reason string
+		switch {
+		case err != nil && ctx.Err() == nil:
+			reason = "unavailable"
+		case err == nil && failoverNotFound && resp.StatusCode == http.StatusNotFound:
+			resp.Body.Close()
+			reason = "not_found"
+		default:
+			return resp, err
+		}
+		replicaFailoverCounter.WithLabelValues(reason).Inc()
+		log15.Debug("gitserver replica failover", "repo", repo, "addr", addr, "reason", reason, "error", err)
+	}
+	return resp, err
 }
 
 // httpPostWithURI does not apply any transformations to the given URI. This allows the consumer to
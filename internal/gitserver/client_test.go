@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -23,6 +24,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 )
 
@@ -58,6 +60,166 @@ func TestClient_ListCloned(t *testing.T) {
 	}
 }
 
+func TestClient_ListCloned_Replicas(t *testing.T) {
+	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+	cli := &gitserver.Client{
+		Addrs:             func() []string { return addrs },
+		ReplicationFactor: func() int { return 2 },
+		HTTPClient: httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.String() {
+			case "http://gitserver-1/list?cloned":
+				// repo1 has replicas on gitserver-3 and gitserver-1.
+				return &http.Response{
+					Body: io.NopCloser(bytes.NewBufferString(`["repo1"]`)),
+				}, nil
+			case "http://gitserver-2/list?cloned":
+				// repo1 does not belong on gitserver-2.
+				return &http.Response{
+					Body: io.NopCloser(bytes.NewBufferString(`["repo1"]`)),
+				}, nil
+			case "http://gitserver-3/list?cloned":
+				return &http.Response{
+					Body: io.NopCloser(bytes.NewBufferString(`["repo1"]`)),
+				}, nil
+			default:
+				return nil, errors.Errorf("unexpected url: %s", r.URL.String())
+			}
+		}),
+	}
+
+	want := []string{"repo1"}
+	got, err := cli.ListCloned(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(want, got, cmpopts.EquateEmpty()) {
+		t.Errorf("mismatch for (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestClient_ReplicaFailover(t *testing.T) {
+	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+	var requested []string
+	cli := &gitserver.Client{
+		Addrs:             func() []string { return addrs },
+		ReplicationFactor: func() int { return 3 },
+		HTTPClient: httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+			requested = append(requested, r.URL.Host)
+			switch r.URL.Host {
+			case "gitserver-3":
+				return nil, errors.New("connection refused")
+			case "gitserver-1":
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(bytes.NewBufferString("")),
+				}, nil
+			default:
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBufferString("")),
+				}, nil
+			}
+		}),
+	}
+
+	// The primary replica of repo1 is unreachable, and it is not cloned on
+	// the next replica yet.
+	cloned, err := cli.IsRepoCloned(context.Background(), "repo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cloned {
+		t.Error("expected repo1 to be cloned")
+	}
+	if want := []string{"gitserver-3", "gitserver-1", "gitserver-2"}; !cmp.Equal(want, requested) {
+		t.Errorf("mismatch for requested gitservers (-want +got):\n%s", cmp.Diff(want, requested))
+	}
+}
+
+func TestClient_ClonedAddrForRepo(t *testing.T) {
+	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+	cli := &gitserver.Client{
+		Addrs:             func() []string { return addrs },
+		ReplicationFactor: func() int { return 3 },
+		HTTPClient: httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Host {
+			case "gitserver-3":
+				return nil, errors.New("connection refused")
+			case "gitserver-1":
+				return &http.Response{
+					StatusCode: http.StatusNotFound,
+					Body:       io.NopCloser(bytes.NewBufferString("")),
+					Request:    r,
+				}, nil
+			default:
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBufferString("")),
+					Request:    r,
+				}, nil
+			}
+		}),
+	}
+
+	// The primary replica of repo1 is unreachable, and it is not cloned on
+	// the next replica yet.
+	if have, want := cli.ClonedAddrForRepo(context.Background(), "repo1"), "gitserver-2"; have != want {
+		t.Errorf("unexpected address: have %q, want %q", have, want)
+	}
+}
+
+func TestClient_RepoInfo_ReplicaFailover(t *testing.T) {
+	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+	down := gitserver.AddrsForRepo("repo1", addrs, 3)[0]
+	notCloned := gitserver.AddrsForRepo("repo2", addrs, 3)[0]
+
+	cli := &gitserver.Client{
+		Addrs:             func() []string { return addrs },
+		ReplicationFactor: func() int { return 3 },
+		HTTPClient: httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Host == down {
+				return nil, errors.New("connection refused")
+			}
+
+			var req protocol.RepoInfoRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				return nil, err
+			}
+			res := protocol.RepoInfoResponse{Results: map[api.RepoName]*protocol.RepoInfo{}}
+			for _, repo := range req.Repos {
+				res.Results[repo] = &protocol.RepoInfo{Cloned: repo != "repo2" || r.URL.Host != notCloned}
+			}
+			body, err := json.Marshal(res)
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewReader(body)),
+				Request:    r,
+			}, nil
+		}),
+	}
+
+	// repo1 is served by its next replica, since its primary is unreachable,
+	// and repo2 by the first reachable replica on which it is cloned.
+	res, err := cli.RepoInfo(context.Background(), "repo1", "repo2", "repo3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, repo := range []api.RepoName{"repo1", "repo2", "repo3"} {
+		if info := res.Results[repo]; info == nil || !info.Cloned {
+			t.Errorf("expected %s to be cloned, got %+v", repo, info)
+		}
+	}
+
+	// Errors are returned once every replica was tried.
+	cli.Addrs = func() []string { return []string{down} }
+	if _, err := cli.RepoInfo(context.Background(), "repo1"); err == nil {
+		t.Error("expected error for unreachable gitservers")
+	}
+}
+
 func TestClient_RequestRepoMigrate(t *testing.T) {
 	repo := api.RepoName("github.com/sourcegraph/sourcegraph")
 	addrs := []string{"172.16.8.1:8080", "172.16.8.2:8080"}
@@ -320,6 +482,51 @@ func TestAddrForRepo(t *testing.T) {
 	}
 }
 
+func TestAddrsForRepo(t *testing.T) {
+	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+
+	testCases := []struct {
+		name              string
+		repo              api.RepoName
+		replicationFactor int
+		want              []string
+	}{
+		{
+			name:              "no replicas",
+			repo:              api.RepoName("repo1"),
+			replicationFactor: 1,
+			want:              []string{"gitserver-3"},
+		},
+		{
+			name:              "invalid replication factor",
+			repo:              api.RepoName("repo1"),
+			replicationFactor: 0,
+			want:              []string{"gitserver-3"},
+		},
+		{
+			name:              "replicas wrap around",
+			repo:              api.RepoName("repo1.git"),
+			replicationFactor: 2,
+			want:              []string{"gitserver-3", "gitserver-1"},
+		},
+		{
+			name:              "capped at number of gitservers",
+			repo:              api.RepoName("github.com/sourcegraph/sourcegraph.git"),
+			replicationFactor: 5,
+			want:              []string{"gitserver-2", "gitserver-3", "gitserver-1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := gitserver.AddrsForRepo(tc.repo, addrs, tc.replicationFactor)
+			if !cmp.Equal(tc.want, got) {
+				t.Fatalf("mismatch (-want +got):\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
 func TestRendezvousAddrForRepo(t *testing.T) {
 	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
 
@@ -211,6 +211,11 @@ type RepoInfo struct {
 	// re-cloned automatically, so this time is likely to move forward
 	// periodically.
 	CloneTime *time.Time
+
+	// SizeBytes is the amount of bytes stored in the .git directory of the
+	// repository, as last computed by the janitor. It is zero if the size has
+	// not been computed yet.
+	SizeBytes int64
 }
 
 // RepoInfoResponse is the response to a repository information request
@@ -229,6 +234,10 @@ type ReposStats struct {
 
 	// GitDirBytes is the amount of bytes stored in .git directories.
 	GitDirBytes int64
+
+	// RepoBytes is the amount of bytes stored in the .git directory of each
+	// repository on the gitserver.
+	RepoBytes map[api.RepoName]int64 `json:",omitempty"`
 }
 
 // RepoCloneProgressRequest is a request for information about the clone progress of multiple
@@ -268,6 +277,10 @@ type CreateCommitFromPatchRequest struct {
 	// Push specifies whether the target ref will be pushed to the code host: if
 	// nil, no push will be attempted, if non-nil, a push will be attempted.
 	Push *PushConfig
+	// PushRef, if set, is the ref on the code host that the commit is pushed
+	// to instead of TargetRef. Gerrit, for example, creates changes for commits
+	// pushed to refs/for/<branch>.
+	PushRef *string
 	// GitApplyArgs are the arguments that will be passed to `git apply` along
 	// with `--cached`.
 	GitApplyArgs []string
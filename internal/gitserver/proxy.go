@@ -35,7 +35,7 @@ type ReverseProxy struct {
 
 // ServeHTTP creates a one-shot proxy with the given director and proxies the given request
 // to gitserver. The director must rewrite the request to the correct gitserver address, which
-// should be obtained via a gitserver client's AddrForRepo method.
+// should be obtained via a gitserver client's ClonedAddrForRepo or AddrForRepo method.
 func (p *ReverseProxy) ServeHTTP(repo api.RepoName, method, op string, director func(req *http.Request), res http.ResponseWriter, req *http.Request) {
 	span, _ := ot.StartSpanFromContext(req.Context(), "ReverseProxy.ServeHTTP")
 	defer func() {
@@ -14,7 +14,9 @@ import (
 // DiffFetcher is a handle to the stdin and stdout of a git diff-tree subprocess
 // started with StartDiffFetcher
 type DiffFetcher struct {
-	dir string
+	dir     string
+	prepare func(*exec.Cmd) func(string) string
+	done    func(stderr string) string
 
 	startOnce sync.Once
 	stdin     io.Writer
@@ -25,10 +27,12 @@ type DiffFetcher struct {
 }
 
 // NewDiffFetcher starts a git diff-tree subprocess that waits, listening on stdin
-// for comimt hashes to generate patches for.
-func NewDiffFetcher(dir string) (*DiffFetcher, error) {
+// for comimt hashes to generate patches for. If prepare is set, it is called
+// with the subprocess before it is started, and the function it returns is
+// called with the stderr of the subprocess once it stopped.
+func NewDiffFetcher(dir string, prepare func(cmd *exec.Cmd) func(stderr string) string) (*DiffFetcher, error) {
 
-	return &DiffFetcher{dir: dir}, nil
+	return &DiffFetcher{dir: dir, prepare: prepare}, nil
 }
 
 func (d *DiffFetcher) Stop() {
@@ -36,6 +40,9 @@ func (d *DiffFetcher) Stop() {
 		d.cancel()
 		d.cmd.Wait()
 	}
+	if d.done != nil {
+		d.done("")
+	}
 }
 
 func (d *DiffFetcher) start() (err error) {
@@ -51,6 +58,7 @@ func (d *DiffFetcher) start() (err error) {
 			"--root",           // Treat the root commit as a big creation event (otherwise the diff would be empty)
 		)
 		d.cmd.Dir = d.dir
+		d.done = prepareCommand(d.prepare, d.cmd)
 
 		var stdoutReader io.ReadCloser
 		stdoutReader, err = d.cmd.StdoutPipe()
@@ -111,7 +119,10 @@ func (d *DiffFetcher) Fetch(hash []byte) ([]byte, error) {
 	} else if err := d.scanner.Err(); err != nil {
 		return nil, err
 	} else if stderr, _ := io.ReadAll(d.stderr); len(stderr) > 0 {
-		return nil, errors.Errorf("git subprocess stderr: %s", string(stderr))
+		// The subprocess exited, so this is all it will write to stderr.
+		done := d.done
+		d.done = nil
+		return nil, errors.Errorf("git subprocess stderr: %s", done(string(stderr)))
 	}
 	return nil, errors.New("expected scan to succeed")
 }
@@ -0,0 +1,73 @@
+package search
+
+import (
+	"bytes"
+
+	"github.com/cockroachdb/errors"
+	"github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// MatchRangeDiff matches q against a diff that spans several commits, such as
+// the output of `git diff base head`. Such a diff has no author, committer,
+// date or message, so q must not have predicates on those (see
+// ValidateRangeDiffQuery). If q matches, the formatted diff and its matched
+// ranges are returned.
+func MatchRangeDiff(q protocol.Node, rawDiff []byte) (result.MatchedString, bool, error) {
+	if err := ValidateRangeDiffQuery(q); err != nil {
+		return result.MatchedString{}, false, err
+	}
+
+	fileDiffs, err := diff.NewMultiFileDiffReader(bytes.NewReader(rawDiff)).ReadAllFiles()
+	if err != nil || len(fileDiffs) == 0 {
+		return result.MatchedString{}, false, err
+	}
+
+	mt, err := ToMatchTree(q)
+	if err != nil {
+		return result.MatchedString{}, false, err
+	}
+
+	lc := &LazyCommit{
+		RawCommit: &RawCommit{},
+		diff:      fileDiffs,
+	}
+	mergedResult, highlights, err := mt.Match(lc)
+	if err != nil || !mergedResult.Satisfies() {
+		return result.MatchedString{}, false, err
+	}
+
+	content, ranges := FormatDiff(fileDiffs, highlights.Diff)
+	return result.MatchedString{Content: content, MatchedRanges: ranges}, true, nil
+}
+
+// ValidateRangeDiffQuery returns an error if q has predicates on commit
+// metadata, which can't be evaluated against a diff that spans several
+// commits.
+func ValidateRangeDiffQuery(q protocol.Node) error {
+	var field string
+	switch v := q.(type) {
+	case *protocol.AuthorMatches:
+		field = "author"
+	case *protocol.CommitterMatches:
+		field = "committer"
+	case *protocol.CommitBefore:
+		field = "before"
+	case *protocol.CommitAfter:
+		field = "after"
+	case *protocol.MessageMatches:
+		field = "message"
+	case *protocol.Operator:
+		for _, operand := range v.Operands {
+			if err := ValidateRangeDiffQuery(operand); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return nil
+	}
+	return errors.Errorf("the %s: filter can't be used in diff searches of revision ranges, since their diffs span several commits", field)
+}
@@ -0,0 +1,63 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+func TestMatchRangeDiff(t *testing.T) {
+	rawDiff := []byte(`diff --git README.md README.md
+index 1111111..2222222 100644
+--- README.md
++++ README.md
+@@ -1,2 +1,2 @@
+ # project
+-old readme
++new readme
+diff --git main.go main.go
+index 3333333..4444444 100644
+--- main.go
++++ main.go
+@@ -1,3 +1,3 @@
+ package main
+-func old() {}
++func renamed() {}
+`)
+
+	t.Run("matches content", func(t *testing.T) {
+		q := &protocol.DiffMatches{Expr: "renamed"}
+		ms, ok, err := MatchRangeDiff(q, rawDiff)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, "main.go main.go\n@@ -2,1 +2,1 @@ \n-func old() {}\n+func renamed() {}\n", ms.Content)
+		require.Len(t, ms.MatchedRanges, 1)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		_, ok, err := MatchRangeDiff(&protocol.DiffMatches{Expr: "missing"}, rawDiff)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("commit predicates", func(t *testing.T) {
+		for _, q := range []protocol.Node{
+			&protocol.AuthorMatches{Expr: "alice"},
+			protocol.NewAnd(&protocol.DiffMatches{Expr: "renamed"}, &protocol.CommitterMatches{Expr: "bob"}),
+			protocol.NewOr(&protocol.DiffMatches{Expr: "renamed"}, &protocol.CommitBefore{}),
+			protocol.NewNot(&protocol.CommitAfter{}),
+			protocol.NewNot(&protocol.MessageMatches{Expr: "fix"}),
+		} {
+			_, _, err := MatchRangeDiff(q, rawDiff)
+			require.Error(t, err, q.String())
+		}
+	})
+
+	t.Run("empty diff", func(t *testing.T) {
+		_, ok, err := MatchRangeDiff(&protocol.DiffMatches{Expr: "a"}, nil)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+}
@@ -77,6 +77,11 @@ type CommitSearcher struct {
 	Query       MatchTree
 	Revisions   []protocol.RevisionSpecifier
 	IncludeDiff bool
+
+	// PrepareCommand, if set, is called with every git command before it is
+	// run. The returned function is called with the stderr of the command,
+	// and returns the stderr to report.
+	PrepareCommand func(cmd *exec.Cmd) func(stderr string) string
 }
 
 // Search runs a search for commits matching the given predicate across the revisions passed in as revisionArgs.
@@ -126,6 +131,7 @@ func (cs *CommitSearcher) feedBatches(ctx context.Context, jobs chan job, result
 	revArgs := revsToGitArgs(cs.Revisions)
 	cmd := exec.CommandContext(ctx, "git", append(logArgs, revArgs...)...)
 	cmd.Dir = cs.RepoDir
+	done := prepareCommand(cs.PrepareCommand, cmd)
 	stdoutReader, err := cmd.StdoutPipe()
 	if err != nil {
 		return err
@@ -134,13 +140,16 @@ func (cs *CommitSearcher) feedBatches(ctx context.Context, jobs chan job, result
 	cmd.Stderr = &stderrBuf
 
 	if err := cmd.Start(); err != nil {
+		done(stderrBuf.String())
 		return err
 	}
 
 	defer func() {
 		// Always call cmd.Wait to avoid leaving zombie processes around.
-		if e := cmd.Wait(); e != nil {
-			err = multierror.Append(err, tryInterpretErrorWithStderr(ctx, err, stderrBuf.String())).ErrorOrNil()
+		e := cmd.Wait()
+		stderr := done(stderrBuf.String())
+		if e != nil {
+			err = multierror.Append(err, tryInterpretErrorWithStderr(ctx, err, stderr)).ErrorOrNil()
 		}
 	}()
 
@@ -189,7 +198,7 @@ func tryInterpretErrorWithStderr(ctx context.Context, err error, stderr string)
 
 func (cs *CommitSearcher) runJobs(ctx context.Context, jobs chan job) error {
 	// Create a new diff fetcher subprocess for each worker
-	diffFetcher, err := NewDiffFetcher(cs.RepoDir)
+	diffFetcher, err := NewDiffFetcher(cs.RepoDir, cs.PrepareCommand)
 	if err != nil {
 		return err
 	}
@@ -233,6 +242,15 @@ func (cs *CommitSearcher) runJobs(ctx context.Context, jobs chan job) error {
 	return errors.ErrorOrNil()
 }
 
+// prepareCommand calls prepare with cmd, if set, and returns the function to
+// call with the stderr of cmd after it ran.
+func prepareCommand(prepare func(*exec.Cmd) func(string) string, cmd *exec.Cmd) func(stderr string) string {
+	if prepare == nil {
+		return func(stderr string) string { return stderr }
+	}
+	return prepare(cmd)
+}
+
 func revsToGitArgs(revs []protocol.RevisionSpecifier) []string {
 	revArgs := make([]string, 0, len(revs))
 	for _, rev := range revs {
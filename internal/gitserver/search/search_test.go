@@ -10,6 +10,7 @@ import (
 	"path"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"testing/quick"
 
@@ -188,6 +189,28 @@ func TestSearch(t *testing.T) {
 		require.Equal(t, matches[0].Author.Name, "camden1")
 		require.Len(t, strings.Split(matches[0].Diff.Content, "\n"), 4)
 	})
+
+	t.Run("prepares commands", func(t *testing.T) {
+		query := &protocol.DiffMatches{Expr: "ipsum"}
+		tree, err := ToMatchTree(query)
+		require.NoError(t, err)
+		var mu sync.Mutex
+		prepared := map[string]int{}
+		searcher := &CommitSearcher{
+			RepoDir: dir,
+			Query:   tree,
+			PrepareCommand: func(cmd *exec.Cmd) func(string) string {
+				mu.Lock()
+				defer mu.Unlock()
+				prepared[cmd.Args[1]]++
+				return func(stderr string) string { return stderr }
+			},
+		}
+		err = searcher.Search(context.Background(), func(*protocol.CommitMatch) {})
+		require.NoError(t, err)
+		require.Equal(t, 1, prepared["log"])
+		require.GreaterOrEqual(t, prepared["diff-tree"], 1)
+	})
 }
 
 func TestCommitScanner(t *testing.T) {
@@ -0,0 +1,149 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"path"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A GerritSource yields repositories from a single Gerrit connection configured
+// in Sourcegraph via the external services configuration.
+type GerritSource struct {
+	svc     *types.ExternalService
+	config  *schema.GerritConnection
+	cli     *gerrit.Client
+	baseURL *url.URL
+	perPage int
+}
+
+// NewGerritSource returns a new GerritSource from the given external service.
+func NewGerritSource(svc *types.ExternalService, cf *httpcli.Factory) (*GerritSource, error) {
+	var c schema.GerritConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing Gerrit URL")
+	}
+	baseURL = extsvc.NormalizeBaseURL(baseURL)
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	httpCli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := gerrit.NewClient(baseURL, &auth.BasicAuth{Username: c.Username, Password: c.Password}, httpCli)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GerritSource{
+		svc:     svc,
+		config:  &c,
+		cli:     cli,
+		baseURL: baseURL,
+		perPage: 100,
+	}, nil
+}
+
+// ListRepos returns the Gerrit projects configured with this GerritSource's
+// config, or all projects visible to its account if none are configured.
+func (s *GerritSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	if len(s.config.Projects) > 0 {
+		for _, name := range s.config.Projects {
+			p, err := s.cli.GetProject(ctx, name)
+			if err != nil {
+				results <- SourceResult{Source: s, Err: errors.Wrapf(err, "getting Gerrit project %q", name)}
+				continue
+			}
+			p.Name = name
+			results <- SourceResult{Source: s, Repo: s.makeRepo(p)}
+		}
+		return
+	}
+
+	for offset := 0; ; offset += s.perPage {
+		projects, more, err := s.cli.ListProjects(ctx, offset, s.perPage)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			return
+		}
+
+		for _, p := range projects {
+			results <- SourceResult{Source: s, Repo: s.makeRepo(p)}
+		}
+
+		if !more {
+			return
+		}
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *GerritSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *GerritSource) makeRepo(p *gerrit.Project) *types.Repo {
+	urn := s.svc.URN()
+	name := path.Join(s.baseURL.Host, s.baseURL.Path, p.Name)
+
+	return &types.Repo{
+		Name:        api.RepoName(name),
+		URI:         name,
+		Description: p.Description,
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          p.ID,
+			ServiceType: extsvc.TypeGerrit,
+			ServiceID:   s.baseURL.String(),
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: s.remoteURL(p),
+			},
+		},
+		Metadata: p,
+	}
+}
+
+// remoteURL returns the project's Git remote URL
+//
+// note: this doesn't contain credentials, if you need to get an authenticated
+// clone url use repos.CloneURL
+func (s *GerritSource) remoteURL(p *gerrit.Project) string {
+	u := *s.baseURL
+	u.Path = path.Join(u.Path, p.Name)
+	return u.String()
+}
+
+// gerritCloneURL returns the authenticated Git remote URL of the given
+// project, which uses the /a/ prefix that Gerrit requires for authenticated
+// requests.
+func gerritCloneURL(p *gerrit.Project, cfg *schema.GerritConnection) (string, error) {
+	u, err := url.Parse(cfg.Url)
+	if err != nil {
+		return "", err
+	}
+	u = extsvc.NormalizeBaseURL(u)
+	u.Path = path.Join(u.Path, "a", p.Name)
+	u.User = url.UserPassword(cfg.Username, cfg.Password)
+	return u.String(), nil
+}
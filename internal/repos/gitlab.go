@@ -181,6 +181,10 @@ func (s GitLabSource) ExternalServices() types.ExternalServices {
 
 func (s GitLabSource) makeRepo(proj *gitlab.Project) *types.Repo {
 	urn := s.svc.URN()
+	metadata := *proj
+	// Older GitLab versions only return topics as tag_list. We store them as
+	// topics so that they can be used for repository search predicates.
+	metadata.Topics = proj.TopicNames()
 	return &types.Repo{
 		Name: reposource.GitLabRepoName(
 			s.config.RepositoryPathPattern,
@@ -206,7 +210,7 @@ func (s GitLabSource) makeRepo(proj *gitlab.Project) *types.Repo {
 				CloneURL: s.remoteURL(proj),
 			},
 		},
-		Metadata: proj,
+		Metadata: &metadata,
 	}
 }
 
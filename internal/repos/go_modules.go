@@ -0,0 +1,96 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gomodules"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A GoModulesSource creates git repositories from the zip archives of Go
+// modules served by Go module proxies.
+type GoModulesSource struct {
+	svc    *types.ExternalService
+	config *schema.GoModulesConnection
+}
+
+// NewGoModulesSource returns a new GoModulesSource from the given external
+// service.
+func NewGoModulesSource(svc *types.ExternalService) (*GoModulesSource, error) {
+	var c schema.GoModulesConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return &GoModulesSource{svc: svc, config: &c}, nil
+}
+
+var _ Source = &GoModulesSource{}
+
+// ListRepos returns all Go modules configured in the external service.
+func (s *GoModulesSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	goModules, err := goModules(*s.config)
+	if err != nil {
+		results <- SourceResult{Err: err}
+		return
+	}
+	for _, goModule := range goModules {
+		results <- SourceResult{
+			Source: s,
+			Repo:   s.makeRepo(goModule),
+		}
+	}
+}
+
+func (s *GoModulesSource) makeRepo(goModule reposource.GoModule) *types.Repo {
+	urn := s.svc.URN()
+	repoName := goModule.RepoName()
+	return &types.Repo{
+		Name: repoName,
+		URI:  string(repoName),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          string(repoName),
+			ServiceID:   extsvc.TypeGoModules,
+			ServiceType: extsvc.TypeGoModules,
+		},
+		Private: false,
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: goModule.CloneURL(),
+			},
+		},
+		Metadata: &gomodules.Metadata{
+			Module: goModule,
+		},
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *GoModulesSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+// goModules gets the list of applicable modules by de-duplicating
+// dependencies present in the configuration.
+func goModules(connection schema.GoModulesConnection) ([]reposource.GoModule, error) {
+	var goModules []reposource.GoModule
+	isAdded := make(map[reposource.GoModule]bool)
+	for _, dep := range connection.Dependencies {
+		dependency, err := reposource.ParseGoDependency(dep)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdded[dependency.GoModule] {
+			goModules = append(goModules, dependency.GoModule)
+		}
+		isAdded[dependency.GoModule] = true
+	}
+	return goModules, nil
+}
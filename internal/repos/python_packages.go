@@ -0,0 +1,96 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/pythonpackages"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A PythonPackagesSource creates git repositories from source distributions
+// and wheels of Python packages published on PyPI-compatible indexes.
+type PythonPackagesSource struct {
+	svc    *types.ExternalService
+	config *schema.PythonPackagesConnection
+}
+
+// NewPythonPackagesSource returns a new PythonPackagesSource from the given
+// external service.
+func NewPythonPackagesSource(svc *types.ExternalService) (*PythonPackagesSource, error) {
+	var c schema.PythonPackagesConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return &PythonPackagesSource{svc: svc, config: &c}, nil
+}
+
+var _ Source = &PythonPackagesSource{}
+
+// ListRepos returns all Python packages configured in the external service.
+func (s *PythonPackagesSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	pythonPackages, err := pythonPackages(*s.config)
+	if err != nil {
+		results <- SourceResult{Err: err}
+		return
+	}
+	for _, pythonPackage := range pythonPackages {
+		results <- SourceResult{
+			Source: s,
+			Repo:   s.makeRepo(pythonPackage),
+		}
+	}
+}
+
+func (s *PythonPackagesSource) makeRepo(pythonPackage reposource.PythonPackage) *types.Repo {
+	urn := s.svc.URN()
+	repoName := pythonPackage.RepoName()
+	return &types.Repo{
+		Name: repoName,
+		URI:  string(repoName),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          string(repoName),
+			ServiceID:   extsvc.TypePythonPackages,
+			ServiceType: extsvc.TypePythonPackages,
+		},
+		Private: false,
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: pythonPackage.CloneURL(),
+			},
+		},
+		Metadata: &pythonpackages.Metadata{
+			Package: pythonPackage,
+		},
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *PythonPackagesSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+// pythonPackages gets the list of applicable packages by de-duplicating
+// dependencies present in the configuration.
+func pythonPackages(connection schema.PythonPackagesConnection) ([]reposource.PythonPackage, error) {
+	var pythonPackages []reposource.PythonPackage
+	isAdded := make(map[reposource.PythonPackage]bool)
+	for _, dep := range connection.Dependencies {
+		dependency, err := reposource.ParsePythonDependency(dep)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdded[dependency.PythonPackage] {
+			pythonPackages = append(pythonPackages, dependency.PythonPackage)
+		}
+		isAdded[dependency.PythonPackage] = true
+	}
+	return pythonPackages, nil
+}
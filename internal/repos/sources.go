@@ -50,6 +50,8 @@ func NewSource(svc *types.ExternalService, cf *httpcli.Factory) (Source, error)
 		return NewBitbucketServerSource(svc, cf)
 	case extsvc.KindBitbucketCloud:
 		return NewBitbucketCloudSource(svc, cf)
+	case extsvc.KindGerrit:
+		return NewGerritSource(svc, cf)
 	case extsvc.KindGitolite:
 		return NewGitoliteSource(svc, cf)
 	case extsvc.KindPhabricator:
@@ -64,6 +66,10 @@ func NewSource(svc *types.ExternalService, cf *httpcli.Factory) (Source, error)
 		return NewPagureSource(svc, cf)
 	case extsvc.KindNPMPackages:
 		return NewNPMPackagesSource(svc)
+	case extsvc.KindPythonPackages:
+		return NewPythonPackagesSource(svc)
+	case extsvc.KindGoModules:
+		return NewGoModulesSource(svc)
 	case extsvc.KindOther:
 		return NewOtherSource(svc, cf)
 	default:
@@ -0,0 +1,204 @@
+// Package codeowners parses CODEOWNERS files and resolves the owners of the
+// files matched by a search.
+package codeowners
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Paths are the locations of CODEOWNERS files, in the order in which code
+// hosts look for them. Only the first file found is used.
+var Paths = []string{
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+}
+
+// Ruleset is a parsed CODEOWNERS file.
+type Ruleset struct {
+	// sections holds the rules of each GitLab section, in order. Files
+	// without sections (such as all GitHub CODEOWNERS files) have a single
+	// unnamed section.
+	sections []*section
+}
+
+type section struct {
+	name  string
+	rules []*rule
+}
+
+type rule struct {
+	pattern string
+	match   *regexp.Regexp
+	owners  []string
+}
+
+var sectionHeader = regexp.MustCompile(`^\^?\[([^\]]+)\](?:\[\d+\])?\s*(.*)$`)
+
+// Parse parses a CODEOWNERS file in GitHub or GitLab syntax.
+//
+// Each rule consists of a gitignore-style pattern followed by zero or more
+// owners. GitLab sections ("[Section] @default-owner") are supported: rules
+// without owners inherit the default owners of their section, and each
+// section contributes owners independently.
+func Parse(r io.Reader) (*Ruleset, error) {
+	current := &section{}
+	rs := &Ruleset{sections: []*section{current}}
+	var defaultOwners []string
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if m := sectionHeader.FindStringSubmatch(line); m != nil {
+			current = &section{name: m[1]}
+			rs.sections = append(rs.sections, current)
+			defaultOwners = strings.Fields(m[2])
+			continue
+		}
+
+		fields := splitFields(line)
+		match, err := compilePattern(fields[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", lineNumber)
+		}
+		owners := fields[1:]
+		if len(owners) == 0 {
+			owners = defaultOwners
+		}
+		current.rules = append(current.rules, &rule{
+			pattern: fields[0],
+			match:   match,
+			owners:  owners,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+// Match returns the owners of the file at path, which is relative to the
+// repository root. Within a section the last matching rule wins. The owners
+// of all sections are combined.
+func (rs *Ruleset) Match(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+
+	var owners []string
+	seen := map[string]struct{}{}
+	for _, s := range rs.sections {
+		for i := len(s.rules) - 1; i >= 0; i-- {
+			if !s.rules[i].match.MatchString(path) {
+				continue
+			}
+			for _, owner := range s.rules[i].owners {
+				key := strings.ToLower(owner)
+				if _, ok := seen[key]; ok {
+					continue
+				}
+				seen[key] = struct{}{}
+				owners = append(owners, owner)
+			}
+			break
+		}
+	}
+	return owners
+}
+
+// NormalizeOwner returns owner in the form used in CODEOWNERS files: user
+// and team names are prefixed with @, email addresses are left as is.
+func NormalizeOwner(owner string) string {
+	owner = strings.TrimSpace(owner)
+	if owner == "" || strings.Contains(owner, "@") {
+		return owner
+	}
+	return "@" + owner
+}
+
+// splitFields splits a rule into its pattern and owners. Spaces in patterns
+// may be escaped with a backslash.
+func splitFields(line string) []string {
+	var fields []string
+	var current strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '\\' && i+1 < len(line) && line[i+1] == ' ':
+			current.WriteByte(' ')
+			i++
+		case c == ' ' || c == '\t':
+			if current.Len() > 0 {
+				fields = append(fields, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteByte(c)
+		}
+	}
+	if current.Len() > 0 {
+		fields = append(fields, current.String())
+	}
+	return fields
+}
+
+// compilePattern converts a gitignore-style pattern to a regular expression
+// that matches paths relative to the repository root.
+//
+// A pattern containing a slash (other than a trailing one) is relative to the
+// root, otherwise it matches at any depth. A pattern matches a path itself and
+// everything below it, except for patterns ending in "/*", which only match
+// the direct children of a directory.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	trimmed := strings.Trim(pattern, "/")
+	if trimmed == "" || trimmed == "*" || trimmed == "**" {
+		return regexp.Compile(`^.*$`)
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !strings.Contains(strings.TrimSuffix(pattern, "/"), "/") {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(trimmed); i++ {
+		switch c := trimmed[i]; c {
+		case '*':
+			if i+1 < len(trimmed) && trimmed[i+1] == '*' {
+				if i+2 < len(trimmed) && trimmed[i+2] == '/' {
+					// "**/" matches zero or more directories.
+					b.WriteString("(?:.*/)?")
+					i += 2
+				} else {
+					b.WriteString(".*")
+					i++
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '\\':
+			if i+1 < len(trimmed) {
+				i++
+				b.WriteString(regexp.QuoteMeta(string(trimmed[i])))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if strings.HasSuffix(pattern, "/*") {
+		b.WriteString("$")
+	} else {
+		b.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,96 @@
+package codeowners
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse_GitHub(t *testing.T) {
+	rs, err := Parse(strings.NewReader(`
+# Default owners for everything.
+*       @global-owner1 @global-owner2
+
+# Order is important; the last matching pattern takes precedence.
+*.js    @js-owner
+*.go    docs@example.com
+/build/logs/ @doctocat
+docs/*  @docs-owner
+apps/   @octocat
+/scripts/ @doctocat @octocat
+**/logs @logs-owner
+/vendor/
+my\ file.txt @spaces
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string][]string{
+		"README.md":                   {"@global-owner1", "@global-owner2"},
+		"web/app.js":                  {"@js-owner"},
+		"main.go":                     {"docs@example.com"},
+		"build/logs/out.txt":          {"@logs-owner"},
+		"docs/getting-started.md":     {"@docs-owner"},
+		"docs/build-app/trouble.md":   {"@global-owner1", "@global-owner2"},
+		"apps/web/index.html":         {"@octocat"},
+		"nested/apps/web/index.html":  {"@octocat"},
+		"scripts/deploy.sh":           {"@doctocat", "@octocat"},
+		"nested/scripts/deploy.sh":    {"@global-owner1", "@global-owner2"},
+		"deeply/nested/logs/out.txt":  {"@logs-owner"},
+		"vendor/github.com/x/y/z.go":  nil,
+		"my file.txt":                 {"@spaces"},
+		"/README.md":                  {"@global-owner1", "@global-owner2"},
+		"scripts-old/run.sh":          {"@global-owner1", "@global-owner2"},
+		"web/app.jsx":                 {"@global-owner1", "@global-owner2"},
+		"build/logs/nested/trace.txt": {"@logs-owner"},
+	} {
+		if diff := cmp.Diff(want, rs.Match(path)); diff != "" {
+			t.Errorf("%s: unexpected owners (-want +got):\n%s", path, diff)
+		}
+	}
+}
+
+func TestParse_GitLabSections(t *testing.T) {
+	rs, err := Parse(strings.NewReader(`
+*.rb @ruby-owner
+
+[Documentation] @docs-team
+docs/
+README.md @readme-owner
+
+^[Database][2] @database-team
+db/
+*.rb @db-ruby-owner
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string][]string{
+		"app/models/user.rb": {"@ruby-owner", "@db-ruby-owner"},
+		"docs/index.md":      {"@docs-team"},
+		"README.md":          {"@readme-owner"},
+		"db/schema.sql":      {"@database-team"},
+		"main.go":            nil,
+	} {
+		if diff := cmp.Diff(want, rs.Match(path)); diff != "" {
+			t.Errorf("%s: unexpected owners (-want +got):\n%s", path, diff)
+		}
+	}
+}
+
+func TestNormalizeOwner(t *testing.T) {
+	for input, want := range map[string]string{
+		"alice":             "@alice",
+		"@alice":            "@alice",
+		"org/team":          "@org/team",
+		"alice@example.com": "alice@example.com",
+		"":                  "",
+	} {
+		if got := NormalizeOwner(input); got != want {
+			t.Errorf("NormalizeOwner(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
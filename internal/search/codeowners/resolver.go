@@ -0,0 +1,144 @@
+package codeowners
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// maxFileSize is the maximum size of a CODEOWNERS file that is read. GitHub
+// ignores CODEOWNERS files larger than 3 MB.
+const maxFileSize = 3 * 1024 * 1024
+
+// Resolver resolves the owners of files. CODEOWNERS files are loaded once per
+// repository and commit.
+type Resolver struct {
+	mu       sync.Mutex
+	rulesets map[repoCommit]*Ruleset
+}
+
+type repoCommit struct {
+	repo   api.RepoName
+	commit api.CommitID
+}
+
+func NewResolver() *Resolver {
+	return &Resolver{rulesets: make(map[repoCommit]*Ruleset)}
+}
+
+// Owners returns the owners of the file at path. It returns no owners if the
+// repository has no CODEOWNERS file at commit.
+func (r *Resolver) Owners(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) ([]string, error) {
+	rs, err := r.ruleset(ctx, repo, commit)
+	if err != nil || rs == nil {
+		return nil, err
+	}
+	return rs.Match(path), nil
+}
+
+// HasOwner reports whether owner owns the file at path. Owners are compared
+// case-insensitively, see NormalizeOwner.
+func (r *Resolver) HasOwner(ctx context.Context, repo api.RepoName, commit api.CommitID, path, owner string) (bool, error) {
+	owners, err := r.Owners(ctx, repo, commit, path)
+	if err != nil {
+		return false, err
+	}
+	owner = NormalizeOwner(owner)
+	for _, o := range owners {
+		if strings.EqualFold(o, owner) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *Resolver) ruleset(ctx context.Context, repo api.RepoName, commit api.CommitID) (*Ruleset, error) {
+	key := repoCommit{repo: repo, commit: commit}
+	r.mu.Lock()
+	rs, ok := r.rulesets[key]
+	r.mu.Unlock()
+	if ok {
+		return rs, nil
+	}
+
+	rs, err := load(ctx, repo, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.rulesets[key] = rs
+	r.mu.Unlock()
+	return rs, nil
+}
+
+// load reads the first CODEOWNERS file found in Paths. It returns a nil
+// Ruleset if there is none.
+func load(ctx context.Context, repo api.RepoName, commit api.CommitID) (*Ruleset, error) {
+	for _, path := range Paths {
+		content, err := git.ReadFile(ctx, repo, commit, path, maxFileSize)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return Parse(bytes.NewReader(content))
+	}
+	return nil, nil
+}
+
+// OwnerMatches converts the file matches among matches to the owners of the
+// matched files. Each owner is returned once, with the number of files it
+// owns. Files without owners and other match types are dropped.
+func (r *Resolver) OwnerMatches(ctx context.Context, matches []result.Match) ([]result.Match, error) {
+	var owners []result.Match
+	byOwner := make(map[string]*result.OwnerMatch)
+	for _, match := range matches {
+		fm, ok := match.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+		handles, err := r.Owners(ctx, fm.Repo.Name, fm.CommitID, fm.Path)
+		if err != nil {
+			return nil, err
+		}
+		for _, handle := range handles {
+			key := strings.ToLower(handle)
+			if om, ok := byOwner[key]; ok {
+				om.Count++
+				continue
+			}
+			om := &result.OwnerMatch{Handle: handle, Repo: fm.Repo, Count: 1}
+			byOwner[key] = om
+			owners = append(owners, om)
+		}
+	}
+	return owners, nil
+}
+
+// FilterByOwner returns the file matches among matches that are owned by
+// owner. Other match types are dropped. matches is not modified.
+func (r *Resolver) FilterByOwner(ctx context.Context, matches []result.Match, owner string) ([]result.Match, error) {
+	filtered := make([]result.Match, 0, len(matches))
+	for _, match := range matches {
+		fm, ok := match.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+		ok, err := r.HasOwner(ctx, fm.Repo.Name, fm.CommitID, fm.Path, owner)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			filtered = append(filtered, fm)
+		}
+	}
+	return filtered, nil
+}
@@ -0,0 +1,90 @@
+package codeowners
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestResolver(t *testing.T) {
+	reads := 0
+	git.Mocks.ReadFile = func(commit api.CommitID, name string) ([]byte, error) {
+		reads++
+		if commit == "c1" && name == ".github/CODEOWNERS" {
+			return []byte("* @alice\n*.go @bob @Alice\n"), nil
+		}
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	t.Cleanup(git.ResetMocks)
+
+	fileMatch := func(commit api.CommitID, path string) *result.FileMatch {
+		return &result.FileMatch{File: result.File{
+			Repo:     types.MinimalRepo{ID: 1, Name: "foo"},
+			CommitID: commit,
+			Path:     path,
+		}}
+	}
+	newMatches := func() []result.Match {
+		return []result.Match{
+			fileMatch("c1", "main.go"),
+			fileMatch("c1", "README.md"),
+			fileMatch("c1", "cmd/main.go"),
+			fileMatch("c2", "other.go"), // no CODEOWNERS file
+			&result.RepoMatch{Name: "foo", ID: 1},
+		}
+	}
+
+	t.Run("owner matches", func(t *testing.T) {
+		r := NewResolver()
+		got, err := r.OwnerMatches(context.Background(), newMatches())
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []result.Match{
+			&result.OwnerMatch{Handle: "@bob", Repo: types.MinimalRepo{ID: 1, Name: "foo"}, Count: 2},
+			&result.OwnerMatch{Handle: "@Alice", Repo: types.MinimalRepo{ID: 1, Name: "foo"}, Count: 3},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unexpected owners (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("filter by owner", func(t *testing.T) {
+		r := NewResolver()
+		matches := newMatches()
+		got, err := r.FilterByOwner(context.Background(), matches, "bob")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(newMatches(), matches); diff != "" {
+			t.Errorf("input matches were modified (-want +got):\n%s", diff)
+		}
+		var paths []string
+		for _, m := range got {
+			paths = append(paths, m.(*result.FileMatch).Path)
+		}
+		if diff := cmp.Diff([]string{"main.go", "cmd/main.go"}, paths); diff != "" {
+			t.Errorf("unexpected files (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("CODEOWNERS files are read once per commit", func(t *testing.T) {
+		reads = 0
+		r := NewResolver()
+		for i := 0; i < 3; i++ {
+			if _, err := r.Owners(context.Background(), "foo", "c2", "main.go"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if want := len(Paths); reads != want {
+			t.Errorf("got %d reads, want %d", reads, want)
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package codeowners
+
+import (
+	"context"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+// WithOwners returns a child Stream of parent that replaces the file matches
+// of each event with the owners of those files. Events are forwarded with
+// per-event owner counts, it is up to parent to aggregate them. If the owners
+// can't be resolved, the event is forwarded unchanged.
+func WithOwners(ctx context.Context, parent streaming.Sender, r *Resolver) streaming.Sender {
+	return streaming.StreamFunc(func(e streaming.SearchEvent) {
+		owners, err := r.OwnerMatches(ctx, e.Results)
+		if err != nil {
+			log15.Warn("codeowners: failed to resolve owners, forwarding matches unchanged", "error", err)
+			parent.Send(e)
+			return
+		}
+		e.Results = owners
+		parent.Send(e)
+	})
+}
+
+// WithOwnerFilter returns a child Stream of parent that only forwards the
+// file matches owned by owner. If the owners can't be resolved, the event is
+// forwarded unfiltered.
+func WithOwnerFilter(ctx context.Context, parent streaming.Sender, r *Resolver, owner string) streaming.Sender {
+	return streaming.StreamFunc(func(e streaming.SearchEvent) {
+		filtered, err := r.FilterByOwner(ctx, e.Results, owner)
+		if err != nil {
+			log15.Warn("codeowners: failed to resolve owners, forwarding matches unfiltered", "error", err)
+			parent.Send(e)
+			return
+		}
+		e.Results = filtered
+		parent.Send(e)
+	})
+}
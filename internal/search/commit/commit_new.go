@@ -99,6 +99,31 @@ func (j *CommitSearch) Run(ctx context.Context, stream streaming.Sender, repos s
 			})
 			return err
 		})
+
+		if !j.Diff {
+			continue
+		}
+
+		// Revision ranges additionally produce a single result for the
+		// aggregated diff across all commits in the range.
+		for _, rev := range repoRev.Revs {
+			rev := rev
+			rr, ok := rev.Range()
+			if !ok {
+				continue
+			}
+
+			g.Go(func() error {
+				match, err := j.searchRangeDiff(ctx, repoRev.Repo, rev, rr)
+				if err != nil || match == nil {
+					return err
+				}
+				stream.Send(streaming.SearchEvent{
+					Results: []result.Match{match},
+				})
+				return nil
+			})
+		}
 	}
 
 	return g.Wait()
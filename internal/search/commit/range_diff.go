@@ -0,0 +1,63 @@
+package commit
+
+import (
+	"context"
+
+	gitsearch "github.com/sourcegraph/sourcegraph/internal/gitserver/search"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// searchRangeDiff matches the query against the aggregated diff of the
+// revision range rev, such as v1.2..v1.3. For ranges of the form a...b, the
+// diff starts at the merge base of a and b. It returns nil if the diff does
+// not match, and an error if the query has predicates on commit metadata.
+func (j *CommitSearch) searchRangeDiff(ctx context.Context, repo types.MinimalRepo, rev search.RevisionSpecifier, rr search.RevisionRange) (*result.RangeDiffMatch, error) {
+	if err := gitsearch.ValidateRangeDiffQuery(j.Query); err != nil {
+		return nil, &query.UnsupportedError{Msg: err.Error()}
+	}
+
+	base, err := git.ResolveRevision(ctx, repo.Name, rr.Base, git.ResolveRevisionOptions{NoEnsureRevision: true})
+	if err != nil {
+		return nil, err
+	}
+	head, err := git.ResolveRevision(ctx, repo.Name, rr.Head, git.ResolveRevisionOptions{NoEnsureRevision: true})
+	if err != nil {
+		return nil, err
+	}
+	if rr.MergeBase {
+		base, err = git.MergeBase(ctx, repo.Name, base, head)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rawDiff, err := git.RangeDiff(ctx, repo.Name, base, head)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, ok, err := gitsearch.MatchRangeDiff(j.Query, rawDiff)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	body := "```diff\n" + diff.Content + "\n```"
+	return &result.RangeDiffMatch{
+		Repo:  repo,
+		Range: rev.RevSpec,
+		Base:  base,
+		Head:  head,
+		DiffPreview: result.HighlightedString{
+			Value:      diff.Content,
+			Highlights: searchRangesToHighlights(diff.Content, diff.MatchedRanges),
+		},
+		Body: result.HighlightedString{
+			Value:      body,
+			Highlights: searchRangesToHighlights(body, diff.MatchedRanges.Add(result.Location{Line: 1, Offset: len("```diff\n")})),
+		},
+	}, nil
+}
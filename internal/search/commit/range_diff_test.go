@@ -0,0 +1,73 @@
+package commit
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestSearchRangeDiff(t *testing.T) {
+	git.Mocks.ResolveRevision = func(spec string, _ git.ResolveRevisionOptions) (api.CommitID, error) {
+		return api.CommitID(spec + "-sha"), nil
+	}
+	git.Mocks.MergeBase = func(_ api.RepoName, a, b api.CommitID) (api.CommitID, error) {
+		return "mergebase-sha", nil
+	}
+	var gotArgs []string
+	git.Mocks.ExecReader = func(args []string) (io.ReadCloser, error) {
+		gotArgs = args
+		return io.NopCloser(strings.NewReader(`diff --git main.go main.go
+index 3333333..4444444 100644
+--- main.go
++++ main.go
+@@ -1,3 +1,3 @@
+ package main
+-func old() {}
++func renamed() {}
+`)), nil
+	}
+	t.Cleanup(git.ResetMocks)
+
+	repo := types.MinimalRepo{ID: 1, Name: "foo"}
+
+	t.Run("two dots", func(t *testing.T) {
+		j := &CommitSearch{Query: &protocol.DiffMatches{Expr: "renamed"}, Diff: true}
+		rev := search.RevisionSpecifier{RevSpec: "v1.2..v1.3"}
+		rr, _ := rev.Range()
+		match, err := j.searchRangeDiff(context.Background(), repo, rev, rr)
+		require.NoError(t, err)
+		require.NotNil(t, match)
+		require.Equal(t, []string{"diff", "--no-prefix", "v1.2-sha", "v1.3-sha", "--"}, gotArgs)
+		require.Equal(t, "v1.2..v1.3", match.Range)
+		require.Equal(t, "```diff\nmain.go main.go\n@@ -2,1 +2,1 @@ \n-func old() {}\n+func renamed() {}\n\n```", match.Body.Value)
+		require.Equal(t, 1, match.ResultCount())
+	})
+
+	t.Run("three dots diff against merge base", func(t *testing.T) {
+		j := &CommitSearch{Query: &protocol.DiffMatches{Expr: "renamed"}, Diff: true}
+		rev := search.RevisionSpecifier{RevSpec: "main...feature"}
+		rr, _ := rev.Range()
+		match, err := j.searchRangeDiff(context.Background(), repo, rev, rr)
+		require.NoError(t, err)
+		require.Equal(t, []string{"diff", "--no-prefix", "mergebase-sha", "feature-sha", "--"}, gotArgs)
+		require.Equal(t, api.CommitID("mergebase-sha"), match.Base)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		j := &CommitSearch{Query: &protocol.DiffMatches{Expr: "missing"}, Diff: true}
+		rev := search.RevisionSpecifier{RevSpec: "v1.2..v1.3"}
+		rr, _ := rev.Range()
+		match, err := j.searchRangeDiff(context.Background(), repo, rev, rr)
+		require.NoError(t, err)
+		require.Nil(t, match)
+	})
+}
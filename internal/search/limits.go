@@ -11,6 +11,7 @@ import (
 const (
 	DefaultMaxSearchResults          = 30
 	DefaultMaxSearchResultsStreaming = 500
+	DefaultMaxExportResults          = 100000
 
 	// The default timeout to use for queries.
 	DefaultTimeout = 20 * time.Second
@@ -39,6 +40,7 @@ func SearchLimits(c *conf.Unified) schema.SearchLimits {
 	withDefault(&limits.CommitDiffMaxRepos, 50)
 	withDefault(&limits.CommitDiffWithTimeFilterMaxRepos, 10000)
 	withDefault(&limits.MaxTimeoutSeconds, 60)
+	withDefault(&limits.MaxExportResults, DefaultMaxExportResults)
 
 	return limits
 }
@@ -14,6 +14,9 @@ const (
 	FieldType               = "type"
 	FieldRepoHasFile        = "repohasfile"
 	FieldRepoHasCommitAfter = "repohascommitafter"
+	FieldRepoHasTopic       = "repohastopic"
+	FieldRepoHasLanguage    = "repohaslanguage"
+	FieldFileHasOwner       = "filehasowner"
 	FieldPatternType        = "patterntype"
 	FieldContent            = "content"
 	FieldVisibility         = "visibility"
@@ -54,6 +57,9 @@ var allFields = map[string]struct{}{
 	FieldVisibility:         empty,
 	FieldRepoHasFile:        empty,
 	FieldRepoHasCommitAfter: empty,
+	FieldRepoHasTopic:       empty,
+	FieldRepoHasLanguage:    empty,
+	FieldFileHasOwner:       empty,
 	FieldBefore:             empty,
 	"until":                 empty,
 	FieldAfter:              empty,
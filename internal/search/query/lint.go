@@ -0,0 +1,273 @@
+package query
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+// Severity is the severity of a Diagnostic.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Diagnostic describes a problem in a query string. Range is the range of
+// the query string that the problem applies to. Columns are byte offsets, and
+// the end column is exclusive.
+type Diagnostic struct {
+	Severity    Severity
+	Message     string
+	Range       Range
+	Suggestions []Suggestion
+}
+
+// Suggestion is a rewrite of a query string that addresses a Diagnostic. It
+// replaces the text within Range with Replacement. Query is the query string
+// after the replacement.
+type Suggestion struct {
+	Description string
+	Range       Range
+	Replacement string
+	Query       string
+}
+
+func newSuggestion(in, description string, r Range, replacement string) Suggestion {
+	return Suggestion{
+		Description: description,
+		Range:       r,
+		Replacement: replacement,
+		Query:       in[:r.Start.Column] + replacement + in[r.End.Column:],
+	}
+}
+
+// Lint returns diagnostics for the query string in, parsed for searchType.
+// Unlike Pipeline, which stops at the first error, Lint reports problems that
+// do not make a query invalid but likely do not express what the user
+// intended, along with suggested rewrites.
+func Lint(in string, searchType SearchType) []Diagnostic {
+	nodes, err := Parse(in, searchType)
+	if err != nil {
+		return []Diagnostic{errorDiagnostic(in, err)}
+	}
+
+	var diagnostics []Diagnostic
+	for _, lint := range []func(string, []Node) []Diagnostic{
+		lintRegexpParens,
+		lintRepoURL,
+		lintCaseNo,
+	} {
+		diagnostics = append(diagnostics, lint(in, nodes)...)
+	}
+
+	if _, err := Pipeline(Init(in, searchType)); err != nil {
+		diagnostics = append(diagnostics, errorDiagnostic(in, err))
+	}
+	return diagnostics
+}
+
+// errorDiagnostic converts an error that invalidates the query to a
+// Diagnostic spanning the whole query.
+func errorDiagnostic(in string, err error) Diagnostic {
+	return Diagnostic{
+		Severity: SeverityError,
+		Message:  err.Error(),
+		Range:    newRange(0, len(in)),
+	}
+}
+
+// unescapedParen returns the first parenthesis in a regular expression that
+// is not escaped or part of a character class, or 0 if there is none.
+func unescapedParen(value string) byte {
+	inClass := false
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case c == '\\':
+			i++
+		case c == '[':
+			inClass = true
+		case c == ']':
+			inClass = false
+		case (c == '(' || c == ')') && !inClass:
+			return c
+		}
+	}
+	return 0
+}
+
+// callLikeGroup matches groups that look like function calls, such as
+// "Println(x)", rather than an intended capture group.
+var callLikeGroup = lazyregexp.New(`\w\([^|()]*\)`)
+
+// escapeAllParens escapes the parentheses in value that are not escaped or
+// part of a character class.
+func escapeAllParens(value string) string {
+	var b strings.Builder
+	inClass := false
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case c == '\\':
+			b.WriteByte(c)
+			if i+1 < len(value) {
+				i++
+				b.WriteByte(value[i])
+			}
+			continue
+		case c == '[':
+			inClass = true
+		case c == ']':
+			inClass = false
+		case (c == '(' || c == ')') && !inClass:
+			b.WriteByte('\\')
+		}
+		b.WriteByte(value[i])
+	}
+	return b.String()
+}
+
+// literalSuggestion returns a suggestion that sets patterntype:literal, by
+// replacing an existing patterntype: parameter or appending one.
+func literalSuggestion(in string, nodes []Node) Suggestion {
+	r := newRange(len(in), len(in))
+	replacement := " patterntype:literal"
+	VisitParameter(nodes, func(field, _ string, _ bool, annotation Annotation) {
+		if strings.ToLower(field) == FieldPatternType {
+			r = annotation.Range
+			replacement = "patterntype:literal"
+		}
+	})
+	return newSuggestion(in, "Search for the pattern literally", r, replacement)
+}
+
+// lintRegexpParens reports regular expression patterns containing
+// parentheses that are likely meant to match literally, as in "Println(".
+func lintRegexpParens(in string, nodes []Node) []Diagnostic {
+	var diagnostics []Diagnostic
+	VisitPattern(nodes, func(value string, _ bool, annotation Annotation) {
+		if !annotation.Labels.IsSet(Regexp) || annotation.Labels.IsSet(Quoted) {
+			return
+		}
+		paren := unescapedParen(value)
+		if paren == 0 {
+			return
+		}
+
+		dangling := false
+		if annotation.Labels.IsSet(HeuristicDanglingParens) {
+			// A trailing ( is searched literally, see escapeParens.
+			escaped := escapeParens(value)
+			dangling = escaped != value
+			value = escaped
+		}
+		_, err := regexp.Compile(value)
+		if err == nil && !dangling && !callLikeGroup.MatchString(value) {
+			// Parentheses form a group that is likely intentional.
+			return
+		}
+		severity := SeverityWarning
+		if err != nil {
+			severity = SeverityError
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity: severity,
+			Message:  fmt.Sprintf("regex pattern has unescaped `%c`. Did you mean patterntype:literal?", paren),
+			Range:    annotation.Range,
+			Suggestions: []Suggestion{
+				literalSuggestion(in, nodes),
+				newSuggestion(in, "Escape the parentheses", annotation.Range, escapeAllParens(value)),
+			},
+		})
+	})
+	return diagnostics
+}
+
+var scpLikeURL = lazyregexp.New(`^git@([\w.-]+):(.+)$`)
+
+// repoNameFromURL returns the repository name that a URL or clone URL like
+// https://github.com/sourcegraph/sourcegraph or
+// git@github.com:sourcegraph/sourcegraph.git refers to.
+func repoNameFromURL(value string) (string, bool) {
+	var host, path string
+	if m := scpLikeURL.FindStringSubmatch(value); m != nil {
+		host, path = m[1], m[2]
+	} else if strings.Contains(value, "://") {
+		u, err := url.Parse(value)
+		if err != nil || u.Host == "" {
+			return "", false
+		}
+		host, path = u.Hostname(), u.Path
+	} else if strings.HasSuffix(value, ".git") {
+		return strings.TrimSuffix(value, ".git"), true
+	} else {
+		return "", false
+	}
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if path == "" {
+		return "", false
+	}
+	return host + "/" + path, true
+}
+
+// lintRepoURL reports repo: values that are URLs rather than repository
+// names.
+func lintRepoURL(in string, nodes []Node) []Diagnostic {
+	var diagnostics []Diagnostic
+	VisitParameter(nodes, func(field, value string, negated bool, annotation Annotation) {
+		if resolveFieldAlias(strings.ToLower(field)) != FieldRepo {
+			return
+		}
+		name, ok := repoNameFromURL(value)
+		if !ok {
+			return
+		}
+		replacement := "repo:^" + regexp.QuoteMeta(name) + "$"
+		if negated {
+			replacement = "-" + replacement
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("repo: value %q looks like a URL. Repository names do not include a scheme or .git suffix", value),
+			Range:    annotation.Range,
+			Suggestions: []Suggestion{
+				newSuggestion(in, fmt.Sprintf("Search the repository %s", name), annotation.Range, replacement),
+			},
+		})
+	})
+	return diagnostics
+}
+
+// lintCaseNo reports case:no, which is the default.
+func lintCaseNo(in string, nodes []Node) []Diagnostic {
+	var diagnostics []Diagnostic
+	VisitParameter(nodes, func(field, value string, _ bool, annotation Annotation) {
+		if strings.ToLower(field) != FieldCase || strings.ToLower(value) != "no" {
+			return
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity: SeverityInfo,
+			Message:  "case:no is the default and can be removed",
+			Range:    annotation.Range,
+			Suggestions: []Suggestion{
+				newSuggestion(in, "Remove case:no", withAdjacentSpace(in, annotation.Range), ""),
+			},
+		})
+	})
+	return diagnostics
+}
+
+// withAdjacentSpace extends r to include the whitespace that follows it, or
+// else the whitespace that precedes it, so that removing r leaves no
+// redundant whitespace.
+func withAdjacentSpace(in string, r Range) Range {
+	start, end := r.Start.Column, r.End.Column
+	if trimmed := strings.TrimLeft(in[end:], " \t"); len(trimmed) < len(in[end:]) {
+		return newRange(start, len(in)-len(trimmed))
+	}
+	return newRange(len(strings.TrimRight(in[:start], " \t")), end)
+}
@@ -0,0 +1,75 @@
+package query
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hexops/autogold"
+)
+
+func TestLint(t *testing.T) {
+	test := func(input string, searchType SearchType) []string {
+		var result []string
+		for _, d := range Lint(input, searchType) {
+			result = append(result, fmt.Sprintf("%s [%d,%d]: %s", d.Severity, d.Range.Start.Column, d.Range.End.Column, d.Message))
+			for _, s := range d.Suggestions {
+				result = append(result, fmt.Sprintf("  %s: %s", s.Description, s.Query))
+			}
+		}
+		return result
+	}
+
+	autogold.Want("no diagnostics", []string(nil)).
+		Equal(t, test("repo:foo bar", SearchTypeRegex))
+
+	autogold.Want("dangling paren", []string{
+		"warning [9,21]: regex pattern has unescaped `(`. Did you mean patterntype:literal?",
+		"  Search for the pattern literally: repo:foo fmt.Println( patterntype:literal",
+		`  Escape the parentheses: repo:foo fmt.Println\(`,
+	}).Equal(t, test("repo:foo fmt.Println(", SearchTypeRegex))
+
+	autogold.Want("call-like group replaces patterntype", []string{
+		"warning [0,10]: regex pattern has unescaped `(`. Did you mean patterntype:literal?",
+		"  Search for the pattern literally: Println(x) patterntype:literal",
+		`  Escape the parentheses: Println\(x\) patterntype:regexp`,
+	}).Equal(t, test("Println(x) patterntype:regexp", SearchTypeRegex))
+
+	autogold.Want("intended groups are fine", []string(nil)).
+		Equal(t, test(`(foo|bar)baz [(]x\(`, SearchTypeRegex))
+
+	autogold.Want("parens in literal search are fine", []string(nil)).
+		Equal(t, test("Println(x)", SearchTypeLiteral))
+
+	autogold.Want("invalid regexp", []string{
+		"error [0,4]: regex pattern has unescaped `(`. Did you mean patterntype:literal?",
+		"  Search for the pattern literally: (foo bar patterntype:literal",
+		`  Escape the parentheses: \(foo bar`,
+		"error [0,8]: error parsing regexp: missing closing ): `((foo).*?(bar)`",
+	}).Equal(t, test("(foo bar", SearchTypeRegex))
+
+	autogold.Want("repo URL", []string{
+		`warning [0,45]: repo: value "https://github.com/sourcegraph/sourcegraph/" looks like a URL. Repository names do not include a scheme or .git suffix`,
+		`  Search the repository github.com/sourcegraph/sourcegraph: repo:^github\.com/sourcegraph/sourcegraph$ foo`,
+	}).Equal(t, test("r:https://github.com/sourcegraph/sourcegraph/ foo", SearchTypeLiteral))
+
+	autogold.Want("negated clone URL", []string{
+		`warning [0,42]: repo: value "git@github.com:sourcegraph/about.git" looks like a URL. Repository names do not include a scheme or .git suffix`,
+		`  Search the repository github.com/sourcegraph/about: -repo:^github\.com/sourcegraph/about$ foo`,
+	}).Equal(t, test("-repo:git@github.com:sourcegraph/about.git foo", SearchTypeLiteral))
+
+	autogold.Want("repo revisions are not URLs", []string(nil)).
+		Equal(t, test("repo:github.com/foo/bar@main:release/x foo", SearchTypeLiteral))
+
+	autogold.Want("redundant case:no", []string{
+		"info [4,11]: case:no is the default and can be removed",
+		"  Remove case:no: foo bar",
+	}).Equal(t, test("foo case:no bar", SearchTypeLiteral))
+
+	autogold.Want("validation error", []string{
+		`error [0,14]: invalid boolean "maybe"`,
+	}).Equal(t, test("foo case:maybe", SearchTypeLiteral))
+
+	autogold.Want("parse error", []string{
+		`error [0,9]: unterminated literal: expected "`,
+	}).Equal(t, test(`repo:"foo`, SearchTypeRegex))
+}
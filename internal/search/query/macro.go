@@ -0,0 +1,198 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+// Macros maps the names of query macros to the query fragments they expand
+// to. A macro is referenced in a query as @name.
+type Macros map[string]string
+
+// maxMacroDepth is the maximum nesting of macro references.
+const maxMacroDepth = 16
+
+// MacroExpansion is a query in which macro references are expanded. It maps
+// ranges in the expanded query back to the original query.
+type MacroExpansion struct {
+	// Query is the query with all macro references expanded.
+	Query string
+
+	// references are the macro references in the original query, in order.
+	references []macroReference
+}
+
+type macroReference struct {
+	name     string
+	original Range // The range of @name in the original query.
+	expanded Range // The range of the expansion in the expanded query.
+}
+
+// ExpandMacros replaces each reference to a macro in the query string in,
+// like @name, with the query of the macro. Macros may reference other macros.
+// A reference is only recognized as a separate token outside of quotes, so
+// that values like repo:foo@rev or "@name" are left alone, as are references
+// to undefined macros.
+//
+// Expansions that contain an or-expression are parenthesized to preserve
+// their meaning. ExpandMacros returns an error if macro references form a
+// cycle.
+func ExpandMacros(in string, macros Macros) (*MacroExpansion, error) {
+	if len(macros) == 0 {
+		return &MacroExpansion{Query: in}, nil
+	}
+	expanded, references, err := expandMacros(in, macros, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &MacroExpansion{Query: expanded, references: references}, nil
+}
+
+var orOperator = lazyregexp.New(`(?i)(?:^|[\s)])or(?:[\s(]|$)`)
+
+// expandMacros expands the macro references in in. stack holds the names of
+// the macros being expanded. References are only returned for the top level.
+func expandMacros(in string, macros Macros, stack []string) (string, []macroReference, error) {
+	var b strings.Builder
+	var references []macroReference
+	var quote byte
+	for i := 0; i < len(in); i++ {
+		c := in[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && i+1 < len(in) {
+				b.WriteByte(c)
+				i++
+				c = in[i]
+			} else if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && (i == 0 || strings.IndexByte(" \t\n(:", in[i-1]) >= 0):
+			quote = c
+		case c == '@' && isMacroStart(in, i):
+			j := i + 1
+			for j < len(in) && isMacroNameByte(in[j]) {
+				j++
+			}
+			name := in[i+1 : j]
+			body, ok := macros[name]
+			if !ok || name == "" || (j < len(in) && !isMacroEnd(in[j])) {
+				break
+			}
+
+			for k, seen := range stack {
+				if seen == name {
+					cycle := append(stack[k:], name)
+					return "", nil, errors.Errorf("macro @%s references itself: @%s", name, strings.Join(cycle, " -> @"))
+				}
+			}
+			if len(stack) >= maxMacroDepth {
+				return "", nil, errors.Errorf("macro @%s exceeds the maximum nesting of %d macros", name, maxMacroDepth)
+			}
+
+			expanded, _, err := expandMacros(body, macros, append(stack, name))
+			if err != nil {
+				return "", nil, err
+			}
+			if orOperator.MatchString(expanded) {
+				expanded = "(" + expanded + ")"
+			}
+
+			start := b.Len()
+			b.WriteString(expanded)
+			references = append(references, macroReference{
+				name:     name,
+				original: newRange(i, j),
+				expanded: newRange(start, b.Len()),
+			})
+			i = j - 1
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String(), references, nil
+}
+
+// isMacroStart reports whether the @ at position i starts a token. It may
+// follow opening parentheses that group expressions, but not one that is part
+// of a value like has.owner(@alice).
+func isMacroStart(in string, i int) bool {
+	for i > 0 && in[i-1] == '(' {
+		i--
+	}
+	return i == 0 || isSpace([]byte{in[i-1]})
+}
+
+func isMacroEnd(c byte) bool {
+	return isSpace([]byte{c}) || c == ')'
+}
+
+func isMacroNameByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-' || c == '.'
+}
+
+// IsValidMacroName reports whether name can be referenced as @name.
+func IsValidMacroName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if !isMacroNameByte(name[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Names returns the names of the macros referenced in the original query, in
+// order.
+func (e *MacroExpansion) Names() []string {
+	names := make([]string, 0, len(e.references))
+	for _, r := range e.references {
+		names = append(names, r.name)
+	}
+	return names
+}
+
+// OriginalRange maps a range in the expanded query to the original query.
+// Ranges within an expansion map to the range of its macro reference.
+func (e *MacroExpansion) OriginalRange(r Range) Range {
+	return newRange(e.originalColumn(r.Start.Column, false), e.originalColumn(r.End.Column, true))
+}
+
+func (e *MacroExpansion) originalColumn(column int, isEnd bool) int {
+	delta := 0
+	for _, r := range e.references {
+		start, end := r.expanded.Start.Column, r.expanded.End.Column
+		if column < start || (isEnd && column == start) {
+			break
+		}
+		if column < end || (isEnd && column == end) {
+			if isEnd {
+				return r.original.End.Column
+			}
+			return r.original.Start.Column
+		}
+		delta = r.original.End.Column - end
+	}
+	return column + delta
+}
+
+// MapRanges maps the ranges of patterns and parameters in nodes, which are
+// parsed from the expanded query, to the original query.
+func (e *MacroExpansion) MapRanges(nodes []Node) []Node {
+	if len(e.references) == 0 {
+		return nodes
+	}
+	nodes = MapParameter(nodes, func(field, value string, negated bool, annotation Annotation) Node {
+		annotation.Range = e.OriginalRange(annotation.Range)
+		return Parameter{Field: field, Value: value, Negated: negated, Annotation: annotation}
+	})
+	return MapPattern(nodes, func(value string, negated bool, annotation Annotation) Node {
+		annotation.Range = e.OriginalRange(annotation.Range)
+		return Pattern{Value: value, Negated: negated, Annotation: annotation}
+	})
+}
@@ -0,0 +1,72 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/hexops/autogold"
+)
+
+func TestExpandMacros(t *testing.T) {
+	macros := Macros{
+		"backend":  `repo:^github\.com/acme/(svc-a|svc-b)$ -file:vendor/`,
+		"services": "@backend or repo:frontend",
+		"go":       "lang:go",
+		"a":        "@b",
+		"b":        "@a",
+	}
+
+	test := func(input string) string {
+		e, err := ExpandMacros(input, macros)
+		if err != nil {
+			return err.Error()
+		}
+		return e.Query
+	}
+
+	autogold.Want("expand macro",
+		`repo:^github\.com/acme/(svc-a|svc-b)$ -file:vendor/ foo`).
+		Equal(t, test("@backend foo"))
+
+	autogold.Want("expand nested macros and parenthesize or-expressions",
+		`(repo:^github\.com/acme/(svc-a|svc-b)$ -file:vendor/ or repo:frontend) lang:go foo`).
+		Equal(t, test("@services @go foo"))
+
+	autogold.Want("expand macro in group",
+		"(lang:go or bar) foo").
+		Equal(t, test("(@go or bar) foo"))
+
+	autogold.Want("leave other @ alone",
+		`repo:foo@go file:has.owner(@go) "@go" foo@go @goo @undefined @go.`).
+		Equal(t, test(`repo:foo@go file:has.owner(@go) "@go" foo@go @goo @undefined @go.`))
+
+	autogold.Want("cycle",
+		"macro @a references itself: @a -> @b -> @a").
+		Equal(t, test("@a foo"))
+}
+
+func TestMacroExpansionRanges(t *testing.T) {
+	e, err := ExpandMacros("@go foo @go", Macros{"go": "lang:go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes, err := Parse(e.Query, SearchTypeLiteral)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ranges []string
+	for _, node := range e.MapRanges(nodes) {
+		VisitParameter([]Node{node}, func(_, _ string, _ bool, annotation Annotation) {
+			ranges = append(ranges, annotation.Range.String())
+		})
+		VisitPattern([]Node{node}, func(_ string, _ bool, annotation Annotation) {
+			ranges = append(ranges, annotation.Range.String())
+		})
+	}
+
+	autogold.Want("ranges map to the original query", []string{
+		`{"start":{"line":0,"column":0},"end":{"line":0,"column":3}}`,
+		`{"start":{"line":0,"column":8},"end":{"line":0,"column":11}}`,
+		`{"start":{"line":0,"column":4},"end":{"line":0,"column":7}}`,
+	}).Equal(t, ranges)
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/errors"
+	"github.com/go-enry/go-enry/v2"
 )
 
 type Predicate interface {
@@ -31,10 +32,16 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"contains.file":         func() Predicate { return &RepoContainsFilePredicate{} },
 		"contains.content":      func() Predicate { return &RepoContainsContentPredicate{} },
 		"contains.commit.after": func() Predicate { return &RepoContainsCommitAfterPredicate{} },
+		"contains.symbol":       func() Predicate { return &RepoContainsSymbolPredicate{} },
+		"has.topic":             func() Predicate { return &RepoHasTopicPredicate{} },
+		"has.language":          func() Predicate { return &RepoHasLanguagePredicate{} },
+		"has.file":              func() Predicate { return &RepoHasFilePredicate{} },
 	},
 	FieldFile: {
 		"contains.content": func() Predicate { return &FileContainsContentPredicate{} },
 		"contains":         func() Predicate { return &FileContainsContentPredicate{} },
+		"contains.symbol":  func() Predicate { return &FileContainsSymbolPredicate{} },
+		"has.owner":        func() Predicate { return &FileHasOwnerPredicate{} },
 	},
 }
 
@@ -296,6 +303,261 @@ func (f *FileContainsContentPredicate) Plan(parent Basic) (Plan, error) {
 	return ToPlan(Dnf(nodes))
 }
 
+/* repo:has.topic(topic) */
+
+// RepoHasTopicPredicate represents the `repo:has.topic()` predicate, which
+// filters to repos tagged with a topic on their code host.
+type RepoHasTopicPredicate struct {
+	Topic string
+}
+
+func (f *RepoHasTopicPredicate) ParseParams(params string) error {
+	topic := strings.TrimSpace(params)
+	if topic == "" {
+		return errors.New("has.topic argument should not be empty")
+	}
+	if strings.ContainsAny(topic, " \t\n") {
+		return errors.Errorf("has.topic argument %q should not contain whitespace", topic)
+	}
+	f.Topic = strings.ToLower(topic)
+	return nil
+}
+
+func (f *RepoHasTopicPredicate) Field() string { return FieldRepo }
+func (f *RepoHasTopicPredicate) Name() string  { return "has.topic" }
+func (f *RepoHasTopicPredicate) Plan(parent Basic) (Plan, error) {
+	return repoMetadataPlan(parent, Parameter{
+		Field: FieldRepoHasTopic,
+		Value: f.Topic,
+	})
+}
+
+/* repo:has.language(language) */
+
+// RepoHasLanguagePredicate represents the `repo:has.language()` predicate,
+// which filters to GitHub repos whose primary language, as reported by
+// GitHub, is Language.
+type RepoHasLanguagePredicate struct {
+	Language string
+}
+
+func (f *RepoHasLanguagePredicate) ParseParams(params string) error {
+	language, ok := enry.GetLanguageByAlias(strings.TrimSpace(params))
+	if !ok {
+		return errors.Errorf("has.language argument: unknown language %q", params)
+	}
+	f.Language = language
+	return nil
+}
+
+func (f *RepoHasLanguagePredicate) Field() string { return FieldRepo }
+func (f *RepoHasLanguagePredicate) Name() string  { return "has.language" }
+func (f *RepoHasLanguagePredicate) Plan(parent Basic) (Plan, error) {
+	return repoMetadataPlan(parent, Parameter{
+		Field: FieldRepoHasLanguage,
+		Value: f.Language,
+	})
+}
+
+// repoMetadataPlan returns a plan that resolves the repos satisfying a repo
+// metadata parameter, scoped to the non-predicate repos of parent.
+func repoMetadataPlan(parent Basic, metadata Parameter) (Plan, error) {
+	nodes := make([]Node, 0, 3)
+	nodes = append(nodes, Parameter{
+		Field: FieldSelect,
+		Value: "repo",
+	}, Parameter{
+		Field: FieldCount,
+		Value: "99999",
+	}, metadata)
+
+	nodes = append(nodes, nonPredicateRepos(parent)...)
+	return ToPlan(Dnf(nodes))
+}
+
+/* repo:has.file(path) */
+
+// RepoHasFilePredicate represents the `repo:has.file()` predicate, which
+// filters to repos that contain a file at exactly Path, relative to the
+// repository root (e.g., `go.mod` or `cmd/main.go`).
+type RepoHasFilePredicate struct {
+	Path string
+}
+
+func (f *RepoHasFilePredicate) ParseParams(params string) error {
+	path := strings.Trim(strings.TrimSpace(params), "/")
+	if path == "" {
+		return errors.New("has.file argument should not be empty")
+	}
+	f.Path = path
+	return nil
+}
+
+func (f *RepoHasFilePredicate) Field() string { return FieldRepo }
+func (f *RepoHasFilePredicate) Name() string  { return "has.file" }
+func (f *RepoHasFilePredicate) Plan(parent Basic) (Plan, error) {
+	contains := RepoContainsPredicate{File: "^" + regexp.QuoteMeta(f.Path) + "$", Content: ""}
+	return contains.Plan(parent)
+}
+
+/* file:has.owner(owner) */
+
+// FileHasOwnerPredicate represents the `file:has.owner()` predicate, which
+// filters to files owned by Owner according to the CODEOWNERS file of their
+// repository. Owner is a user or team handle (e.g., `@alice` or
+// `@org/team`) or an email address. See SubstituteFileHasOwner for how it is
+// evaluated.
+type FileHasOwnerPredicate struct {
+	Owner string
+}
+
+func (f *FileHasOwnerPredicate) ParseParams(params string) error {
+	owner := strings.TrimSpace(params)
+	if owner == "" || owner == "@" {
+		return errors.New("has.owner argument should not be empty")
+	}
+	if strings.ContainsAny(owner, " \t\n") {
+		return errors.Errorf("has.owner argument %q should not contain whitespace", owner)
+	}
+	if !strings.Contains(owner, "@") {
+		owner = "@" + owner
+	}
+	f.Owner = owner
+	return nil
+}
+
+func (f *FileHasOwnerPredicate) Field() string { return FieldFile }
+func (f *FileHasOwnerPredicate) Name() string  { return "has.owner" }
+
+// Plan always fails: has.owner is never evaluated as a subquery. It is
+// replaced by a filter on the file matches of its parent query by
+// SubstituteFileHasOwner.
+func (f *FileHasOwnerPredicate) Plan(parent Basic) (Plan, error) {
+	return nil, errors.New("has.owner is applied as a filter on file matches and has no plan")
+}
+
+/* file:contains.symbol(pattern kind:kind) and repo:contains.symbol(pattern kind:kind) */
+
+// SymbolPredicate holds the parsed arguments shared by symbol predicates. It
+// matches symbols whose name matches Pattern, optionally restricted to symbols
+// of the given Kind (e.g., `interface`, `function`).
+type SymbolPredicate struct {
+	Pattern string
+	Kind    string
+}
+
+func (f *SymbolPredicate) ParseParams(params string) error {
+	nodes, err := Parse(params, SearchTypeRegex)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		if err := f.parseNode(node); err != nil {
+			return err
+		}
+	}
+
+	if f.Pattern == "" {
+		return errors.New("contains.symbol argument should not be empty")
+	}
+
+	return nil
+}
+
+func (f *SymbolPredicate) parseNode(n Node) error {
+	switch v := n.(type) {
+	case Parameter:
+		return errors.Errorf("unsupported option %q", v.Field)
+	case Pattern:
+		if v.Negated {
+			return errors.New("predicates do not currently support negated values")
+		}
+		// `kind` is not a query field, so it is scanned as a pattern.
+		if kind := strings.TrimPrefix(v.Value, "kind:"); kind != v.Value {
+			if f.Kind != "" {
+				return errors.New("cannot specify kind multiple times")
+			}
+			if err := validateSymbolKind(kind); err != nil {
+				return err
+			}
+			f.Kind = strings.ToLower(kind)
+			return nil
+		}
+		if f.Pattern != "" {
+			return errors.New("cannot specify more than one symbol pattern")
+		}
+		if _, err := regexp.Compile(v.Value); err != nil {
+			return errors.Errorf("contains.symbol argument: %w", err)
+		}
+		f.Pattern = v.Value
+	case Operator:
+		if v.Kind == Or {
+			return errors.New("predicates do not currently support 'or' queries")
+		}
+		for _, operand := range v.Operands {
+			if err := f.parseNode(operand); err != nil {
+				return err
+			}
+		}
+	default:
+		return errors.Errorf("unsupported node type %T", n)
+	}
+	return nil
+}
+
+// plan returns a symbol search for the predicate, scoped to the non-predicate
+// repos of parent. The results are file matches containing the matching
+// symbols, which callers convert to file or repo filters.
+func (f *SymbolPredicate) plan(parent Basic) (Plan, error) {
+	selectValue := "symbol"
+	if f.Kind != "" {
+		selectValue = "symbol." + f.Kind
+	}
+
+	nodes := make([]Node, 0, 4)
+	nodes = append(nodes, Parameter{
+		Field: FieldCount,
+		Value: "99999",
+	}, Parameter{
+		Field: FieldType,
+		Value: "symbol",
+	}, Parameter{
+		Field: FieldSelect,
+		Value: selectValue,
+	}, Pattern{
+		Value:      f.Pattern,
+		Annotation: Annotation{Labels: Regexp},
+	})
+
+	nodes = append(nodes, nonPredicateRepos(parent)...)
+	return ToPlan(Dnf(nodes))
+}
+
+// RepoContainsSymbolPredicate represents the `repo:contains.symbol()`
+// predicate, which filters to repos that define a matching symbol.
+type RepoContainsSymbolPredicate struct {
+	SymbolPredicate
+}
+
+func (f *RepoContainsSymbolPredicate) Field() string { return FieldRepo }
+func (f *RepoContainsSymbolPredicate) Name() string  { return "contains.symbol" }
+func (f *RepoContainsSymbolPredicate) Plan(parent Basic) (Plan, error) {
+	return f.plan(parent)
+}
+
+// FileContainsSymbolPredicate represents the `file:contains.symbol()`
+// predicate, which filters to files that define a matching symbol.
+type FileContainsSymbolPredicate struct {
+	SymbolPredicate
+}
+
+func (f *FileContainsSymbolPredicate) Field() string { return FieldFile }
+func (f *FileContainsSymbolPredicate) Name() string  { return "contains.symbol" }
+func (f *FileContainsSymbolPredicate) Plan(parent Basic) (Plan, error) {
+	return f.plan(parent)
+}
+
 // nonPredicateRepos returns the repo nodes in a query that aren't predicates,
 // respecting parameters that determine repo results.
 func nonPredicateRepos(q Basic) []Node {
@@ -80,3 +80,197 @@ func TestParseAsPredicate(t *testing.T) {
 	}
 
 }
+
+func TestSymbolPredicate(t *testing.T) {
+	t.Run("ParseParams", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			expected *SymbolPredicate
+		}
+
+		valid := []test{
+			{`pattern`, `Foo`, &SymbolPredicate{Pattern: "Foo"}},
+			{`regex pattern`, `^New[A-Z]\w+$`, &SymbolPredicate{Pattern: `^New[A-Z]\w+$`}},
+			{`pattern and kind`, `Foo kind:interface`, &SymbolPredicate{Pattern: "Foo", Kind: "interface"}},
+			{`kind and pattern`, `kind:Function Foo`, &SymbolPredicate{Pattern: "Foo", Kind: "function"}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &SymbolPredicate{}
+				err := p.ParseParams(tc.params)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`empty`, ``, nil},
+			{`only kind`, `kind:class`, nil},
+			{`unknown kind`, `Foo kind:banana`, nil},
+			{`multiple kinds`, `Foo kind:class kind:struct`, nil},
+			{`multiple patterns`, `Foo Bar`, nil},
+			{`negated pattern`, `-content:Foo`, nil},
+			{`unsupported field`, `Foo file:bar`, nil},
+			{`invalid regexp`, `Foo(`, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &SymbolPredicate{}
+				err := p.ParseParams(tc.params)
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+
+	t.Run("Plan", func(t *testing.T) {
+		parent, err := ParseLiteral(`repo:^github\.com/sourcegraph/ file:contains.symbol(Foo kind:interface) bar`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		basic, err := ToBasicQuery(parent)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		p := &FileContainsSymbolPredicate{}
+		if err := p.ParseParams(`Foo kind:interface`); err != nil {
+			t.Fatal(err)
+		}
+		plan, err := p.Plan(basic)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := plan.ToParseTree().String()
+		want := `(and "count:99999" "type:symbol" "select:symbol.interface" "repo:^github\\.com/sourcegraph/" "Foo")`
+		if got != want {
+			t.Fatalf("expected %s, got %s", want, got)
+		}
+	})
+}
+
+func TestRepoMetadataPredicates(t *testing.T) {
+	t.Run("ParseParams", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			predicate Predicate
+			params    string
+			expected  Predicate
+		}{
+			{`topic`, &RepoHasTopicPredicate{}, `payments`, &RepoHasTopicPredicate{Topic: "payments"}},
+			{`topic is lowercased`, &RepoHasTopicPredicate{}, `Payments`, &RepoHasTopicPredicate{Topic: "payments"}},
+			{`language`, &RepoHasLanguagePredicate{}, `go`, &RepoHasLanguagePredicate{Language: "Go"}},
+			{`language alias`, &RepoHasLanguagePredicate{}, `golang`, &RepoHasLanguagePredicate{Language: "Go"}},
+			{`file`, &RepoHasFilePredicate{}, `go.mod`, &RepoHasFilePredicate{Path: "go.mod"}},
+			{`file with leading slash`, &RepoHasFilePredicate{}, `/cmd/main.go`, &RepoHasFilePredicate{Path: "cmd/main.go"}},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				if err := tc.predicate.ParseParams(tc.params); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if !reflect.DeepEqual(tc.expected, tc.predicate) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, tc.predicate)
+				}
+			})
+		}
+
+		invalid := []struct {
+			name      string
+			predicate Predicate
+			params    string
+		}{
+			{`empty topic`, &RepoHasTopicPredicate{}, ``},
+			{`topic with space`, &RepoHasTopicPredicate{}, `a b`},
+			{`unknown language`, &RepoHasLanguagePredicate{}, `notalanguage`},
+			{`empty file`, &RepoHasFilePredicate{}, ``},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				if err := tc.predicate.ParseParams(tc.params); err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+
+	t.Run("Plan", func(t *testing.T) {
+		tests := []struct {
+			predicate Predicate
+			params    string
+			want      string
+		}{
+			{&RepoHasTopicPredicate{}, `payments`, `(and "select:repo" "count:99999" "repohastopic:payments" "repo:foo")`},
+			{&RepoHasLanguagePredicate{}, `go`, `(and "select:repo" "count:99999" "repohaslanguage:Go" "repo:foo")`},
+			{&RepoHasFilePredicate{}, `go.mod`, `(and "select:repo" "count:99999" "file:^go\\.mod$" "repo:foo")`},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.want, func(t *testing.T) {
+				if err := tc.predicate.ParseParams(tc.params); err != nil {
+					t.Fatal(err)
+				}
+				plan, err := tc.predicate.Plan(Basic{Parameters: []Parameter{{Field: FieldRepo, Value: "foo"}}})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := plan.ToParseTree().String(); got != tc.want {
+					t.Fatalf("expected %s, got %s", tc.want, got)
+				}
+			})
+		}
+	})
+}
+
+func TestFileHasOwnerPredicate(t *testing.T) {
+	t.Run("ParseParams", func(t *testing.T) {
+		tests := []struct {
+			params   string
+			expected string
+		}{
+			{`@alice`, "@alice"},
+			{`alice`, "@alice"},
+			{` @org/team `, "@org/team"},
+			{`alice@example.com`, "alice@example.com"},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.params, func(t *testing.T) {
+				p := &FileHasOwnerPredicate{}
+				if err := p.ParseParams(tc.params); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if p.Owner != tc.expected {
+					t.Fatalf("expected %q, got %q", tc.expected, p.Owner)
+				}
+			})
+		}
+
+		for _, params := range []string{``, `@`, `alice bob`} {
+			t.Run(params, func(t *testing.T) {
+				if err := (&FileHasOwnerPredicate{}).ParseParams(params); err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+
+	t.Run("Plan", func(t *testing.T) {
+		p := &FileHasOwnerPredicate{Owner: "@alice"}
+		if _, err := p.Plan(Basic{Parameters: []Parameter{{Field: FieldRepo, Value: "foo"}}}); err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+}
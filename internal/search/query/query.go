@@ -50,7 +50,7 @@ func For(searchType SearchType) step {
 	case SearchTypeStructural:
 		processType = succeeds(labelStructural, ellipsesForHoles, substituteConcat(space))
 	}
-	normalize := succeeds(LowercaseFieldNames, SubstituteAliases(searchType), SubstituteCountAll)
+	normalize := succeeds(LowercaseFieldNames, SubstituteAliases(searchType), SubstituteFileHasOwner, SubstituteCountAll)
 	return sequence(normalize, processType)
 }
 
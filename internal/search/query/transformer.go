@@ -41,6 +41,27 @@ func LowercaseFieldNames(nodes []Node) []Node {
 	})
 }
 
+// SubstituteFileHasOwner replaces file:has.owner(owner) predicates with the
+// internal filehasowner:owner parameter. Unlike other predicates, has.owner
+// isn't expanded by running a subquery. It filters the file matches of the
+// query it is part of instead, since enumerating every file of every repo
+// to find the owned ones doesn't scale. Predicates with invalid arguments are
+// left as is so that validation reports them.
+func SubstituteFileHasOwner(nodes []Node) []Node {
+	return MapParameter(nodes, func(field, value string, negated bool, annotation Annotation) Node {
+		if field == FieldFile && annotation.Labels.IsSet(IsPredicate) {
+			name, params := ParseAsPredicate(value)
+			if name == "has.owner" {
+				p := &FileHasOwnerPredicate{}
+				if err := p.ParseParams(params); err == nil {
+					return Parameter{Field: FieldFileHasOwner, Value: p.Owner, Negated: negated}
+				}
+			}
+		}
+		return Parameter{Field: field, Value: value, Negated: negated, Annotation: annotation}
+	})
+}
+
 // SubstituteCountAll replaces count:all with count:99999999.
 func SubstituteCountAll(nodes []Node) []Node {
 	return MapParameter(nodes, func(field, value string, negated bool, annotation Annotation) Node {
@@ -952,6 +952,18 @@ func TestQueryField(t *testing.T) {
 	autogold.Want("omit repo alias", "alias-pattern").Equal(t, test("r:stuff alias-pattern", "repo"))
 }
 
+func TestSubstituteFileHasOwner(t *testing.T) {
+	test := func(input string) string {
+		query, _ := Parse(input, SearchTypeLiteral)
+		q := SubstituteFileHasOwner(query)
+		return toString(q)
+	}
+
+	autogold.Want("owner", `(and "repo:foo" "filehasowner:@alice" "bar")`).Equal(t, test("repo:foo file:has.owner(alice) bar"))
+	autogold.Want("other predicates", `(and "file:contains.content(baz)" "filehasowner:@org/team")`).Equal(t, test("file:contains.content(baz) file:has.owner(@org/team)"))
+	autogold.Want("invalid argument", `(and "file:has.owner()" "bar")`).Equal(t, test("file:has.owner() bar"))
+}
+
 func TestSubstituteCountAll(t *testing.T) {
 	test := func(input string) string {
 		query, _ := Parse(input, SearchTypeLiteral)
@@ -401,6 +401,9 @@ func (q Q) valueToTypedValue(field, value string, label labels) []*Value {
 
 	case
 		FieldRepoHasCommitAfter,
+		FieldRepoHasTopic,
+		FieldRepoHasLanguage,
+		FieldFileHasOwner,
 		FieldBefore, "until",
 		FieldAfter, "since":
 		return []*Value{{String: &value}}
@@ -322,6 +322,15 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 	case
 		FieldRepoHasCommitAfter:
 		return satisfies(isSingular, isNotNegated)
+	case
+		FieldRepoHasTopic:
+		return satisfies(isNotNegated)
+	case
+		FieldRepoHasLanguage:
+		return satisfies(isNotNegated, isLanguage)
+	case
+		FieldFileHasOwner:
+		return satisfies(isSingular, isNotNegated)
 	case
 		FieldBefore,
 		FieldAfter:
@@ -411,6 +420,51 @@ func validateCommitParameters(nodes []Node) error {
 	return nil
 }
 
+// Revision ranges, such as rev:v1.2..v1.3, only have a meaning for commit and
+// diff searches. Diff searches match the diff across the whole range, which
+// has no author, committer, date or message, so commit parameters can't be
+// used with them.
+func validateRevRanges(nodes []Node) error {
+	var seenRange, seenCommitParam string
+	var typeCommitExists, typeDiffExists bool
+	isRange := func(revs string) {
+		for _, rev := range strings.Split(revs, ":") {
+			if strings.Contains(rev, "..") {
+				seenRange = rev
+			}
+		}
+	}
+	VisitParameter(nodes, func(field, value string, negated bool, _ Annotation) {
+		switch field {
+		case FieldRev:
+			isRange(value)
+		case FieldRepo:
+			if i := strings.Index(value, "@"); i != -1 && !negated {
+				isRange(value[i+1:])
+			}
+		case FieldType:
+			if value == "commit" || value == "diff" {
+				typeCommitExists = true
+			}
+			if value == "diff" {
+				typeDiffExists = true
+			}
+		case FieldAuthor, FieldCommitter, FieldBefore, FieldAfter, FieldMessage:
+			seenCommitParam = field
+		}
+	})
+	if seenRange == "" {
+		return nil
+	}
+	if !typeCommitExists {
+		return errors.Errorf("the revision range '%s' requires type:commit or type:diff in the query", seenRange)
+	}
+	if typeDiffExists && seenCommitParam != "" {
+		return errors.Errorf("your query contains the field '%s', which can't be used with the revision range '%s' in type:diff searches", seenCommitParam, seenRange)
+	}
+	return nil
+}
+
 func validateTypeStructural(nodes []Node) error {
 	seenStructural := false
 	seenType := false
@@ -462,6 +516,15 @@ func validatePredicate(field, value string, negated bool) error {
 	return nil
 }
 
+// validateSymbolKind validates that kind is a symbol kind that may be
+// selected, as in `select:symbol.<kind>`.
+func validateSymbolKind(kind string) error {
+	if _, err := filter.SelectPathFromString(filter.Symbol + "." + strings.ToLower(kind)); err != nil {
+		return errors.Errorf("invalid symbol kind %q", kind)
+	}
+	return nil
+}
+
 // validateRepoHasFile validates that the repohasfile parameter can be executed.
 // A query like `repohasfile:foo type:symbol patter-to-match-symbols` is
 // currently not supported.
@@ -554,6 +617,7 @@ func validate(nodes []Node) error {
 		validateRepoRevPair,
 		validateRepoHasFile,
 		validateCommitParameters,
+		validateRevRanges,
 		validateTypeStructural,
 		validateRefGlobs,
 	)
@@ -83,6 +83,18 @@ func TestValidation(t *testing.T) {
 			input: "repo:foo author:rob@saucegraph.com",
 			want:  `your query contains the field 'author', which requires type:commit or type:diff in the query`,
 		},
+		{
+			input: "repo:foo rev:v1.2..v1.3 bar",
+			want:  `the revision range 'v1.2..v1.3' requires type:commit or type:diff in the query`,
+		},
+		{
+			input: "repo:foo@main...feature bar",
+			want:  `the revision range 'main...feature' requires type:commit or type:diff in the query`,
+		},
+		{
+			input: "repo:foo rev:v1.2..v1.3 type:diff author:alice bar",
+			want:  `your query contains the field 'author', which can't be used with the revision range 'v1.2..v1.3' in type:diff searches`,
+		},
 		{
 			input: "repohasfile:README type:symbol yolo",
 			want:  "repohasfile is not compatible for type:symbol. Subscribe to https://github.com/sourcegraph/sourcegraph/issues/4610 for updates",
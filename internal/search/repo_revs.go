@@ -52,6 +52,51 @@ func (r1 RevisionSpecifier) Less(r2 RevisionSpecifier) bool {
 	return r1.ExcludeRefGlob < r2.ExcludeRefGlob
 }
 
+// RevisionRange is a revision range of the form base..head or base...head. See
+// "Specifying Ranges" in the manpage gitrevisions(7).
+type RevisionRange struct {
+	Base string
+	Head string
+
+	// MergeBase is true for ranges of the form base...head. When diffing, such
+	// a range describes the changes on head since it diverged from base.
+	MergeBase bool
+}
+
+// Range returns the revision range described by the revspec of r1, if any.
+// An omitted endpoint (as in "v1.2..") defaults to HEAD, matching git.
+func (r1 RevisionSpecifier) Range() (RevisionRange, bool) {
+	return ParseRevisionRange(r1.RevSpec)
+}
+
+// ParseRevisionRange parses spec as a revision range of the form base..head
+// or base...head.
+func ParseRevisionRange(spec string) (RevisionRange, bool) {
+	i := strings.Index(spec, "..")
+	if i == -1 || strings.HasPrefix(spec, "^") {
+		return RevisionRange{}, false
+	}
+
+	rr := RevisionRange{Base: spec[:i]}
+	rest := spec[i+len(".."):]
+	if strings.HasPrefix(rest, ".") {
+		rr.MergeBase = true
+		rest = rest[1:]
+	}
+	rr.Head = rest
+
+	if strings.Contains(rr.Head, "..") || (rr.Base == "" && rr.Head == "") {
+		return RevisionRange{}, false
+	}
+	if rr.Base == "" {
+		rr.Base = "HEAD"
+	}
+	if rr.Head == "" {
+		rr.Head = "HEAD"
+	}
+	return rr, true
+}
+
 // RepositoryRevisions specifies a repository and 0 or more revspecs and ref
 // globs.  If no revspecs and no ref globs are specified, then the
 // repository's default branch is used.
@@ -43,3 +43,32 @@ func TestParseRepositoryRevisions(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRevisionRange(t *testing.T) {
+	tests := []struct {
+		spec string
+		want RevisionRange
+		ok   bool
+	}{
+		{spec: "v1.2..v1.3", want: RevisionRange{Base: "v1.2", Head: "v1.3"}, ok: true},
+		{spec: "main...feature", want: RevisionRange{Base: "main", Head: "feature", MergeBase: true}, ok: true},
+		{spec: "v1.2..", want: RevisionRange{Base: "v1.2", Head: "HEAD"}, ok: true},
+		{spec: "...feature", want: RevisionRange{Base: "HEAD", Head: "feature", MergeBase: true}, ok: true},
+		{spec: "main"},
+		{spec: "^main"},
+		{spec: ".."},
+		{spec: "a..b..c"},
+		{spec: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, ok := ParseRevisionRange(tt.spec)
+			if ok != tt.ok {
+				t.Fatalf("got ok %v, want %v", ok, tt.ok)
+			}
+			if got != tt.want {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
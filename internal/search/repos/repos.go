@@ -149,6 +149,8 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (Resolved
 		OnlyArchived:           op.OnlyArchived,
 		NoPrivate:              op.Visibility == query.Public,
 		OnlyPrivate:            op.Visibility == query.Private,
+		Topics:                 op.HasTopics,
+		Languages:              op.HasLanguages,
 		SearchContextID:        searchContext.ID,
 		UserID:                 searchContext.NamespaceUserID,
 		OrgID:                  searchContext.NamespaceOrgID,
@@ -299,8 +301,21 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (Resolved
 					rev.RevSpec = "HEAD"
 				}
 
-				trimmedRefSpec := strings.TrimPrefix(rev.RevSpec, "^") // handle negated revisions, such as ^<branch>, ^<tag>, or ^<commit>
-				commitID, err := git.ResolveRevision(ctx, repoRev.Repo.Name, trimmedRefSpec, git.ResolveRevisionOptions{NoEnsureRevision: true})
+				var (
+					commitID api.CommitID
+					err      error
+				)
+				if rr, ok := rev.Range(); ok {
+					// Validate both endpoints of revision ranges, such as v1.2..v1.3.
+					// The head of the range is used for the checks below.
+					_, err = git.ResolveRevision(ctx, repoRev.Repo.Name, rr.Base, git.ResolveRevisionOptions{NoEnsureRevision: true})
+					if err == nil {
+						commitID, err = git.ResolveRevision(ctx, repoRev.Repo.Name, rr.Head, git.ResolveRevisionOptions{NoEnsureRevision: true})
+					}
+				} else {
+					trimmedRefSpec := strings.TrimPrefix(rev.RevSpec, "^") // handle negated revisions, such as ^<branch>, ^<tag>, or ^<commit>
+					commitID, err = git.ResolveRevision(ctx, repoRev.Repo.Name, trimmedRefSpec, git.ResolveRevisionOptions{NoEnsureRevision: true})
+				}
 				if err != nil {
 					if errors.Is(err, context.DeadlineExceeded) || errors.HasType(err, gitdomain.BadCommitError{}) {
 						return err
@@ -431,6 +446,8 @@ func (r *Resolver) Excluded(ctx context.Context, op search.RepoOptions) (ex Excl
 		OnlyArchived:           op.OnlyArchived,
 		NoPrivate:              op.Visibility == query.Public,
 		OnlyPrivate:            op.Visibility == query.Private,
+		Topics:                 op.HasTopics,
+		Languages:              op.HasLanguages,
 		SearchContextID:        searchContext.ID,
 		UserID:                 searchContext.NamespaceUserID,
 		OrgID:                  searchContext.NamespaceOrgID,
@@ -714,6 +731,8 @@ func PrivateReposForUser(ctx context.Context, db database.DB, userID int32, repo
 		NoForks:        repoOptions.NoForks,
 		OnlyArchived:   repoOptions.OnlyArchived,
 		NoArchived:     repoOptions.NoArchived,
+		Topics:         repoOptions.HasTopics,
+		Languages:      repoOptions.HasLanguages,
 		ExcludePattern: UnionRegExps(repoOptions.MinusRepoFilters),
 	})
 
@@ -166,10 +166,19 @@ func modifiedLinesExist(lines []string, prefix string) bool {
 // (i.e., no highlight information) coresponding to modified lines, it is
 // removed from the result set (returns nil).
 func selectCommitDiffKind(c *CommitMatch, field string) Match {
-	diff := c.DiffPreview
-	if diff == nil {
+	if c.DiffPreview == nil {
 		return nil // Not a diff result.
 	}
+	if selectDiffKind(&c.Body, c.DiffPreview, field) {
+		return c
+	}
+	return nil
+}
+
+// selectDiffKind reports whether a diff result with the markdown `body` and
+// the preview `diff` contains `added` (resp. `removed`) lines set by `field`.
+// The highlights of body and diff are restricted to the selected lines.
+func selectDiffKind(body, diff *HighlightedString, field string) bool {
 	var prefix string
 	if field == "added" {
 		prefix = "+"
@@ -180,28 +189,25 @@ func selectCommitDiffKind(c *CommitMatch, field string) Match {
 	if len(diff.Highlights) == 0 {
 		// No highlights, implying no pattern was specified. Filter by
 		// whether there exists lines corresponding to additions or
-		// removals. Inspect c.Body, which is the diff markdown in the
+		// removals. Inspect body, which is the diff markdown in the
 		// format ```diff <...>``` and which doesn't contain a unified
 		// diff header with +++ or --- in diff.Value, which would would
 		// otherwise confuse this check.
-		if modifiedLinesExist(strings.Split(c.Body.Value, "\n"), prefix) {
-			return c
-		}
-		return nil
+		return modifiedLinesExist(strings.Split(body.Value, "\n"), prefix)
 	}
 	// We have two data structures storing highlight information for diff
 	// results. We must keep these in sync. Additionally the diff highlights
 	// line number is offset by 1.
-	bodyHighlights := selectModifiedLines(strings.Split(c.Body.Value, "\n"), c.Body.Highlights, prefix, 0)
+	bodyHighlights := selectModifiedLines(strings.Split(body.Value, "\n"), body.Highlights, prefix, 0)
 	diffHighlights := selectModifiedLines(strings.Split(diff.Value, "\n"), diff.Highlights, prefix, 1)
 	if len(bodyHighlights) > 0 {
 		// Only rely on bodyHighlights since the header in diff.Value
 		// will create bogus highlights due to `+++` or `---`.
-		c.Body.Highlights = bodyHighlights
-		c.DiffPreview.Highlights = diffHighlights
-		return c
+		body.Highlights = bodyHighlights
+		diff.Highlights = diffHighlights
+		return true
 	}
-	return nil // No matching lines.
+	return false // No matching lines.
 }
 
 func (r *CommitMatch) searchResultMarker() {}
@@ -74,6 +74,11 @@ func (fm *FileMatch) Select(selectPath filter.SelectPath) Match {
 			ID:   fm.Repo.ID,
 		}
 	case filter.File:
+		if len(selectPath) > 1 && selectPath[1] == "owners" {
+			// Owners are resolved from CODEOWNERS files before select runs,
+			// see OwnerMatch.
+			return nil
+		}
 		fm.LineMatches = nil
 		fm.Symbols = nil
 		if len(selectPath) > 1 && selectPath[1] == "directory" {
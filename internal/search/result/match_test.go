@@ -30,3 +30,37 @@ func TestSelect(t *testing.T) {
 	autogold.Want("filter any symbol", "a():func, b():function, var c:variable").Equal(t, test("symbol"))
 	autogold.Want("filter symbol kind variable", "var c:variable").Equal(t, test("symbol.variable"))
 }
+
+func TestRangeDiffMatchSelect(t *testing.T) {
+	newMatch := func() *RangeDiffMatch {
+		body := "```diff\nfoo.go foo.go\n@@ -1,2 +1,2 @@\n-bar\n+baz\n```"
+		preview := "foo.go foo.go\n@@ -1,2 +1,2 @@\n-bar\n+baz\n"
+		return &RangeDiffMatch{
+			Range:       "v1.2..v1.3",
+			Body:        HighlightedString{Value: body, Highlights: []HighlightedRange{{Line: 3, Character: 1, Length: 2}, {Line: 4, Character: 1, Length: 2}}},
+			DiffPreview: HighlightedString{Value: preview, Highlights: []HighlightedRange{{Line: 2, Character: 1, Length: 2}, {Line: 3, Character: 1, Length: 2}}},
+		}
+	}
+
+	test := func(input string) string {
+		selectPath, _ := filter.SelectPathFromString(input)
+		switch m := newMatch().Select(selectPath).(type) {
+		case *RangeDiffMatch:
+			var lines []string
+			for _, h := range m.Body.Highlights {
+				lines = append(lines, strings.Split(m.Body.Value, "\n")[h.Line])
+			}
+			return strings.Join(lines, ", ")
+		case *RepoMatch:
+			return "repo"
+		default:
+			return "<nil>"
+		}
+	}
+
+	autogold.Want("select repo", "repo").Equal(t, test("repo"))
+	autogold.Want("select commit.diff", "-bar, +baz").Equal(t, test("commit.diff"))
+	autogold.Want("select commit.diff.added", "+baz").Equal(t, test("commit.diff.added"))
+	autogold.Want("select commit.diff.removed", "-bar").Equal(t, test("commit.diff.removed"))
+	autogold.Want("select file", "<nil>").Equal(t, test("file"))
+}
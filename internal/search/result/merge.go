@@ -34,6 +34,8 @@ func Intersect(left, right []Match) []Match {
 			leftMatch.AppendMatches(r.(*FileMatch))
 		case *CommitMatch:
 			leftMatch.AppendMatches(r.(*CommitMatch))
+		case *OwnerMatch:
+			leftMatch.AppendMatches(r.(*OwnerMatch))
 		}
 		merged = append(merged, l)
 	}
@@ -0,0 +1,58 @@
+package result
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// OwnerMatch is the owner of one or more matched files, as declared in a
+// CODEOWNERS file. It is produced by select:file.owners.
+type OwnerMatch struct {
+	// Handle is the owner as written in CODEOWNERS, such as @alice,
+	// @org/team or an email address.
+	Handle string
+
+	// Repo is the repository of the first file attributed to the owner.
+	Repo types.MinimalRepo
+
+	// Count is the number of matched files owned by Handle.
+	Count int
+}
+
+func (r *OwnerMatch) ResultCount() int {
+	return r.Count
+}
+
+func (r *OwnerMatch) RepoName() types.MinimalRepo {
+	return r.Repo
+}
+
+func (r *OwnerMatch) Limit(limit int) int {
+	// Always represents one result and limit > 0 so we just return limit - 1.
+	return limit - 1
+}
+
+func (r *OwnerMatch) Select(path filter.SelectPath) Match {
+	if path.Root() == filter.File && len(path) > 1 && path[1] == "owners" {
+		return r
+	}
+	return nil
+}
+
+// AppendMatches adds the file count of src to the receiver.
+func (r *OwnerMatch) AppendMatches(src *OwnerMatch) {
+	r.Count += src.Count
+}
+
+// Key identifies owners across repositories, so that the files of an owner
+// in all repositories are counted together.
+func (r *OwnerMatch) Key() Key {
+	return Key{
+		TypeRank: rankOwnerMatch,
+		Path:     strings.ToLower(r.Handle),
+	}
+}
+
+func (r *OwnerMatch) searchResultMarker() {}
@@ -0,0 +1,98 @@
+package result
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// RangeDiffMatch is the aggregated diff between the endpoints of a revision
+// range, such as rev:v1.2..v1.3. Diff searches return it alongside the
+// CommitMatch results for the individual commits in the range.
+type RangeDiffMatch struct {
+	Repo types.MinimalRepo
+
+	// Range is the revision range as specified in the query.
+	Range string
+
+	// Base and Head are the resolved endpoints of the diff. For ranges of
+	// the form a...b, Base is the merge base of a and b.
+	Base api.CommitID
+	Head api.CommitID
+
+	DiffPreview HighlightedString
+	Body        HighlightedString
+}
+
+func (r *RangeDiffMatch) ResultCount() int {
+	if n := len(r.Body.Highlights); n > 0 {
+		return n
+	}
+	return 1
+}
+
+func (r *RangeDiffMatch) RepoName() types.MinimalRepo {
+	return r.Repo
+}
+
+func (r *RangeDiffMatch) Limit(limit int) int {
+	if len(r.Body.Highlights) == 0 {
+		return limit - 1 // just counting the diff
+	} else if len(r.Body.Highlights) > limit {
+		r.Body.Highlights = r.Body.Highlights[:limit]
+		return 0
+	}
+	return limit - len(r.Body.Highlights)
+}
+
+func (r *RangeDiffMatch) Select(path filter.SelectPath) Match {
+	switch path.Root() {
+	case filter.Repository:
+		return &RepoMatch{
+			Name: r.Repo.Name,
+			ID:   r.Repo.ID,
+		}
+	case filter.Commit:
+		fields := path[1:]
+		switch len(fields) {
+		case 0, 1:
+			return r
+		case 2:
+			if selectDiffKind(&r.Body, &r.DiffPreview, fields[1]) {
+				return r
+			}
+		}
+	}
+	return nil
+}
+
+func (r *RangeDiffMatch) Key() Key {
+	return Key{
+		TypeRank: rankRangeDiffMatch,
+		Repo:     r.Repo.Name,
+		Rev:      r.Range,
+		Commit:   r.Head,
+	}
+}
+
+func (r *RangeDiffMatch) Label() string {
+	repoName := displayRepoName(string(r.Repo.Name))
+	repoURL := (&RepoMatch{Name: r.Repo.Name, ID: r.Repo.ID}).URL().String()
+	return fmt.Sprintf("[%s](%s) › [%s](%s)", repoName, repoURL, r.Range, r.URL())
+}
+
+func (r *RangeDiffMatch) Detail() string {
+	return fmt.Sprintf("[`%v...%v`](%v)", r.Base.Short(), r.Head.Short(), r.URL())
+}
+
+// URL links to the comparison page for the range.
+func (r *RangeDiffMatch) URL() *url.URL {
+	u := (&RepoMatch{Name: r.Repo.Name, ID: r.Repo.ID}).URL()
+	u.Path = u.Path + "/-/compare/" + string(r.Base) + "..." + string(r.Head)
+	return u
+}
+
+func (r *RangeDiffMatch) searchResultMarker() {}
@@ -26,6 +26,14 @@ type Symbol struct {
 	Signature  string
 	Pattern    string
 
+	// Character is the 1-based character offset of Name on Line, and EndLine
+	// and EndCharacter are the 1-based position right after Name. They are
+	// only set by parsers which know the exact range of the symbol. If they
+	// are 0, the range is computed by searching for Name in Pattern.
+	Character    int
+	EndLine      int
+	EndCharacter int
+
 	FileLimited bool
 }
 
@@ -109,11 +117,15 @@ func (s Symbol) LSPKind() lsp.SymbolKind {
 	return 0
 }
 
-// offset calculates a symbol offset based on the the only Symbol
-// data member that currently exposes line content: the symbols Pattern member,
-// which has the form /^ ... $/. We find the offset of the symbol name in this
-// line, after escaping the Pattern.
+// offset calculates a symbol offset. If the parser did not record the exact
+// Character of the symbol, it is based on the only Symbol data member that
+// exposes line content: the symbols Pattern member, which has the form
+// /^ ... $/. We find the offset of the symbol name in this line, after
+// escaping the Pattern.
 func (s *Symbol) offset() int {
+	if s.Character > 0 {
+		return s.Character - 1
+	}
 	if s.Pattern == "" {
 		return 0
 	}
@@ -188,6 +200,12 @@ func unescapePattern(pattern string) string {
 }
 
 func (s Symbol) Range() lsp.Range {
+	if s.Character > 0 && s.EndLine > 0 && s.EndCharacter > 0 {
+		return lsp.Range{
+			Start: lsp.Position{Line: s.Line - 1, Character: s.Character - 1},
+			End:   lsp.Position{Line: s.EndLine - 1, Character: s.EndCharacter - 1},
+		}
+	}
 	offset := s.offset()
 	return lsp.Range{
 		Start: lsp.Position{Line: s.Line - 1, Character: offset},
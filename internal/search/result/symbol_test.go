@@ -20,6 +20,28 @@ func TestSymbolRange(t *testing.T) {
 			t.Fatal(diff)
 		}
 	})
+
+	t.Run("character", func(t *testing.T) {
+		want := lsp.Range{
+			Start: lsp.Position{Line: 1, Character: 16},
+			End:   lsp.Position{Line: 1, Character: 19},
+		}
+		got := Symbol{Line: 2, Character: 17, Name: "foo", Pattern: `/^func (foo *Foo) foo() {$/`}.Range()
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("end", func(t *testing.T) {
+		want := lsp.Range{
+			Start: lsp.Position{Line: 1, Character: 5},
+			End:   lsp.Position{Line: 2, Character: 3},
+		}
+		got := Symbol{Line: 2, Character: 6, EndLine: 3, EndCharacter: 4, Name: "foo\nbar"}.Range()
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatal(diff)
+		}
+	})
 }
 
 func TestSymbolURL(t *testing.T) {
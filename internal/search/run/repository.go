@@ -47,6 +47,8 @@ func (s *RepoSearch) Run(ctx context.Context, stream streaming.Sender, repos sea
 		query.FieldCase:               {},
 		query.FieldRepoHasFile:        {},
 		query.FieldRepoHasCommitAfter: {},
+		query.FieldRepoHasTopic:       {},
+		query.FieldRepoHasLanguage:    {},
 		query.FieldPatternType:        {},
 		query.FieldSelect:             {},
 	}
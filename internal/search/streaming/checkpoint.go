@@ -0,0 +1,355 @@
+package streaming
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/uuid"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// Checkpoint records the progress of a resumable search stream: which
+// matches were sent in which event, and which repositories were searched
+// completely. A client which loses its connection reconnects with the ID of
+// the last event it received, and the search is resumed by skipping the
+// completed repositories and the matches the client acknowledged.
+type Checkpoint struct {
+	// StreamID identifies the stream. It is assigned when the stream starts.
+	StreamID string
+
+	// Query identifies the search the stream is for. A stream can only be
+	// resumed for the same search.
+	Query string
+
+	// Seq is the sequence number of the last event which was sent.
+	Seq int64
+
+	// Sent maps the key of each match sent to the sequence number of the
+	// first event it was sent in.
+	Sent map[result.Key]int64
+
+	// Completed maps each repository which was searched completely to the
+	// sequence number of the event its last match was sent in.
+	Completed map[api.RepoName]int64
+}
+
+// CheckpointUpdate is the progress recorded for a single event. Only the
+// matches and repositories which are new to the checkpoint are included, so
+// that stores can append updates rather than rewrite the whole checkpoint.
+//
+// Unsent and Uncompleted are only set by Resume. They are the matches and
+// repositories recorded in events the client never received, which are
+// removed from the checkpoint.
+type CheckpointUpdate struct {
+	Seq         int64
+	Sent        []result.Key
+	Completed   []api.RepoName
+	Unsent      []result.Key
+	Uncompleted []api.RepoName
+}
+
+// NewCheckpoint returns the checkpoint of a new stream for query.
+func NewCheckpoint(query string) *Checkpoint {
+	return &Checkpoint{
+		StreamID:  uuid.New().String(),
+		Query:     query,
+		Sent:      map[result.Key]int64{},
+		Completed: map[api.RepoName]int64{},
+	}
+}
+
+// EventID returns the ID of the event with sequence number seq, which is sent
+// as the id field of server-sent events.
+func (c *Checkpoint) EventID(seq int64) string {
+	return c.StreamID + ":" + strconv.FormatInt(seq, 10)
+}
+
+// ParseEventID parses an event ID returned by EventID.
+func ParseEventID(id string) (streamID string, seq int64, err error) {
+	i := strings.LastIndexByte(id, ':')
+	if i <= 0 {
+		return "", 0, errors.Errorf("invalid event ID %q", id)
+	}
+	seq, err = strconv.ParseInt(id[i+1:], 10, 64)
+	if err != nil || seq < 0 {
+		return "", 0, errors.Errorf("invalid event ID %q", id)
+	}
+	return id[:i], seq, nil
+}
+
+// Add records that the matches with keys are sent in the next event, and that
+// the repositories in completed have no matches left to send. It returns the
+// update to store.
+func (c *Checkpoint) Add(keys []result.Key, completed []api.RepoName) CheckpointUpdate {
+	u := CheckpointUpdate{Seq: c.Seq + 1}
+	for _, key := range keys {
+		if _, ok := c.Sent[key]; ok {
+			// Already sent in an earlier event, which is the one that
+			// must be acknowledged to skip it.
+			continue
+		}
+		c.Sent[key] = u.Seq
+		u.Sent = append(u.Sent, key)
+	}
+	for _, repo := range completed {
+		if _, ok := c.Completed[repo]; ok {
+			continue
+		}
+		c.Completed[repo] = u.Seq
+		u.Completed = append(u.Completed, repo)
+	}
+	c.Seq = u.Seq
+	return u
+}
+
+// Resume prepares c for resuming the stream after the event with sequence
+// number seq, the last one the client received. The matches and repositories
+// recorded in later events are removed, so that they are recorded again with
+// the sequence number of the event they are sent in next. Otherwise they'd
+// keep the sequence number of an event the client never received, and be
+// skipped if the client acknowledged a later event before reconnecting again.
+// It returns the update to store.
+func (c *Checkpoint) Resume(seq int64) CheckpointUpdate {
+	u := CheckpointUpdate{Seq: c.Seq}
+	for key, sent := range c.Sent {
+		if sent > seq {
+			delete(c.Sent, key)
+			u.Unsent = append(u.Unsent, key)
+		}
+	}
+	for repo, completed := range c.Completed {
+		if completed > seq {
+			delete(c.Completed, repo)
+			u.Uncompleted = append(u.Uncompleted, repo)
+		}
+	}
+	return u
+}
+
+// apply applies an update returned by Add or Resume to c.
+func (c *Checkpoint) apply(u CheckpointUpdate) {
+	for _, key := range u.Unsent {
+		delete(c.Sent, key)
+	}
+	for _, repo := range u.Uncompleted {
+		delete(c.Completed, repo)
+	}
+	for _, key := range u.Sent {
+		if _, ok := c.Sent[key]; !ok {
+			c.Sent[key] = u.Seq
+		}
+	}
+	for _, repo := range u.Completed {
+		if _, ok := c.Completed[repo]; !ok {
+			c.Completed[repo] = u.Seq
+		}
+	}
+	if u.Seq > c.Seq {
+		c.Seq = u.Seq
+	}
+}
+
+// Acknowledged reports whether the match with key was sent in an event with a
+// sequence number of at most seq.
+func (c *Checkpoint) Acknowledged(key result.Key, seq int64) bool {
+	sent, ok := c.Sent[key]
+	return ok && sent <= seq
+}
+
+// CompletedRepos returns the repositories which were searched completely,
+// and all matches of which were sent in events with a sequence number of at
+// most seq. They don't need to be searched again when the stream is resumed.
+func (c *Checkpoint) CompletedRepos(seq int64) []api.RepoName {
+	var repos []api.RepoName
+	for repo, completed := range c.Completed {
+		if completed <= seq {
+			repos = append(repos, repo)
+		}
+	}
+	sort.Slice(repos, func(i, j int) bool { return repos[i] < repos[j] })
+	return repos
+}
+
+// CheckpointStore stores the checkpoints of resumable streams.
+type CheckpointStore interface {
+	Get(streamID string) (*Checkpoint, bool)
+
+	// Append stores the update u of checkpoint c, which was returned by
+	// c.Add or c.Resume.
+	Append(c *Checkpoint, u CheckpointUpdate) error
+}
+
+// NewCheckpointStore returns a redis backed CheckpointStore which expires
+// checkpoints ttlSeconds after they were last updated.
+//
+// Each checkpoint is stored as a hash, to which every update adds a field per
+// match and completed repository. Updating a checkpoint thus only costs the
+// size of the update, rather than the size of the checkpoint.
+func NewCheckpointStore(ttlSeconds int) CheckpointStore {
+	return &redisCheckpointStore{ttlSeconds: ttlSeconds}
+}
+
+type redisCheckpointStore struct {
+	ttlSeconds int
+}
+
+const (
+	checkpointKeyPrefix = "search_stream_checkpoint:"
+
+	checkpointFieldQuery          = "query"
+	checkpointFieldSeq            = "seq"
+	checkpointFieldSentPrefix     = "sent:"
+	checkpointFieldCompletePrefix = "completed:"
+)
+
+func (s *redisCheckpointStore) Get(streamID string) (*Checkpoint, bool) {
+	c := redispool.Cache.Get()
+	defer c.Close()
+
+	fields, err := redis.StringMap(c.Do("HGETALL", checkpointKeyPrefix+streamID))
+	if err != nil {
+		log15.Warn("streaming: failed to get checkpoint", "stream", streamID, "error", err)
+		return nil, false
+	}
+	if len(fields) == 0 {
+		return nil, false
+	}
+
+	checkpoint := &Checkpoint{
+		StreamID:  streamID,
+		Sent:      map[result.Key]int64{},
+		Completed: map[api.RepoName]int64{},
+	}
+	for field, value := range fields {
+		switch {
+		case field == checkpointFieldQuery:
+			checkpoint.Query = value
+		case field == checkpointFieldSeq:
+			checkpoint.Seq, err = strconv.ParseInt(value, 10, 64)
+		case strings.HasPrefix(field, checkpointFieldSentPrefix):
+			var key result.Key
+			if err = json.Unmarshal([]byte(strings.TrimPrefix(field, checkpointFieldSentPrefix)), &key); err == nil {
+				checkpoint.Sent[key], err = strconv.ParseInt(value, 10, 64)
+			}
+		case strings.HasPrefix(field, checkpointFieldCompletePrefix):
+			repo := api.RepoName(strings.TrimPrefix(field, checkpointFieldCompletePrefix))
+			checkpoint.Completed[repo], err = strconv.ParseInt(value, 10, 64)
+		}
+		if err != nil {
+			log15.Warn("streaming: invalid checkpoint", "stream", streamID, "field", field, "error", err)
+			return nil, false
+		}
+	}
+	return checkpoint, true
+}
+
+func (s *redisCheckpointStore) Append(checkpoint *Checkpoint, u CheckpointUpdate) error {
+	key := checkpointKeyPrefix + checkpoint.StreamID
+	args := redis.Args{key,
+		checkpointFieldQuery, checkpoint.Query,
+		checkpointFieldSeq, u.Seq,
+	}
+	for _, k := range u.Sent {
+		b, err := json.Marshal(k)
+		if err != nil {
+			return err
+		}
+		args = args.Add(checkpointFieldSentPrefix+string(b), u.Seq)
+	}
+	for _, repo := range u.Completed {
+		args = args.Add(checkpointFieldCompletePrefix+string(repo), u.Seq)
+	}
+	removed := redis.Args{key}
+	for _, k := range u.Unsent {
+		b, err := json.Marshal(k)
+		if err != nil {
+			return err
+		}
+		removed = removed.Add(checkpointFieldSentPrefix + string(b))
+	}
+	for _, repo := range u.Uncompleted {
+		removed = removed.Add(checkpointFieldCompletePrefix + string(repo))
+	}
+
+	c := redispool.Cache.Get()
+	defer c.Close()
+
+	if err := c.Send("MULTI"); err != nil {
+		return err
+	}
+	if len(removed) > 1 {
+		if err := c.Send("HDEL", removed...); err != nil {
+			return err
+		}
+	}
+	if err := c.Send("HSET", args...); err != nil {
+		return err
+	}
+	if err := c.Send("EXPIRE", key, s.ttlSeconds); err != nil {
+		return err
+	}
+	_, err := c.Do("EXEC")
+	return err
+}
+
+// NewMemoryCheckpointStore returns a CheckpointStore which keeps checkpoints
+// in memory. It is intended for tests.
+func NewMemoryCheckpointStore() CheckpointStore {
+	return &memoryCheckpointStore{checkpoints: map[string]*Checkpoint{}}
+}
+
+type memoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]*Checkpoint
+}
+
+func (s *memoryCheckpointStore) Get(streamID string) (*Checkpoint, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.checkpoints[streamID]
+	if !ok {
+		return nil, false
+	}
+	// Return a copy, so that the caller's changes are not visible until they
+	// are appended.
+	c := &Checkpoint{
+		StreamID:  stored.StreamID,
+		Query:     stored.Query,
+		Seq:       stored.Seq,
+		Sent:      map[result.Key]int64{},
+		Completed: map[api.RepoName]int64{},
+	}
+	for key, seq := range stored.Sent {
+		c.Sent[key] = seq
+	}
+	for repo, seq := range stored.Completed {
+		c.Completed[repo] = seq
+	}
+	return c, true
+}
+
+func (s *memoryCheckpointStore) Append(c *Checkpoint, u CheckpointUpdate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.checkpoints[c.StreamID]
+	if !ok {
+		stored = &Checkpoint{
+			StreamID:  c.StreamID,
+			Query:     c.Query,
+			Sent:      map[result.Key]int64{},
+			Completed: map[api.RepoName]int64{},
+		}
+		s.checkpoints[c.StreamID] = stored
+	}
+	stored.apply(u)
+	return nil
+}
@@ -0,0 +1,138 @@
+package streaming
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestCheckpoint(t *testing.T) {
+	key := func(repo, path string) result.Key {
+		return result.Key{Repo: api.RepoName(repo), Path: path}
+	}
+
+	store := NewMemoryCheckpointStore()
+	c := NewCheckpoint("foo")
+	for _, u := range []struct {
+		keys      []result.Key
+		completed []api.RepoName
+		want      CheckpointUpdate
+	}{{
+		keys:      []result.Key{key("a", "2"), key("b", "1")},
+		completed: []api.RepoName{"b"},
+		want: CheckpointUpdate{
+			Seq:       1,
+			Sent:      []result.Key{key("a", "2"), key("b", "1")},
+			Completed: []api.RepoName{"b"},
+		},
+	}, {
+		keys:      []result.Key{key("a", "1"), key("a", "2")},
+		completed: []api.RepoName{"a"},
+		want: CheckpointUpdate{
+			Seq:       2,
+			Sent:      []result.Key{key("a", "1")}, // only new keys are stored
+			Completed: []api.RepoName{"a"},
+		},
+	}} {
+		got := c.Add(u.keys, u.completed)
+		if diff := cmp.Diff(u.want, got); diff != "" {
+			t.Fatalf("unexpected update (-want +got):\n%s", diff)
+		}
+		if err := store.Append(c, got); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	streamID, seq, err := ParseEventID(c.EventID(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, ok := store.Get(streamID)
+	if !ok {
+		t.Fatal("checkpoint not found")
+	}
+
+	for _, tc := range []struct {
+		key  result.Key
+		want bool
+	}{
+		{key("a", "2"), true},
+		{key("b", "1"), true},
+		{key("a", "1"), false}, // sent after the acknowledged event
+		{key("c", "1"), false},
+	} {
+		if got := c.Acknowledged(tc.key, seq); got != tc.want {
+			t.Errorf("Acknowledged(%v, %d) = %t, want %t", tc.key, seq, got, tc.want)
+		}
+	}
+
+	if diff := cmp.Diff([]api.RepoName{"b"}, c.CompletedRepos(seq)); diff != "" {
+		t.Errorf("unexpected completed repos (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]api.RepoName{"a", "b"}, c.CompletedRepos(c.Seq)); diff != "" {
+		t.Errorf("unexpected completed repos (-want +got):\n%s", diff)
+	}
+
+	if _, _, err := ParseEventID("nocolon"); err == nil {
+		t.Error("expected error for invalid event ID")
+	}
+}
+
+func TestCheckpoint_resumeTwice(t *testing.T) {
+	key := func(repo, path string) result.Key {
+		return result.Key{Repo: api.RepoName(repo), Path: path}
+	}
+
+	store := NewMemoryCheckpointStore()
+	c := NewCheckpoint("foo")
+	add := func(keys []result.Key, completed ...api.RepoName) int64 {
+		u := c.Add(keys, completed)
+		if err := store.Append(c, u); err != nil {
+			t.Fatal(err)
+		}
+		return u.Seq
+	}
+	resume := func(seq int64) {
+		var ok bool
+		if c, ok = store.Get(c.StreamID); !ok {
+			t.Fatal("checkpoint not found")
+		}
+		if err := store.Append(c, c.Resume(seq)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The client receives the first event, but not the second.
+	received := add([]result.Key{key("a", "1")}, "a")
+	add([]result.Key{key("b", "1"), key("c", "1")}, "b")
+
+	// On the first resume, the matches of the second event are sent again,
+	// but after a new match. The client only receives the new match.
+	resume(received)
+	received = add([]result.Key{key("d", "1")})
+	add([]result.Key{key("b", "1"), key("c", "1")}, "b")
+
+	// On the second resume, the matches the client never received must not
+	// be skipped.
+	resume(received)
+	c, _ = store.Get(c.StreamID)
+	for _, tc := range []struct {
+		key  result.Key
+		want bool
+	}{
+		{key("a", "1"), true},
+		{key("d", "1"), true},
+		{key("b", "1"), false},
+		{key("c", "1"), false},
+	} {
+		if got := c.Acknowledged(tc.key, received); got != tc.want {
+			t.Errorf("Acknowledged(%v, %d) = %t, want %t", tc.key, received, got, tc.want)
+		}
+	}
+	if diff := cmp.Diff([]api.RepoName{"a"}, c.CompletedRepos(received)); diff != "" {
+		t.Errorf("unexpected completed repos (-want +got):\n%s", diff)
+	}
+}
@@ -33,6 +33,11 @@ type FrontendStreamDecoder struct {
 	OnAlert    func(*EventAlert)
 	OnError    func(*EventError)
 	OnUnknown  func(event, data []byte)
+
+	// OnEventID is called with the ID of each event that has one. Clients of
+	// resumable streams pass the last ID in the Last-Event-ID header when
+	// they reconnect.
+	OnEventID func(id string)
 }
 
 func (rr FrontendStreamDecoder) ReadAll(r io.Reader) error {
@@ -42,6 +47,10 @@ func (rr FrontendStreamDecoder) ReadAll(r io.Reader) error {
 		event := dec.Event()
 		data := dec.Data()
 
+		if id := dec.ID(); id != nil && rr.OnEventID != nil {
+			rr.OnEventID(string(id))
+		}
+
 		if bytes.Equal(event, []byte("progress")) {
 			if rr.OnProgress == nil {
 				continue
@@ -128,6 +137,8 @@ func (r *eventMatchUnmarshaller) UnmarshalJSON(b []byte) error {
 		r.EventMatch = &EventSymbolMatch{}
 	case CommitMatchType:
 		r.EventMatch = &EventCommitMatch{}
+	case OwnerMatchType:
+		r.EventMatch = &EventOwnerMatch{}
 	default:
 		return errors.Errorf("unknown MatchType %v", typeU.Type)
 	}
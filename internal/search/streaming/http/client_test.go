@@ -48,6 +48,11 @@ func TestFrontendClient(t *testing.T) {
 				Type:   CommitMatchType,
 				Detail: "test",
 			},
+			&EventOwnerMatch{
+				Type:   OwnerMatchType,
+				Handle: "@test",
+				Count:  2,
+			},
 		},
 	}, {
 		Name: "filters",
@@ -14,6 +14,7 @@ import (
 type Decoder struct {
 	scanner *bufio.Scanner
 	event   []byte
+	id      []byte
 	data    []byte
 	err     error
 }
@@ -52,6 +53,7 @@ func (d *Decoder) Scan() bool {
 	}
 
 	// event: $event\n
+	// id: $id\n (optional)
 	// data: json($data)\n\n
 	data := d.scanner.Bytes()
 	nl := bytes.Index(data, []byte("\n"))
@@ -61,7 +63,19 @@ func (d *Decoder) Scan() bool {
 	}
 
 	eventK, event := splitColon(data[:nl])
-	dataK, data := splitColon(data[nl+1:])
+	data = data[nl+1:]
+
+	var id []byte
+	if bytes.HasPrefix(data, []byte("id:")) {
+		nl = bytes.Index(data, []byte("\n"))
+		if nl < 0 {
+			d.err = errors.Errorf("malformed event %s, no data", eventK)
+			return false
+		}
+		_, id = splitColon(data[:nl])
+		data = data[nl+1:]
+	}
+	dataK, data := splitColon(data)
 
 	if !bytes.Equal(eventK, []byte("event")) {
 		d.err = errors.Errorf("malformed event, expected event: %s", eventK)
@@ -73,6 +87,7 @@ func (d *Decoder) Scan() bool {
 	}
 
 	d.event = event
+	d.id = id
 	d.data = data
 	return true
 }
@@ -82,6 +97,11 @@ func (d *Decoder) Event() []byte {
 	return d.event
 }
 
+// ID returns the ID of the last decoded event, or nil if it has none.
+func (d *Decoder) ID() []byte {
+	return d.id
+}
+
 // Event returns the event data of the last decoded event
 func (d *Decoder) Data() []byte {
 	return d.data
@@ -39,6 +39,16 @@ func TestDecoder(t *testing.T) {
 		require.Equal(t, events, []event{{name: "a", data: "b"}, {name: "b", data: "c"}})
 	})
 
+	t.Run("ID", func(t *testing.T) {
+		dec := NewDecoder(strings.NewReader("event:a\nid:s:1\ndata:b\n\nevent:b\ndata:c\n\n"))
+		require.True(t, dec.Scan())
+		require.Equal(t, "s:1", string(dec.ID()))
+		require.Equal(t, "b", string(dec.Data()))
+		require.True(t, dec.Scan())
+		require.Nil(t, dec.ID())
+		require.NoError(t, dec.Err())
+	})
+
 	t.Run("ErrNoNewline", func(t *testing.T) {
 		_, err := decodeAll("abc:a")
 		require.Contains(t, err.Error(), "malformed event, no newline")
@@ -56,8 +56,15 @@ func (e *Writer) Event(event string, data interface{}) error {
 
 // EventBytes writes dataLine as an event. dataLine is not allowed to contain
 // a newline.
-func (e *Writer) EventBytes(event string, dataLine []byte) (err error) {
-	if payloadSize := 16 /* event: \ndata: \n\n */ + len(event) + len(dataLine); payloadSize > maxPayloadSize {
+func (e *Writer) EventBytes(event string, dataLine []byte) error {
+	return e.EventBytesWithID(event, "", dataLine)
+}
+
+// EventBytesWithID writes dataLine as an event with the given ID. A client
+// which reconnects sends the ID of the last event it received in the
+// Last-Event-ID header. dataLine is not allowed to contain a newline.
+func (e *Writer) EventBytesWithID(event, id string, dataLine []byte) (err error) {
+	if payloadSize := 16 /* event: \ndata: \n\n */ + len(event) + len(id) + len(dataLine); payloadSize > maxPayloadSize {
 		return errors.Errorf("payload size %d is greater than max payload size %d", payloadSize, maxPayloadSize)
 	}
 
@@ -92,6 +99,13 @@ func (e *Writer) EventBytes(event string, dataLine []byte) (err error) {
 		write([]byte("\n"))
 	}
 
+	if id != "" {
+		// id: $id\n
+		write([]byte("id: "))
+		write([]byte(id))
+		write([]byte("\n"))
+	}
+
 	// data: json($data)\n\n
 	write([]byte("data: "))
 	write(dataLine)
@@ -31,6 +31,11 @@ type Stats struct {
 
 	// IsIndexUnavailable is true if indexed search was unavailable.
 	IsIndexUnavailable bool
+
+	// CompletedRepos are the repositories all matches of which have been
+	// sent. It is only populated for searches which track completed
+	// repositories, see graphqlbackend.SearchArgs.
+	CompletedRepos map[api.RepoName]struct{}
 }
 
 // Update updates c with the other data, deduping as necessary. It modifies c but
@@ -52,6 +57,13 @@ func (c *Stats) Update(other *Stats) {
 		}
 	}
 
+	if c.CompletedRepos == nil && len(other.CompletedRepos) > 0 {
+		c.CompletedRepos = make(map[api.RepoName]struct{}, len(other.CompletedRepos))
+	}
+	for name := range other.CompletedRepos {
+		c.CompletedRepos[name] = struct{}{}
+	}
+
 	c.Status.Union(&other.Status)
 
 	c.ExcludedForks = c.ExcludedForks + other.ExcludedForks
@@ -70,7 +82,8 @@ func (c *Stats) Zero() bool {
 		c.Status.Len() > 0 ||
 		c.ExcludedForks > 0 ||
 		c.ExcludedArchived > 0 ||
-		c.IsIndexUnavailable)
+		c.IsIndexUnavailable ||
+		len(c.CompletedRepos) > 0)
 }
 
 func (c *Stats) String() string {
@@ -88,6 +101,7 @@ func (c *Stats) String() string {
 		{"repos", len(c.Repos)},
 		{"excludedForks", c.ExcludedForks},
 		{"excludedArchived", c.ExcludedArchived},
+		{"completedRepos", len(c.CompletedRepos)},
 	}
 	for _, p := range nums {
 		if p.n != 0 {
@@ -141,6 +141,12 @@ func (s *SearchFilters) Update(event SearchEvent) {
 			// We leave "rev" empty, instead of using "CommitMatch.Commit.ID". This way we
 			// get 1 filter per repo instead of 1 filter per sha in the side-bar.
 			addRepoFilter(v.Repo.Name, v.Repo.ID, "", int32(v.ResultCount()))
+		case *result.RangeDiffMatch:
+			addRepoFilter(v.Repo.Name, v.Repo.ID, "", int32(v.ResultCount()))
+		case *result.OwnerMatch:
+			// Owner filters count the matched files of each owner.
+			value := fmt.Sprintf(`file:has.owner(%s)`, v.Handle)
+			s.filters.Add(value, v.Handle, int32(v.Count), event.Stats.IsLimitHit, "owner")
 		}
 	}
 }
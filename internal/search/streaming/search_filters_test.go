@@ -71,6 +71,24 @@ func TestSearchFiltersUpdate(t *testing.T) {
 			wantFilterKind:  "repo",
 			wantFilterCount: 2,
 		},
+		{
+			name: "OwnerMatch",
+			events: []SearchEvent{
+				{
+					Results: []result.Match{
+						&result.OwnerMatch{Handle: "@alice", Repo: repo, Count: 2},
+					},
+				},
+				{
+					Results: []result.Match{
+						&result.OwnerMatch{Handle: "@alice", Repo: repo, Count: 3},
+					},
+				},
+			},
+			wantFilterName:  "file:has.owner(@alice)",
+			wantFilterKind:  "owner",
+			wantFilterCount: 5,
+		},
 	}
 
 	for _, c := range cases {
@@ -94,6 +94,11 @@ func WithSelect(parent Sender, s filter.SelectPath) Sender {
 			_, isFileMatch := current.(*result.FileMatch)
 			seen := dedup.Seen(current)
 			if seen && !isFileMatch {
+				// Repeated owners are sent as well, so that the counts of
+				// the files they own in this event are not lost.
+				if _, isOwnerMatch := current.(*result.OwnerMatch); isOwnerMatch {
+					selected = append(selected, current)
+				}
 				continue
 			}
 
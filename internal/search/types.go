@@ -277,6 +277,8 @@ type RepoOptions struct {
 	NoArchived               bool
 	OnlyArchived             bool
 	CommitAfter              string
+	HasTopics                []string
+	HasLanguages             []string
 	Visibility               query.RepoVisibility
 	Limit                    int
 	Cursors                  []*types.Cursor
@@ -301,6 +303,12 @@ func (op *RepoOptions) String() string {
 	if op.CommitAfter != "" {
 		_, _ = fmt.Fprintf(&b, " CommitAfter=%q", op.CommitAfter)
 	}
+	if len(op.HasTopics) > 0 {
+		_, _ = fmt.Fprintf(&b, " HasTopics=%v", op.HasTopics)
+	}
+	if len(op.HasLanguages) > 0 {
+		_, _ = fmt.Fprintf(&b, " HasLanguages=%v", op.HasLanguages)
+	}
 
 	if op.CaseSensitiveRepoFilters {
 		b.WriteString(" CaseSensitiveRepoFilters")
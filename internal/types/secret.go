@@ -90,6 +90,8 @@ func redactionInfo(cfg interface{}) ([]jsonStringField, error) {
 		return fields, nil
 	case *schema.BitbucketCloudConnection:
 		return []jsonStringField{{[]string{"appPassword"}, &cfg.AppPassword}}, nil
+	case *schema.GerritConnection:
+		return []jsonStringField{{[]string{"password"}, &cfg.Password}}, nil
 	case *schema.AWSCodeCommitConnection:
 		return []jsonStringField{
 			{[]string{"secretAccessKey"}, &cfg.SecretAccessKey},
@@ -111,6 +113,10 @@ func redactionInfo(cfg interface{}) ([]jsonStringField, error) {
 	case *schema.NPMPackagesConnection:
 		// TODO: [npm-package-support-credentials] Redact credentials here.
 		return []jsonStringField{}, nil
+	case *schema.PythonPackagesConnection:
+		return []jsonStringField{}, nil
+	case *schema.GoModulesConnection:
+		return []jsonStringField{}, nil
 	case *schema.OtherExternalServiceConnection:
 		return []jsonStringField{{[]string{"url"}, &cfg.Url}}, nil
 	default:
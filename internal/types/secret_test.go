@@ -34,6 +34,11 @@ func TestRoundTripRedactExternalServiceConfig(t *testing.T) {
 		AppPassword: someSecret,
 		Url:         "https://bitbucket.com",
 	}
+	gerritConfig := schema.GerritConnection{
+		Password: someSecret,
+		Url:      "https://gerrit.example.com",
+		Username: "admin",
+	}
 	bitbucketServerConfigWithPassword := schema.BitbucketServerConnection{
 		Password: someSecret,
 		Url:      "https://bitbucket.com",
@@ -98,6 +103,11 @@ func TestRoundTripRedactExternalServiceConfig(t *testing.T) {
 			config:    &bitbucketCloudConfig,
 			editField: func(cfg interface{}) *string { return &cfg.(*schema.BitbucketCloudConnection).Url },
 		},
+		{
+			kind:      extsvc.KindGerrit,
+			config:    &gerritConfig,
+			editField: func(cfg interface{}) *string { return &cfg.(*schema.GerritConnection).Username },
+		},
 		// BitbucketServer can have a password OR token, not both
 		{
 			kind:      extsvc.KindBitbucketServer,
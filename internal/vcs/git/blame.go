@@ -24,8 +24,37 @@ type BlameOptions struct {
 
 	StartLine int `json:",omitempty" url:",omitempty"` // 1-indexed start byte (or 0 for beginning of file)
 	EndLine   int `json:",omitempty" url:",omitempty"` // 1-indexed end byte (or 0 for end of file)
+
+	// IgnoreRevs are commits whose changes are ignored, like git blame
+	// --ignore-rev. Lines changed by them are blamed on the commit that
+	// changed them before. Each must be a full 40-character commit ID.
+	// Commits that don't exist are skipped.
+	IgnoreRevs []api.CommitID `json:",omitempty" url:",omitempty"`
+
+	// IgnoreRevsFile additionally ignores the commits listed in the
+	// .git-blame-ignore-revs file of the repository at NewestCommit, if it
+	// exists.
+	IgnoreRevsFile bool `json:",omitempty" url:",omitempty"`
+
+	// DetectMoves detects lines moved or copied within the file, like git
+	// blame -M.
+	DetectMoves bool `json:",omitempty" url:",omitempty"`
+
+	// DetectCopies detects lines moved or copied from other files, like
+	// passing git blame -C DetectCopies times (at most 3). With 1, only files
+	// modified in the same commit are considered, with 2 also files in the
+	// commit that created the file, and with 3 all commits.
+	DetectCopies int `json:",omitempty" url:",omitempty"`
 }
 
+// blameIgnoreRevsFile is the conventional name of the file listing commits to
+// ignore in blame, such as mass reformatting commits.
+const blameIgnoreRevsFile = ".git-blame-ignore-revs"
+
+// maxBlameDetectCopies is the maximum number of times -C is passed to git
+// blame. Git doesn't distinguish more.
+const maxBlameDetectCopies = 3
+
 // A Hunk is a contiguous portion of a file associated with a commit.
 type Hunk struct {
 	StartLine int // 1-indexed start line number
@@ -72,11 +101,27 @@ func blameFileCmd(ctx context.Context, command cmdFunc, path string, opt *BlameO
 	if err := checkSpecArgSafety(string(opt.OldestCommit)); err != nil {
 		return nil, err
 	}
+	if opt.DetectCopies < 0 || opt.DetectCopies > maxBlameDetectCopies {
+		return nil, errors.Errorf("DetectCopies must be between 0 and %d", maxBlameDetectCopies)
+	}
+	ignoreRevs, err := blameIgnoreRevs(ctx, command, opt)
+	if err != nil {
+		return nil, err
+	}
 
 	args := []string{"blame", "-w", "--porcelain"}
 	if opt.StartLine != 0 || opt.EndLine != 0 {
 		args = append(args, fmt.Sprintf("-L%d,%d", opt.StartLine, opt.EndLine))
 	}
+	if opt.DetectMoves {
+		args = append(args, "-M")
+	}
+	for i := 0; i < opt.DetectCopies; i++ {
+		args = append(args, "-C")
+	}
+	for _, rev := range ignoreRevs {
+		args = append(args, "--ignore-rev", string(rev))
+	}
 	args = append(args, string(opt.NewestCommit), "--", filepath.ToSlash(path))
 
 	out, err := command(args).Output(ctx)
@@ -185,3 +230,69 @@ func blameFileCmd(ctx context.Context, command cmdFunc, path string, opt *BlameO
 
 	return hunks, nil
 }
+
+// blameIgnoreRevs returns the commits to ignore in a blame with opt. Only
+// commits which exist in the repository are returned, since git blame fails
+// for others, and the list may well reference commits of other branches or
+// rewritten history.
+func blameIgnoreRevs(ctx context.Context, command cmdFunc, opt *BlameOptions) ([]api.CommitID, error) {
+	revs := make([]string, 0, len(opt.IgnoreRevs))
+	for _, rev := range opt.IgnoreRevs {
+		// Like in a .git-blame-ignore-revs file, only accept unabbreviated
+		// object names, so that a rev can't be interpreted as an option or
+		// revision range.
+		if !IsAbsoluteRevision(string(rev)) {
+			return nil, errors.Errorf("invalid ignore rev %q: must be a 40-character hexadecimal commit ID", rev)
+		}
+		revs = append(revs, string(rev))
+	}
+
+	if opt.IgnoreRevsFile {
+		rev := string(opt.NewestCommit)
+		if rev == "" {
+			rev = "HEAD"
+		}
+		// A missing file is not an error, most repositories don't have one.
+		if out, err := command([]string{"show", rev + ":" + blameIgnoreRevsFile}).Output(ctx); err == nil {
+			revs = append(revs, parseBlameIgnoreRevs(string(out))...)
+		} else if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+
+	if len(revs) == 0 {
+		return nil, nil
+	}
+
+	args := append([]string{"rev-list", "--no-walk", "--ignore-missing"}, revs...)
+	out, err := command(args).Output(ctx)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("git command %v failed (output: %q)", args, out))
+	}
+	var commits []api.CommitID
+	for _, line := range strings.Split(string(out), "\n") {
+		if line != "" {
+			commits = append(commits, api.CommitID(line))
+		}
+	}
+	return commits, nil
+}
+
+// parseBlameIgnoreRevs parses the contents of a .git-blame-ignore-revs file,
+// which lists one commit per line. Comments start with "#".
+func parseBlameIgnoreRevs(contents string) []string {
+	var revs []string
+	for _, line := range strings.Split(contents, "\n") {
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		// Like git, only accept unabbreviated object names. This also
+		// ensures a line can't be interpreted as an option.
+		if !IsAbsoluteRevision(line) {
+			continue
+		}
+		revs = append(revs, line)
+	}
+	return revs
+}
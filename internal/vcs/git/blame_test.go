@@ -2,6 +2,7 @@ package git
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
@@ -110,3 +111,104 @@ func runBlameFileTest(ctx context.Context, t *testing.T, repo api.RepoName, path
 		t.Errorf("%s: hunks != wantHunks\n\nhunks ==========\n%s\n\nwantHunks ==========\n%s", label, AsJSON(hunks), AsJSON(wantHunks))
 	}
 }
+
+func TestRepository_BlameFile_IgnoreRevsAndCopies(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	commit := "GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m %s --author='a <a@a.com>' --date 2006-01-02T15:04:05Z"
+	repo := MakeGitRepository(t,
+		"printf 'a\\nb\\n' > f",
+		"printf 'func thisIsAFunctionWithALongName(argument int) {\\n\\treturn somethingVeryLongAndUnique(argument)\\n}\\n' > g",
+		"git add f g",
+		fmt.Sprintf(commit, "add"),
+		"printf 'A\\nB\\n' > f",
+		"(echo 'package x'; cat g) > h",
+		"git add f h",
+		fmt.Sprintf(commit, "reformat"),
+		"git rev-parse HEAD > .git-blame-ignore-revs",
+		"git add .git-blame-ignore-revs",
+		fmt.Sprintf(commit, "ignore"),
+	)
+
+	resolve := func(rev string) api.CommitID {
+		t.Helper()
+		commitID, err := ResolveRevision(ctx, repo, rev, ResolveRevisionOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return commitID
+	}
+	head, reformat, add := resolve("HEAD"), resolve("HEAD~1"), resolve("HEAD~2")
+
+	tests := map[string]struct {
+		path string
+		opt  BlameOptions
+
+		wantCommit   api.CommitID
+		wantFilename string
+	}{
+		"not ignored": {
+			path:         "f",
+			opt:          BlameOptions{NewestCommit: head},
+			wantCommit:   reformat,
+			wantFilename: "f",
+		},
+		"ignore revs file": {
+			path:         "f",
+			opt:          BlameOptions{NewestCommit: head, IgnoreRevsFile: true},
+			wantCommit:   add,
+			wantFilename: "f",
+		},
+		"ignore revs file does not exist": {
+			path:         "f",
+			opt:          BlameOptions{NewestCommit: reformat, IgnoreRevsFile: true},
+			wantCommit:   reformat,
+			wantFilename: "f",
+		},
+		"ignore revs with missing commit": {
+			path:         "f",
+			opt:          BlameOptions{NewestCommit: head, IgnoreRevs: []api.CommitID{"deadbeefdeadbeefdeadbeefdeadbeefdeadbeef", reformat}},
+			wantCommit:   add,
+			wantFilename: "f",
+		},
+		"no copy detection": {
+			path:         "h",
+			opt:          BlameOptions{NewestCommit: head},
+			wantCommit:   reformat,
+			wantFilename: "h",
+		},
+		"copy detection": {
+			path:         "h",
+			opt:          BlameOptions{NewestCommit: head, DetectCopies: 2},
+			wantCommit:   add,
+			wantFilename: "g",
+		},
+	}
+
+	for label, test := range tests {
+		t.Run(label, func(t *testing.T) {
+			hunks, err := BlameFile(ctx, repo, test.path, &test.opt, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(hunks) == 0 {
+				t.Fatal("got no hunks")
+			}
+			// The last hunk is the one of the reformatted or copied lines.
+			hunk := hunks[len(hunks)-1]
+			if hunk.CommitID != test.wantCommit || hunk.Filename != test.wantFilename {
+				t.Errorf("got hunk of commit %s in %q, want commit %s in %q", hunk.CommitID, hunk.Filename, test.wantCommit, test.wantFilename)
+			}
+		})
+	}
+
+	if _, err := BlameFile(ctx, repo, "f", &BlameOptions{NewestCommit: head, DetectCopies: 4}, nil); err == nil {
+		t.Error("expected error for DetectCopies > 3")
+	}
+	for _, rev := range []api.CommitID{"HEAD~1", reformat[:7], "--since=2021-01-01", reformat + ".." + head} {
+		if _, err := BlameFile(ctx, repo, "f", &BlameOptions{NewestCommit: head, IgnoreRevs: []api.CommitID{rev}}, nil); err == nil {
+			t.Errorf("expected error for ignore rev %q", rev)
+		}
+	}
+}
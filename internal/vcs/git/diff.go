@@ -85,6 +85,37 @@ func DiffPath(ctx context.Context, repo api.RepoName, sourceCommit, targetCommit
 	return d.Hunks, nil
 }
 
+// maxRangeDiffSize is the maximum size of the patch returned by RangeDiff.
+// Ranges between distant revisions can produce arbitrarily large patches,
+// which are read into memory.
+const maxRangeDiffSize = 20 * 1024 * 1024
+
+// ErrRangeDiffTooLarge is returned by RangeDiff if the patch exceeds
+// maxRangeDiffSize.
+var ErrRangeDiffTooLarge = errors.Errorf("range diff is larger than %d MB", maxRangeDiffSize/1024/1024)
+
+// RangeDiff returns the patch between the given commits, without a/ and b/
+// prefixes on file names. This is the same format produced by git diff-tree
+// for the commit diffs used by diff search. ErrRangeDiffTooLarge is returned
+// if the patch exceeds maxRangeDiffSize.
+func RangeDiff(ctx context.Context, repo api.RepoName, base, head api.CommitID) ([]byte, error) {
+	reader, err := execReader(ctx, repo, []string{"diff", "--no-prefix", string(base), string(head), "--"})
+	if err != nil {
+		return nil, err
+	}
+	// Closing the reader early stops the command.
+	defer reader.Close()
+
+	output, err := io.ReadAll(io.LimitReader(reader, maxRangeDiffSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(output) > maxRangeDiffSize {
+		return nil, ErrRangeDiffTooLarge
+	}
+	return output, nil
+}
+
 // DiffSymbols performs a diff command which is expected to be parsed by our symbols package
 func DiffSymbols(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID) ([]byte, error) {
 	command := gitserver.DefaultClient.Command("git", "diff", "-z", "--name-status", "--no-renames", string(commitA), string(commitB))
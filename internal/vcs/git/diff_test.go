@@ -165,6 +165,36 @@ index 9bd8209..d2acfa9 100644
 	})
 }
 
+func TestRangeDiff(t *testing.T) {
+	ctx := context.Background()
+	defer ResetMocks()
+
+	t.Run("returns patch", func(t *testing.T) {
+		const patch = "diff --git README.md README.md\n"
+		Mocks.ExecReader = func(args []string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(patch)), nil
+		}
+
+		have, err := RangeDiff(ctx, "repo", "base", "head")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(have) != patch {
+			t.Errorf("unexpected patch: %q", have)
+		}
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		Mocks.ExecReader = func(args []string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(strings.Repeat("+", maxRangeDiffSize+1))), nil
+		}
+
+		if _, err := RangeDiff(ctx, "repo", "base", "head"); err != ErrRangeDiffTooLarge {
+			t.Errorf("unexpected error: have %v, want %v", err, ErrRangeDiffTooLarge)
+		}
+	})
+}
+
 func TestDiffFileIterator(t *testing.T) {
 	t.Run("Close", func(t *testing.T) {
 		c := new(closer)
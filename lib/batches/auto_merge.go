@@ -0,0 +1,62 @@
+package batches
+
+// AutoMerge is the policy that decides which changesets of a batch change are
+// merged automatically, and when.
+type AutoMerge struct {
+	Method              AutoMergeMethod          `json:"method,omitempty" yaml:"method"`
+	RequiredCheckState  AutoMergeCheckState      `json:"requiredCheckState,omitempty" yaml:"requiredCheckState"`
+	RequiredReviewState AutoMergeReviewState     `json:"requiredReviewState,omitempty" yaml:"requiredReviewState"`
+	MaxMergesPerHour    int                      `json:"maxMergesPerHour,omitempty" yaml:"maxMergesPerHour"`
+	RolloutWindows      []AutoMergeRolloutWindow `json:"rolloutWindows,omitempty" yaml:"rolloutWindows"`
+}
+
+// AutoMergeRolloutWindow is a time window in which changesets are merged
+// automatically. Times are in UTC.
+type AutoMergeRolloutWindow struct {
+	Days  []string `json:"days,omitempty" yaml:"days"`
+	Start string   `json:"start,omitempty" yaml:"start"`
+	End   string   `json:"end,omitempty" yaml:"end"`
+}
+
+// AutoMergeMethod is the method used to merge changesets automatically.
+type AutoMergeMethod string
+
+const (
+	AutoMergeMethodMerge  AutoMergeMethod = "merge"
+	AutoMergeMethodSquash AutoMergeMethod = "squash"
+)
+
+// AutoMergeCheckState is the state the checks of a changeset need to be in for
+// it to be merged automatically.
+type AutoMergeCheckState string
+
+const (
+	AutoMergeCheckStatePassed AutoMergeCheckState = "passed"
+	AutoMergeCheckStateAny    AutoMergeCheckState = "any"
+)
+
+// AutoMergeReviewState is the review state a changeset needs to be in for it
+// to be merged automatically.
+type AutoMergeReviewState string
+
+const (
+	AutoMergeReviewStateApproved AutoMergeReviewState = "approved"
+	AutoMergeReviewStateAny      AutoMergeReviewState = "any"
+)
+
+// Squash returns true if changesets should be squash merged.
+func (a *AutoMerge) Squash() bool {
+	return a.Method == AutoMergeMethodSquash
+}
+
+// RequiresPassedChecks returns true if only changesets whose checks passed
+// may be merged. This is the default.
+func (a *AutoMerge) RequiresPassedChecks() bool {
+	return a.RequiredCheckState != AutoMergeCheckStateAny
+}
+
+// RequiresApproval returns true if only approved changesets may be merged.
+// This is the default.
+func (a *AutoMerge) RequiresApproval() bool {
+	return a.RequiredReviewState != AutoMergeReviewStateAny
+}
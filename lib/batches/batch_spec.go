@@ -30,14 +30,16 @@ import (
 //    pointers, which is ugly and inefficient.
 
 type BatchSpec struct {
-	Name              string                   `json:"name,omitempty" yaml:"name"`
-	Description       string                   `json:"description,omitempty" yaml:"description"`
-	On                []OnQueryOrRepository    `json:"on,omitempty" yaml:"on"`
-	Workspaces        []WorkspaceConfiguration `json:"workspaces,omitempty"  yaml:"workspaces"`
-	Steps             []Step                   `json:"steps,omitempty" yaml:"steps"`
-	TransformChanges  *TransformChanges        `json:"transformChanges,omitempty" yaml:"transformChanges,omitempty"`
-	ImportChangesets  []ImportChangeset        `json:"importChangesets,omitempty" yaml:"importChangesets"`
-	ChangesetTemplate *ChangesetTemplate       `json:"changesetTemplate,omitempty" yaml:"changesetTemplate"`
+	Name                  string                   `json:"name,omitempty" yaml:"name"`
+	Description           string                   `json:"description,omitempty" yaml:"description"`
+	On                    []OnQueryOrRepository    `json:"on,omitempty" yaml:"on"`
+	Workspaces            []WorkspaceConfiguration `json:"workspaces,omitempty"  yaml:"workspaces"`
+	Steps                 []Step                   `json:"steps,omitempty" yaml:"steps"`
+	TransformChanges      *TransformChanges        `json:"transformChanges,omitempty" yaml:"transformChanges,omitempty"`
+	ImportChangesets      []ImportChangeset        `json:"importChangesets,omitempty" yaml:"importChangesets"`
+	ChangesetTemplate     *ChangesetTemplate       `json:"changesetTemplate,omitempty" yaml:"changesetTemplate"`
+	ChangesetDependencies []ChangesetDependency    `json:"changesetDependencies,omitempty" yaml:"changesetDependencies"`
+	AutoMerge             *AutoMerge               `json:"autoMerge,omitempty" yaml:"autoMerge,omitempty"`
 }
 
 type ChangesetTemplate struct {
@@ -184,6 +186,12 @@ func parseBatchSpec(schema string, data []byte, opts ParseBatchSpecOptions) (*Ba
 		errs = multierror.Append(errs, NewValidationError(errors.New("batch spec includes workspaces, which is not supported in this Sourcegraph version")))
 	}
 
+	for i, dep := range spec.ChangesetDependencies {
+		if err := dep.validate(); err != nil {
+			errs = multierror.Append(errs, NewValidationError(errors.Wrapf(err, "changesetDependencies entry %d", i+1)))
+		}
+	}
+
 	if !opts.AllowConditionalExec {
 		for i, step := range spec.Steps {
 			if step.IfCondition() != "" {
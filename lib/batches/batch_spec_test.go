@@ -237,6 +237,113 @@ changesetTemplate:
 		wantErr := `1 error occurred:
 	* step 1 in batch spec uses the 'files' attribute to create files in the step container, which is not supported in this Batch Changes version
 
+`
+		haveErr := err.Error()
+		if haveErr != wantErr {
+			t.Fatalf("wrong error. want=%q, have=%q", wantErr, haveErr)
+		}
+	})
+
+	t.Run("invalid changeset dependency pattern", func(t *testing.T) {
+		const spec = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  published: false
+changesetDependencies:
+  - repository: github.com/sourcegraph/frontend
+    dependsOn: ["github.com/sourcegraph/[lib"]
+`
+
+		_, err := ParseBatchSpec([]byte(spec), ParseBatchSpecOptions{})
+		if err == nil {
+			t.Fatal("no error returned")
+		}
+
+		wantErr := `1 error occurred:
+	* changesetDependencies entry 1: compiling dependsOn pattern "github.com/sourcegraph/[lib": unexpected end of input
+
+`
+		haveErr := err.Error()
+		if haveErr != wantErr {
+			t.Fatalf("wrong error. want=%q, have=%q", wantErr, haveErr)
+		}
+	})
+
+	t.Run("auto merge policy", func(t *testing.T) {
+		const spec = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  published: true
+autoMerge:
+  method: squash
+  requiredCheckState: any
+  maxMergesPerHour: 10
+  rolloutWindows:
+    - days: [saturday, sunday]
+      start: "10:00"
+      end: "18:00"
+`
+
+		have, err := ParseBatchSpec([]byte(spec), ParseBatchSpecOptions{})
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+
+		want := &AutoMerge{
+			Method:             AutoMergeMethodSquash,
+			RequiredCheckState: AutoMergeCheckStateAny,
+			MaxMergesPerHour:   10,
+			RolloutWindows: []AutoMergeRolloutWindow{
+				{Days: []string{"saturday", "sunday"}, Start: "10:00", End: "18:00"},
+			},
+		}
+		assert.Equal(t, want, have.AutoMerge)
+		assert.True(t, have.AutoMerge.Squash())
+		assert.False(t, have.AutoMerge.RequiresPassedChecks())
+		assert.True(t, have.AutoMerge.RequiresApproval())
+	})
+
+	t.Run("invalid auto merge method", func(t *testing.T) {
+		const spec = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  published: true
+autoMerge:
+  method: rebase
+`
+
+		_, err := ParseBatchSpec([]byte(spec), ParseBatchSpecOptions{})
+		if err == nil {
+			t.Fatal("no error returned")
+		}
+
+		wantErr := `1 error occurred:
+	* autoMerge.method: autoMerge.method must be one of the following: "merge", "squash"
+
 `
 		haveErr := err.Error()
 		if haveErr != wantErr {
@@ -0,0 +1,177 @@
+package batches
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gobwas/glob"
+)
+
+// ChangesetDependency declares that the changesets in the repositories
+// matching Repository depend on the changesets in the repositories matching
+// any of the DependsOn patterns. Both fields are glob patterns matched against
+// repository names.
+type ChangesetDependency struct {
+	Repository string   `json:"repository" yaml:"repository"`
+	DependsOn  []string `json:"dependsOn" yaml:"dependsOn"`
+}
+
+func (d ChangesetDependency) validate() error {
+	_, _, err := d.compile()
+	return err
+}
+
+func (d ChangesetDependency) compile() (glob.Glob, []glob.Glob, error) {
+	repository, err := glob.Compile(d.Repository)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "compiling repository pattern %q", d.Repository)
+	}
+
+	dependsOn := make([]glob.Glob, 0, len(d.DependsOn))
+	for _, pattern := range d.DependsOn {
+		g, err := glob.Compile(pattern)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "compiling dependsOn pattern %q", pattern)
+		}
+		dependsOn = append(dependsOn, g)
+	}
+
+	return repository, dependsOn, nil
+}
+
+// ChangesetDependencyGraph is the directed acyclic graph of dependencies
+// between the repositories of a batch change, as declared by the
+// changesetDependencies of a batch spec.
+type ChangesetDependencyGraph struct {
+	prerequisites map[string][]string
+	dependents    map[string][]string
+}
+
+// NewChangesetDependencyGraph resolves the given dependencies against the
+// given repository names. A repository never depends on itself, even if it is
+// matched by one of its own dependsOn patterns. An error is returned if a
+// pattern is invalid or if the dependencies form a cycle.
+func NewChangesetDependencyGraph(deps []ChangesetDependency, repos []string) (*ChangesetDependencyGraph, error) {
+	g := &ChangesetDependencyGraph{
+		prerequisites: map[string][]string{},
+		dependents:    map[string][]string{},
+	}
+
+	edges := map[[2]string]struct{}{}
+	for _, dep := range deps {
+		repository, dependsOn, err := dep.compile()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, repo := range repos {
+			if !repository.Match(repo) {
+				continue
+			}
+
+			for _, prereq := range repos {
+				if prereq == repo || !matchesAny(dependsOn, prereq) {
+					continue
+				}
+
+				edge := [2]string{repo, prereq}
+				if _, ok := edges[edge]; ok {
+					continue
+				}
+				edges[edge] = struct{}{}
+
+				g.prerequisites[repo] = append(g.prerequisites[repo], prereq)
+				g.dependents[prereq] = append(g.dependents[prereq], repo)
+			}
+		}
+	}
+
+	for _, m := range []map[string][]string{g.prerequisites, g.dependents} {
+		for _, names := range m {
+			sort.Strings(names)
+		}
+	}
+
+	if cycle := g.findCycle(); cycle != nil {
+		return nil, errors.Errorf("changeset dependencies contain a cycle: %s", strings.Join(cycle, " -> "))
+	}
+
+	return g, nil
+}
+
+// Prerequisites returns the names of the repositories whose changesets the
+// changesets in the given repository depend on.
+func (g *ChangesetDependencyGraph) Prerequisites(repo string) []string {
+	return g.prerequisites[repo]
+}
+
+// Dependents returns the names of the repositories whose changesets depend on
+// the changesets in the given repository.
+func (g *ChangesetDependencyGraph) Dependents(repo string) []string {
+	return g.dependents[repo]
+}
+
+// findCycle returns the repositories forming a cycle, with the first
+// repository repeated at the end, or nil if the graph is acyclic.
+func (g *ChangesetDependencyGraph) findCycle() []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	repos := make([]string, 0, len(g.prerequisites))
+	for repo := range g.prerequisites {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+
+	state := map[string]int{}
+	var path []string
+
+	var visit func(repo string) []string
+	visit = func(repo string) []string {
+		switch state[repo] {
+		case visited:
+			return nil
+		case visiting:
+			for i, r := range path {
+				if r == repo {
+					return append(append([]string{}, path[i:]...), repo)
+				}
+			}
+		}
+
+		state[repo] = visiting
+		path = append(path, repo)
+		for _, prereq := range g.prerequisites[repo] {
+			if cycle := visit(prereq); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[repo] = visited
+
+		return nil
+	}
+
+	for _, repo := range repos {
+		if state[repo] == unvisited {
+			if cycle := visit(repo); cycle != nil {
+				return cycle
+			}
+		}
+	}
+
+	return nil
+}
+
+func matchesAny(globs []glob.Glob, name string) bool {
+	for _, g := range globs {
+		if g.Match(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package batches
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewChangesetDependencyGraph(t *testing.T) {
+	repos := []string{
+		"github.com/sourcegraph/lib",
+		"github.com/sourcegraph/api",
+		"github.com/sourcegraph/frontend",
+		"github.com/sourcegraph/docs",
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		g, err := NewChangesetDependencyGraph([]ChangesetDependency{
+			{Repository: "github.com/sourcegraph/api", DependsOn: []string{"github.com/sourcegraph/lib"}},
+			{Repository: "github.com/sourcegraph/frontend", DependsOn: []string{"github.com/sourcegraph/api", "github.com/sourcegraph/lib"}},
+			// The pattern also matches docs itself, which is ignored.
+			{Repository: "github.com/sourcegraph/docs", DependsOn: []string{"github.com/sourcegraph/*"}},
+		}, repos)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for repo, want := range map[string][]string{
+			"github.com/sourcegraph/lib":      nil,
+			"github.com/sourcegraph/api":      {"github.com/sourcegraph/lib"},
+			"github.com/sourcegraph/frontend": {"github.com/sourcegraph/api", "github.com/sourcegraph/lib"},
+			"github.com/sourcegraph/docs":     {"github.com/sourcegraph/api", "github.com/sourcegraph/frontend", "github.com/sourcegraph/lib"},
+		} {
+			if diff := cmp.Diff(want, g.Prerequisites(repo)); diff != "" {
+				t.Errorf("wrong prerequisites of %s (-want +got):\n%s", repo, diff)
+			}
+		}
+
+		for repo, want := range map[string][]string{
+			"github.com/sourcegraph/lib":      {"github.com/sourcegraph/api", "github.com/sourcegraph/docs", "github.com/sourcegraph/frontend"},
+			"github.com/sourcegraph/api":      {"github.com/sourcegraph/docs", "github.com/sourcegraph/frontend"},
+			"github.com/sourcegraph/frontend": {"github.com/sourcegraph/docs"},
+			"github.com/sourcegraph/docs":     nil,
+		} {
+			if diff := cmp.Diff(want, g.Dependents(repo)); diff != "" {
+				t.Errorf("wrong dependents of %s (-want +got):\n%s", repo, diff)
+			}
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		_, err := NewChangesetDependencyGraph([]ChangesetDependency{
+			{Repository: "github.com/sourcegraph/api", DependsOn: []string{"github.com/sourcegraph/lib"}},
+			{Repository: "github.com/sourcegraph/lib", DependsOn: []string{"github.com/sourcegraph/frontend"}},
+			{Repository: "github.com/sourcegraph/frontend", DependsOn: []string{"github.com/sourcegraph/api"}},
+		}, repos)
+		if err == nil {
+			t.Fatal("no error returned")
+		}
+
+		want := "changeset dependencies contain a cycle: github.com/sourcegraph/api -> github.com/sourcegraph/lib -> github.com/sourcegraph/frontend -> github.com/sourcegraph/api"
+		if have := err.Error(); have != want {
+			t.Fatalf("wrong error. want=%q, have=%q", want, have)
+		}
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := NewChangesetDependencyGraph([]ChangesetDependency{
+			{Repository: "github.com/sourcegraph/api", DependsOn: []string{"github.com/sourcegraph/[lib"}},
+		}, repos)
+		if err == nil || !strings.Contains(err.Error(), "compiling dependsOn pattern") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
@@ -285,6 +285,95 @@ const BatchSpecJSON = `{
         }
       }
     },
+    "changesetDependencies": {
+      "type": ["array", "null"],
+      "description": "Dependencies between the changesets of the batch change in different repositories. A changeset that depends on other changesets is held back until all of them are merged: it is published as a draft on code hosts that support drafts, and left unpublished otherwise.",
+      "items": {
+        "title": "ChangesetDependency",
+        "type": "object",
+        "description": "Declares that the changesets in the matching repositories depend on the changesets in other repositories.",
+        "additionalProperties": false,
+        "required": ["repository", "dependsOn"],
+        "properties": {
+          "repository": {
+            "type": "string",
+            "description": "The repositories whose changesets depend on the changesets in the dependsOn repositories. Supports globbing.",
+            "examples": ["github.com/sourcegraph/*"]
+          },
+          "dependsOn": {
+            "type": "array",
+            "description": "The repositories whose changesets have to be merged before the changesets in the matching repositories are published. Supports globbing.",
+            "minItems": 1,
+            "items": {
+              "type": "string"
+            },
+            "examples": [["github.com/sourcegraph/lib"]]
+          }
+        }
+      }
+    },
+    "autoMerge": {
+      "type": "object",
+      "description": "A policy to automatically merge the open changesets published by the batch change once they satisfy it. Omit to disable automatic merging.",
+      "additionalProperties": false,
+      "properties": {
+        "method": {
+          "type": "string",
+          "description": "How changesets are merged. Code hosts that don't support squash merges fall back to a regular merge.",
+          "enum": ["merge", "squash"],
+          "default": "merge"
+        },
+        "requiredCheckState": {
+          "type": "string",
+          "description": "The state the checks of a changeset need to be in for it to be merged. Use \"any\" to merge changesets regardless of their checks.",
+          "enum": ["passed", "any"],
+          "default": "passed"
+        },
+        "requiredReviewState": {
+          "type": "string",
+          "description": "The review state a changeset needs to be in for it to be merged. Use \"any\" to merge changesets regardless of their reviews.",
+          "enum": ["approved", "any"],
+          "default": "approved"
+        },
+        "maxMergesPerHour": {
+          "type": "integer",
+          "description": "The maximum number of changesets of the batch change that are merged automatically in any hour. If omitted, changesets are merged as soon as they satisfy the policy.",
+          "minimum": 1
+        },
+        "rolloutWindows": {
+          "type": "array",
+          "description": "Time windows in which changesets are merged. If omitted, changesets are merged at any time.",
+          "items": {
+            "title": "AutoMergeRolloutWindow",
+            "type": "object",
+            "additionalProperties": false,
+            "properties": {
+              "start": {
+                "description": "Window start time, in UTC. If omitted, no time window is applied to the day(s) that match this rule.",
+                "type": "string",
+                "pattern": "^[0-9]?[0-9]:[0-9]{2}$"
+              },
+              "end": {
+                "description": "Window end time, in UTC. If omitted, no time window is applied to the day(s) that match this rule.",
+                "type": "string",
+                "pattern": "^[0-9]?[0-9]:[0-9]{2}$"
+              },
+              "days": {
+                "description": "Day(s) the window applies to. If omitted, this rule applies to all days of the week.",
+                "type": "array",
+                "items": {
+                  "type": "string",
+                  "pattern": "^([mM]on(day)?|[tT]ue(s|sday)?|[wW]ed(nesday)?|[tT]hu(r|rs|rsday)?|[fF]ri(day)?|[sS]at(urday)?|[sS]un(day)?)$"
+                }
+              }
+            },
+            "dependencies": {
+              "start": ["end"]
+            }
+          }
+        }
+      }
+    },
     "changesetTemplate": {
       "type": "object",
       "description": "A template describing how to create (and update) changesets with the file changes produced by the command steps.",
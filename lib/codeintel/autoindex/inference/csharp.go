@@ -0,0 +1,62 @@
+package inference
+
+import (
+	"path/filepath"
+	"regexp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func CSharpPatterns() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		extensionPattern(rawPattern("sln")),
+		extensionPattern(rawPattern("csproj")),
+	}
+}
+
+// There is no published image containing lsif-dotnet, so the LsifDotnet tool
+// is installed into the .NET SDK image before indexing.
+const (
+	dotnetSDKImage           = "mcr.microsoft.com/dotnet/sdk:6.0"
+	lsifDotnetInstallCommand = "dotnet tool install LsifDotnet --tool-path /usr/local/bin"
+)
+
+func InferCSharpIndexJobs(gitclient GitClient, paths []string) (indexes []config.IndexJob) {
+	// A solution file references the projects that belong to it, so we prefer to
+	// index solutions and only fall back to indexing each project on its own when
+	// the repository does not contain any solution.
+	projectPaths := filterPaths(paths, isDotnetSolutionPath)
+	if len(projectPaths) == 0 {
+		projectPaths = filterPaths(paths, isCSharpProjectPath)
+	}
+
+	for _, path := range projectPaths {
+		root := dirWithoutDot(path)
+		name := filepath.Base(path)
+
+		indexes = append(indexes, config.IndexJob{
+			Steps: []config.DockerStep{
+				{
+					Root:     root,
+					Image:    dotnetSDKImage,
+					Commands: []string{"dotnet restore " + name},
+				},
+			},
+			LocalSteps:  []string{lsifDotnetInstallCommand},
+			Root:        root,
+			Indexer:     dotnetSDKImage,
+			IndexerArgs: []string{"lsif-dotnet", name, "--output", "dump.lsif"},
+			Outfile:     "dump.lsif",
+		})
+	}
+
+	return indexes
+}
+
+func isDotnetSolutionPath(path string) bool {
+	return filepath.Ext(path) == ".sln" && containsNoSegments(path, segmentBlockList...)
+}
+
+func isCSharpProjectPath(path string) bool {
+	return filepath.Ext(path) == ".csproj" && containsNoSegments(path, segmentBlockList...)
+}
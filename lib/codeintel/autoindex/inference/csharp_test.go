@@ -0,0 +1,77 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestCSharpPatterns(t *testing.T) {
+	testLangPatterns(t, CSharpPatterns(), []PathTestCase{
+		{"App.sln", true},
+		{"subdir/App.sln", true},
+		{"App.csproj", true},
+		{"src/App/App.csproj", true},
+		{"App.csproj/subdir", false},
+		{"Program.cs", false},
+	})
+}
+
+func TestInferCSharpIndexJobs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		paths    []string
+		expected []config.IndexJob
+	}{
+		{
+			name:  "solution",
+			paths: []string{"App.sln", "src/App/App.csproj", "src/Lib/Lib.csproj"},
+			expected: []config.IndexJob{
+				newCSharpIndexJob("", "App.sln"),
+			},
+		},
+		{
+			name:  "multiple solutions",
+			paths: []string{"a/A.sln", "b/B.sln", "tests/Tests.sln"},
+			expected: []config.IndexJob{
+				newCSharpIndexJob("a", "A.sln"),
+				newCSharpIndexJob("b", "B.sln"),
+			},
+		},
+		{
+			name:  "projects",
+			paths: []string{"src/App/App.csproj", "src/Lib/Lib.csproj"},
+			expected: []config.IndexJob{
+				newCSharpIndexJob("src/App", "App.csproj"),
+				newCSharpIndexJob("src/Lib", "Lib.csproj"),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if diff := cmp.Diff(testCase.expected, InferCSharpIndexJobs(NewMockGitClient(), testCase.paths)); diff != "" {
+				t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func newCSharpIndexJob(root, name string) config.IndexJob {
+	return config.IndexJob{
+		Steps: []config.DockerStep{
+			{
+				Root:     root,
+				Image:    "mcr.microsoft.com/dotnet/sdk:6.0",
+				Commands: []string{"dotnet restore " + name},
+			},
+		},
+		LocalSteps:  []string{"dotnet tool install LsifDotnet --tool-path /usr/local/bin"},
+		Root:        root,
+		Indexer:     "mcr.microsoft.com/dotnet/sdk:6.0",
+		IndexerArgs: []string{"lsif-dotnet", name, "--output", "dump.lsif"},
+		Outfile:     "dump.lsif",
+	}
+}
@@ -45,11 +45,12 @@ func javaBuildTool(paths []string) string {
 			}
 			return ""
 		}
-		// Maven, Gradle and sbt are intentionally left out to begin
-		// with as we gain more experience with auto-indexing package
-		// repos, which have a higher likelyhood of indexing
-		// successfully because they have a simpler build structure
-		// compared to Gradle/Maven repos.
+		// Maven and Gradle are intentionally left out to begin with as
+		// we gain more experience with auto-indexing package repos,
+		// which have a higher likelyhood of indexing successfully
+		// because they have a simpler build structure compared to
+		// Gradle/Maven repos. sbt builds are inferred by
+		// InferScalaIndexJobs instead, see there for why.
 	}
 	return ""
 }
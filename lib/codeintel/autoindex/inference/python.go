@@ -0,0 +1,79 @@
+package inference
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func PythonPatterns() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		pathPattern(rawPattern("pyproject.toml")),
+		pathPattern(rawPattern("setup.py")),
+		pathPattern(rawPattern("requirements.txt")),
+	}
+}
+
+const lsifPyImage = "sourcegraph/lsif-py:latest"
+
+func InferPythonIndexJobs(gitclient GitClient, paths []string) (indexes []config.IndexJob) {
+	for _, root := range pythonProjectRoots(paths) {
+		var commands []string
+		if contains(paths, filepath.Join(root, "requirements.txt")) {
+			commands = append(commands, "pip install -r requirements.txt")
+		}
+		if contains(paths, filepath.Join(root, "pyproject.toml")) || contains(paths, filepath.Join(root, "setup.py")) {
+			// Installs the project itself along with the dependencies declared in its
+			// package metadata, so that imports of the project's own modules resolve.
+			commands = append(commands, "pip install .")
+		}
+
+		indexes = append(indexes, config.IndexJob{
+			Steps: []config.DockerStep{
+				{
+					Root:     root,
+					Image:    lsifPyImage,
+					Commands: commands,
+				},
+			},
+			Root:        root,
+			Indexer:     lsifPyImage,
+			IndexerArgs: []string{"lsif-py", ".", "--file", "dump.lsif"},
+			Outfile:     "dump.lsif",
+		})
+	}
+
+	return indexes
+}
+
+// pythonProjectRoots returns the sorted set of directories that contain a file declaring
+// the dependencies of a Python project.
+func pythonProjectRoots(paths []string) []string {
+	rootMap := map[string]struct{}{}
+	for _, path := range paths {
+		if isPythonProjectPath(path) {
+			rootMap[dirWithoutDot(path)] = struct{}{}
+		}
+	}
+
+	roots := make([]string, 0, len(rootMap))
+	for root := range rootMap {
+		roots = append(roots, root)
+	}
+	sort.Strings(roots)
+
+	return roots
+}
+
+var pythonSegmentBlockList = append([]string{"venv", ".venv", "site-packages"}, segmentBlockList...)
+
+func isPythonProjectPath(path string) bool {
+	switch filepath.Base(path) {
+	case "pyproject.toml", "setup.py", "requirements.txt":
+		return containsNoSegments(path, pythonSegmentBlockList...)
+	}
+
+	return false
+}
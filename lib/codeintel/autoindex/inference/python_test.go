@@ -0,0 +1,89 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestPythonPatterns(t *testing.T) {
+	testLangPatterns(t, PythonPatterns(), []PathTestCase{
+		{"pyproject.toml", true},
+		{"setup.py", true},
+		{"subdir/setup.py", true},
+		{"requirements.txt", true},
+		{"subdir/requirements.txt", true},
+		{"dev-requirements.txt", false},
+		{"setup.py/subdir", false},
+		{"main.py", false},
+	})
+}
+
+func TestInferPythonIndexJobs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		paths    []string
+		expected []config.IndexJob
+	}{
+		{
+			name:  "requirements",
+			paths: []string{"requirements.txt"},
+			expected: []config.IndexJob{
+				newPythonIndexJob("", "pip install -r requirements.txt"),
+			},
+		},
+		{
+			name:  "package",
+			paths: []string{"setup.py", "requirements.txt", "pyproject.toml"},
+			expected: []config.IndexJob{
+				newPythonIndexJob("", "pip install -r requirements.txt", "pip install ."),
+			},
+		},
+		{
+			name:  "subdirs",
+			paths: []string{"b/pyproject.toml", "a/setup.py", "a/requirements.txt"},
+			expected: []config.IndexJob{
+				newPythonIndexJob("a", "pip install -r requirements.txt", "pip install ."),
+				newPythonIndexJob("b", "pip install ."),
+			},
+		},
+		{
+			name:  "blocked segments",
+			paths: []string{"pyproject.toml", "venv/lib/setup.py", "tests/requirements.txt"},
+			expected: []config.IndexJob{
+				newPythonIndexJob("", "pip install ."),
+			},
+		},
+		{
+			name:     "no project",
+			paths:    []string{"main.py"},
+			expected: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if diff := cmp.Diff(testCase.expected, InferPythonIndexJobs(NewMockGitClient(), testCase.paths)); diff != "" {
+				t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func newPythonIndexJob(root string, commands ...string) config.IndexJob {
+	return config.IndexJob{
+		Steps: []config.DockerStep{
+			{
+				Root:     root,
+				Image:    lsifPyImage,
+				Commands: commands,
+			},
+		},
+		Root:        root,
+		Indexer:     lsifPyImage,
+		IndexerArgs: []string{"lsif-py", ".", "--file", "dump.lsif"},
+		Outfile:     "dump.lsif",
+	}
+}
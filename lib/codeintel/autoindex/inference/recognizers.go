@@ -20,11 +20,18 @@ type IndexJobRecognizer interface {
 }
 
 // Recognizers is a list of registered index job recognizers.
+//
+// There is no recognizer for Ruby: there is no LSIF indexer for Ruby whose
+// output the upload pipeline accepts, so a Gemfile can't be turned into an
+// index job that indexes the project.
 var Recognizers = map[string]IndexJobRecognizer{
-	"go":   recognizer{GoPatterns, InferGoIndexJobs},
-	"tsc":  recognizer{TypeScriptPatterns, InferTypeScriptIndexJobs},
-	"java": recognizer{JavaPatterns, InferJavaIndexJobs},
-	"rust": recognizer{RustPatterns, InferRustIndexJobs},
+	"go":     recognizer{GoPatterns, InferGoIndexJobs},
+	"tsc":    recognizer{TypeScriptPatterns, InferTypeScriptIndexJobs},
+	"java":   recognizer{JavaPatterns, InferJavaIndexJobs},
+	"rust":   recognizer{RustPatterns, InferRustIndexJobs},
+	"python": recognizer{PythonPatterns, InferPythonIndexJobs},
+	"csharp": recognizer{CSharpPatterns, InferCSharpIndexJobs},
+	"scala":  recognizer{ScalaPatterns, InferScalaIndexJobs},
 }
 
 type recognizer struct {
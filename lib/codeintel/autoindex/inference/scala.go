@@ -0,0 +1,67 @@
+package inference
+
+import (
+	"path/filepath"
+	"regexp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func ScalaPatterns() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		pathPattern(rawPattern("build.sbt")),
+	}
+}
+
+const lsifJavaImage = "sourcegraph/lsif-java"
+
+// InferScalaIndexJobs infers an index job for each sbt build. Unlike Maven and
+// Gradle builds, which InferJavaIndexJobs leaves out, sbt builds are indexed:
+// a build.sbt file is declarative enough for lsif-java to index it without
+// further configuration, and the build is what tells lsif-java how to compile
+// Scala sources, which it can't index on their own.
+func InferScalaIndexJobs(gitclient GitClient, paths []string) (indexes []config.IndexJob) {
+	for _, path := range paths {
+		if !isSbtBuildPath(path) || hasAncestorSbtBuild(path, paths) {
+			// The build definition of an enclosing directory aggregates the
+			// subprojects, which are indexed along with it.
+			continue
+		}
+
+		root := dirWithoutDot(path)
+
+		indexes = append(indexes, config.IndexJob{
+			Steps: []config.DockerStep{
+				{
+					Root:  root,
+					Image: lsifJavaImage,
+					// Resolves the dependencies of the build and its plugins.
+					Commands: []string{"sbt update"},
+				},
+			},
+			Indexer: lsifJavaImage,
+			IndexerArgs: []string{
+				"lsif-java index --build-tool=sbt",
+			},
+			Outfile: "dump.lsif",
+			Root:    root,
+		})
+	}
+
+	return indexes
+}
+
+func isSbtBuildPath(path string) bool {
+	return filepath.Base(path) == "build.sbt" && containsNoSegments(path, segmentBlockList...)
+}
+
+func hasAncestorSbtBuild(path string, paths []string) bool {
+	// The first ancestor is the directory containing the given build definition
+	for _, dir := range ancestorDirs(path)[1:] {
+		if contains(paths, filepath.Join(dir, "build.sbt")) {
+			return true
+		}
+	}
+
+	return false
+}
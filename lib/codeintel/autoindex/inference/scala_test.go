@@ -0,0 +1,68 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestScalaPatterns(t *testing.T) {
+	testLangPatterns(t, ScalaPatterns(), []PathTestCase{
+		{"build.sbt", true},
+		{"subdir/build.sbt", true},
+		{"project/plugins.sbt", false},
+		{"build.sbt/subdir", false},
+	})
+}
+
+func TestInferScalaIndexJobs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		paths    []string
+		expected []config.IndexJob
+	}{
+		{
+			name:     "root",
+			paths:    []string{"build.sbt"},
+			expected: []config.IndexJob{newScalaIndexJob("")},
+		},
+		{
+			name:     "subprojects",
+			paths:    []string{"build.sbt", "core/build.sbt", "server/build.sbt"},
+			expected: []config.IndexJob{newScalaIndexJob("")},
+		},
+		{
+			name:     "independent builds",
+			paths:    []string{"a/build.sbt", "b/build.sbt", "b/sub/build.sbt"},
+			expected: []config.IndexJob{newScalaIndexJob("a"), newScalaIndexJob("b")},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if diff := cmp.Diff(testCase.expected, InferScalaIndexJobs(NewMockGitClient(), testCase.paths)); diff != "" {
+				t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func newScalaIndexJob(root string) config.IndexJob {
+	return config.IndexJob{
+		Steps: []config.DockerStep{
+			{
+				Root:     root,
+				Image:    "sourcegraph/lsif-java",
+				Commands: []string{"sbt update"},
+			},
+		},
+		Indexer: "sourcegraph/lsif-java",
+		IndexerArgs: []string{
+			"lsif-java index --build-tool=sbt",
+		},
+		Outfile: "dump.lsif",
+		Root:    root,
+	}
+}
@@ -9,3 +9,14 @@ func contains(haystack []string, needle string) bool {
 
 	return false
 }
+
+// filterPaths returns the paths for which the given predicate returns true.
+func filterPaths(paths []string, predicate func(path string) bool) (filtered []string) {
+	for _, path := range paths {
+		if predicate(path) {
+			filtered = append(filtered, path)
+		}
+	}
+
+	return filtered
+}
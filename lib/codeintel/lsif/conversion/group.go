@@ -24,7 +24,7 @@ const resultsPerResultChunk = 512
 
 // groupBundleData converts a raw (but canonicalized) correlation State into a GroupedBundleData.
 func groupBundleData(ctx context.Context, state *State) (*precise.GroupedBundleDataChans, error) {
-	numResults := len(state.DefinitionData) + len(state.ReferenceData) + len(state.ImplementationData)
+	numResults := len(state.DefinitionData) + len(state.ReferenceData) + len(state.ImplementationData) + len(state.TypeDefinitionData)
 	numResultChunks := int(math.Max(1, math.Floor(float64(numResults)/resultsPerResultChunk)))
 
 	meta := precise.MetaData{NumResultChunks: numResultChunks}
@@ -123,9 +123,11 @@ func serializeDocument(state *State, documentID int) precise.DocumentData {
 			DefinitionResultID:     toID(rangeData.DefinitionResultID),
 			ReferenceResultID:      toID(rangeData.ReferenceResultID),
 			ImplementationResultID: toID(rangeData.ImplementationResultID),
+			TypeDefinitionResultID: toID(rangeData.TypeDefinitionResultID),
 			HoverResultID:          toID(rangeData.HoverResultID),
 			DocumentationResultID:  toID(rangeData.DocumentationResultID),
 			MonikerIDs:             monikerIDs,
+			Symbol:                 serializeSymbol(rangeData),
 		}
 
 		if rangeData.HoverResultID != 0 {
@@ -152,6 +154,25 @@ func serializeDocument(state *State, documentID int) precise.DocumentData {
 	return document
 }
 
+// serializeSymbol returns the symbol defined at the given range, if the range is tagged as a
+// definition with a full range. The full range is necessary to determine which other ranges
+// are enclosed by the definition (e.g., the calls made from the body of a function).
+func serializeSymbol(rangeData Range) *precise.SymbolData {
+	tag := rangeData.Tag
+	if tag == nil || tag.Type != "definition" || tag.FullRange == nil {
+		return nil
+	}
+
+	return &precise.SymbolData{
+		Name:               tag.Text,
+		Kind:               tag.Kind,
+		FullStartLine:      tag.FullRange.Start.Line,
+		FullStartCharacter: tag.FullRange.Start.Character,
+		FullEndLine:        tag.FullRange.End.Line,
+		FullEndCharacter:   tag.FullRange.End.Character,
+	}
+}
+
 func serializeResultChunks(ctx context.Context, state *State, numResultChunks int) chan precise.IndexedResultChunkData {
 	type entry struct {
 		id     int
@@ -170,6 +191,10 @@ func serializeResultChunks(ctx context.Context, state *State, numResultChunks in
 		index := precise.HashKey(toID(id), numResultChunks)
 		chunkAssignments[index] = append(chunkAssignments[index], entry{id: id, ranges: ranges})
 	}
+	for id, ranges := range state.TypeDefinitionData {
+		index := precise.HashKey(toID(id), numResultChunks)
+		chunkAssignments[index] = append(chunkAssignments[index], entry{id: id, ranges: ranges})
+	}
 
 	ch := make(chan precise.IndexedResultChunkData)
 
@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP TABLE IF EXISTS cm_result_snapshots;
+
+ALTER TABLE
+    cm_queries
+DROP COLUMN IF EXISTS
+    mode,
+DROP COLUMN IF EXISTS
+    snapshot_at;
+
+COMMIT;
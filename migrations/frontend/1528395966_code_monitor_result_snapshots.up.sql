@@ -0,0 +1,23 @@
+BEGIN;
+
+ALTER TABLE
+    cm_queries
+ADD COLUMN IF NOT EXISTS
+    mode TEXT NOT NULL DEFAULT 'commits',
+ADD COLUMN IF NOT EXISTS
+    snapshot_at TIMESTAMP WITH TIME ZONE NULL DEFAULT NULL;
+
+COMMENT ON COLUMN cm_queries.mode IS 'How new results are detected: commits (with an after: filter) or content (by diffing the result keys against the snapshot of the last run).';
+COMMENT ON COLUMN cm_queries.snapshot_at IS 'When the result snapshots of a content query were last taken. NULL if the next run only takes a snapshot.';
+
+CREATE TABLE IF NOT EXISTS cm_result_snapshots (
+    query BIGINT NOT NULL REFERENCES cm_queries(id) ON DELETE CASCADE,
+    repo_name TEXT NOT NULL,
+    result_keys TEXT[] NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (query, repo_name)
+);
+
+COMMENT ON TABLE cm_result_snapshots IS 'The keys of the results of the last run of a content query, per repository.';
+
+COMMIT;
@@ -131,6 +131,27 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab"})
 }
 
+// AutoMerge description: A policy to automatically merge the open changesets published by the batch change once they satisfy it. Omit to disable automatic merging.
+type AutoMerge struct {
+	// MaxMergesPerHour description: The maximum number of changesets of the batch change that are merged automatically in any hour. If omitted, changesets are merged as soon as they satisfy the policy.
+	MaxMergesPerHour int `json:"maxMergesPerHour,omitempty"`
+	// Method description: How changesets are merged. Code hosts that don't support squash merges fall back to a regular merge.
+	Method string `json:"method,omitempty"`
+	// RequiredCheckState description: The state the checks of a changeset need to be in for it to be merged. Use "any" to merge changesets regardless of their checks.
+	RequiredCheckState string `json:"requiredCheckState,omitempty"`
+	// RequiredReviewState description: The review state a changeset needs to be in for it to be merged. Use "any" to merge changesets regardless of their reviews.
+	RequiredReviewState string `json:"requiredReviewState,omitempty"`
+	// RolloutWindows description: Time windows in which changesets are merged. If omitted, changesets are merged at any time.
+	RolloutWindows []*AutoMergeRolloutWindow `json:"rolloutWindows,omitempty"`
+}
+type AutoMergeRolloutWindow struct {
+	// Days description: Day(s) the window applies to. If omitted, this rule applies to all days of the week.
+	Days []string `json:"days,omitempty"`
+	// End description: Window end time, in UTC. If omitted, no time window is applied to the day(s) that match this rule.
+	End string `json:"end,omitempty"`
+	// Start description: Window start time, in UTC. If omitted, no time window is applied to the day(s) that match this rule.
+	Start string `json:"start,omitempty"`
+}
 type BackendInsight struct {
 	// Description description: The description of this insight
 	Description string          `json:"description,omitempty"`
@@ -161,6 +182,10 @@ type BatchChangeRolloutWindow struct {
 
 // BatchSpec description: A batch specification, which describes the batch change and what kinds of changes to make (or what existing changesets to track).
 type BatchSpec struct {
+	// AutoMerge description: A policy to automatically merge the open changesets published by the batch change once they satisfy it. Omit to disable automatic merging.
+	AutoMerge *AutoMerge `json:"autoMerge,omitempty"`
+	// ChangesetDependencies description: Dependencies between the changesets of the batch change in different repositories. A changeset that depends on other changesets is held back until all of them are merged: it is published as a draft on code hosts that support drafts, and left unpublished otherwise.
+	ChangesetDependencies []*ChangesetDependency `json:"changesetDependencies,omitempty"`
 	// ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 	ChangesetTemplate *ChangesetTemplate `json:"changesetTemplate,omitempty"`
 	// Description description: The description of the batch change.
@@ -195,6 +220,10 @@ type BitbucketCloudConnection struct {
 	//
 	// If "ssh", Sourcegraph will access Bitbucket Cloud repositories using Git URLs of the form git@bitbucket.org:myteam/myproject.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
 	GitURLType string `json:"gitURLType,omitempty"`
+	// GitserverDiskQuota description: Maximum amount of disk space the repositories of this code host may use on each gitserver. The size is in bytes and may have a k, m, g or t suffix. When the repositories exceed the quota, gitservers remove them according to the gitServerEviction site configuration until they fit. If unset, there is no quota.
+	GitserverDiskQuota string `json:"gitserverDiskQuota,omitempty"`
+	// PartialClones description: Repositories to clone as partial clones, which omit large blobs until they are needed. Missing blobs are fetched from the code host on demand. Use this for very large repositories which take long to clone or use lots of disk space. If the code host does not support partial clones, the repositories are fully cloned.
+	PartialClones []*BitbucketCloudPartialClone `json:"partialClones,omitempty"`
 	// RateLimit description: Rate limit applied when making background API requests to Bitbucket Cloud.
 	RateLimit *BitbucketCloudRateLimit `json:"rateLimit,omitempty"`
 	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for a Bitbucket Cloud repository.
@@ -211,6 +240,14 @@ type BitbucketCloudConnection struct {
 	Url string `json:"url"`
 	// Username description: The username to use when authenticating to the Bitbucket Cloud. Also set the corresponding "appPassword" field.
 	Username string `json:"username"`
+	// WebhookSecret description: A shared secret used to authenticate incoming webhook payloads. Bitbucket Cloud webhooks do not sign their payloads, so the secret must be appended to the webhook URL configured in Bitbucket Cloud as the "secret" query parameter.
+	WebhookSecret string `json:"webhookSecret,omitempty"`
+}
+type BitbucketCloudPartialClone struct {
+	// BlobLimit description: Blobs larger than this size in bytes are omitted. The size may have a k, m or g suffix. A limit of 0 omits all blobs.
+	BlobLimit string `json:"blobLimit,omitempty"`
+	// Pattern description: Regular expression which matches the Sourcegraph names of the repositories to clone partially.
+	Pattern string `json:"pattern"`
 }
 
 // BitbucketCloudRateLimit description: Rate limit applied when making background API requests to Bitbucket Cloud.
@@ -247,8 +284,12 @@ type BitbucketServerConnection struct {
 	//
 	// If "ssh", Sourcegraph will access Bitbucket Server repositories using Git URLs of the form ssh://git@example.bitbucket.com/myproject/myrepo.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
 	GitURLType string `json:"gitURLType,omitempty"`
+	// GitserverDiskQuota description: Maximum amount of disk space the repositories of this code host may use on each gitserver. The size is in bytes and may have a k, m, g or t suffix. When the repositories exceed the quota, gitservers remove them according to the gitServerEviction site configuration until they fit. If unset, there is no quota.
+	GitserverDiskQuota string `json:"gitserverDiskQuota,omitempty"`
 	// InitialRepositoryEnablement description: Deprecated and ignored field which will be removed entirely in the next release. BitBucket repositories can no longer be enabled or disabled explicitly.
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
+	// PartialClones description: Repositories to clone as partial clones, which omit large blobs until they are needed. Missing blobs are fetched from the code host on demand. Use this for very large repositories which take long to clone or use lots of disk space. If the code host does not support partial clones, the repositories are fully cloned.
+	PartialClones []*BitbucketServerPartialClone `json:"partialClones,omitempty"`
 	// Password description: The password to use when authenticating to the Bitbucket Server instance. Also set the corresponding "username" field.
 	//
 	// For Bitbucket Server instances that support personal access tokens (Bitbucket Server version 5.5 and newer), it is recommended to provide a token instead (in the "token" field).
@@ -317,6 +358,12 @@ type BitbucketServerOAuth struct {
 	// SigningKey description: Base64 encoding of the OAuth PEM encoded RSA private key used to generate the public key specified when creating the Bitbucket Server Application Link with incoming authentication.
 	SigningKey string `json:"signingKey"`
 }
+type BitbucketServerPartialClone struct {
+	// BlobLimit description: Blobs larger than this size in bytes are omitted. The size may have a k, m or g suffix. A limit of 0 omits all blobs.
+	BlobLimit string `json:"blobLimit,omitempty"`
+	// Pattern description: Regular expression which matches the Sourcegraph names of the repositories to clone partially.
+	Pattern string `json:"pattern"`
+}
 
 // BitbucketServerPlugin description: Configuration for Bitbucket Server Sourcegraph plugin
 type BitbucketServerPlugin struct {
@@ -391,6 +438,14 @@ type BuiltinAuthProvider struct {
 	Type        string `json:"type"`
 }
 
+// ChangesetDependency description: Declares that the changesets in the matching repositories depend on the changesets in other repositories.
+type ChangesetDependency struct {
+	// DependsOn description: The repositories whose changesets have to be merged before the changesets in the matching repositories are published. Supports globbing.
+	DependsOn []string `json:"dependsOn"`
+	// Repository description: The repositories whose changesets depend on the changesets in the dependsOn repositories. Supports globbing.
+	Repository string `json:"repository"`
+}
+
 // ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 type ChangesetTemplate struct {
 	// Body description: The body (description) of the changeset.
@@ -575,6 +630,10 @@ type ExperimentalFeatures struct {
 	EnablePostSignupFlow bool `json:"enablePostSignupFlow,omitempty"`
 	// EventLogging description: Enables user event logging inside of the Sourcegraph instance. This will allow admins to have greater visibility of user activity, such as frequently viewed pages, frequent searches, and more. These event logs (and any specific user actions) are only stored locally, and never leave this Sourcegraph instance.
 	EventLogging string `json:"eventLogging,omitempty"`
+	// Gerrit description: Allow adding Gerrit code host connections
+	Gerrit string `json:"gerrit,omitempty"`
+	// GoModules description: Allow adding Go module proxy code host connections
+	GoModules string `json:"goModules,omitempty"`
 	// JvmPackages description: Allow adding JVM packages code host connections
 	JvmPackages string `json:"jvmPackages,omitempty"`
 	// NpmPackages description: Allow adding NPM packages code host connections
@@ -583,6 +642,8 @@ type ExperimentalFeatures struct {
 	Pagure string `json:"pagure,omitempty"`
 	// Perforce description: Allow adding Perforce code host connections
 	Perforce string `json:"perforce,omitempty"`
+	// PythonPackages description: Allow adding Python packages code host connections
+	PythonPackages string `json:"pythonPackages,omitempty"`
 	// Ranking description: Experimental search result ranking options.
 	Ranking *Ranking `json:"ranking,omitempty"`
 	// RateLimitAnonymous description: Configures the hourly rate limits for anonymous calls to the GraphQL API. Setting limit to 0 disables the limiter. This is only relevant if unauthenticated calls to the API are permitted.
@@ -647,6 +708,20 @@ type FusionClient struct {
 	Retries int `json:"retries,omitempty"`
 }
 
+// GerritConnection description: Configuration for a connection to Gerrit.
+type GerritConnection struct {
+	// Password description: The HTTP password of the Gerrit account, as generated on the HTTP Credentials page of its settings.
+	Password string `json:"password"`
+	// Projects description: An array of Gerrit project names to mirror. If not set, all projects visible to the account are mirrored.
+	Projects []string `json:"projects,omitempty"`
+	// Url description: URL of a Gerrit instance, such as https://gerrit.example.com
+	Url string `json:"url"`
+	// Username description: The username of the Gerrit account used to access the REST API and to clone repositories.
+	Username string `json:"username"`
+	// WebhookSecret description: A shared secret used to authenticate incoming events from the Gerrit webhooks plugin. The plugin does not sign its payloads, so the secret must be appended to the webhook URL configured in Gerrit as the "secret" query parameter.
+	WebhookSecret string `json:"webhookSecret,omitempty"`
+}
+
 // GitCommitAuthor description: The author of the Git commit.
 type GitCommitAuthor struct {
 	// Email description: The Git commit author email.
@@ -713,10 +788,14 @@ type GitHubConnection struct {
 	//
 	// If "ssh", Sourcegraph will access GitHub repositories using Git URLs of the form git@github.com:myteam/myproject.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
 	GitURLType string `json:"gitURLType,omitempty"`
+	// GitserverDiskQuota description: Maximum amount of disk space the repositories of this code host may use on each gitserver. The size is in bytes and may have a k, m, g or t suffix. When the repositories exceed the quota, gitservers remove them according to the gitServerEviction site configuration until they fit. If unset, there is no quota.
+	GitserverDiskQuota string `json:"gitserverDiskQuota,omitempty"`
 	// InitialRepositoryEnablement description: Deprecated and ignored field which will be removed entirely in the next release. GitHub repositories can no longer be enabled or disabled explicitly. Configure repositories to be mirrored via "repos", "exclude" and "repositoryQuery" instead.
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
 	// Orgs description: An array of organization names identifying GitHub organizations whose repositories should be mirrored on Sourcegraph.
 	Orgs []string `json:"orgs,omitempty"`
+	// PartialClones description: Repositories to clone as partial clones, which omit large blobs until they are needed. Missing blobs are fetched from the code host on demand. Use this for very large repositories which take long to clone or use lots of disk space. If the code host does not support partial clones, the repositories are fully cloned.
+	PartialClones []*GitHubPartialClone `json:"partialClones,omitempty"`
 	// RateLimit description: Rate limit applied when making background API requests to GitHub.
 	RateLimit *GitHubRateLimit `json:"rateLimit,omitempty"`
 	// Repos description: An array of repository "owner/name" strings specifying which GitHub or GitHub Enterprise repositories to mirror on Sourcegraph.
@@ -751,6 +830,12 @@ type GitHubConnection struct {
 	// Webhooks description: An array of configurations defining existing GitHub webhooks that send updates back to Sourcegraph.
 	Webhooks []*GitHubWebhook `json:"webhooks,omitempty"`
 }
+type GitHubPartialClone struct {
+	// BlobLimit description: Blobs larger than this size in bytes are omitted. The size may have a k, m or g suffix. A limit of 0 omits all blobs.
+	BlobLimit string `json:"blobLimit,omitempty"`
+	// Pattern description: Regular expression which matches the Sourcegraph names of the repositories to clone partially.
+	Pattern string `json:"pattern"`
+}
 
 // GitHubRateLimit description: Rate limit applied when making background API requests to GitHub.
 type GitHubRateLimit struct {
@@ -804,10 +889,14 @@ type GitLabConnection struct {
 	//
 	// If "ssh", Sourcegraph will access GitLab repositories using Git URLs of the form git@example.gitlab.com:myteam/myproject.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
 	GitURLType string `json:"gitURLType,omitempty"`
+	// GitserverDiskQuota description: Maximum amount of disk space the repositories of this code host may use on each gitserver. The size is in bytes and may have a k, m, g or t suffix. When the repositories exceed the quota, gitservers remove them according to the gitServerEviction site configuration until they fit. If unset, there is no quota.
+	GitserverDiskQuota string `json:"gitserverDiskQuota,omitempty"`
 	// InitialRepositoryEnablement description: Deprecated and ignored field which will be removed entirely in the next release. GitLab repositories can no longer be enabled or disabled explicitly.
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
 	// NameTransformations description: An array of transformations will apply to the repository name. Currently, only regex replacement is supported. All transformations happen after "repositoryPathPattern" is processed.
 	NameTransformations []*GitLabNameTransformation `json:"nameTransformations,omitempty"`
+	// PartialClones description: Repositories to clone as partial clones, which omit large blobs until they are needed. Missing blobs are fetched from the code host on demand. Use this for very large repositories which take long to clone or use lots of disk space. If the code host does not support partial clones, the repositories are fully cloned.
+	PartialClones []*GitLabPartialClone `json:"partialClones,omitempty"`
 	// ProjectQuery description: An array of strings specifying which GitLab projects to mirror on Sourcegraph. Each string is a URL path and query that targets a GitLab API endpoint returning a list of projects. If the string only contains a query, then "projects" is used as the path. Examples: "?membership=true&search=foo", "groups/mygroup/projects".
 	//
 	// The special string "none" can be used as the only element to disable this feature. Projects matched by multiple query strings are only imported once. Here are a few endpoints that return a list of projects: https://docs.gitlab.com/ee/api/projects.html#list-all-projects, https://docs.gitlab.com/ee/api/groups.html#list-a-groups-projects, https://docs.gitlab.com/ee/api/search.html#scope-projects.
@@ -837,6 +926,12 @@ type GitLabNameTransformation struct {
 	// Replacement description: The replacement used to replace all matched occurrences by the regex.
 	Replacement string `json:"replacement,omitempty"`
 }
+type GitLabPartialClone struct {
+	// BlobLimit description: Blobs larger than this size in bytes are omitted. The size may have a k, m or g suffix. A limit of 0 omits all blobs.
+	BlobLimit string `json:"blobLimit,omitempty"`
+	// Pattern description: Regular expression which matches the Sourcegraph names of the repositories to clone partially.
+	Pattern string `json:"pattern"`
+}
 type GitLabProject struct {
 	// Id description: The ID of a GitLab project (as returned by the GitLab instance's API) to mirror.
 	Id int `json:"id,omitempty"`
@@ -856,6 +951,14 @@ type GitLabWebhook struct {
 	Secret string `json:"secret"`
 }
 
+// GitServerEviction description: Controls which repositories gitservers remove when they run low on disk space or when the repositories of a code host exceed its gitserverDiskQuota. Removed repositories are cloned again when they are next used.
+type GitServerEviction struct {
+	// PinnedRepos description: Regular expressions matching the names of repositories which are never removed to free up space, such as critical repositories which are expensive to clone.
+	PinnedRepos []string `json:"pinnedRepos,omitempty"`
+	// Policy description: The order in which repositories are removed. "lru" removes the least recently used repositories first. "largest-first" removes the largest repositories first, which frees up space while removing the fewest repositories.
+	Policy string `json:"policy,omitempty"`
+}
+
 // GitoliteConnection description: Configuration for a connection to Gitolite.
 type GitoliteConnection struct {
 	// Exclude description: A list of repositories to never mirror from this Gitolite instance. Supports excluding by exact name ({"name": "foo"}).
@@ -872,6 +975,14 @@ type GitoliteConnection struct {
 	Prefix string `json:"prefix"`
 }
 
+// GoModulesConnection description: Configuration for a connection to Go module proxies
+type GoModulesConnection struct {
+	// Dependencies description: An array of "module@version" strings specifying which Go modules to mirror on Sourcegraph.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// Urls description: The list of Go module proxy URLs to fetch modules from, tried in order like the GOPROXY environment variable. Local file-backed proxies can be referenced with file:// URLs.
+	Urls []string `json:"urls"`
+}
+
 // HTTPHeaderAuthProvider description: Configures the HTTP header authentication provider (which authenticates users by consulting an HTTP request header set by an authentication proxy such as https://github.com/bitly/oauth2_proxy).
 type HTTPHeaderAuthProvider struct {
 	// EmailHeader description: The name (case-insensitive) of an HTTP header whose value is taken to be the email of the client requesting the page. Set this value when using an HTTP proxy that authenticates requests, and you don't want the extra configurability of the other authentication methods.
@@ -1195,7 +1306,11 @@ type OpenIDConnectAuthProvider struct {
 
 // OtherExternalServiceConnection description: Configuration for a Connection to Git repositories for which an external service integration isn't yet available.
 type OtherExternalServiceConnection struct {
-	Repos []string `json:"repos"`
+	// GitserverDiskQuota description: Maximum amount of disk space the repositories of this code host may use on each gitserver. The size is in bytes and may have a k, m, g or t suffix. When the repositories exceed the quota, gitservers remove them according to the gitServerEviction site configuration until they fit. If unset, there is no quota.
+	GitserverDiskQuota string `json:"gitserverDiskQuota,omitempty"`
+	// PartialClones description: Repositories to clone as partial clones, which omit large blobs until they are needed. Missing blobs are fetched from the code host on demand. Use this for very large repositories which take long to clone or use lots of disk space. If the code host does not support partial clones, the repositories are fully cloned.
+	PartialClones []*OtherPartialClone `json:"partialClones,omitempty"`
+	Repos         []string             `json:"repos"`
 	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for the repositories. In the pattern, the variable "{base}" is replaced with the Git clone base URL host and path, and "{repo}" is replaced with the repository path taken from the `repos` field.
 	//
 	// For example, if your Git clone base URL is https://git.example.com/repos and `repos` contains the value "my/repo", then a repositoryPathPattern of "{base}/{repo}" would mean that a repository at https://git.example.com/repos/my/repo is available on Sourcegraph at https://sourcegraph.example.com/git.example.com/repos/my/repo.
@@ -1203,6 +1318,14 @@ type OtherExternalServiceConnection struct {
 	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
 	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
 	Url                   string `json:"url,omitempty"`
+	// Vcs description: The version control system of the repositories. Mercurial repositories are converted to Git repositories when they are cloned and updated.
+	Vcs string `json:"vcs,omitempty"`
+}
+type OtherPartialClone struct {
+	// BlobLimit description: Blobs larger than this size in bytes are omitted. The size may have a k, m or g suffix. A limit of 0 omits all blobs.
+	BlobLimit string `json:"blobLimit,omitempty"`
+	// Pattern description: Regular expression which matches the Sourcegraph names of the repositories to clone partially.
+	Pattern string `json:"pattern"`
 }
 type OutputVariable struct {
 	// Format description: The expected format of the output. If set, the output is being parsed in that format before being stored in the var. If not set, 'text' is assumed to the format.
@@ -1315,6 +1438,14 @@ type PhabricatorConnection struct {
 	// Url description: URL of a Phabricator instance, such as https://phabricator.example.com
 	Url string `json:"url,omitempty"`
 }
+
+// PythonPackagesConnection description: Configuration for a connection to Python simple repository APIs compatible with PyPI
+type PythonPackagesConnection struct {
+	// Dependencies description: An array of "name==version" strings specifying which Python packages to mirror on Sourcegraph.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// Urls description: The list of PEP-503 compliant "simple" APIs to fetch packages from, tried in order. Local file-backed indexes can be referenced with file:// URLs.
+	Urls []string `json:"urls"`
+}
 type QuickLink struct {
 	// Description description: A description for this quick link
 	Description string `json:"description,omitempty"`
@@ -1402,11 +1533,21 @@ type SearchLimits struct {
 	CommitDiffMaxRepos int `json:"commitDiffMaxRepos,omitempty"`
 	// CommitDiffWithTimeFilterMaxRepos description: The maximum number of repositories to search across when doing a "type:diff" or "type:commit" with a "after:" or "before:" filter. The user is prompted to narrow their query if the limit is exceeded. There is a separate limit (commitDiffMaxRepos) when "after:" or "before:" is not specified because those queries are slower. Defaults to 10000.
 	CommitDiffWithTimeFilterMaxRepos int `json:"commitDiffWithTimeFilterMaxRepos,omitempty"`
+	// MaxExportResults description: The maximum number of results that an export of search results writes. Exports run queries exhaustively, so results beyond this limit are not written. Defaults to 100000.
+	MaxExportResults int `json:"maxExportResults,omitempty"`
 	// MaxRepos description: The maximum number of repositories to search across. The user is prompted to narrow their query if exceeded. Any value less than or equal to zero means unlimited.
 	MaxRepos int `json:"maxRepos,omitempty"`
 	// MaxTimeoutSeconds description: The maximum value for "timeout:" that search will respect. "timeout:" values larger than maxTimeoutSeconds are capped at maxTimeoutSeconds. Note: You need to ensure your load balancer / reverse proxy in front of Sourcegraph won't timeout the request for larger values. Note: Too many large rearch requests may harm Soucregraph for other users. Defaults to 1 minute.
 	MaxTimeoutSeconds int `json:"maxTimeoutSeconds,omitempty"`
 }
+type SearchMacro struct {
+	// Description description: A description of this macro
+	Description string `json:"description,omitempty"`
+	// Query description: The query fragment that references to this macro expand to
+	Query string `json:"query"`
+	// Version description: The version of this macro, incremented each time the macro is updated through the API
+	Version int `json:"version,omitempty"`
+}
 type SearchSavedQueries struct {
 	// Description description: Description of this saved query
 	Description string `json:"description"`
@@ -1499,6 +1640,8 @@ type Settings struct {
 	SearchIncludeArchived *bool `json:"search.includeArchived,omitempty"`
 	// SearchIncludeForks description: Whether searches should include searching forked repositories.
 	SearchIncludeForks *bool `json:"search.includeForks,omitempty"`
+	// SearchMacros description: Named query fragments that can be referenced in a search query as `@name`. References are replaced with the query of the macro before the query is parsed. Macros may reference other macros. Macros defined in user settings take precedence over those of the same name in organization and global settings.
+	SearchMacros map[string]SearchMacro `json:"search.macros,omitempty"`
 	// SearchMigrateParser description: REMOVED. Previously, a flag to enable and/or-expressions in queries as an aid transition to new language features in versions <= 3.24.0.
 	SearchMigrateParser *bool `json:"search.migrateParser,omitempty"`
 	// SearchRepositoryGroups description: DEPRECATED: Use search contexts instead.
@@ -1667,6 +1810,10 @@ type SiteConfiguration struct {
 	GitMaxCodehostRequestsPerSecond *int `json:"gitMaxCodehostRequestsPerSecond,omitempty"`
 	// GitMaxConcurrentClones description: Maximum number of git clone processes that will be run concurrently per gitserver to update repositories. Note: the global git update scheduler respects gitMaxConcurrentClones. However, we allow each gitserver to run upto gitMaxConcurrentClones to allow for urgent fetches. Urgent fetches are used when a user is browsing a PR and we do not have the commit yet.
 	GitMaxConcurrentClones int `json:"gitMaxConcurrentClones,omitempty"`
+	// GitServerEviction description: Controls which repositories gitservers remove when they run low on disk space or when the repositories of a code host exceed its gitserverDiskQuota. Removed repositories are cloned again when they are next used.
+	GitServerEviction *GitServerEviction `json:"gitServerEviction,omitempty"`
+	// GitServerReplicationFactor description: Number of gitservers each repository is cloned to. Reads fail over to another replica when a gitserver is unavailable. The value is capped at the number of gitservers. The default is 1, which means every repository lives on exactly one gitserver.
+	GitServerReplicationFactor int `json:"gitServerReplicationFactor,omitempty"`
 	// GitUpdateInterval description: JSON array of repo name patterns and update intervals. If a repo matches a pattern, the associated interval will be used. If it matches no patterns a default backoff heuristic will be used. Pattern matches are attempted in the order they are provided.
 	GitUpdateInterval []*UpdateIntervalRule `json:"gitUpdateInterval,omitempty"`
 	// GithubClientID description: Client ID for GitHub. (DEPRECATED)